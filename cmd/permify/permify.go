@@ -23,6 +23,9 @@ func main() {
 	migrate := cmd.NewMigrateCommand()
 	root.AddCommand(migrate)
 
+	backup := cmd.NewBackupCommand()
+	root.AddCommand(backup)
+
 	version := cmd.NewVersionCommand()
 	root.AddCommand(version)
 
@@ -95,7 +95,9 @@ module.exports = {
 					items: [
 						"api-overview/relationship/write-relationships",
 						"api-overview/relationship/read-api", 
-						"api-overview/relationship/delete-relationships"
+						"api-overview/relationship/delete-relationships",
+						"api-overview/relationship/transact-relationships",
+						"api-overview/relationship/bulk-import"
 					],
 				  },
 				  {
@@ -109,6 +111,7 @@ module.exports = {
 					items: [
 						"api-overview/permission/check-api",
 						"api-overview/permission/lookup-entity",
+						"api-overview/permission/permission-matrix",
 						"api-overview/permission/expand-api"
 					],
 				  },
@@ -167,7 +170,8 @@ module.exports = {
 				"reference/glossary",
 				"reference/configuration",
 				"reference/snap-tokens",
-				"reference/tracing"
+				"reference/tracing",
+				"reference/backup"
 			],
 			collapsed: true
 		},
@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+const (
+	// Format identifies the archives written by Create
+	Format = "permify-backup"
+	// Version is the version of the archive layout written by Create, Restore reads archives up to this version
+	Version = 1
+
+	_pageSize = 1000
+)
+
+// An archive is a gzip compressed stream of JSON records, one per line. The first record is the header, it is followed
+// by a tenant record for each tenant, and the schema definition and relation tuple records of the tenant.
+type record struct {
+	Header *header         `json:"header,omitempty"`
+	Tenant *tenant         `json:"tenant,omitempty"`
+	Schema *schema         `json:"schema,omitempty"`
+	Tuple  json.RawMessage `json:"tuple,omitempty"`
+}
+
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type tenant struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// SnapToken is the snapshot the relation tuples of the tenant are read at
+	SnapToken string `json:"snap_token"`
+}
+
+type schema struct {
+	EntityType string `json:"entity_type"`
+	Version    string `json:"version"`
+	Definition string `json:"definition"`
+}
+
+// Creator - Writes the backups of the tenants of a repository
+type Creator struct {
+	tr storage.TenantReader
+	sr storage.SchemaReader
+	rr storage.RelationshipReader
+}
+
+// NewCreator - Creates a new Creator
+func NewCreator(tr storage.TenantReader, sr storage.SchemaReader, rr storage.RelationshipReader) *Creator {
+	return &Creator{
+		tr: tr,
+		sr: sr,
+		rr: rr,
+	}
+}
+
+// Create writes the archive of the tenants to w, all the tenants if none is given. The archive contains every version
+// of the schema of each tenant, and the live relation tuples of the tenant at its head snapshot.
+func (c *Creator) Create(ctx context.Context, w io.Writer, tenantIDs ...string) (err error) {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+
+	if err = enc.Encode(record{Header: &header{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}}); err != nil {
+		return err
+	}
+
+	var tenants []*base.Tenant
+	tenants, err = c.tenants(ctx, tenantIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tenants {
+		if err = c.createTenant(ctx, enc, t); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// createTenant writes the records of the tenant.
+func (c *Creator) createTenant(ctx context.Context, enc *json.Encoder, t *base.Tenant) error {
+	head, err := c.rr.HeadSnapshot(ctx, t.GetId())
+	if err != nil {
+		return err
+	}
+	snap := head.Encode().String()
+
+	if err = enc.Encode(record{Tenant: &tenant{
+		ID:        t.GetId(),
+		Name:      t.GetName(),
+		CreatedAt: t.GetCreatedAt().AsTime(),
+		SnapToken: snap,
+	}}); err != nil {
+		return err
+	}
+
+	definitions, err := c.sr.ListSchemaDefinitions(ctx, t.GetId())
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		if err = enc.Encode(record{Schema: &schema{
+			EntityType: definition.EntityType,
+			Version:    definition.Version,
+			Definition: definition.Serialized(),
+		}}); err != nil {
+			return err
+		}
+	}
+
+	ct := ""
+	for {
+		var collection *database.TupleCollection
+		var next database.EncodedContinuousToken
+		collection, next, err = c.rr.ReadRelationships(ctx, t.GetId(), &base.TupleFilter{}, snap, database.NewPagination(database.Size(_pageSize), database.Token(ct)))
+		if err != nil {
+			return err
+		}
+		for _, tup := range collection.GetTuples() {
+			var raw []byte
+			raw, err = protojson.Marshal(tup)
+			if err != nil {
+				return err
+			}
+			if err = enc.Encode(record{Tuple: raw}); err != nil {
+				return err
+			}
+		}
+		ct = next.String()
+		if ct == "" {
+			return nil
+		}
+	}
+}
+
+// tenants lists the tenants of the repository with the given ids, or all of them if no id is given.
+func (c *Creator) tenants(ctx context.Context, ids []string) (tenants []*base.Tenant, err error) {
+	filter := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		filter[id] = struct{}{}
+	}
+
+	ct := ""
+	for {
+		var page []*base.Tenant
+		var next database.EncodedContinuousToken
+		page, next, err = c.tr.ListTenants(ctx, database.NewPagination(database.Size(_pageSize), database.Token(ct)))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page {
+			if _, ok := filter[t.GetId()]; ok || len(ids) == 0 {
+				tenants = append(tenants, t)
+			}
+		}
+		ct = next.String()
+		if ct == "" {
+			return tenants, nil
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+type repository struct {
+	tr storage.TenantReader
+	tw storage.TenantWriter
+	sr storage.SchemaReader
+	sw storage.SchemaWriter
+	rr storage.RelationshipReader
+	rw storage.RelationshipWriter
+}
+
+func newRepository(t *testing.T) repository {
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	return repository{
+		tr: memory.NewTenantReader(db, l),
+		tw: memory.NewTenantWriter(db, l),
+		sr: memory.NewSchemaReader(db, l),
+		sw: memory.NewSchemaWriter(db, l),
+		rr: memory.NewRelationshipReader(db, l),
+		rw: memory.NewRelationshipWriter(db, l),
+	}
+}
+
+func (r repository) tuples(t *testing.T, tenantID string) []string {
+	collection, _, err := r.rr.ReadRelationships(context.Background(), tenantID, &base.TupleFilter{}, "", database.NewPagination(database.Size(100)))
+	require.NoError(t, err)
+
+	values := make([]string, 0)
+	for _, tup := range collection.GetTuples() {
+		values = append(values, tuple.EntityToString(tup.GetEntity())+"#"+tup.GetRelation()+"@"+tuple.SubjectToString(tup.GetSubject()))
+	}
+	return values
+}
+
+func TestBackup_CreateAndRestore(t *testing.T) {
+	ctx := context.Background()
+	source := newRepository(t)
+
+	_, err := source.tw.CreateTenant(ctx, "t1", "example tenant")
+	require.NoError(t, err)
+	_, err = source.tw.CreateTenant(ctx, "t2", "other tenant")
+	require.NoError(t, err)
+
+	first, second := xid.New().String(), xid.New().String()
+	require.NoError(t, source.sw.WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: first},
+	}))
+	require.NoError(t, source.sw.WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: second},
+		{TenantID: "t1", EntityType: "organization", SerializedDefinition: []byte("entity organization {\n relation member @user\n}"), Version: second},
+	}))
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	collection := database.NewTupleCollection()
+	for _, v := range []string{"organization:1#member@user:1", "organization:1#member@user:2"} {
+		tup, err := tuple.Tuple(v)
+		require.NoError(t, err)
+		collection.Add(tup)
+	}
+	collection.GetTuples()[1].ExpiresAt = timestamppb.New(expiresAt)
+	_, err = source.rw.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+
+	other, err := tuple.Tuple("organization:2#member@user:3")
+	require.NoError(t, err)
+	_, err = source.rw.WriteRelationships(ctx, "t2", database.NewTupleCollection(other))
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	require.NoError(t, NewCreator(source.tr, source.sr, source.rr).Create(ctx, &archive))
+
+	target := newRepository(t)
+	restorer := NewRestorer(target.tr, target.tw, target.sr, target.sw, target.rw)
+	require.NoError(t, restorer.Restore(ctx, bytes.NewReader(archive.Bytes())))
+
+	tenants, _, err := target.tr.ListTenants(ctx, database.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, tenants, 2)
+	assert.Equal(t, "example tenant", tenants[0].GetName())
+	assert.Equal(t, "other tenant", tenants[1].GetName())
+
+	// Every version of the schema is restored, with its original version
+	definitions, err := target.sr.ListSchemaDefinitions(ctx, "t1")
+	require.NoError(t, err)
+	written, err := source.sr.ListSchemaDefinitions(ctx, "t1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, written, definitions)
+
+	head, err := target.sr.HeadVersion(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, second, head)
+
+	assert.ElementsMatch(t, []string{"organization:1#member@user:1", "organization:1#member@user:2"}, target.tuples(t, "t1"))
+	assert.Equal(t, []string{"organization:2#member@user:3"}, target.tuples(t, "t2"))
+
+	// The expiration of the relation tuples is kept
+	restored, _, err := target.rr.ReadRelationships(ctx, "t1", &base.TupleFilter{Subject: &base.SubjectFilter{Type: "user", Ids: []string{"2"}}}, "", database.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, restored.GetTuples(), 1)
+	assert.True(t, expiresAt.Equal(restored.GetTuples()[0].GetExpiresAt().AsTime()))
+
+	// Restoring again does not write the schema versions or the relation tuples twice
+	require.NoError(t, restorer.Restore(ctx, bytes.NewReader(archive.Bytes())))
+	definitions, err = target.sr.ListSchemaDefinitions(ctx, "t1")
+	require.NoError(t, err)
+	assert.Len(t, definitions, 3)
+	assert.Len(t, target.tuples(t, "t1"), 2)
+}
+
+func TestBackup_FiltersTenants(t *testing.T) {
+	ctx := context.Background()
+	source := newRepository(t)
+
+	for _, id := range []string{"t1", "t2", "t3"} {
+		_, err := source.tw.CreateTenant(ctx, id, id)
+		require.NoError(t, err)
+		tup, err := tuple.Tuple("organization:1#member@user:" + id)
+		require.NoError(t, err)
+		_, err = source.rw.WriteRelationships(ctx, id, database.NewTupleCollection(tup))
+		require.NoError(t, err)
+	}
+
+	var archive bytes.Buffer
+	require.NoError(t, NewCreator(source.tr, source.sr, source.rr).Create(ctx, &archive, "t1", "t3"))
+
+	target := newRepository(t)
+	require.NoError(t, NewRestorer(target.tr, target.tw, target.sr, target.sw, target.rw).Restore(ctx, bytes.NewReader(archive.Bytes()), "t3"))
+
+	tenants, _, err := target.tr.ListTenants(ctx, database.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, "t3", tenants[0].GetId())
+	assert.Equal(t, []string{"organization:1#member@user:t3"}, target.tuples(t, "t3"))
+	assert.Empty(t, target.tuples(t, "t1"))
+}
+
+func TestBackup_RejectsUnsupportedArchives(t *testing.T) {
+	ctx := context.Background()
+	target := newRepository(t)
+	restorer := NewRestorer(target.tr, target.tw, target.sr, target.sw, target.rw)
+
+	err := restorer.Restore(ctx, bytes.NewReader([]byte("not an archive")))
+	require.ErrorIs(t, err, ErrMalformedArchive)
+
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	require.NoError(t, json.NewEncoder(zw).Encode(record{Header: &header{Format: Format, Version: Version + 1}}))
+	require.NoError(t, zw.Close())
+
+	err = restorer.Restore(ctx, bytes.NewReader(archive.Bytes()))
+	require.EqualError(t, err, "backup archive version 2 is not supported, the latest supported version is 1")
+}
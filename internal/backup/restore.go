@@ -0,0 +1,256 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// ErrMalformedArchive is returned when the archive to restore is not a backup written by Create.
+var ErrMalformedArchive = errors.New("malformed backup archive")
+
+// Restorer - Restores the backups of tenants into a repository
+type Restorer struct {
+	tr storage.TenantReader
+	tw storage.TenantWriter
+	sr storage.SchemaReader
+	sw storage.SchemaWriter
+	rw storage.RelationshipWriter
+}
+
+// NewRestorer - Creates a new Restorer
+func NewRestorer(tr storage.TenantReader, tw storage.TenantWriter, sr storage.SchemaReader, sw storage.SchemaWriter, rw storage.RelationshipWriter) *Restorer {
+	return &Restorer{
+		tr: tr,
+		tw: tw,
+		sr: sr,
+		sw: sw,
+		rw: rw,
+	}
+}
+
+// Restore reads the archive from r and restores its tenants, all the tenants of the archive if none is given. The
+// tenants that do not exist are created, the schema versions that do not exist are written with their original
+// versions, and the relation tuples of each tenant are imported so that they become visible at once.
+func (r *Restorer) Restore(ctx context.Context, rd io.Reader, tenantIDs ...string) error {
+	zr, err := gzip.NewReader(rd)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedArchive, err)
+	}
+	defer zr.Close()
+
+	d := &decoder{scanner: bufio.NewScanner(zr)}
+	d.scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	rec, err := d.next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrMalformedArchive
+		}
+		return err
+	}
+	if rec.Header == nil || rec.Header.Format != Format {
+		return ErrMalformedArchive
+	}
+	if rec.Header.Version > Version {
+		return fmt.Errorf("backup archive version %d is not supported, the latest supported version is %d", rec.Header.Version, Version)
+	}
+
+	filter := make(map[string]struct{}, len(tenantIDs))
+	for _, id := range tenantIDs {
+		filter[id] = struct{}{}
+	}
+
+	existing, err := r.tenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rec, err = d.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if rec.Tenant == nil {
+			return ErrMalformedArchive
+		}
+
+		if _, ok := filter[rec.Tenant.ID]; !ok && len(tenantIDs) > 0 {
+			if err = d.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := existing[rec.Tenant.ID]; !ok {
+			if _, err = r.tw.CreateTenant(ctx, rec.Tenant.ID, rec.Tenant.Name); err != nil {
+				return err
+			}
+		}
+
+		if err = r.restoreTenant(ctx, d, rec.Tenant.ID); err != nil {
+			return err
+		}
+	}
+}
+
+// restoreTenant restores the schema definition and relation tuple records that follow the record of the tenant.
+func (r *Restorer) restoreTenant(ctx context.Context, d *decoder, tenantID string) error {
+	written, err := r.sr.ListSchemaDefinitions(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]struct{}, len(written))
+	for _, definition := range written {
+		exists[definition.Version+"|"+definition.EntityType] = struct{}{}
+	}
+
+	// The definitions of a version are written together, versions are in order in the archive
+	var definitions []storage.SchemaDefinition
+	flush := func() error {
+		if len(definitions) == 0 {
+			return nil
+		}
+		err := r.sw.WriteSchema(ctx, definitions)
+		definitions = nil
+		return err
+	}
+
+	for {
+		rec, err := d.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if rec.Schema == nil {
+			d.unread(rec)
+			break
+		}
+		if _, ok := exists[rec.Schema.Version+"|"+rec.Schema.EntityType]; ok {
+			continue
+		}
+		if len(definitions) > 0 && definitions[0].Version != rec.Schema.Version {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+		definitions = append(definitions, storage.SchemaDefinition{
+			TenantID:             tenantID,
+			EntityType:           rec.Schema.EntityType,
+			SerializedDefinition: []byte(rec.Schema.Definition),
+			Version:              rec.Schema.Version,
+		})
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+
+	_, _, err = r.rw.BulkImport(ctx, tenantID, func() (*database.TupleCollection, error) {
+		collection := database.NewTupleCollection()
+		for len(collection.GetTuples()) < _pageSize {
+			rec, err := d.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if rec.Tuple == nil {
+				d.unread(rec)
+				break
+			}
+			tup := &base.Tuple{}
+			if err = protojson.Unmarshal(rec.Tuple, tup); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrMalformedArchive, err)
+			}
+			collection.Add(tup)
+		}
+		if len(collection.GetTuples()) == 0 {
+			return nil, io.EOF
+		}
+		return collection, nil
+	})
+	return err
+}
+
+// tenants lists the ids of the tenants of the repository.
+func (r *Restorer) tenants(ctx context.Context) (map[string]struct{}, error) {
+	ids := make(map[string]struct{})
+	ct := ""
+	for {
+		page, next, err := r.tr.ListTenants(ctx, database.NewPagination(database.Size(_pageSize), database.Token(ct)))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page {
+			ids[t.GetId()] = struct{}{}
+		}
+		ct = next.String()
+		if ct == "" {
+			return ids, nil
+		}
+	}
+}
+
+// decoder reads the records of an archive, a record can be put back to be read again.
+type decoder struct {
+	scanner *bufio.Scanner
+	peeked  *record
+}
+
+// next returns the next record of the archive, or io.EOF after the last one.
+func (d *decoder) next() (*record, error) {
+	if d.peeked != nil {
+		rec := d.peeked
+		d.peeked = nil
+		return rec, nil
+	}
+	if !d.scanner.Scan() {
+		if err := d.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedArchive, err)
+		}
+		return nil, io.EOF
+	}
+	rec := &record{}
+	if err := json.Unmarshal(d.scanner.Bytes(), rec); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedArchive, err)
+	}
+	return rec, nil
+}
+
+// unread puts the record back, it is returned by the next call to next.
+func (d *decoder) unread(rec *record) {
+	d.peeked = rec
+}
+
+// skip reads the records of the current tenant, up to the record of the next tenant.
+func (d *decoder) skip() error {
+	for {
+		rec, err := d.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if rec.Tenant != nil {
+			d.unread(rec)
+			return nil
+		}
+	}
+}
@@ -112,9 +112,11 @@ type (
 
 	// Permission contains configuration for the permission service.
 	Permission struct {
-		BulkLimit        int   `mapstructure:"bulk_limit"`        // Limit for bulk operations
-		ConcurrencyLimit int   `mapstructure:"concurrency_limit"` // Limit for concurrent operations
-		Cache            Cache `mapstructure:"cache"`             // Cache configuration for the permission service
+		BulkLimit            int           `mapstructure:"bulk_limit"`            // Limit for bulk operations
+		ConcurrencyLimit     int           `mapstructure:"concurrency_limit"`     // Limit for concurrent operations
+		SnapshotQuantization time.Duration `mapstructure:"snapshot_quantization"` // Window that minimize latency snapshots are quantized to
+		Materialization      bool          `mapstructure:"materialization"`       // Whether to answer materialized permissions from the materialized permission index
+		Cache                Cache         `mapstructure:"cache"`                 // Cache configuration for the permission service
 	}
 
 	// Relationship is a placeholder struct for the relationship service configuration.
@@ -128,7 +130,7 @@ type (
 
 	// Database contains configuration for the database.
 	Database struct {
-		Engine                    string                    `mapstructure:"engine"`                  // Database engine type (e.g., "postgres" or "memory")
+		Engine                    string                    `mapstructure:"engine"`                  // Database engine type (e.g., "postgres", "mysql", "sqlite" or "memory")
 		URI                       string                    `mapstructure:"uri"`                     // Database connection URI
 		AutoMigrate               bool                      `mapstructure:"auto_migrate"`            // Whether to enable automatic migration
 		MaxOpenConnections        int                       `mapstructure:"max_open_connections"`    // Maximum number of open connections to the database
@@ -136,6 +138,7 @@ type (
 		MaxConnectionLifetime     time.Duration             `mapstructure:"max_connection_lifetime"` // Maximum duration a connection can be reused
 		MaxConnectionIdleTime     time.Duration             `mapstructure:"max_connection_idle_time"`
 		DatabaseGarbageCollection DatabaseGarbageCollection `mapstructure:"garbage_collection"`
+		Persistence               Persistence               `mapstructure:"persistence"` // Persistence of the memory database engine
 	}
 
 	DatabaseGarbageCollection struct {
@@ -146,9 +149,25 @@ type (
 		NumberOfThreads int           `mapstructure:"number_of_threads"`
 	}
 
+	// Persistence contains configuration for the persistence of the memory database engine.
+	Persistence struct {
+		Enabled          bool          `mapstructure:"enabled"`           // Whether to keep the memory database in a local directory
+		Directory        string        `mapstructure:"directory"`         // Directory of the write log and the snapshots
+		SnapshotInterval time.Duration `mapstructure:"snapshot_interval"` // Interval the memory database is snapshotted at
+	}
+
 	Distributed struct {
 		Enabled bool     `mapstructure:"enabled"`
 		Nodes   []string `mapstructure:"nodes"`
+		Port    string   `mapstructure:"port"` // Port of the internal listener the nodes dispatch checks to each other on
+		TLS     PeerTLS  `mapstructure:"tls"`  // Mutual TLS configuration of the internal listener and of the connections to it
+	}
+
+	// PeerTLS contains configuration for the mutual TLS between the nodes of a cluster.
+	PeerTLS struct {
+		CertPath string `mapstructure:"cert"` // Path to the certificate file of the node
+		KeyPath  string `mapstructure:"key"`  // Path to the key file of the node
+		CAPath   string `mapstructure:"ca"`   // Path to the certificate file of the authority that signs the certificates of the nodes
 	}
 )
 
@@ -272,8 +291,10 @@ func DefaultConfig() *Config {
 				},
 			},
 			Permission: Permission{
-				BulkLimit:        100,
-				ConcurrencyLimit: 100,
+				BulkLimit:            100,
+				ConcurrencyLimit:     100,
+				SnapshotQuantization: 5 * time.Second,
+				Materialization:      false,
 				Cache: Cache{
 					NumberOfCounters: 10_000,
 					MaxCost:          "10MiB",
@@ -292,10 +313,16 @@ func DefaultConfig() *Config {
 			DatabaseGarbageCollection: DatabaseGarbageCollection{
 				Enable: false,
 			},
+			Persistence: Persistence{
+				Enabled:          false,
+				Directory:        "permify-data",
+				SnapshotInterval: 5 * time.Minute,
+			},
 		},
 		Distributed: Distributed{
 			Enabled: false,
 			Nodes:   []string{},
+			Port:    "3479",
 		},
 	}
 }
@@ -121,7 +121,9 @@ func NewBulkPublisher(ctx context.Context, request *base.PermissionLookupEntityR
 
 // Publish publishes a permission check request to the BulkChecker.
 func (s *BulkPublisher) Publish(entity *base.Entity, metadata *base.PermissionCheckRequestMetadata, result base.PermissionCheckResponse_Result) {
-	s.bulkChecker.RequestChan <- BulkCheckerRequest{
+	// The BulkChecker stops reading requests once the context is done.
+	select {
+	case s.bulkChecker.RequestChan <- BulkCheckerRequest{
 		Request: &base.PermissionCheckRequest{
 			TenantId:   s.request.GetTenantId(),
 			Metadata:   metadata,
@@ -130,5 +132,7 @@ func (s *BulkPublisher) Publish(entity *base.Entity, metadata *base.PermissionCh
 			Subject:    s.request.GetSubject(),
 		},
 		Result: result,
+	}:
+	case <-s.ctx.Done():
 	}
 }
@@ -3,7 +3,9 @@ package engines
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Permify/permify/internal/invoke"
 	"github.com/Permify/permify/internal/schema"
@@ -25,6 +27,12 @@ type CheckEngine struct {
 	relationshipReader storage.RelationshipReader
 	// concurrencyLimit is the maximum number of concurrent permission checks allowed
 	concurrencyLimit int
+	// cycleHandler is called when a cycle is found in the relationships, if set
+	cycleHandler CycleHandler
+	// planner orders the children of rewrites by their estimated cost
+	planner *Planner
+	// index answers materialized permissions, if set
+	index PermissionIndex
 }
 
 // NewCheckEngine creates a new CheckEngine instance for performing permission checks.
@@ -36,6 +44,7 @@ func NewCheckEngine(sr storage.SchemaReader, rr storage.RelationshipReader, opts
 		schemaReader:       sr,
 		relationshipReader: rr,
 		concurrencyLimit:   _defaultConcurrencyLimit,
+		planner:            NewPlanner(),
 	}
 
 	// Apply provided options to configure the CheckEngine
@@ -60,6 +69,16 @@ func (engine *CheckEngine) Check(ctx context.Context, request *base.PermissionCh
 		CheckCount: 0,
 	})
 
+	// Check the subjects of the request together, if it has more than one
+	if len(request.GetSubjects()) > 0 {
+		return engine.checkSubjects(ctx, request)
+	}
+
+	// Start the evaluation path if this is the top of the evaluation
+	if path := invoke.PathFromContext(ctx); path == nil {
+		ctx = invoke.ContextWithPath(ctx, path.Push(checkPathKey(request)))
+	}
+
 	// Retrieve entity definition
 	var en *base.EntityDefinition
 	en, _, err = engine.schemaReader.ReadSchemaDefinition(ctx, request.GetTenantId(), request.GetEntity().GetType(), request.GetMetadata().GetSchemaVersion())
@@ -74,11 +93,13 @@ func (engine *CheckEngine) Check(ctx context.Context, request *base.PermissionCh
 		return emptyResp, err
 	}
 
-	// Perform permission check
-	var res *base.PermissionCheckResponse
-	res, err = engine.check(ctx, request, tor, en)(ctx)
-	if err != nil {
-		return emptyResp, err
+	// Perform permission check, from the permission index if it can answer
+	res, ok := engine.checkIndex(ctx, request, tor, en)
+	if !ok {
+		res, err = engine.check(ctx, request, tor, en)(ctx)
+		if err != nil {
+			return emptyResp, err
+		}
 	}
 
 	if request.GetMetadata().GetExclusion() {
@@ -94,6 +115,65 @@ func (engine *CheckEngine) Check(ctx context.Context, request *base.PermissionCh
 	}, nil
 }
 
+// checkIndex answers the request from the permission index, if the requested permission is materialized and the
+// index is current at the schema version and snapshot of the request. Subject sets are not held by the index.
+func (engine *CheckEngine) checkIndex(ctx context.Context, request *base.PermissionCheckRequest, tor base.EntityDefinition_RelationalReference, en *base.EntityDefinition) (*base.PermissionCheckResponse, bool) {
+	if engine.index == nil || tor != base.EntityDefinition_RELATIONAL_REFERENCE_PERMISSION || !tuple.IsSubjectUser(request.GetSubject()) {
+		return nil, false
+	}
+	if !en.GetPermissions()[request.GetPermission()].GetMaterialized() {
+		return nil, false
+	}
+
+	can, ok := engine.index.Check(ctx, request.GetTenantId(), request.GetMetadata().GetSchemaVersion(), request.GetMetadata().GetSnapToken(), request.GetEntity(), request.GetPermission(), request.GetSubject())
+	if !ok {
+		return nil, false
+	}
+
+	metadata := &base.PermissionCheckResponseMetadata{
+		CheckCount: 1,
+	}
+	if can {
+		return allowed(metadata), true
+	}
+	return denied(metadata), true
+}
+
+// checkSubjects checks the subject and the subjects of the request, and combines their results by the subject
+// match of the request. The subjects are checked concurrently and share the relationships they read, so the
+// relations of the entities on the way are read once for all subjects.
+func (engine *CheckEngine) checkSubjects(ctx context.Context, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	if sharedReadsFromContext(ctx) == nil {
+		ctx = contextWithSharedReads(ctx, &sharedReads{})
+	}
+
+	seen := map[string]struct{}{}
+	var functions []CheckFunction
+	for _, subject := range append([]*base.Subject{request.GetSubject()}, request.GetSubjects()...) {
+		key := tuple.SubjectToString(subject)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		req := &base.PermissionCheckRequest{
+			TenantId:   request.GetTenantId(),
+			Metadata:   request.GetMetadata(),
+			Entity:     request.GetEntity(),
+			Permission: request.GetPermission(),
+			Subject:    subject,
+		}
+		functions = append(functions, func(ctx context.Context) (*base.PermissionCheckResponse, error) {
+			return engine.Check(ctx, req)
+		})
+	}
+
+	if request.GetSubjectMatch() == base.SubjectMatch_SUBJECT_MATCH_ALL {
+		return checkIntersection(ctx, functions, engine.concurrencyLimit)
+	}
+	return checkUnion(ctx, functions, engine.concurrencyLimit)
+}
+
 // CheckFunction is a type that represents a function that takes a context
 // and returns a PermissionCheckResponse along with an error. It is used
 // to perform individual permission checks within the CheckEngine.
@@ -105,16 +185,40 @@ type CheckFunction func(ctx context.Context) (*base.PermissionCheckResponse, err
 // a PermissionCheckResponse along with an error.
 type CheckCombiner func(ctx context.Context, functions []CheckFunction, limit int) (*base.PermissionCheckResponse, error)
 
-// run is a helper function that takes a context and a PermissionCheckRequest,
+// invoke is a helper function that takes a context and a PermissionCheckRequest,
 // and returns a CheckFunction. The returned CheckFunction, when called with
 // a context, executes the Run method of the CheckEngine with the given
 // request, and returns the resulting PermissionCheckResponse and error.
+//
+// If the request is already being evaluated further up the evaluation path,
+// the relationships form a cycle. The cycle contributes nothing to the result,
+// so the request is answered as denied (or allowed, when it is excluded)
+// without being evaluated again.
 func (engine *CheckEngine) invoke(ctx context.Context, request *base.PermissionCheckRequest) CheckFunction {
 	return func(ctx context.Context) (*base.PermissionCheckResponse, error) {
-		return engine.invoker.Check(ctx, request)
+		key := checkPathKey(request)
+		path := invoke.PathFromContext(ctx)
+		if cycle, ok := path.Cycle(key); ok {
+			if engine.cycleHandler != nil {
+				engine.cycleHandler(ctx, request.GetTenantId(), cycle)
+			}
+			if request.GetMetadata().GetExclusion() {
+				return allowed(&base.PermissionCheckResponseMetadata{}), nil
+			}
+			return denied(&base.PermissionCheckResponseMetadata{}), nil
+		}
+		return engine.invoker.Check(invoke.ContextWithPath(ctx, path.Push(key)), request)
 	}
 }
 
+// checkPathKey returns the key a permission check is identified by on the evaluation path.
+func checkPathKey(request *base.PermissionCheckRequest) string {
+	return fmt.Sprintf("%s@%s", tuple.EntityAndRelationToString(&base.EntityAndRelation{
+		Entity:   request.GetEntity(),
+		Relation: request.GetPermission(),
+	}), tuple.SubjectToString(request.GetSubject()))
+}
+
 // check is a function that takes a context, a PermissionCheckRequest, a
 // RelationalReference, and an EntityDefinition. It determines the appropriate
 // CheckFunction based on the provided parameters and returns a wrapped
@@ -133,9 +237,9 @@ func (engine *CheckEngine) check(ctx context.Context, request *base.PermissionCh
 		}
 		child = permission.GetChild()
 		if child.GetRewrite() != nil {
-			fn = engine.checkRewrite(ctx, request, child.GetRewrite())
+			fn = engine.checkRewrite(ctx, request, child.GetRewrite(), en)
 		} else {
-			fn = engine.checkLeaf(ctx, request, child.GetLeaf())
+			fn = engine.checkLeaf(ctx, request, child.GetLeaf(), en)
 		}
 	} else {
 		fn = engine.checkDirect(ctx, request)
@@ -154,13 +258,18 @@ func (engine *CheckEngine) check(ctx context.Context, request *base.PermissionCh
 // and a Rewrite object. It returns a CheckFunction based on the Rewrite
 // operation type (union or intersection). The returned CheckFunction, when
 // called with a context, executes the appropriate rewrite operation and
-// returns the resulting PermissionCheckResponse and error.
-func (engine *CheckEngine) checkRewrite(ctx context.Context, request *base.PermissionCheckRequest, rewrite *base.Rewrite) CheckFunction {
+// returns the resulting PermissionCheckResponse and error. The children are
+// started in the order the planner estimates to reach a result the fastest,
+// each one once the child before it has finished without deciding the result
+// or the stagger delay has passed, and the ones still running are cancelled
+// once the result is decided.
+func (engine *CheckEngine) checkRewrite(ctx context.Context, request *base.PermissionCheckRequest, rewrite *base.Rewrite, en *base.EntityDefinition) CheckFunction {
+	children := engine.planner.order(request.GetTenantId(), en, rewrite.GetRewriteOperation(), rewrite.GetChildren())
 	switch rewrite.GetRewriteOperation() {
 	case *base.Rewrite_OPERATION_UNION.Enum():
-		return engine.setChild(ctx, request, rewrite.GetChildren(), checkUnion)
+		return engine.setChild(ctx, request, children, staggered(checkUnion, base.PermissionCheckResponse_RESULT_ALLOWED), en)
 	case *base.Rewrite_OPERATION_INTERSECTION.Enum():
-		return engine.setChild(ctx, request, rewrite.GetChildren(), checkIntersection)
+		return engine.setChild(ctx, request, children, staggered(checkIntersection, base.PermissionCheckResponse_RESULT_DENIED), en)
 	default:
 		return checkFail(errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String()))
 	}
@@ -170,16 +279,29 @@ func (engine *CheckEngine) checkRewrite(ctx context.Context, request *base.Permi
 // a Leaf object. It returns a CheckFunction based on the Leaf type
 // (TupleToUserSet or ComputedUserSet). The returned CheckFunction, when called
 // with a context, executes the appropriate leaf operation and returns the
-// resulting PermissionCheckResponse and error.
-func (engine *CheckEngine) checkLeaf(ctx context.Context, request *base.PermissionCheckRequest, leaf *base.Leaf) CheckFunction {
+// resulting PermissionCheckResponse and error. The result is recorded by the
+// planner to refine its estimates for the leaf, unless it was cut short by a
+// cycle or by the depth of the request.
+func (engine *CheckEngine) checkLeaf(ctx context.Context, request *base.PermissionCheckRequest, leaf *base.Leaf, en *base.EntityDefinition) CheckFunction {
+	var fn CheckFunction
 	switch op := leaf.GetType().(type) {
 	case *base.Leaf_TupleToUserSet:
-		return engine.checkTupleToUserSet(ctx, request, op.TupleToUserSet, leaf.GetExclusion())
+		fn = engine.checkTupleToUserSet(ctx, request, op.TupleToUserSet, leaf.GetExclusion())
 	case *base.Leaf_ComputedUserSet:
-		return engine.checkComputedUserSet(ctx, request, op.ComputedUserSet, leaf.GetExclusion())
+		fn = engine.checkComputedUserSet(ctx, request, op.ComputedUserSet, leaf.GetExclusion())
 	default:
 		return checkFail(errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String()))
 	}
+
+	key := leafKey(en.GetName(), leaf)
+	return func(ctx context.Context) (*base.PermissionCheckResponse, error) {
+		response, err := fn(ctx)
+		// Running out of depth fails the check, and a cycle marks the path the leaf is evaluated on.
+		if err == nil && !invoke.PathFromContext(ctx).Cyclic() {
+			engine.planner.observeResult(request.GetTenantId(), key, response)
+		}
+		return response, err
+	}
 }
 
 // setChild is a function that takes a context, a PermissionCheckRequest, a
@@ -188,14 +310,14 @@ func (engine *CheckEngine) checkLeaf(ctx context.Context, request *base.Permissi
 // and returns a new CheckFunction that, when called with a context, combines
 // the results of the child functions using the provided CheckCombiner function,
 // returning the resulting PermissionCheckResponse and error.
-func (engine *CheckEngine) setChild(ctx context.Context, request *base.PermissionCheckRequest, children []*base.Child, combiner CheckCombiner) CheckFunction {
+func (engine *CheckEngine) setChild(ctx context.Context, request *base.PermissionCheckRequest, children []*base.Child, combiner CheckCombiner, en *base.EntityDefinition) CheckFunction {
 	var functions []CheckFunction
 	for _, child := range children {
 		switch child.GetType().(type) {
 		case *base.Child_Rewrite:
-			functions = append(functions, engine.checkRewrite(ctx, request, child.GetRewrite()))
+			functions = append(functions, engine.checkRewrite(ctx, request, child.GetRewrite(), en))
 		case *base.Child_Leaf:
-			functions = append(functions, engine.checkLeaf(ctx, request, child.GetLeaf()))
+			functions = append(functions, engine.checkLeaf(ctx, request, child.GetLeaf(), en))
 		default:
 			return checkFail(errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String()))
 		}
@@ -217,17 +339,14 @@ func (engine *CheckEngine) setChild(ctx context.Context, request *base.Permissio
 func (engine *CheckEngine) checkDirect(ctx context.Context, request *base.PermissionCheckRequest) CheckFunction {
 	return func(ctx context.Context) (result *base.PermissionCheckResponse, err error) {
 		var it *database.TupleIterator
-		it, err = engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
-			Entity: &base.EntityFilter{
-				Type: request.GetEntity().GetType(),
-				Ids:  []string{request.GetEntity().GetId()},
-			},
-			Relation: request.GetPermission(),
-		}, request.GetMetadata().GetSnapToken())
+		it, err = engine.queryRelationships(ctx, request.GetTenantId(), request.GetEntity(), request.GetPermission(), request.GetMetadata().GetSnapToken())
 		if err != nil {
 			return denied(&base.PermissionCheckResponseMetadata{}), err
 		}
 
+		// Record the fan-out of the relation for the planner.
+		engine.planner.observeFanout(request.GetTenantId(), relationKey(request.GetEntity().GetType(), request.GetPermission()), it.Count())
+
 		var checkFunctions []CheckFunction
 		for it.HasNext() {
 			subject := it.GetNext().GetSubject()
@@ -266,17 +385,14 @@ func (engine *CheckEngine) checkTupleToUserSet(ctx context.Context, request *bas
 	return func(ctx context.Context) (*base.PermissionCheckResponse, error) {
 		var err error
 		var it *database.TupleIterator
-		it, err = engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
-			Entity: &base.EntityFilter{
-				Type: request.GetEntity().GetType(),
-				Ids:  []string{request.GetEntity().GetId()},
-			},
-			Relation: ttu.GetTupleSet().GetRelation(),
-		}, request.GetMetadata().GetSnapToken())
+		it, err = engine.queryRelationships(ctx, request.GetTenantId(), request.GetEntity(), ttu.GetTupleSet().GetRelation(), request.GetMetadata().GetSnapToken())
 		if err != nil {
 			return denied(&base.PermissionCheckResponseMetadata{}), err
 		}
 
+		// Record the fan-out of the tuple set for the planner.
+		engine.planner.observeFanout(request.GetTenantId(), relationKey(request.GetEntity().GetType(), ttu.GetTupleSet().GetRelation()), it.Count())
+
 		var checkFunctions []CheckFunction
 		for it.HasNext() {
 			subject := it.GetNext().GetSubject()
@@ -427,6 +543,46 @@ func checkIntersection(ctx context.Context, functions []CheckFunction, limit int
 	return allowed(responseMetadata), nil
 }
 
+// staggered returns a CheckCombiner that combines the CheckFunctions with the given combiner, starting each of them
+// once the function before it has finished without the deciding result, or once the stagger delay has passed. A
+// cheap child that decides the result saves starting the ones after it, while slow children still run concurrently
+// within the concurrency limit.
+func staggered(combiner CheckCombiner, decision base.PermissionCheckResponse_Result) CheckCombiner {
+	return func(ctx context.Context, functions []CheckFunction, limit int) (*base.PermissionCheckResponse, error) {
+		return combiner(ctx, stagger(functions, _rewriteStagger, decision), limit)
+	}
+}
+
+// stagger wraps the CheckFunctions so that each of them starts once the function before it has finished without the
+// deciding result, or once the delay has passed. The functions after a deciding result are cancelled by the combiner
+// before they start.
+func stagger(functions []CheckFunction, delay time.Duration, decision base.PermissionCheckResponse_Result) []CheckFunction {
+	staggered := make([]CheckFunction, 0, len(functions))
+	var previous chan struct{}
+	for _, fn := range functions {
+		fn, wait, next := fn, previous, make(chan struct{})
+		staggered = append(staggered, func(ctx context.Context) (*base.PermissionCheckResponse, error) {
+			if wait != nil {
+				timer := time.NewTimer(delay)
+				defer timer.Stop()
+				select {
+				case <-wait:
+				case <-timer.C:
+				case <-ctx.Done():
+					return denied(&base.PermissionCheckResponseMetadata{}), errors.New(base.ErrorCode_ERROR_CODE_CANCELLED.String())
+				}
+			}
+			response, err := fn(ctx)
+			if err == nil && response.GetCan() != decision {
+				close(next)
+			}
+			return response, err
+		})
+		previous = next
+	}
+	return staggered
+}
+
 // run is a function that concurrently executes a set of CheckFunctions within a context,
 // with a specified concurrency limit, and writes their results to a decision channel.
 // The function returns a cleanup function that waits for all CheckFunctions to complete
@@ -2,14 +2,22 @@ package engines
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/Permify/permify/internal/invoke"
 	"github.com/Permify/permify/internal/schema"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
 	"github.com/Permify/permify/internal/storage/mocks"
 	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 	"github.com/Permify/permify/pkg/token"
 	"github.com/Permify/permify/pkg/tuple"
@@ -208,6 +216,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -333,6 +342,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -512,6 +522,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -615,6 +626,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -759,6 +771,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -900,6 +913,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -1083,6 +1097,7 @@ entity doc {
 				checkEngine,
 				nil,
 				nil,
+				nil,
 			)
 
 			checkEngine.SetInvoker(invoker)
@@ -1106,4 +1121,511 @@ entity doc {
 			Expect(base.PermissionCheckResponse_RESULT_ALLOWED).Should(Equal(response.GetCan()))
 		})
 	})
+
+	// CYCLE SAMPLE
+
+	cycleSchema := `
+entity user {}
+
+entity group {
+	relation member @user @group#member
+}
+`
+
+	Context("Cycle Sample: Check", func() {
+		It("Cycle Sample: Case 1", func() {
+			var err error
+
+			// SCHEMA
+
+			schemaReader := new(mocks.SchemaReader)
+
+			var sch *base.SchemaDefinition
+			sch, err = schema.NewSchemaFromStringDefinitions(true, cycleSchema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var group *base.EntityDefinition
+			group, err = schema.GetEntityByName(sch, "group")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			schemaReader.On("ReadSchemaDefinition", "t1", "group", "noop").Return(group, "noop", nil).Times(2)
+
+			// RELATIONSHIPS
+
+			relationshipReader := new(mocks.RelationshipReader)
+
+			relationshipReader.On("QueryRelationships", "t1", &base.TupleFilter{
+				Entity: &base.EntityFilter{
+					Type: "group",
+					Ids:  []string{"a"},
+				},
+				Relation: "member",
+			}, token.NewNoopToken().Encode().String()).Return(database.NewTupleIterator([]*base.Tuple{
+				{
+					Entity: &base.Entity{
+						Type: "group",
+						Id:   "a",
+					},
+					Relation: "member",
+					Subject: &base.Subject{
+						Type:     "group",
+						Id:       "b",
+						Relation: "member",
+					},
+				},
+			}...), nil).Times(1)
+
+			relationshipReader.On("QueryRelationships", "t1", &base.TupleFilter{
+				Entity: &base.EntityFilter{
+					Type: "group",
+					Ids:  []string{"b"},
+				},
+				Relation: "member",
+			}, token.NewNoopToken().Encode().String()).Return(database.NewTupleIterator([]*base.Tuple{
+				{
+					Entity: &base.Entity{
+						Type: "group",
+						Id:   "b",
+					},
+					Relation: "member",
+					Subject: &base.Subject{
+						Type:     "group",
+						Id:       "a",
+						Relation: "member",
+					},
+				},
+			}...), nil).Times(1)
+
+			var cycles [][]string
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader, CheckCycleHandler(func(ctx context.Context, tenantID string, cycle []string) {
+				cycles = append(cycles, cycle)
+			}))
+
+			invoker := invoke.NewDirectInvoker(
+				schemaReader,
+				relationshipReader,
+				checkEngine,
+				nil,
+				nil,
+				nil,
+			)
+
+			checkEngine.SetInvoker(invoker)
+
+			req := &base.PermissionCheckRequest{
+				TenantId:   "t1",
+				Entity:     &base.Entity{Type: "group", Id: "a"},
+				Subject:    &base.Subject{Type: tuple.USER, Id: "1"},
+				Permission: "member",
+				Metadata: &base.PermissionCheckRequestMetadata{
+					SnapToken:     token.NewNoopToken().Encode().String(),
+					SchemaVersion: "noop",
+					Exclusion:     false,
+					Depth:         20,
+				},
+			}
+
+			var response *base.PermissionCheckResponse
+			response, err = checkEngine.Check(context.Background(), req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(base.PermissionCheckResponse_RESULT_DENIED).Should(Equal(response.GetCan()))
+			Expect(cycles).Should(Equal([][]string{
+				{"group:a#member@user:1", "group:b#member@user:1", "group:a#member@user:1"},
+			}))
+		})
+	})
+
+	Context("Historical Sample: Check", func() {
+		historicalSchema := `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	permission view = viewer
+}
+`
+
+		It("Historical Sample: Case 1", func() {
+			var err error
+
+			at := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+			// SCHEMA
+
+			schemaReader := new(mocks.SchemaReader)
+
+			var sch *base.SchemaDefinition
+			sch, err = schema.NewSchemaFromStringDefinitions(true, historicalSchema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var doc *base.EntityDefinition
+			doc, err = schema.GetEntityByName(sch, "doc")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			schemaReader.On("VersionAt", "t1", at).Return("v1", nil).Times(1)
+			schemaReader.On("ReadSchemaDefinition", "t1", "doc", "v1").Return(doc, "v1", nil).Times(2)
+
+			// RELATIONSHIPS
+
+			relationshipReader := new(mocks.RelationshipReader)
+
+			relationshipReader.On("SnapshotAt", "t1", at).Return(token.NewNoopToken(), nil).Times(1)
+			relationshipReader.On("QueryRelationships", "t1", &base.TupleFilter{
+				Entity: &base.EntityFilter{
+					Type: "doc",
+					Ids:  []string{"1"},
+				},
+				Relation: "viewer",
+			}, token.NewNoopToken().Encode().String()).Return(database.NewTupleIterator([]*base.Tuple{
+				{
+					Entity: &base.Entity{
+						Type: "doc",
+						Id:   "1",
+					},
+					Relation: "viewer",
+					Subject: &base.Subject{
+						Type:     tuple.USER,
+						Id:       "1",
+						Relation: "",
+					},
+				},
+			}...), nil).Times(1)
+
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader)
+
+			invoker := invoke.NewDirectInvoker(
+				schemaReader,
+				relationshipReader,
+				checkEngine,
+				nil,
+				nil,
+				nil,
+			)
+
+			checkEngine.SetInvoker(invoker)
+
+			req := &base.PermissionCheckRequest{
+				TenantId:   "t1",
+				Entity:     &base.Entity{Type: "doc", Id: "1"},
+				Subject:    &base.Subject{Type: tuple.USER, Id: "1"},
+				Permission: "view",
+				Metadata: &base.PermissionCheckRequestMetadata{
+					AtTime: timestamppb.New(at),
+					Depth:  20,
+				},
+			}
+
+			var response *base.PermissionCheckResponse
+			response, err = invoker.Check(context.Background(), req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(base.PermissionCheckResponse_RESULT_ALLOWED).Should(Equal(response.GetCan()))
+
+			// The snapshot and the schema version of the time are resolved once, for the whole check.
+			schemaReader.AssertExpectations(GinkgoT())
+			relationshipReader.AssertExpectations(GinkgoT())
+		})
+
+		It("Historical Sample: Case 2", func() {
+			at := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+			schemaReader := new(mocks.SchemaReader)
+			relationshipReader := new(mocks.RelationshipReader)
+			relationshipReader.On("SnapshotAt", "t1", at).Return(token.NewNoopToken(), errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())).Times(1)
+
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader)
+
+			invoker := invoke.NewDirectInvoker(
+				schemaReader,
+				relationshipReader,
+				checkEngine,
+				nil,
+				nil,
+				nil,
+			)
+
+			checkEngine.SetInvoker(invoker)
+
+			_, err := invoker.Check(context.Background(), &base.PermissionCheckRequest{
+				TenantId:   "t1",
+				Entity:     &base.Entity{Type: "doc", Id: "1"},
+				Subject:    &base.Subject{Type: tuple.USER, Id: "1"},
+				Permission: "view",
+				Metadata: &base.PermissionCheckRequestMetadata{
+					AtTime: timestamppb.New(at),
+					Depth:  20,
+				},
+			})
+			Expect(err).Should(Equal(errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())))
+		})
+	})
+
+	Context("Budget Sample: Check", func() {
+		It("Budget Sample: Case 1", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+
+			writeSchema(ctx, db, l, "t1", "v1", `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	permission view = viewer
+}
+`)
+
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(&base.Tuple{
+				Entity:   &base.Entity{Type: "doc", Id: "1"},
+				Relation: "viewer",
+				Subject:  &base.Subject{Type: tuple.USER, Id: "1"},
+			}))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The relations of doc 2 cannot be read within the budget
+			slowReader := &slowRelationshipReader{
+				RelationshipReader: relationshipReader,
+				slow:               map[string]struct{}{"2": {}},
+			}
+
+			checkEngine = NewCheckEngine(schemaReader, slowReader)
+
+			invoker := invoke.NewDirectInvoker(
+				schemaReader,
+				slowReader,
+				checkEngine,
+				nil,
+				nil,
+				nil,
+			)
+
+			checkEngine.SetInvoker(invoker)
+
+			request := func(id string) *base.PermissionCheckRequest {
+				return &base.PermissionCheckRequest{
+					TenantId:   "t1",
+					Entity:     &base.Entity{Type: "doc", Id: id},
+					Subject:    &base.Subject{Type: tuple.USER, Id: "1"},
+					Permission: "view",
+					Metadata: &base.PermissionCheckRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+						Budget:        durationpb.New(50 * time.Millisecond),
+					},
+				}
+			}
+
+			response, err := invoker.Check(ctx, request("1"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(response.GetMetadata().GetReason()).Should(Equal(base.PermissionCheckResponseMetadata_REASON_UNSPECIFIED))
+
+			// A check that exceeds its budget is neither allowed nor denied
+			response, err = invoker.Check(ctx, request("2"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_UNKNOWN))
+			Expect(response.GetMetadata().GetReason()).Should(Equal(base.PermissionCheckResponseMetadata_REASON_BUDGET_EXCEEDED))
+
+			// Without a budget, the check is only bounded by the context of the caller
+			cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			noBudget := request("2")
+			noBudget.Metadata.Budget = nil
+			_, err = invoker.Check(cancelCtx, noBudget)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Materialized Sample: Check", func() {
+		It("Materialized Sample: Case 1", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+
+			writeSchema(ctx, db, l, "t1", "v1", `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	materialized permission view = viewer
+	permission edit = viewer
+}
+`)
+
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(&base.Tuple{
+				Entity:   &base.Entity{Type: "doc", Id: "1"},
+				Relation: "viewer",
+				Subject:  &base.Subject{Type: tuple.USER, Id: "1"},
+			}))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The index disagrees with the relationships, to tell its answers apart
+			index := &staticIndex{current: true, allowed: []string{"2"}}
+
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader, CheckPermissionIndex(index))
+			checkEngine.SetInvoker(checkEngine)
+
+			request := func(id, permission string, subject *base.Subject) *base.PermissionCheckRequest {
+				return &base.PermissionCheckRequest{
+					TenantId:   "t1",
+					Entity:     &base.Entity{Type: "doc", Id: id},
+					Subject:    subject,
+					Permission: permission,
+					Metadata: &base.PermissionCheckRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+					},
+				}
+			}
+			user := &base.Subject{Type: tuple.USER, Id: "1"}
+
+			response, err := checkEngine.Check(ctx, request("2", "view", user))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(response.GetMetadata().GetCheckCount()).Should(Equal(int32(1)))
+
+			response, err = checkEngine.Check(ctx, request("1", "view", user))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_DENIED))
+			Expect(index.calls).Should(Equal(2))
+
+			// Permissions that are not materialized are evaluated from the relationships
+			response, err = checkEngine.Check(ctx, request("1", "edit", user))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(index.calls).Should(Equal(2))
+
+			// So are subject sets, which the index does not hold
+			_, err = checkEngine.Check(ctx, request("1", "view", &base.Subject{Type: "doc", Id: "2", Relation: "viewer"}))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(index.calls).Should(Equal(2))
+
+			// An index that is not current falls back to the relationships
+			index.current = false
+			response, err = checkEngine.Check(ctx, request("1", "view", user))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(index.calls).Should(Equal(3))
+		})
+	})
+
+	Context("Multiple Subjects Sample: Check", func() {
+		It("Multiple Subjects Sample: Case 1", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := &countingRelationshipReader{RelationshipReader: memory.NewRelationshipReader(db, l)}
+
+			writeSchema(ctx, db, l, "t1", "v1", `
+entity user {}
+
+entity folder {
+	relation viewer @user
+
+	permission view = viewer
+}
+
+entity doc {
+	relation parent @folder
+	relation owner @user
+
+	permission view = owner or parent.view
+}
+`)
+
+			var tuples []*base.Tuple
+			for _, t := range []string{
+				"doc:1#owner@user:1",
+				"doc:1#parent@folder:1#...",
+				"folder:1#viewer@user:2",
+				"folder:1#viewer@user:4",
+			} {
+				tup, err := tuple.Tuple(t)
+				Expect(err).ShouldNot(HaveOccurred())
+				tuples = append(tuples, tup)
+			}
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(tuples...))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader)
+
+			invoker := invoke.NewDirectInvoker(
+				schemaReader,
+				relationshipReader,
+				checkEngine,
+				nil,
+				nil,
+				nil,
+			)
+
+			checkEngine.SetInvoker(invoker)
+
+			request := func(match base.SubjectMatch, ids ...string) *base.PermissionCheckRequest {
+				var subjects []*base.Subject
+				for _, id := range ids {
+					entity, err := tuple.E(id)
+					Expect(err).ShouldNot(HaveOccurred())
+					subjects = append(subjects, &base.Subject{Type: entity.GetType(), Id: entity.GetId()})
+				}
+				return &base.PermissionCheckRequest{
+					TenantId:   "t1",
+					Entity:     &base.Entity{Type: "doc", Id: "1"},
+					Permission: "view",
+					Subject:    subjects[0],
+					Subjects:   subjects[1:],
+					Metadata: &base.PermissionCheckRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+					},
+					SubjectMatch: match,
+				}
+			}
+
+			// The subjects share the relations they read, so none of the relations is read twice
+			response, err := invoker.Check(ctx, request(base.SubjectMatch_SUBJECT_MATCH_ANY, "user:3", "user:5", "user:6"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_DENIED))
+			Expect(relationshipReader.reads).ShouldNot(BeEmpty())
+			for key, count := range relationshipReader.reads {
+				Expect(count).Should(Equal(1), key)
+			}
+
+			tests := []struct {
+				match    base.SubjectMatch
+				subjects []string
+				can      base.PermissionCheckResponse_Result
+			}{
+				{base.SubjectMatch_SUBJECT_MATCH_UNSPECIFIED, []string{"user:3", "user:2"}, base.PermissionCheckResponse_RESULT_ALLOWED},
+				{base.SubjectMatch_SUBJECT_MATCH_ANY, []string{"user:3", "user:5"}, base.PermissionCheckResponse_RESULT_DENIED},
+				{base.SubjectMatch_SUBJECT_MATCH_ALL, []string{"user:1", "user:2", "user:4"}, base.PermissionCheckResponse_RESULT_ALLOWED},
+				{base.SubjectMatch_SUBJECT_MATCH_ALL, []string{"user:2", "user:5"}, base.PermissionCheckResponse_RESULT_DENIED},
+				{base.SubjectMatch_SUBJECT_MATCH_ALL, []string{"user:1", "user:1"}, base.PermissionCheckResponse_RESULT_ALLOWED},
+			}
+
+			for _, tt := range tests {
+				response, err := invoker.Check(ctx, request(tt.match, tt.subjects...))
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(response.GetCan()).Should(Equal(tt.can), "%v %v", tt.match, tt.subjects)
+			}
+		})
+	})
 })
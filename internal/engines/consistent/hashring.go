@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/Permify/permify/internal/invoke"
 	hash "github.com/Permify/permify/pkg/consistent"
@@ -17,98 +18,179 @@ import (
 	"github.com/Permify/permify/pkg/tuple"
 )
 
-// Hashring is a wrapper around the consistent hash implementation that
+const (
+	_defaultHealthCheckInterval = 5 * time.Second
+	_defaultHealthCheckTimeout  = time.Second
+)
+
+// Hashring dispatches permission checks to the node of the cluster that owns their key on the consistent hash ring.
+// The check engine invokes its sub-problems through the Hashring too, so every sub-problem of a recursive check is
+// evaluated, and cached, on the node that owns it. Checks that are owned by the local node, or by a node that is
+// unhealthy, are evaluated locally.
 type Hashring struct {
-	checker           invoke.Check
-	gossip            gossip.IGossip
-	consistent        hash.Consistent
-	localNodeAddress  string
-	connectionOptions []grpc.DialOption
-	l                 *logger.Logger
+	// checker evaluates checks on the local node
+	checker    invoke.Check
+	gossip     gossip.IGossip
+	consistent hash.Consistent
+	// localNodeAddress is the address the local node is known by on the ring
+	localNodeAddress string
+	// peers holds the connections to the other nodes of the ring
+	peers *peers
+	// done stops the health checks of the peers
+	done chan struct{}
+	l    *logger.Logger
 }
 
-// NewCheckEngineWithHashring creates a new instance of EngineKeyManager by initializing an EngineKeys
-// struct with the provided cache.Cache instance.
-func NewCheckEngineWithHashring(checker invoke.Check, consistent *hash.ConsistentHash, g *gossip.Gossip, port string, l *logger.Logger, options ...grpc.DialOption) (invoke.Check, error) {
-	// Return a new instance of EngineKeys with the provided cache
+// NewCheckEngineWithHashring creates a new Hashring that evaluates the checks owned by the local node with the
+// given checker, and dispatches the others to the dispatch port of their owners with the given dial options. The
+// connections to the other nodes are health-checked in the background until the Hashring is closed.
+func NewCheckEngineWithHashring(checker invoke.Check, consistent *hash.ConsistentHash, g *gossip.Gossip, port, dispatchPort string, l *logger.Logger, options ...grpc.DialOption) (*Hashring, error) {
 	ip, err := gossip.ExternalIP()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Hashring{
-		checker:           checker,
-		localNodeAddress:  ip + ":" + port,
-		gossip:            g,
-		consistent:        consistent,
-		connectionOptions: options,
-		l:                 l,
-	}, nil
+	return newHashring(checker, consistent, g, ip+":"+port, dispatchPort, _defaultHealthCheckInterval, l, options...), nil
 }
 
-func (c *Hashring) Check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
-	// Generate a unique checkKey string based on the provided PermissionCheckRequest
-	k := fmt.Sprintf("check_%s_%s:%s:%s@%s", request.GetTenantId(), request.GetMetadata().GetSchemaVersion(), request.GetMetadata().GetSnapToken(), tuple.EntityAndRelationToString(&base.EntityAndRelation{
-		Entity:   request.GetEntity(),
-		Relation: request.GetPermission(),
-	}), tuple.SubjectToString(request.GetSubject()))
+// newHashring creates a new Hashring for the local node address and starts the health checks of its peers. The
+// peers are dialed at the dispatch port, or at their address on the ring if it is empty.
+func newHashring(checker invoke.Check, consistent hash.Consistent, g gossip.IGossip, localNodeAddress, dispatchPort string, healthCheckInterval time.Duration, l *logger.Logger, options ...grpc.DialOption) *Hashring {
+	ring := &Hashring{
+		checker:          checker,
+		gossip:           g,
+		consistent:       consistent,
+		localNodeAddress: localNodeAddress,
+		peers:            newPeers(dispatchPort, options...),
+		done:             make(chan struct{}),
+		l:                l,
+	}
 
-	ok := c.consistent.AddKey(k)
-	if !ok {
-		// If there's an error, return false
-		return &base.PermissionCheckResponse{
-			Can: base.PermissionCheckResponse_RESULT_DENIED,
-			Metadata: &base.PermissionCheckResponseMetadata{
-				CheckCount: 0,
-			},
-		}, errors.New("error adding key %s to consistent hash")
+	go ring.healthCheck(healthCheckInterval)
+
+	return ring
+}
+
+// Check evaluates the check on the node that owns its key. If the owner cannot be reached, the check is evaluated
+// locally and the owner is skipped until it passes a health check again.
+func (c *Hashring) Check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
+	// A check of multiple subjects is evaluated locally, so that its subjects share the relationships they read.
+	// The sub-problems of the subjects are dispatched to their owners.
+	if len(request.GetSubjects()) > 0 {
+		return c.checker.Check(ctx, request)
 	}
-	c.l.Info("added key %s to consistent hash", k)
 
-	node, found := c.consistent.Get(k)
-	if !found {
-		// If the responsible node is not found, return false
-		return &base.PermissionCheckResponse{
-			Can: base.PermissionCheckResponse_RESULT_DENIED,
-			Metadata: &base.PermissionCheckResponseMetadata{
-				CheckCount: 0,
-			},
-		}, errors.New("node not found for key")
+	node, found := c.consistent.Get(dispatchKey(request))
+	if !found || node == c.localNodeAddress {
+		return c.checker.Check(ctx, request)
 	}
 
-	if node == c.localNodeAddress {
-		// Get the value from the cache using the generated cache key
+	p, err := c.peers.get(node)
+	if err != nil {
+		c.l.Warn(fmt.Sprintf("failed to connect to %s, evaluating the check locally: %s", node, err.Error()))
+		return c.checker.Check(ctx, request)
+	}
+	if !p.healthy() {
 		return c.checker.Check(ctx, request)
 	}
 
-	resp, err := c.forwardRequestToNode(ctx, node, request)
+	response, err = c.dispatch(ctx, p, request)
 	if err != nil {
+		if ctx.Err() == nil && unavailable(err) {
+			c.l.Warn(fmt.Sprintf("failed to dispatch the check to %s, evaluating the check locally: %s", node, err.Error()))
+			p.setHealthy(false)
+			return c.checker.Check(ctx, request)
+		}
 		return &base.PermissionCheckResponse{
 			Can: base.PermissionCheckResponse_RESULT_DENIED,
 			Metadata: &base.PermissionCheckResponseMetadata{
 				CheckCount: 0,
 			},
-		}, err
+		}, errorFromStatus(err)
 	}
 
-	return resp, nil
+	return response, nil
 }
 
-// forwardRequestGetToNode forwards a request to the responsible node
-func (c *Hashring) forwardRequestToNode(ctx context.Context, node string, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
-	// Set up a connection to the server.
-	conn, err := grpc.DialContext(ctx, node, c.connectionOptions...)
+// Close stops the health checks and closes the connections to the other nodes.
+func (c *Hashring) Close() error {
+	close(c.done)
+	return c.peers.close()
+}
+
+// dispatch evaluates the check on the given peer. The evaluation path of the check is passed on, so that cycles
+// are detected across nodes, and the cycles the peer found are applied to the local path.
+func (c *Hashring) dispatch(ctx context.Context, p *peer, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	path := invoke.PathFromContext(ctx)
+
+	response, err := p.client.DispatchCheck(ctx, &base.DispatchCheckRequest{
+		TenantId: request.GetTenantId(),
+		Metadata: &base.DispatchCheckRequestMetadata{
+			SchemaVersion:  request.GetMetadata().GetSchemaVersion(),
+			SnapToken:      request.GetMetadata().GetSnapToken(),
+			Exclusion:      request.GetMetadata().GetExclusion(),
+			RemainingDepth: request.GetMetadata().GetDepth(),
+			Path:           path.Keys(),
+		},
+		Entity:     request.GetEntity(),
+		Permission: request.GetPermission(),
+		Subject:    request.GetSubject(),
+	})
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		return nil, err
+	}
+
+	path.MarkCyclic(int(response.GetCyclicLength()))
+
+	return &base.PermissionCheckResponse{
+		Can:      response.GetCan(),
+		Metadata: response.GetMetadata(),
+	}, nil
+}
+
+// healthCheck checks the health of the peers at the given interval until the Hashring is closed.
+func (c *Hashring) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			var members []string
+			if c.gossip != nil {
+				members = c.gossip.SyncMemberList()
+			}
+			c.peers.probe(members, _defaultHealthCheckTimeout)
+		}
 	}
-	defer conn.Close()
+}
 
-	// Create a PermissionClient using the connection.
-	client := base.NewPermissionClient(conn)
+// dispatchKey returns the key a check is owned by on the ring. It identifies the same sub-problem as the cache
+// key of the check, so a sub-problem is cached on the node that owns it.
+func dispatchKey(request *base.PermissionCheckRequest) string {
+	return fmt.Sprintf("check_%s_%s:%s:%s@%s", request.GetTenantId(), request.GetMetadata().GetSchemaVersion(), request.GetMetadata().GetSnapToken(), tuple.EntityAndRelationToString(&base.EntityAndRelation{
+		Entity:   request.GetEntity(),
+		Relation: request.GetPermission(),
+	}), tuple.SubjectToString(request.GetSubject()))
+}
 
-	// Prepare a context with a timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// unavailable reports whether the error means that the peer could not evaluate the check, rather than that the
+// check failed. Errors of the check itself are returned as they would be by the local node.
+func unavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unimplemented, codes.DeadlineExceeded, codes.Canceled:
+		return true
+	default:
+		return false
+	}
+}
 
-	return client.Check(ctx, request)
+// errorFromStatus returns the error of the check that a peer returned as a status.
+func errorFromStatus(err error) error {
+	if s, ok := status.FromError(err); ok {
+		return errors.New(s.Message())
+	}
+	return err
 }
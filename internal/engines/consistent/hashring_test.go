@@ -0,0 +1,228 @@
+package consistent
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Permify/permify/internal/invoke"
+	hash "github.com/Permify/permify/pkg/consistent"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// localChecker records the checks that are evaluated on the local node.
+type localChecker struct {
+	mu       sync.Mutex
+	requests []*base.PermissionCheckRequest
+}
+
+func (c *localChecker) Check(_ context.Context, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, request)
+	return &base.PermissionCheckResponse{
+		Can:      base.PermissionCheckResponse_RESULT_DENIED,
+		Metadata: &base.PermissionCheckResponseMetadata{},
+	}, nil
+}
+
+func (c *localChecker) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+// remoteNode is a node that records the checks dispatched to it and reports a cycle at the end of their path.
+type remoteNode struct {
+	base.UnimplementedDispatchServer
+	health.UnimplementedHealthServer
+
+	mu       sync.Mutex
+	requests []*base.DispatchCheckRequest
+}
+
+func (n *remoteNode) DispatchCheck(_ context.Context, request *base.DispatchCheckRequest) (*base.DispatchCheckResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.requests = append(n.requests, request)
+	return &base.DispatchCheckResponse{
+		Can:          base.PermissionCheckResponse_RESULT_ALLOWED,
+		Metadata:     &base.PermissionCheckResponseMetadata{CheckCount: 2},
+		CyclicLength: 1,
+	}, nil
+}
+
+func (n *remoteNode) Check(context.Context, *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
+	return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+}
+
+// startRemoteNode serves a remoteNode on a local port and returns its address.
+func startRemoteNode(t *testing.T) (*remoteNode, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	node := &remoteNode{}
+	server := grpc.NewServer()
+	base.RegisterDispatchServer(server, node)
+	health.RegisterHealthServer(server, node)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	return node, lis.Addr().String()
+}
+
+// unusedAddress returns a local address that nothing listens on.
+func unusedAddress(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	require.NoError(t, lis.Close())
+	return address
+}
+
+// newTestHashring creates a Hashring whose ring only contains the given node, so that it owns every key.
+func newTestHashring(t *testing.T, checker invoke.Check, owner string) *Hashring {
+	ring := newHashring(checker, hash.NewConsistentHash(100, []string{owner}, nil), nil, "local:3478", "", time.Hour, logger.New("error"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	t.Cleanup(func() {
+		_ = ring.Close()
+	})
+	return ring
+}
+
+func checkRequest() *base.PermissionCheckRequest {
+	return &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "v1",
+			SnapToken:     "snap",
+			Exclusion:     true,
+			Depth:         7,
+		},
+		Entity:     &base.Entity{Type: "repository", Id: "1"},
+		Permission: "edit",
+		Subject:    &base.Subject{Type: tuple.USER, Id: "1"},
+	}
+}
+
+func TestHashring_EvaluatesOwnChecksLocally(t *testing.T) {
+	checker := &localChecker{}
+	ring := newTestHashring(t, checker, "local:3478")
+
+	response, err := ring.Check(context.Background(), checkRequest())
+	require.NoError(t, err)
+	assert.Equal(t, base.PermissionCheckResponse_RESULT_DENIED, response.GetCan())
+	assert.Equal(t, 1, checker.count())
+}
+
+func TestHashring_DispatchesSubProblemsToTheirOwner(t *testing.T) {
+	node, address := startRemoteNode(t)
+	checker := &localChecker{}
+	ring := newTestHashring(t, checker, address)
+
+	// The sub-problem is evaluated below a parent on the local node
+	parent := invoke.NewPath("parent")
+	path := parent.Push("child")
+	ctx := invoke.ContextWithPath(context.Background(), path)
+
+	response, err := ring.Check(ctx, checkRequest())
+	require.NoError(t, err)
+	assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, response.GetCan())
+	assert.Equal(t, int32(2), response.GetMetadata().GetCheckCount())
+	assert.Equal(t, 0, checker.count())
+
+	require.Len(t, node.requests, 1)
+	dispatched := node.requests[0]
+	assert.Equal(t, "t1", dispatched.GetTenantId())
+	assert.Equal(t, "v1", dispatched.GetMetadata().GetSchemaVersion())
+	assert.Equal(t, "snap", dispatched.GetMetadata().GetSnapToken())
+	assert.True(t, dispatched.GetMetadata().GetExclusion())
+	assert.Equal(t, int32(7), dispatched.GetMetadata().GetRemainingDepth())
+	assert.Equal(t, []string{"parent", "child"}, dispatched.GetMetadata().GetPath())
+
+	// The cycle the owner found is applied to the local path
+	assert.True(t, path.Cyclic())
+	assert.False(t, parent.Cyclic())
+
+	// The connection to the owner is reused
+	_, err = ring.Check(ctx, checkRequest())
+	require.NoError(t, err)
+	assert.Len(t, ring.peers.conns, 1)
+}
+
+func TestHashring_DispatchesToTheDispatchPortOfTheOwner(t *testing.T) {
+	node, address := startRemoteNode(t)
+	_, dispatchPort, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+
+	// The owner is known by the address it serves the API on, which nothing listens on here
+	_, apiPort, err := net.SplitHostPort(unusedAddress(t))
+	require.NoError(t, err)
+	owner := net.JoinHostPort("127.0.0.1", apiPort)
+
+	checker := &localChecker{}
+	ring := newHashring(checker, hash.NewConsistentHash(100, []string{owner}, nil), nil, "local:3478", dispatchPort, time.Hour, logger.New("error"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer ring.Close()
+
+	response, err := ring.Check(context.Background(), checkRequest())
+	require.NoError(t, err)
+	assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, response.GetCan())
+	assert.Equal(t, 0, checker.count())
+	assert.Len(t, node.requests, 1)
+}
+
+func TestHashring_FallsBackToLocalEvaluationWhenTheOwnerIsUnavailable(t *testing.T) {
+	address := unusedAddress(t)
+	checker := &localChecker{}
+	ring := newTestHashring(t, checker, address)
+
+	response, err := ring.Check(context.Background(), checkRequest())
+	require.NoError(t, err)
+	assert.Equal(t, base.PermissionCheckResponse_RESULT_DENIED, response.GetCan())
+	assert.Equal(t, 1, checker.count())
+
+	// The owner is skipped until it passes a health check again
+	p, err := ring.peers.get(address)
+	require.NoError(t, err)
+	assert.False(t, p.healthy())
+
+	_, err = ring.Check(context.Background(), checkRequest())
+	require.NoError(t, err)
+	assert.Equal(t, 2, checker.count())
+}
+
+func TestPeers_Probe(t *testing.T) {
+	_, healthy := startRemoteNode(t)
+	unhealthy := unusedAddress(t)
+
+	ps := newPeers("", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer ps.close()
+
+	h, err := ps.get(healthy)
+	require.NoError(t, err)
+	h.setHealthy(false)
+	u, err := ps.get(unhealthy)
+	require.NoError(t, err)
+
+	ps.probe(nil, time.Second)
+	assert.True(t, h.healthy())
+	assert.False(t, u.healthy())
+
+	// Connections to nodes that left the cluster are closed
+	ps.probe([]string{healthy}, time.Second)
+	assert.Len(t, ps.conns, 1)
+	assert.Contains(t, ps.conns, healthy)
+}
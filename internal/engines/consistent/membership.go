@@ -0,0 +1,77 @@
+package consistent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+
+	hash "github.com/Permify/permify/pkg/consistent"
+	"github.com/Permify/permify/pkg/gossip"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+var meter = global.Meter("consistent")
+
+// Membership keeps the consistent hash ring in sync with the members of the gossip cluster. Nodes are added to the
+// ring as they join, and removed as they leave or fail, so checks are only dispatched to live members.
+type Membership struct {
+	consistent hash.Consistent
+	// localNodeAddress is the address the local node is known by on the ring
+	localNodeAddress string
+	// changes counts the membership events by type
+	changes metric.Int64Counter
+	l       *logger.Logger
+}
+
+// NewMembership creates a new Membership for the ring of the local node.
+func NewMembership(consistent hash.Consistent, localNodeAddress string, l *logger.Logger) *Membership {
+	changes, _ := meter.Int64Counter(
+		"cluster_membership_changes",
+		metric.WithDescription("number of nodes that joined, left or updated in the cluster"),
+	)
+	return &Membership{
+		consistent:       consistent,
+		localNodeAddress: localNodeAddress,
+		changes:          changes,
+		l:                l,
+	}
+}
+
+// Handle applies a membership event of the gossip cluster to the ring. It is meant to be passed to the gossip
+// protocol with gossip.OnEvent.
+func (m *Membership) Handle(event gossip.Event) {
+	switch event.Type {
+	case gossip.EventJoin:
+		m.consistent.AddWithWeight(event.Node, hash.TopWeight)
+	case gossip.EventLeave:
+		m.consistent.Remove(event.Node)
+	}
+
+	m.l.Info(fmt.Sprintf("🔗 cluster member %s: %s", event.Type.String(), event.Node))
+	if m.changes != nil {
+		m.changes.Add(context.Background(), 1, metric.WithAttributes(attribute.String("event", event.Type.String())))
+	}
+}
+
+// Members returns the nodes of the ring, ordered by address, with the share of the ring each of them owns.
+func (m *Membership) Members() []*base.ClusterMember {
+	var members []*base.ClusterMember
+	for node, share := range m.consistent.Ownership() {
+		members = append(members, &base.ClusterMember{
+			Address:   node,
+			Local:     node == m.localNodeAddress,
+			Ownership: share,
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].GetAddress() < members[j].GetAddress()
+	})
+
+	return members
+}
@@ -0,0 +1,48 @@
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hash "github.com/Permify/permify/pkg/consistent"
+	"github.com/Permify/permify/pkg/gossip"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+func TestMembership_Handle(t *testing.T) {
+	ring := hash.NewConsistentHash(100, []string{"10.0.0.1:3478"}, nil)
+	membership := NewMembership(ring, "10.0.0.1:3478", logger.New("error"))
+
+	membership.Handle(gossip.Event{Type: gossip.EventJoin, Node: "10.0.0.2:3478"})
+	membership.Handle(gossip.Event{Type: gossip.EventJoin, Node: "10.0.0.3:3478"})
+
+	members := membership.Members()
+	require.Len(t, members, 3)
+	assert.Equal(t, "10.0.0.1:3478", members[0].GetAddress())
+	assert.True(t, members[0].GetLocal())
+	assert.Equal(t, "10.0.0.2:3478", members[1].GetAddress())
+	assert.False(t, members[1].GetLocal())
+
+	total := 0.0
+	for _, member := range members {
+		assert.Greater(t, member.GetOwnership(), 0.0)
+		total += member.GetOwnership()
+	}
+	assert.InDelta(t, 1.0, total, 0.001)
+
+	// A node that fails is removed from the ring, so no check is dispatched to it anymore
+	membership.Handle(gossip.Event{Type: gossip.EventLeave, Node: "10.0.0.2:3478"})
+	members = membership.Members()
+	require.Len(t, members, 2)
+	for i := 0; i < 100; i++ {
+		node, ok := ring.Get(string(rune('a' + i)))
+		require.True(t, ok)
+		assert.NotEqual(t, "10.0.0.2:3478", node)
+	}
+
+	// Updates do not change the ring
+	membership.Handle(gossip.Event{Type: gossip.EventUpdate, Node: "10.0.0.3:3478"})
+	assert.Len(t, membership.Members(), 2)
+}
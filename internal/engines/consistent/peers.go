@@ -0,0 +1,150 @@
+package consistent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// peer is a pooled connection to another node of the cluster.
+type peer struct {
+	conn   *grpc.ClientConn
+	client base.DispatchClient
+	// unhealthy is set when the node failed its last health check or dispatch
+	unhealthy atomic.Bool
+}
+
+// healthy reports whether checks can be dispatched to the node.
+func (p *peer) healthy() bool {
+	return !p.unhealthy.Load()
+}
+
+// setHealthy records the health of the node.
+func (p *peer) setHealthy(healthy bool) {
+	p.unhealthy.Store(!healthy)
+}
+
+// peers is a pool of connections to the other nodes of the cluster. A connection is created when a check is first
+// dispatched to a node, and is kept until the node leaves the cluster.
+type peers struct {
+	mu sync.Mutex
+	// port is the port of the dispatch listener of the nodes, or empty if they are dialed at their own address
+	port    string
+	options []grpc.DialOption
+	conns   map[string]*peer
+}
+
+// newPeers creates a new pool whose connections are created to the given dispatch port of the nodes with the given
+// dial options.
+func newPeers(port string, options ...grpc.DialOption) *peers {
+	return &peers{
+		port:    port,
+		options: options,
+		conns:   map[string]*peer{},
+	}
+}
+
+// get returns the connection to the node, creating it if there is none. Connections are established in the
+// background, so get does not wait for the node.
+func (ps *peers) get(node string) (*peer, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if p, ok := ps.conns[node]; ok {
+		return p, nil
+	}
+
+	conn, err := grpc.Dial(ps.address(node), ps.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &peer{
+		conn:   conn,
+		client: base.NewDispatchClient(conn),
+	}
+	ps.conns[node] = p
+	return p, nil
+}
+
+// address returns the address the dispatch listener of the node is reached at. Nodes are known by the address they
+// serve the API on, and serve the dispatch service on the same host.
+func (ps *peers) address(node string) string {
+	if ps.port == "" {
+		return node
+	}
+	host, _, err := net.SplitHostPort(node)
+	if err != nil {
+		return node
+	}
+	return net.JoinHostPort(host, ps.port)
+}
+
+// probe checks the health of every node of the pool, each within the given timeout. If the members of the cluster
+// are given, the connections to the nodes that left the cluster are closed instead.
+func (ps *peers) probe(members []string, timeout time.Duration) {
+	ps.mu.Lock()
+	current := make(map[string]*peer, len(ps.conns))
+	for node, p := range ps.conns {
+		current[node] = p
+	}
+	ps.mu.Unlock()
+
+	if members != nil {
+		joined := make(map[string]struct{}, len(members))
+		for _, member := range members {
+			joined[member] = struct{}{}
+		}
+		for node := range current {
+			if _, ok := joined[node]; !ok {
+				ps.remove(node)
+				delete(current, node)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range current {
+		wg.Add(1)
+		go func(p *peer) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			response, err := health.NewHealthClient(p.conn).Check(ctx, &health.HealthCheckRequest{})
+			p.setHealthy(err == nil && response.GetStatus() == health.HealthCheckResponse_SERVING)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// remove closes the connection to the node and removes it from the pool.
+func (ps *peers) remove(node string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if p, ok := ps.conns[node]; ok {
+		_ = p.conn.Close()
+		delete(ps.conns, node)
+	}
+}
+
+// close closes every connection of the pool.
+func (ps *peers) close() error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	var errs []error
+	for node, p := range ps.conns {
+		errs = append(errs, p.conn.Close())
+		delete(ps.conns, node)
+	}
+	return errors.Join(errs...)
+}
@@ -1,10 +1,22 @@
 package engines
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/dsl/ast"
+	"github.com/Permify/permify/pkg/dsl/compiler"
+	"github.com/Permify/permify/pkg/dsl/parser"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
 // This is the entry point for the test suite for the "engine" package.
@@ -13,3 +25,76 @@ func TestEngines(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "engine-suite")
 }
+
+// writeSchema parses the schema definition and writes it to the memory database under the given version.
+func writeSchema(ctx context.Context, db *IMDatabase.Memory, l logger.Interface, tenantID, version, definition string) {
+	sch, err := parser.NewParser(definition).Parse()
+	Expect(err).ShouldNot(HaveOccurred(), definition)
+	_, err = compiler.NewCompiler(false, sch).Compile()
+	Expect(err).ShouldNot(HaveOccurred(), definition)
+
+	var definitions []storage.SchemaDefinition
+	for _, st := range sch.Statements {
+		definitions = append(definitions, storage.SchemaDefinition{
+			TenantID:             tenantID,
+			Version:              version,
+			EntityType:           st.(*ast.EntityStatement).Name.Literal,
+			SerializedDefinition: []byte(st.String()),
+		})
+	}
+	Expect(memory.NewSchemaWriter(db, l).WriteSchema(ctx, definitions)).Should(Succeed())
+}
+
+// slowRelationshipReader is a relationship reader whose queries for the relations of the given entity ids do not
+// return before the context is done.
+type slowRelationshipReader struct {
+	storage.RelationshipReader
+	slow map[string]struct{}
+}
+
+// QueryRelationships blocks until the context is done if the filter is for one of the slow entity ids.
+func (r *slowRelationshipReader) QueryRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string) (*database.TupleIterator, error) {
+	for _, id := range filter.GetEntity().GetIds() {
+		if _, ok := r.slow[id]; ok {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+	return r.RelationshipReader.QueryRelationships(ctx, tenantID, filter, snap)
+}
+
+// lookupEntityStreamRecorder records the responses of a streamed lookup.
+type lookupEntityStreamRecorder struct {
+	grpc.ServerStream
+	responses []*base.PermissionLookupEntityStreamResponse
+}
+
+func (r *lookupEntityStreamRecorder) Send(response *base.PermissionLookupEntityStreamResponse) error {
+	r.responses = append(r.responses, response)
+	return nil
+}
+
+// staticIndex is a permission index that answers with fixed results while it is current.
+type staticIndex struct {
+	current bool
+	// allowed are the entity ids the subjects have the permission on
+	allowed []string
+	calls   int
+}
+
+// Check reports whether the entity is one of the allowed entities.
+func (i *staticIndex) Check(_ context.Context, _, _, _ string, entity *base.Entity, _ string, _ *base.Subject) (bool, bool) {
+	i.calls++
+	for _, id := range i.allowed {
+		if id == entity.GetId() {
+			return true, i.current
+		}
+	}
+	return false, i.current
+}
+
+// LookupEntity returns the allowed entities.
+func (i *staticIndex) LookupEntity(_ context.Context, _, _, _, _, _ string, _ *base.Subject) ([]string, bool) {
+	i.calls++
+	return i.allowed, i.current
+}
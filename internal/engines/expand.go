@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/Permify/permify/internal/invoke"
 	"github.com/Permify/permify/internal/schema"
 	"github.com/Permify/permify/internal/storage"
 	"github.com/Permify/permify/pkg/database"
@@ -19,16 +20,28 @@ type ExpandEngine struct {
 	schemaReader storage.SchemaReader
 	// relationshipReader is responsible for reading relationship information
 	relationshipReader storage.RelationshipReader
+	// cycleHandler is called when a cycle is found in the relationships, if set
+	cycleHandler CycleHandler
+	// streamChunkSize is the maximum number of subjects in a leaf of a streamed expansion
+	streamChunkSize int
 }
 
 // NewExpandEngine - This function creates a new instance of ExpandEngine by taking a SchemaReader and a RelationshipReader as
 // parameters and returning a pointer to the created instance. The SchemaReader is used to read schema definitions, while the
 // RelationshipReader is used to read relationship definitions.
-func NewExpandEngine(sr storage.SchemaReader, rr storage.RelationshipReader) *ExpandEngine {
-	return &ExpandEngine{
+func NewExpandEngine(sr storage.SchemaReader, rr storage.RelationshipReader, opts ...ExpandOption) *ExpandEngine {
+	engine := &ExpandEngine{
 		schemaReader:       sr,
 		relationshipReader: rr,
+		streamChunkSize:    _defaultExpandStreamChunkSize,
 	}
+
+	// options
+	for _, opt := range opts {
+		opt(engine)
+	}
+
+	return engine
 }
 
 // Expand - This is the Run function of the ExpandEngine type, which takes a context, a PermissionExpandRequest,
@@ -40,7 +53,14 @@ func NewExpandEngine(sr storage.SchemaReader, rr storage.RelationshipReader) *Ex
 // Finally, the function calls the expand function of the ExpandEngine type with the context, PermissionExpandRequest,
 // and false value, and returns the resulting PermissionExpandResponse and error. If there is an error, the span records
 // the error and sets the status to indicate an error.
+//
+// If the request asks for a flattened result, the tree is collapsed to a single leaf holding the terminal subjects of
+// the permission instead.
 func (engine *ExpandEngine) Expand(ctx context.Context, request *base.PermissionExpandRequest) (response *base.PermissionExpandResponse, err error) {
+	if request.GetFlatten() {
+		return engine.expandFlatten(ctx, request)
+	}
+
 	resp := engine.e(ctx, request, false)
 	if resp.Err != nil {
 		return nil, resp.Err
@@ -68,26 +88,25 @@ type ExpandCombiner func(ctx context.Context, functions []ExpandFunction) Expand
 // and selects the appropriate expand function to execute on it. It returns an ExpandResponse that contains the result of
 // the selected expand function.
 func (engine *ExpandEngine) e(ctx context.Context, request *base.PermissionExpandRequest, exclusion bool) ExpandResponse {
-	en, _, err := engine.schemaReader.ReadSchemaDefinition(ctx, request.GetTenantId(), request.GetEntity().GetType(), request.GetMetadata().GetSchemaVersion())
+	ctx, stop, permission, err := engine.resolve(ctx, request, exclusion)
 	if err != nil {
 		return ExpandResponse{Err: err}
 	}
-
-	var typeOfRelation base.EntityDefinition_RelationalReference
-	typeOfRelation, err = schema.GetTypeOfRelationalReferenceByNameInEntityDefinition(en, request.GetPermission())
-	if err != nil {
-		return ExpandResponse{Err: err}
+	if stop != nil {
+		return ExpandResponse{
+			Response: &base.PermissionExpandResponse{
+				Tree: &base.Expand{
+					Node: &base.Expand_Leaf{
+						Leaf: stop,
+					},
+				},
+			},
+		}
 	}
 
 	var fn ExpandFunction
-	if typeOfRelation == base.EntityDefinition_RELATIONAL_REFERENCE_PERMISSION {
-		var child *base.Child
-		var permission *base.PermissionDefinition
-		permission, err = schema.GetPermissionByNameInEntityDefinition(en, request.GetPermission())
-		if err != nil {
-			return ExpandResponse{Err: err}
-		}
-		child = permission.GetChild()
+	if permission != nil {
+		child := permission.GetChild()
 		if child.GetRewrite() != nil {
 			fn = engine.expandRewrite(ctx, request, child.GetRewrite())
 		} else {
@@ -104,6 +123,77 @@ func (engine *ExpandEngine) e(ctx context.Context, request *base.PermissionExpan
 	return expandRoot(ctx, fn)
 }
 
+// resolve prepares the expansion of the entity and permission of the request. It returns the context to expand them
+// in, and the permission definition if the request names a permission, or nil if it names a relation.
+//
+// If the expansion stops at the request, resolve returns the leaf it stops at instead:
+//   - If the requested entity and relation is already being expanded further up the evaluation path, the
+//     relationships form a cycle. The cycle contributes no further subjects, so it is expanded to an empty leaf.
+//   - If the maximum depth of the request has been reached, the entity and relation is not expanded, and the leaf
+//     contains it as a subject set.
+func (engine *ExpandEngine) resolve(ctx context.Context, request *base.PermissionExpandRequest, exclusion bool) (context.Context, *base.Result, *base.PermissionDefinition, error) {
+	target := &base.EntityAndRelation{
+		Entity:   request.GetEntity(),
+		Relation: request.GetPermission(),
+	}
+
+	key := tuple.EntityAndRelationToString(target)
+	path := invoke.PathFromContext(ctx)
+	if cycle, ok := path.Cycle(key); ok {
+		if engine.cycleHandler != nil {
+			engine.cycleHandler(ctx, request.GetTenantId(), cycle)
+		}
+		return ctx, &base.Result{
+			Target:    target,
+			Exclusion: exclusion,
+			Subjects:  []*base.Subject{},
+		}, nil, nil
+	}
+
+	if request.GetMaxDepth() > 0 && path.Depth() >= int(request.GetMaxDepth()) {
+		return ctx, &base.Result{
+			Target:    target,
+			Exclusion: exclusion,
+			Subjects: []*base.Subject{{
+				Type:     request.GetEntity().GetType(),
+				Id:       request.GetEntity().GetId(),
+				Relation: request.GetPermission(),
+			}},
+		}, nil, nil
+	}
+	ctx = invoke.ContextWithPath(ctx, path.Push(key))
+
+	en, _, err := engine.schemaReader.ReadSchemaDefinition(ctx, request.GetTenantId(), request.GetEntity().GetType(), request.GetMetadata().GetSchemaVersion())
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+
+	typeOfRelation, err := schema.GetTypeOfRelationalReferenceByNameInEntityDefinition(en, request.GetPermission())
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+	if typeOfRelation != base.EntityDefinition_RELATIONAL_REFERENCE_PERMISSION {
+		return ctx, nil, nil, nil
+	}
+
+	permission, err := schema.GetPermissionByNameInEntityDefinition(en, request.GetPermission())
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+	return ctx, nil, permission, nil
+}
+
+// subRequest creates the request to expand the given entity and permission as part of the expansion of the request.
+func subRequest(request *base.PermissionExpandRequest, entity *base.Entity, permission string) *base.PermissionExpandRequest {
+	return &base.PermissionExpandRequest{
+		TenantId:   request.GetTenantId(),
+		Entity:     entity,
+		Permission: permission,
+		Metadata:   request.GetMetadata(),
+		MaxDepth:   request.GetMaxDepth(),
+	}
+}
+
 // expandRewrite is a function that returns an ExpandFunction. It takes the current context, a PermissionExpandRequest, and a Rewrite as input parameters.
 // It selects the appropriate expansion function based on the given operation of the Rewrite, and returns that expansion function as an ExpandFunction.
 // If the operation is not recognized, it returns an ExpandFunction that indicates an error.
@@ -186,15 +276,10 @@ func (engine *ExpandEngine) expandDirect(ctx context.Context, request *base.Perm
 			subject := it.GetNext().GetSubject()
 			if !tuple.IsSubjectUser(subject) && subject.GetRelation() != tuple.ELLIPSIS {
 				expandFunctions = append(expandFunctions, func(ctx context.Context, resultChan chan<- ExpandResponse) {
-					result := engine.e(ctx, &base.PermissionExpandRequest{
-						TenantId: request.GetTenantId(),
-						Entity: &base.Entity{
-							Type: subject.GetType(),
-							Id:   subject.GetId(),
-						},
-						Permission: subject.GetRelation(),
-						Metadata:   request.GetMetadata(),
-					}, exclusion)
+					result := engine.e(ctx, subRequest(request, &base.Entity{
+						Type: subject.GetType(),
+						Id:   subject.GetId(),
+					}, subject.GetRelation()), exclusion)
 					resultChan <- result
 				})
 			} else {
@@ -280,15 +365,10 @@ func (engine *ExpandEngine) expandTupleToUserSet(ctx context.Context, request *b
 		for it.HasNext() {
 			subject := it.GetNext().GetSubject()
 			if subject.GetRelation() == tuple.ELLIPSIS {
-				expandFunctions = append(expandFunctions, engine.expandComputedUserSet(ctx, &base.PermissionExpandRequest{
-					TenantId: request.GetTenantId(),
-					Entity: &base.Entity{
-						Type: subject.GetType(),
-						Id:   subject.GetId(),
-					},
-					Permission: subject.GetRelation(),
-					Metadata:   request.GetMetadata(),
-				}, ttu.GetComputed(), exclusion))
+				expandFunctions = append(expandFunctions, engine.expandComputedUserSet(ctx, subRequest(request, &base.Entity{
+					Type: subject.GetType(),
+					Id:   subject.GetId(),
+				}, subject.GetRelation()), ttu.GetComputed(), exclusion))
 			}
 		}
 
@@ -312,15 +392,10 @@ func (engine *ExpandEngine) expandTupleToUserSet(ctx context.Context, request *b
 //   - ExpandFunction that sends the expanded user set to the provided channel
 func (engine *ExpandEngine) expandComputedUserSet(ctx context.Context, request *base.PermissionExpandRequest, cu *base.ComputedUserSet, exclusion bool) ExpandFunction {
 	return func(ctx context.Context, resultChan chan<- ExpandResponse) {
-		result := engine.e(ctx, &base.PermissionExpandRequest{
-			TenantId: request.GetTenantId(),
-			Entity: &base.Entity{
-				Type: request.GetEntity().GetType(),
-				Id:   request.GetEntity().GetId(),
-			},
-			Permission: cu.GetRelation(),
-			Metadata:   request.GetMetadata(),
-		}, exclusion)
+		result := engine.e(ctx, subRequest(request, &base.Entity{
+			Type: request.GetEntity().GetType(),
+			Id:   request.GetEntity().GetId(),
+		}, cu.GetRelation()), exclusion)
 		resultChan <- result
 	}
 }
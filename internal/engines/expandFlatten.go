@@ -0,0 +1,288 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// subjectSet is a set of terminal subjects. A set that can contain everyone but a few subjects, such as the
+// result of an exclusion, is held as the subjects it excludes.
+type subjectSet struct {
+	// subjects are the subjects of the set, or the subjects excluded from everyone if exclusion is set
+	subjects map[string]*base.Subject
+	// exclusion reports whether the set holds everyone except its subjects
+	exclusion bool
+	// truncated reports whether the set holds subject sets that were not expanded, because the maximum depth of the
+	// request was reached
+	truncated bool
+}
+
+// newSubjectSet creates a new subjectSet of the given subjects.
+func newSubjectSet(subjects ...*base.Subject) *subjectSet {
+	set := &subjectSet{subjects: make(map[string]*base.Subject, len(subjects))}
+	for _, subject := range subjects {
+		set.subjects[tuple.SubjectToString(subject)] = subject
+	}
+	return set
+}
+
+// complement returns the set of everyone not in the set.
+func (s *subjectSet) complement() *subjectSet {
+	return &subjectSet{subjects: s.subjects, exclusion: !s.exclusion}
+}
+
+// union returns the set of everyone in either of the sets.
+func (s *subjectSet) union(o *subjectSet) *subjectSet {
+	var set *subjectSet
+	switch {
+	case !s.exclusion && !o.exclusion:
+		set = &subjectSet{subjects: unionSubjects(s.subjects, o.subjects)}
+	case s.exclusion && o.exclusion:
+		set = &subjectSet{subjects: intersectSubjects(s.subjects, o.subjects), exclusion: true}
+	case s.exclusion:
+		set = &subjectSet{subjects: subtractSubjects(s.subjects, o.subjects), exclusion: true}
+	default:
+		set = &subjectSet{subjects: subtractSubjects(o.subjects, s.subjects), exclusion: true}
+	}
+	set.truncated = s.truncated || o.truncated
+	return set
+}
+
+// intersection returns the set of everyone in both of the sets.
+func (s *subjectSet) intersection(o *subjectSet) *subjectSet {
+	switch {
+	case !s.exclusion && !o.exclusion:
+		return &subjectSet{subjects: intersectSubjects(s.subjects, o.subjects)}
+	case s.exclusion && o.exclusion:
+		return &subjectSet{subjects: unionSubjects(s.subjects, o.subjects), exclusion: true}
+	case s.exclusion:
+		return &subjectSet{subjects: subtractSubjects(o.subjects, s.subjects)}
+	default:
+		return &subjectSet{subjects: subtractSubjects(s.subjects, o.subjects)}
+	}
+}
+
+// result returns the set as the result of the target, with its subjects in a stable order.
+func (s *subjectSet) result(target *base.EntityAndRelation) *base.Result {
+	keys := make([]string, 0, len(s.subjects))
+	for key := range s.subjects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	subjects := make([]*base.Subject, 0, len(keys))
+	for _, key := range keys {
+		subjects = append(subjects, s.subjects[key])
+	}
+	return &base.Result{
+		Target:    target,
+		Exclusion: s.exclusion,
+		Subjects:  subjects,
+	}
+}
+
+// unionSubjects returns the subjects that are in a or b.
+func unionSubjects(a, b map[string]*base.Subject) map[string]*base.Subject {
+	subjects := make(map[string]*base.Subject, len(a)+len(b))
+	for key, subject := range a {
+		subjects[key] = subject
+	}
+	for key, subject := range b {
+		subjects[key] = subject
+	}
+	return subjects
+}
+
+// intersectSubjects returns the subjects that are in both a and b.
+func intersectSubjects(a, b map[string]*base.Subject) map[string]*base.Subject {
+	subjects := map[string]*base.Subject{}
+	for key, subject := range a {
+		if _, ok := b[key]; ok {
+			subjects[key] = subject
+		}
+	}
+	return subjects
+}
+
+// subtractSubjects returns the subjects that are in a but not in b.
+func subtractSubjects(a, b map[string]*base.Subject) map[string]*base.Subject {
+	subjects := map[string]*base.Subject{}
+	for key, subject := range a {
+		if _, ok := b[key]; !ok {
+			subjects[key] = subject
+		}
+	}
+	return subjects
+}
+
+// expandFlatten expands the permission of the request to its terminal subjects, and returns them as a single leaf.
+func (engine *ExpandEngine) expandFlatten(ctx context.Context, request *base.PermissionExpandRequest) (*base.PermissionExpandResponse, error) {
+	set, err := engine.flatten(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &base.PermissionExpandResponse{
+		Tree: &base.Expand{
+			Node: &base.Expand_Leaf{
+				Leaf: set.result(&base.EntityAndRelation{
+					Entity:   request.GetEntity(),
+					Relation: request.GetPermission(),
+				}),
+			},
+		},
+	}, nil
+}
+
+// flatten collapses the expansion of the entity and permission of the request to its terminal subjects. Subject
+// sets that are not expanded, because the maximum depth of the request has been reached, are terminal subjects of
+// unions.
+func (engine *ExpandEngine) flatten(ctx context.Context, request *base.PermissionExpandRequest) (*subjectSet, error) {
+	ctx, stop, permission, err := engine.resolve(ctx, request, false)
+	if err != nil {
+		return nil, err
+	}
+	if stop != nil {
+		set := newSubjectSet(stop.GetSubjects()...)
+		// The leaf of a cycle is empty, the leaf of the maximum depth holds the subject set that is not expanded.
+		set.truncated = len(stop.GetSubjects()) > 0
+		return set, nil
+	}
+	if permission != nil {
+		return engine.flattenChild(ctx, request, permission.GetChild())
+	}
+	return engine.flattenDirect(ctx, request)
+}
+
+// flattenChild collapses a child of a permission to its terminal subjects, applying the operation of a rewrite to
+// the sets of its children and the exclusion of a leaf to the set of the leaf.
+//
+// The subjects of a subject set that was not expanded are unknown, so it cannot be intersected or excluded. Such a
+// set fails the flattening with ERROR_CODE_DEPTH_NOT_ENOUGH instead of being treated as a terminal subject.
+func (engine *ExpandEngine) flattenChild(ctx context.Context, request *base.PermissionExpandRequest, child *base.Child) (*subjectSet, error) {
+	switch child.GetType().(type) {
+	case *base.Child_Rewrite:
+		var combine func(s, o *subjectSet) *subjectSet
+		switch child.GetRewrite().GetRewriteOperation() {
+		case base.Rewrite_OPERATION_UNION:
+			combine = (*subjectSet).union
+		case base.Rewrite_OPERATION_INTERSECTION:
+			combine = (*subjectSet).intersection
+		default:
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String())
+		}
+
+		var set *subjectSet
+		for _, c := range child.GetRewrite().GetChildren() {
+			s, err := engine.flattenChild(ctx, request, c)
+			if err != nil {
+				return nil, err
+			}
+			if s.truncated && child.GetRewrite().GetRewriteOperation() == base.Rewrite_OPERATION_INTERSECTION {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_DEPTH_NOT_ENOUGH.String())
+			}
+			if set == nil {
+				set = s
+			} else {
+				set = combine(set, s)
+			}
+		}
+		if set == nil {
+			return newSubjectSet(), nil
+		}
+		return set, nil
+	case *base.Child_Leaf:
+		leaf := child.GetLeaf()
+
+		var set *subjectSet
+		var err error
+		switch op := leaf.GetType().(type) {
+		case *base.Leaf_TupleToUserSet:
+			set, err = engine.flattenTupleToUserSet(ctx, request, op.TupleToUserSet)
+		case *base.Leaf_ComputedUserSet:
+			set, err = engine.flatten(ctx, subRequest(request, request.GetEntity(), op.ComputedUserSet.GetRelation()))
+		default:
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if leaf.GetExclusion() {
+			if set.truncated {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_DEPTH_NOT_ENOUGH.String())
+			}
+			return set.complement(), nil
+		}
+		return set, nil
+	default:
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_KIND.String())
+	}
+}
+
+// flattenDirect collapses a relation of the entity of the request to the subjects it holds directly and the terminal
+// subjects of the subject sets it holds.
+func (engine *ExpandEngine) flattenDirect(ctx context.Context, request *base.PermissionExpandRequest) (*subjectSet, error) {
+	it, err := engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+		Entity: &base.EntityFilter{
+			Type: request.GetEntity().GetType(),
+			Ids:  []string{request.GetEntity().GetId()},
+		},
+		Relation: request.GetPermission(),
+	}, request.GetMetadata().GetSnapToken())
+	if err != nil {
+		return nil, err
+	}
+
+	set := newSubjectSet()
+	for it.HasNext() {
+		subject := it.GetNext().GetSubject()
+		if tuple.IsSubjectUser(subject) || subject.GetRelation() == tuple.ELLIPSIS {
+			set.subjects[tuple.SubjectToString(subject)] = subject
+			continue
+		}
+		s, err := engine.flatten(ctx, subRequest(request, &base.Entity{
+			Type: subject.GetType(),
+			Id:   subject.GetId(),
+		}, subject.GetRelation()))
+		if err != nil {
+			return nil, err
+		}
+		set = set.union(s)
+	}
+	return set, nil
+}
+
+// flattenTupleToUserSet collapses a tuple to user set of the entity of the request to the union of the terminal
+// subjects of the computed user set of every entity in the tuple set.
+func (engine *ExpandEngine) flattenTupleToUserSet(ctx context.Context, request *base.PermissionExpandRequest, ttu *base.TupleToUserSet) (*subjectSet, error) {
+	it, err := engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+		Entity: &base.EntityFilter{
+			Type: request.GetEntity().GetType(),
+			Ids:  []string{request.GetEntity().GetId()},
+		},
+		Relation: ttu.GetTupleSet().GetRelation(),
+	}, request.GetMetadata().GetSnapToken())
+	if err != nil {
+		return nil, err
+	}
+
+	set := newSubjectSet()
+	for it.HasNext() {
+		subject := it.GetNext().GetSubject()
+		if subject.GetRelation() != tuple.ELLIPSIS {
+			continue
+		}
+		s, err := engine.flatten(ctx, subRequest(request, &base.Entity{
+			Type: subject.GetType(),
+			Id:   subject.GetId(),
+		}, ttu.GetComputed().GetRelation()))
+		if err != nil {
+			return nil, err
+		}
+		set = set.union(s)
+	}
+	return set, nil
+}
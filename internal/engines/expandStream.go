@@ -0,0 +1,259 @@
+package engines
+
+import (
+	"context"
+	"errors"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// ExpandStream expands the permission of the request like Expand, but sends the nodes of the tree one at a time, as
+// soon as they are expanded, instead of building the whole tree in memory. Every node is sent without its children,
+// which follow as nodes of their own that refer to it as their parent. Leaves with more subjects than the chunk size
+// of the engine are split into several leaves under a node of their own.
+//
+// If the request asks for a flattened result, the terminal subjects of the permission are sent as a single leaf, or
+// split into chunks like any other leaf.
+func (engine *ExpandEngine) ExpandStream(ctx context.Context, request *base.PermissionExpandRequest, server base.Permission_ExpandStreamServer) (err error) {
+	streamer := &expandStreamer{
+		engine:    engine,
+		chunkSize: engine.streamChunkSize,
+		send:      server.Send,
+	}
+
+	if request.GetFlatten() {
+		var set *subjectSet
+		set, err = engine.flatten(ctx, request)
+		if err != nil {
+			return err
+		}
+		return streamer.leaf(0, set.result(&base.EntityAndRelation{
+			Entity:   request.GetEntity(),
+			Relation: request.GetPermission(),
+		}))
+	}
+
+	return streamer.e(ctx, request, false, 0)
+}
+
+// expandStreamer walks the expand tree depth-first and sends its nodes as it goes.
+type expandStreamer struct {
+	engine *ExpandEngine
+	// chunkSize is the maximum number of subjects in a leaf
+	chunkSize int
+	// send sends a node to the client
+	send func(*base.PermissionExpandStreamResponse) error
+	// last is the id of the last node that has been sent
+	last uint32
+}
+
+// emit sends a node under the given parent and returns its id.
+func (s *expandStreamer) emit(parent uint32, node *base.Expand) (uint32, error) {
+	s.last++
+	return s.last, s.send(&base.PermissionExpandStreamResponse{
+		Id:       s.last,
+		ParentId: parent,
+		Node:     node,
+	})
+}
+
+// operation sends an operation node under the given parent and returns its id.
+func (s *expandStreamer) operation(parent uint32, op base.ExpandTreeNode_Operation) (uint32, error) {
+	return s.emit(parent, &base.Expand{
+		Node: &base.Expand_Expand{
+			Expand: &base.ExpandTreeNode{
+				Operation: op,
+				Children:  []*base.Expand{},
+			},
+		},
+	})
+}
+
+// leaf sends a leaf under the given parent. A leaf with more subjects than the chunk size is split into chunks under
+// an operation node that keeps the meaning of the leaf: a union of the chunks, or an intersection of the chunks if
+// the subjects are excluded.
+func (s *expandStreamer) leaf(parent uint32, result *base.Result) error {
+	if len(result.GetSubjects()) <= s.chunkSize {
+		_, err := s.emit(parent, &base.Expand{
+			Node: &base.Expand_Leaf{
+				Leaf: result,
+			},
+		})
+		return err
+	}
+
+	op := base.ExpandTreeNode_OPERATION_UNION
+	if result.GetExclusion() {
+		op = base.ExpandTreeNode_OPERATION_INTERSECTION
+	}
+	id, err := s.operation(parent, op)
+	if err != nil {
+		return err
+	}
+
+	subjects := result.GetSubjects()
+	for len(subjects) > 0 {
+		n := s.chunkSize
+		if n > len(subjects) {
+			n = len(subjects)
+		}
+		_, err = s.emit(id, &base.Expand{
+			Node: &base.Expand_Leaf{
+				Leaf: &base.Result{
+					Target:    result.GetTarget(),
+					Exclusion: result.GetExclusion(),
+					Subjects:  subjects[:n],
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		subjects = subjects[n:]
+	}
+	return nil
+}
+
+// e sends the expansion of the entity and permission of the request under the given parent.
+func (s *expandStreamer) e(ctx context.Context, request *base.PermissionExpandRequest, exclusion bool, parent uint32) error {
+	ctx, stop, permission, err := s.engine.resolve(ctx, request, exclusion)
+	if err != nil {
+		return err
+	}
+	if stop != nil {
+		return s.leaf(parent, stop)
+	}
+
+	if permission != nil {
+		child := permission.GetChild()
+		if child.GetRewrite() != nil {
+			return s.rewrite(ctx, request, child.GetRewrite(), parent)
+		}
+		return s.leafChild(ctx, request, child.GetLeaf(), parent)
+	}
+	return s.direct(ctx, request, exclusion, parent)
+}
+
+// rewrite sends the operation of a rewrite under the given parent, followed by the expansions of its children.
+func (s *expandStreamer) rewrite(ctx context.Context, request *base.PermissionExpandRequest, rewrite *base.Rewrite, parent uint32) error {
+	var op base.ExpandTreeNode_Operation
+	switch rewrite.GetRewriteOperation() {
+	case base.Rewrite_OPERATION_UNION:
+		op = base.ExpandTreeNode_OPERATION_UNION
+	case base.Rewrite_OPERATION_INTERSECTION:
+		op = base.ExpandTreeNode_OPERATION_INTERSECTION
+	default:
+		return errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String())
+	}
+
+	id, err := s.operation(parent, op)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range rewrite.GetChildren() {
+		switch child.GetType().(type) {
+		case *base.Child_Rewrite:
+			err = s.rewrite(ctx, request, child.GetRewrite(), id)
+		case *base.Child_Leaf:
+			err = s.leafChild(ctx, request, child.GetLeaf(), id)
+		default:
+			err = errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_KIND.String())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leafChild sends the expansion of a tuple to user set or a computed user set under the given parent.
+func (s *expandStreamer) leafChild(ctx context.Context, request *base.PermissionExpandRequest, leaf *base.Leaf, parent uint32) error {
+	switch op := leaf.GetType().(type) {
+	case *base.Leaf_TupleToUserSet:
+		return s.tupleToUserSet(ctx, request, op.TupleToUserSet, leaf.GetExclusion(), parent)
+	case *base.Leaf_ComputedUserSet:
+		return s.e(ctx, subRequest(request, request.GetEntity(), op.ComputedUserSet.GetRelation()), leaf.GetExclusion(), parent)
+	default:
+		return errors.New(base.ErrorCode_ERROR_CODE_UNDEFINED_CHILD_TYPE.String())
+	}
+}
+
+// direct sends the subjects a relation of the entity of the request holds directly under the given parent. If the
+// relation holds subject sets, the direct subjects are sent under a union, like in the tree of Expand.
+func (s *expandStreamer) direct(ctx context.Context, request *base.PermissionExpandRequest, exclusion bool, parent uint32) error {
+	it, err := s.engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+		Entity: &base.EntityFilter{
+			Type: request.GetEntity().GetType(),
+			Ids:  []string{request.GetEntity().GetId()},
+		},
+		Relation: request.GetPermission(),
+	}, request.GetMetadata().GetSnapToken())
+	if err != nil {
+		return err
+	}
+
+	var subjects []*base.Subject
+	sets := false
+	for it.HasNext() {
+		subject := it.GetNext().GetSubject()
+		if !tuple.IsSubjectUser(subject) && subject.GetRelation() != tuple.ELLIPSIS {
+			sets = true
+		} else {
+			subjects = append(subjects, subject)
+		}
+	}
+
+	result := &base.Result{
+		Target: &base.EntityAndRelation{
+			Entity:   request.GetEntity(),
+			Relation: request.GetPermission(),
+		},
+		Exclusion: exclusion,
+		Subjects:  subjects,
+	}
+	if !sets {
+		return s.leaf(parent, result)
+	}
+
+	id, err := s.operation(parent, base.ExpandTreeNode_OPERATION_UNION)
+	if err != nil {
+		return err
+	}
+	return s.leaf(id, result)
+}
+
+// tupleToUserSet sends a union under the given parent, followed by the expansions of the computed user set of every
+// entity in the tuple set.
+func (s *expandStreamer) tupleToUserSet(ctx context.Context, request *base.PermissionExpandRequest, ttu *base.TupleToUserSet, exclusion bool, parent uint32) error {
+	it, err := s.engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+		Entity: &base.EntityFilter{
+			Type: request.GetEntity().GetType(),
+			Ids:  []string{request.GetEntity().GetId()},
+		},
+		Relation: ttu.GetTupleSet().GetRelation(),
+	}, request.GetMetadata().GetSnapToken())
+	if err != nil {
+		return err
+	}
+
+	id, err := s.operation(parent, base.ExpandTreeNode_OPERATION_UNION)
+	if err != nil {
+		return err
+	}
+	for it.HasNext() {
+		subject := it.GetNext().GetSubject()
+		if subject.GetRelation() != tuple.ELLIPSIS {
+			continue
+		}
+		err = s.e(ctx, subRequest(request, &base.Entity{
+			Type: subject.GetType(),
+			Id:   subject.GetId(),
+		}, ttu.GetComputed().GetRelation()), exclusion, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
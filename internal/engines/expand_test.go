@@ -2,18 +2,54 @@ package engines
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/Permify/permify/internal/schema"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
 	"github.com/Permify/permify/internal/storage/mocks"
 	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 	"github.com/Permify/permify/pkg/token"
 	"github.com/Permify/permify/pkg/tuple"
 )
 
+// expandStreamRecorder records the nodes of a streamed expansion.
+type expandStreamRecorder struct {
+	grpc.ServerStream
+	nodes []*base.PermissionExpandStreamResponse
+}
+
+func (r *expandStreamRecorder) Send(node *base.PermissionExpandStreamResponse) error {
+	r.nodes = append(r.nodes, node)
+	return nil
+}
+
+// tree reassembles the streamed nodes into a tree.
+func (r *expandStreamRecorder) tree() *base.Expand {
+	nodes := map[uint32]*base.Expand{}
+	var root *base.Expand
+	for _, n := range r.nodes {
+		node := proto.Clone(n.GetNode()).(*base.Expand)
+		nodes[n.GetId()] = node
+		if n.GetParentId() == 0 {
+			root = node
+			continue
+		}
+		parent := nodes[n.GetParentId()].GetExpand()
+		parent.Children = append(parent.Children, node)
+	}
+	return root
+}
+
 var _ = Describe("expand-engine", func() {
 	var expandEngine *ExpandEngine
 
@@ -310,4 +346,301 @@ var _ = Describe("expand-engine", func() {
 			}).Should(Equal(response.Tree))
 		})
 	})
+
+	// CYCLE SAMPLE
+	cycleSchema := `
+	entity user {}
+
+	entity group {
+		relation member @user @group#member
+	}
+	`
+
+	Context("Cycle Sample: Expand", func() {
+		It("Cycle Sample: Case 1", func() {
+			var err error
+
+			// SCHEMA
+
+			schemaReader := new(mocks.SchemaReader)
+
+			var sch *base.SchemaDefinition
+			sch, err = schema.NewSchemaFromStringDefinitions(true, cycleSchema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var group *base.EntityDefinition
+			group, err = schema.GetEntityByName(sch, "group")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			schemaReader.On("ReadSchemaDefinition", "t1", "group", "noop").Return(group, "noop", nil).Times(2)
+
+			// RELATIONSHIPS
+
+			relationshipReader := new(mocks.RelationshipReader)
+
+			relationshipReader.On("QueryRelationships", "t1", &base.TupleFilter{
+				Entity: &base.EntityFilter{
+					Type: "group",
+					Ids:  []string{"a"},
+				},
+				Relation: "member",
+			}, token.NewNoopToken().Encode().String()).Return(database.NewTupleIterator([]*base.Tuple{
+				{
+					Entity: &base.Entity{
+						Type: "group",
+						Id:   "a",
+					},
+					Relation: "member",
+					Subject: &base.Subject{
+						Type:     "group",
+						Id:       "b",
+						Relation: "member",
+					},
+				},
+			}...), nil).Times(1)
+
+			relationshipReader.On("QueryRelationships", "t1", &base.TupleFilter{
+				Entity: &base.EntityFilter{
+					Type: "group",
+					Ids:  []string{"b"},
+				},
+				Relation: "member",
+			}, token.NewNoopToken().Encode().String()).Return(database.NewTupleIterator([]*base.Tuple{
+				{
+					Entity: &base.Entity{
+						Type: "group",
+						Id:   "b",
+					},
+					Relation: "member",
+					Subject: &base.Subject{
+						Type:     "group",
+						Id:       "a",
+						Relation: "member",
+					},
+				},
+				{
+					Entity: &base.Entity{
+						Type: "group",
+						Id:   "b",
+					},
+					Relation: "member",
+					Subject: &base.Subject{
+						Type: tuple.USER,
+						Id:   "1",
+					},
+				},
+			}...), nil).Times(1)
+
+			var cycles [][]string
+			expandEngine = NewExpandEngine(schemaReader, relationshipReader, ExpandCycleHandler(func(ctx context.Context, tenantID string, cycle []string) {
+				cycles = append(cycles, cycle)
+			}))
+
+			req := &base.PermissionExpandRequest{
+				TenantId:   "t1",
+				Entity:     &base.Entity{Type: "group", Id: "a"},
+				Permission: "member",
+				Metadata: &base.PermissionExpandRequestMetadata{
+					SnapToken:     token.NewNoopToken().Encode().String(),
+					SchemaVersion: "noop",
+				},
+			}
+
+			var response *base.PermissionExpandResponse
+			response, err = expandEngine.Expand(context.Background(), req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetTree()).ShouldNot(BeNil())
+			Expect(cycles).Should(Equal([][]string{
+				{"group:a#member", "group:b#member", "group:a#member"},
+			}))
+		})
+	})
+
+	Context("Stream", func() {
+		streamSchema := `
+entity user {}
+
+entity organization {
+	relation admin @user
+	relation member @user
+	relation banned @user
+}
+
+entity doc {
+	relation org @organization
+	relation owner @user
+	relation editor @user @organization#member
+
+	permission edit = owner or editor or org.admin
+	permission view = edit and not org.banned
+	permission share = not owner
+}
+`
+
+		var (
+			ctx          context.Context
+			snap         string
+			checkEngine  *CheckEngine
+			expandEngine *ExpandEngine
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+
+			writeSchema(ctx, db, l, "t1", "v1", streamSchema)
+
+			user := func(id int) *base.Subject {
+				return &base.Subject{Type: tuple.USER, Id: fmt.Sprint(id)}
+			}
+			tuples := []*base.Tuple{
+				{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "org", Subject: &base.Subject{Type: "organization", Id: "1", Relation: tuple.ELLIPSIS}},
+				{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "owner", Subject: user(1)},
+				{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "editor", Subject: user(2)},
+				{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "editor", Subject: &base.Subject{Type: "organization", Id: "1", Relation: "member"}},
+				{Entity: &base.Entity{Type: "organization", Id: "1"}, Relation: "admin", Subject: user(3)},
+				{Entity: &base.Entity{Type: "organization", Id: "1"}, Relation: "banned", Subject: user(4)},
+				{Entity: &base.Entity{Type: "organization", Id: "1"}, Relation: "banned", Subject: user(1)},
+			}
+			for u := 4; u < 9; u++ {
+				tuples = append(tuples, &base.Tuple{Entity: &base.Entity{Type: "organization", Id: "1"}, Relation: "member", Subject: user(u)})
+			}
+
+			var st interface{ String() string }
+			st, err = memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(tuples...))
+			Expect(err).ShouldNot(HaveOccurred())
+			snap = st.String()
+
+			checkEngine = NewCheckEngine(schemaReader, relationshipReader)
+			checkEngine.SetInvoker(checkEngine)
+			expandEngine = NewExpandEngine(schemaReader, relationshipReader, ExpandStreamChunkSize(2))
+		})
+
+		request := func(permission string) *base.PermissionExpandRequest {
+			return &base.PermissionExpandRequest{
+				TenantId:   "t1",
+				Entity:     &base.Entity{Type: "doc", Id: "1"},
+				Permission: permission,
+				Metadata: &base.PermissionExpandRequestMetadata{
+					SnapToken:     snap,
+					SchemaVersion: "v1",
+				},
+			}
+		}
+
+		It("should stream the nodes of the tree", func() {
+			for _, permission := range []string{"edit", "view", "share"} {
+				res, err := NewExpandEngine(expandEngine.schemaReader, expandEngine.relationshipReader).Expand(ctx, request(permission))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				recorder := &expandStreamRecorder{}
+				err = NewExpandEngine(expandEngine.schemaReader, expandEngine.relationshipReader).ExpandStream(ctx, request(permission), recorder)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(proto.Equal(recorder.tree(), res.GetTree())).Should(BeTrue(), permission)
+
+				for i, node := range recorder.nodes {
+					Expect(node.GetId()).Should(Equal(uint32(i + 1)))
+					Expect(node.GetParentId()).Should(BeNumerically("<", node.GetId()))
+				}
+			}
+		})
+
+		It("should split large leaves into chunks", func() {
+			req := request("member")
+			req.Entity = &base.Entity{Type: "organization", Id: "1"}
+
+			recorder := &expandStreamRecorder{}
+			Expect(expandEngine.ExpandStream(ctx, req, recorder)).Should(Succeed())
+
+			for _, node := range recorder.nodes {
+				Expect(len(node.GetNode().GetLeaf().GetSubjects())).Should(BeNumerically("<=", 2))
+			}
+
+			// The five members of the organization are split into three leaves under a union.
+			members := recorder.tree()
+			Expect(members.GetExpand().GetOperation()).Should(Equal(base.ExpandTreeNode_OPERATION_UNION))
+			Expect(members.GetExpand().GetChildren()).Should(HaveLen(3))
+		})
+
+		It("should stop at the maximum depth", func() {
+			req := request("edit")
+			req.MaxDepth = 1
+
+			res, err := expandEngine.Expand(ctx, req)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// edit = (owner or editor) or org.admin
+			children := res.GetTree().GetExpand().GetChildren()
+			Expect(children).Should(HaveLen(2))
+			Expect(children[0].GetExpand().GetChildren()[0].GetLeaf().GetSubjects()).Should(Equal([]*base.Subject{{Type: "doc", Id: "1", Relation: "owner"}}))
+			Expect(children[0].GetExpand().GetChildren()[1].GetLeaf().GetSubjects()).Should(Equal([]*base.Subject{{Type: "doc", Id: "1", Relation: "editor"}}))
+			Expect(children[1].GetExpand().GetChildren()[0].GetLeaf().GetSubjects()).Should(Equal([]*base.Subject{{Type: "organization", Id: "1", Relation: "admin"}}))
+
+			req = request("edit")
+			req.MaxDepth = 2
+			req.Flatten = true
+
+			res, err = expandEngine.Expand(ctx, req)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetTree().GetLeaf().GetSubjects()).Should(ContainElement(&base.Subject{Type: "organization", Id: "1", Relation: "member"}))
+
+			// The members of the organization are not known, so they cannot be intersected
+			req = request("view")
+			req.MaxDepth = 3
+			req.Flatten = true
+
+			_, err = expandEngine.Expand(ctx, req)
+			Expect(err).Should(Equal(errors.New(base.ErrorCode_ERROR_CODE_DEPTH_NOT_ENOUGH.String())))
+		})
+
+		It("should flatten the tree to the subjects that have the permission", func() {
+			for _, permission := range []string{"edit", "view", "share"} {
+				req := request(permission)
+				req.Flatten = true
+
+				res, err := expandEngine.Expand(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				recorder := &expandStreamRecorder{}
+				Expect(expandEngine.ExpandStream(ctx, req, recorder)).Should(Succeed())
+
+				// A streamed flattened result is split into chunks of the same meaning.
+				streamed := map[string]struct{}{}
+				for _, node := range recorder.nodes {
+					for _, subject := range node.GetNode().GetLeaf().GetSubjects() {
+						streamed[tuple.SubjectToString(subject)] = struct{}{}
+					}
+				}
+
+				leaf := res.GetTree().GetLeaf()
+				Expect(streamed).Should(HaveLen(len(leaf.GetSubjects())))
+
+				for u := 0; u < 10; u++ {
+					subject := &base.Subject{Type: tuple.USER, Id: fmt.Sprint(u)}
+
+					c, err := checkEngine.Check(ctx, &base.PermissionCheckRequest{
+						TenantId: "t1",
+						Metadata: &base.PermissionCheckRequestMetadata{
+							SnapToken:     snap,
+							SchemaVersion: "v1",
+							Depth:         20,
+						},
+						Entity:     &base.Entity{Type: "doc", Id: "1"},
+						Permission: permission,
+						Subject:    subject,
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					_, ok := streamed[tuple.SubjectToString(subject)]
+					Expect(ok != leaf.GetExclusion()).Should(Equal(c.GetCan() == base.PermissionCheckResponse_RESULT_ALLOWED), "%s of user %d", permission, u)
+				}
+			}
+		})
+	})
 })
@@ -0,0 +1,18 @@
+package engines
+
+import (
+	"context"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// PermissionIndex is a materialized index of the permissions that are marked as materialized in the schema of a
+// tenant. The index only answers at the schema versions and snapshots it is current at, the engines walk the
+// relationships otherwise.
+type PermissionIndex interface {
+	// Check reports whether the subject has the permission on the entity, and whether the index could answer.
+	Check(ctx context.Context, tenantID, schemaVersion, snapToken string, entity *base.Entity, permission string, subject *base.Subject) (allowed, ok bool)
+	// LookupEntity returns the IDs of the entities of the entity type that the subject has the permission on, in
+	// lexicographic order, and whether the index could answer.
+	LookupEntity(ctx context.Context, tenantID, schemaVersion, snapToken, entityType, permission string, subject *base.Subject) (entityIDs []string, ok bool)
+}
@@ -33,6 +33,11 @@ func NewCheckEngineWithKeys(checker invoke.Check, cache cache.Cache, l *logger.L
 
 // Check performs a permission check for a given request, using the cached results if available.
 func (c *CheckEngineWithKeys) Check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
+	// A check of multiple subjects is not cached as a whole; the checks of its subjects are.
+	if len(request.GetSubjects()) > 0 {
+		return c.checker.Check(ctx, request)
+	}
+
 	// Try to get the cached result for the given request.
 	res, found := c.getCheckKey(request)
 
@@ -75,6 +80,11 @@ func (c *CheckEngineWithKeys) Check(ctx context.Context, request *base.Permissio
 		}, err
 	}
 
+	// Results that were cut short by a cycle are only valid for the current evaluation path.
+	if invoke.PathFromContext(ctx).Cyclic() {
+		return res, err
+	}
+
 	// Cache the result of the permission check for future use.
 	c.setCheckKey(request, &base.PermissionCheckResponse{
 		Can:      res.GetCan(),
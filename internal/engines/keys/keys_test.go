@@ -1,6 +1,7 @@
 package keys
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -268,3 +269,46 @@ func TestEngineKeys_SetAndGetMultipleKeys(t *testing.T) {
 	assert.True(t, found3)
 	assert.Equal(t, checkResp3, resp3)
 }
+
+func TestEngineKeys_DoesNotCacheMultipleSubjects(t *testing.T) {
+	cache, err := ristretto.New()
+	assert.Nil(t, err)
+
+	checker := &blockingChecker{release: make(chan struct{})}
+	close(checker.release)
+	engine := NewCheckEngineWithKeys(checker, cache, logger.New("debug"))
+
+	checkReq := &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "test_version",
+			SnapToken:     "test_snap_token",
+			Depth:         20,
+		},
+		Entity: &base.Entity{
+			Type: "test-entity",
+			Id:   "e1",
+		},
+		Permission: "test-permission",
+		Subject: &base.Subject{
+			Type: tuple.USER,
+			Id:   "u1",
+		},
+		Subjects: []*base.Subject{
+			{Type: "client", Id: "c1"},
+		},
+		SubjectMatch: base.SubjectMatch_SUBJECT_MATCH_ALL,
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := engine.Check(context.Background(), checkReq)
+		assert.Nil(t, err)
+		assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, res.GetCan())
+		cache.Wait()
+	}
+
+	// The result of both subjects is not the result of the first subject alone
+	assert.Equal(t, int32(2), checker.calls)
+	_, found := engine.(*CheckEngineWithKeys).getCheckKey(checkReq)
+	assert.False(t, found)
+}
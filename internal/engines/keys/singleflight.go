@@ -0,0 +1,152 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+
+	"github.com/Permify/permify/internal/invoke"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+var meter = global.Meter("keys")
+
+// CheckEngineWithSingleflight coalesces identical permission checks that are in flight at the same time,
+// so that only one of them reaches the underlying checker and the others share its result.
+type CheckEngineWithSingleflight struct {
+	checker invoke.Check
+	// flights holds the checks that are in flight by their key
+	mu      sync.Mutex
+	flights map[string]*flight
+	// collapsed counts the checks that were answered by another in-flight check
+	collapsed metric.Int64Counter
+}
+
+// NewCheckEngineWithSingleflight creates a new instance of CheckEngineWithSingleflight wrapping the given checker.
+func NewCheckEngineWithSingleflight(checker invoke.Check) invoke.Check {
+	collapsed, _ := meter.Int64Counter(
+		"check_singleflight_collapsed",
+		metric.WithDescription("number of permission checks answered by an identical in-flight check"),
+	)
+	return &CheckEngineWithSingleflight{
+		checker:   checker,
+		flights:   make(map[string]*flight),
+		collapsed: collapsed,
+	}
+}
+
+// Check performs a permission check for the given request, joining an identical check if one is already in flight.
+// A check only joins a flight made with at most its own depth: the result of a check that succeeded with less depth
+// is the same with more, and every sub-check of a flight is made with a smaller depth than the flight, so a check can
+// never wait on a flight that is itself waiting on the check, even when the relationships contain cycles.
+func (c *CheckEngineWithSingleflight) Check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
+	// A check of multiple subjects is not coalesced as a whole; the checks of its subjects are.
+	if len(request.GetSubjects()) > 0 {
+		return c.checker.Check(ctx, request)
+	}
+
+	key := flightKey(request)
+	depth := request.GetMetadata().GetDepth()
+
+	c.mu.Lock()
+	f, ok := c.flights[key]
+	if !ok {
+		f = &flight{depth: depth, done: make(chan struct{})}
+		c.flights[key] = f
+		c.mu.Unlock()
+		return c.fly(ctx, key, f, request)
+	}
+	c.mu.Unlock()
+
+	if depth < f.depth {
+		return c.checker.Check(ctx, request)
+	}
+
+	// Every caller waits for the shared result only as long as its own context allows.
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return denied(), ctx.Err()
+	}
+
+	// The check of another caller fails when that caller is cancelled or runs out of time, so it is made again
+	if f.err != nil && ctx.Err() == nil && (errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded)) {
+		return c.checker.Check(ctx, request)
+	}
+
+	// A result that was cut short by a cycle is only valid for the evaluation path of the caller that
+	// produced it, so the other callers evaluate the check themselves.
+	if f.err == nil && f.cyclic {
+		return c.checker.Check(ctx, request)
+	}
+
+	// The check was answered by another caller, record the collapse.
+	if c.collapsed != nil {
+		c.collapsed.Add(ctx, 1, metric.WithAttributes(attribute.String("tenant_id", request.GetTenantId())))
+	}
+	return f.result()
+}
+
+// fly makes the check of the flight and shares its result with the callers that joined it.
+func (c *CheckEngineWithSingleflight) fly(ctx context.Context, key string, f *flight, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.response, f.err = c.checker.Check(ctx, request)
+	f.cyclic = invoke.PathFromContext(ctx).Cyclic()
+	return f.result()
+}
+
+// flight is a check in flight whose result is shared between its callers.
+type flight struct {
+	// depth is the depth the check is made with
+	depth int32
+	// done is closed once the result is set
+	done     chan struct{}
+	response *base.PermissionCheckResponse
+	cyclic   bool
+	err      error
+}
+
+// result returns the result of the flight. The response is shared between callers, so every caller gets its own copy.
+func (f *flight) result() (*base.PermissionCheckResponse, error) {
+	if f.err != nil {
+		return denied(), f.err
+	}
+	return &base.PermissionCheckResponse{
+		Can: f.response.GetCan(),
+		Metadata: &base.PermissionCheckResponseMetadata{
+			CheckCount: f.response.GetMetadata().GetCheckCount(),
+		},
+	}, nil
+}
+
+// denied is the response of a check that failed.
+func denied() *base.PermissionCheckResponse {
+	return &base.PermissionCheckResponse{
+		Can: base.PermissionCheckResponse_RESULT_DENIED,
+		Metadata: &base.PermissionCheckResponseMetadata{
+			CheckCount: 0,
+		},
+	}
+}
+
+// flightKey generates the key identical checks are coalesced by. Unlike the cache key it includes
+// the exclusion flag, since the in-flight result has exclusion already applied. The depth is left out,
+// so that the checks of the same sub-problem reached at different depths share a flight.
+func flightKey(key *base.PermissionCheckRequest) string {
+	return fmt.Sprintf("check_%s_%s:%s:%s@%s:%t", key.GetTenantId(), key.GetMetadata().GetSchemaVersion(), key.GetMetadata().GetSnapToken(), tuple.EntityAndRelationToString(&base.EntityAndRelation{
+		Entity:   key.GetEntity(),
+		Relation: key.GetPermission(),
+	}), tuple.SubjectToString(key.GetSubject()), key.GetMetadata().GetExclusion())
+}
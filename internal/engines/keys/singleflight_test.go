@@ -0,0 +1,267 @@
+package keys
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// blockingChecker counts its calls, signals started when one begins and answers them only once release is
+// closed, or fails them when their context is done.
+type blockingChecker struct {
+	calls   int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingChecker() *blockingChecker {
+	return &blockingChecker{started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (b *blockingChecker) Check(ctx context.Context, _ *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	atomic.AddInt32(&b.calls, 1)
+	select {
+	case b.started <- struct{}{}:
+	default:
+	}
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &base.PermissionCheckResponse{
+		Can: base.PermissionCheckResponse_RESULT_ALLOWED,
+		Metadata: &base.PermissionCheckResponseMetadata{
+			CheckCount: 3,
+		},
+	}, nil
+}
+
+// joiningContext signals joined the first time its Done channel is asked for, which a caller of the check engine
+// does once it is waiting for the result of a check.
+type joiningContext struct {
+	context.Context
+	once   sync.Once
+	joined chan struct{}
+}
+
+func (c *joiningContext) Done() <-chan struct{} {
+	c.once.Do(func() {
+		c.joined <- struct{}{}
+	})
+	return c.Context.Done()
+}
+
+func TestCheckEngineWithSingleflight_CollapsesIdenticalChecks(t *testing.T) {
+	checker := newBlockingChecker()
+	engine := NewCheckEngineWithSingleflight(checker)
+
+	checkReq := &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "test_version",
+			SnapToken:     "test_snap_token",
+			Depth:         20,
+		},
+		Entity: &base.Entity{
+			Type: "test-entity",
+			Id:   "e1",
+		},
+		Permission: "test-permission",
+		Subject: &base.Subject{
+			Type: tuple.USER,
+			Id:   "u1",
+		},
+	}
+
+	// Start several identical checks while the first one is still in flight
+	var wg sync.WaitGroup
+	joined := make(chan struct{})
+	responses := make([]*base.PermissionCheckResponse, 5)
+	for i := range responses {
+		var ctx context.Context = &joiningContext{Context: context.Background(), joined: joined}
+		if i == 0 {
+			ctx = context.Background()
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := engine.Check(ctx, checkReq)
+			assert.Nil(t, err)
+			responses[i] = res
+		}(i)
+		if i == 0 {
+			<-checker.started
+		}
+	}
+
+	// Release the in-flight check once every other caller has joined it
+	for range responses[1:] {
+		<-joined
+	}
+	close(checker.release)
+	wg.Wait()
+
+	// Check that the underlying checker was called once and every caller got its own copy of the result
+	assert.Equal(t, int32(1), atomic.LoadInt32(&checker.calls))
+	for i, res := range responses {
+		assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, res.GetCan())
+		assert.Equal(t, int32(3), res.GetMetadata().GetCheckCount())
+		for _, other := range responses[i+1:] {
+			assert.NotSame(t, res, other)
+		}
+	}
+}
+
+func TestCheckEngineWithSingleflight_DoesNotCollapseExclusion(t *testing.T) {
+	checker := newBlockingChecker()
+	close(checker.release)
+	engine := NewCheckEngineWithSingleflight(checker)
+
+	checkReq := &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "test_version",
+			SnapToken:     "test_snap_token",
+			Depth:         20,
+		},
+		Entity: &base.Entity{
+			Type: "test-entity",
+			Id:   "e1",
+		},
+		Permission: "test-permission",
+		Subject: &base.Subject{
+			Type: tuple.USER,
+			Id:   "u1",
+		},
+	}
+
+	excludedReq := &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "test_version",
+			SnapToken:     "test_snap_token",
+			Exclusion:     true,
+			Depth:         20,
+		},
+		Entity:     checkReq.GetEntity(),
+		Permission: checkReq.GetPermission(),
+		Subject:    checkReq.GetSubject(),
+	}
+
+	// Check that the exclusion flag is part of the key
+	assert.NotEqual(t, flightKey(checkReq), flightKey(excludedReq))
+
+	_, err := engine.Check(context.Background(), excludedReq)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&checker.calls))
+}
+
+func TestCheckEngineWithSingleflight_RetriesCancelledFlight(t *testing.T) {
+	checker := newBlockingChecker()
+	engine := NewCheckEngineWithSingleflight(checker)
+
+	checkReq := &base.PermissionCheckRequest{
+		TenantId: "t1",
+		Metadata: &base.PermissionCheckRequestMetadata{
+			SchemaVersion: "test_version",
+			SnapToken:     "test_snap_token",
+			Depth:         20,
+		},
+		Entity: &base.Entity{
+			Type: "test-entity",
+			Id:   "e1",
+		},
+		Permission: "test-permission",
+		Subject: &base.Subject{
+			Type: tuple.USER,
+			Id:   "u1",
+		},
+	}
+
+	// The first caller is cancelled while the second one waits for its check
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := engine.Check(ctx, checkReq)
+		first <- err
+	}()
+	<-checker.started
+
+	second := make(chan *base.PermissionCheckResponse)
+	go func() {
+		res, err := engine.Check(context.Background(), checkReq)
+		assert.Nil(t, err)
+		second <- res
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	// The cancellation of the first caller does not fail the second one, it makes the check itself
+	<-checker.started
+	close(checker.release)
+	assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, (<-second).GetCan())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&checker.calls))
+}
+
+func TestCheckEngineWithSingleflight_JoinsFlightsOfLowerDepth(t *testing.T) {
+	checker := newBlockingChecker()
+	engine := NewCheckEngineWithSingleflight(checker)
+
+	request := func(depth int32) *base.PermissionCheckRequest {
+		return &base.PermissionCheckRequest{
+			TenantId: "t1",
+			Metadata: &base.PermissionCheckRequestMetadata{
+				SchemaVersion: "test_version",
+				SnapToken:     "test_snap_token",
+				Depth:         depth,
+			},
+			Entity: &base.Entity{
+				Type: "test-entity",
+				Id:   "e1",
+			},
+			Permission: "test-permission",
+			Subject: &base.Subject{
+				Type: tuple.USER,
+				Id:   "u1",
+			},
+		}
+	}
+
+	// Check that the depth is not part of the key
+	assert.Equal(t, flightKey(request(10)), flightKey(request(20)))
+
+	var wg sync.WaitGroup
+	check := func(ctx context.Context, depth int32) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := engine.Check(ctx, request(depth))
+			assert.Nil(t, err)
+			assert.Equal(t, base.PermissionCheckResponse_RESULT_ALLOWED, res.GetCan())
+		}()
+	}
+
+	check(context.Background(), 10)
+	<-checker.started
+
+	// A check with more depth joins the flight
+	joined := make(chan struct{})
+	check(&joiningContext{Context: context.Background(), joined: joined}, 20)
+	<-joined
+
+	// A check with less depth is made on its own
+	check(context.Background(), 5)
+	<-checker.started
+
+	close(checker.release)
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&checker.calls))
+}
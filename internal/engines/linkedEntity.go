@@ -38,11 +38,24 @@ func (engine *LinkedEntityEngine) LinkedEntity(
 	request *base.PermissionLinkedEntityRequest, // A permission request for linked entities.
 	visits *ERMap, // A map that keeps track of visited entities to avoid infinite loops.
 	publisher *BulkPublisher, // A custom publisher that publishes results in bulk.
+) (err error) { // Returns an error if one occurs during execution.
+	return engine.linkedEntity(ctx, request, visits, publisher, true)
+}
+
+// linkedEntity executes a permission request for linked entities. The subject of the request is either the subject of
+// the lookup itself, or a subject set that was found on the way to the entity reference. Only the subject of the lookup
+// has to satisfy every child of an intersection itself, which lets the entrances be narrowed to one of the children.
+func (engine *LinkedEntityEngine) linkedEntity(
+	ctx context.Context, // A context used for tracing and cancellation.
+	request *base.PermissionLinkedEntityRequest, // A permission request for linked entities.
+	visits *ERMap, // A map that keeps track of visited entities to avoid infinite loops.
+	publisher *BulkPublisher, // A custom publisher that publishes results in bulk.
+	subject bool, // Whether the subject of the request is the subject of the lookup.
 ) (err error) { // Returns an error if one occurs during execution.
 	// Check if direct result
 	if request.GetEntityReference().GetType() == request.GetSubject().GetType() && request.GetEntityReference().GetRelation() == request.GetSubject().GetRelation() {
-		// TODO: Implement direct result and exclusion logic.
-
+		// Candidates are published without exclusion. Exclusions and intersections are taken into account by the
+		// linked schema graph when the entrances are searched, and every candidate is checked before it is returned.
 		found := &base.Entity{
 			Type: request.GetSubject().GetType(),
 			Id:   request.GetSubject().GetId(),
@@ -66,17 +79,23 @@ func (engine *LinkedEntityEngine) LinkedEntity(
 
 	// Retrieve linked entrances
 	cn := schema.NewLinkedGraph(sc) // Create a new linked graph from the schema definition.
+	target := &base.RelationReference{
+		Type:     request.GetEntityReference().GetType(),
+		Relation: request.GetEntityReference().GetRelation(),
+	}
+	source := &base.RelationReference{
+		Type:     request.GetSubject().GetType(),
+		Relation: request.GetSubject().GetRelation(),
+	}
 	var entrances []*schema.LinkedEntrance
-	entrances, err = cn.RelationshipLinkedEntrances(
-		&base.RelationReference{
-			Type:     request.GetEntityReference().GetType(),
-			Relation: request.GetEntityReference().GetRelation(),
-		},
-		&base.RelationReference{
-			Type:     request.GetSubject().GetType(),
-			Relation: request.GetSubject().GetRelation(),
-		},
-	) // Retrieve the linked entrances between the entity reference and subject.
+	if subject {
+		entrances, err = cn.SubjectLinkedEntrances(target, source) // Retrieve the linked entrances the subject itself can satisfy.
+	} else {
+		entrances, err = cn.RelationshipLinkedEntrances(target, source) // Retrieve the linked entrances between the entity reference and subject.
+	}
+	if err != nil {
+		return err
+	}
 
 	// Create a new context for executing goroutines and a cancel function.
 	cctx, cancel := context.WithCancel(ctx)
@@ -110,6 +129,11 @@ func (engine *LinkedEntityEngine) LinkedEntity(
 			if err != nil {
 				return err
 			}
+		case schema.EntityTypeLinkedEntrance: // If the linked entrance is an entity type entrance.
+			err = engine.entityTypeEntrance(cont, request, entrance, visits, g, publisher) // Call the entity type entrance method.
+			if err != nil {
+				return err
+			}
 		default:
 			return errors.New("unknown linked entrance type") // Return an error if the linked entrance is of an unknown type.
 		}
@@ -206,6 +230,58 @@ func (engine *LinkedEntityEngine) tupleToUserSetEntrance(
 	return nil
 }
 
+// entityTypeEntrance is a method of the LinkedEntityEngine struct. It handles entity type entrances, which are
+// reached when the target can be granted by an exclusion, by treating every entity of the target type as found.
+func (engine *LinkedEntityEngine) entityTypeEntrance(
+	ctx context.Context, // A context used for tracing and cancellation.
+	request *base.PermissionLinkedEntityRequest, // A permission request for linked entities.
+	entrance *schema.LinkedEntrance, // A linked entrance.
+	visits *ERMap, // A map that keeps track of visited entities to avoid infinite loops.
+	g *errgroup.Group, // An errgroup used for executing goroutines.
+	publisher *BulkPublisher, // A custom publisher that publishes results in bulk.
+) error { // Returns an error if one occurs during execution.
+	// The entities of a type only have to be read once per lookup. No entity has an empty id, so the
+	// entity type itself is recorded under one.
+	if !visits.Add(&base.EntityAndRelation{
+		Entity: &base.Entity{
+			Type: entrance.TargetEntrance.GetType(),
+			Id:   "",
+		},
+		Relation: entrance.TargetEntrance.GetRelation(),
+	}) {
+		return nil
+	}
+
+	it, err := engine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+		Entity: &base.EntityFilter{
+			Type: entrance.TargetEntrance.GetType(),
+			Ids:  []string{},
+		},
+	}, request.GetMetadata().GetSnapToken()) // Query the relationship reader for every relationship of the target entity type.
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]struct{}{}
+	for it.HasNext() { // Loop over each relationship.
+		current := it.GetNext()
+		if _, ok := ids[current.GetEntity().GetId()]; ok {
+			continue
+		}
+		ids[current.GetEntity().GetId()] = struct{}{}
+		g.Go(func() error {
+			return engine.l(ctx, request, &base.EntityAndRelation{ // Call the run method with a new entity and relation.
+				Entity: &base.Entity{
+					Type: entrance.TargetEntrance.GetType(),
+					Id:   current.GetEntity().GetId(),
+				},
+				Relation: entrance.TargetEntrance.GetRelation(),
+			}, visits, g, publisher)
+		})
+	}
+	return nil
+}
+
 // run is a method of the LinkedEntityEngine struct. It executes the linked entity engine for a given request.
 func (engine *LinkedEntityEngine) l(
 	ctx context.Context, // A context used for tracing and cancellation.
@@ -254,7 +330,7 @@ func (engine *LinkedEntityEngine) l(
 	}
 
 	g.Go(func() error {
-		return engine.LinkedEntity(ctx, &base.PermissionLinkedEntityRequest{ // Call the Run method recursively with a new permission request.
+		return engine.linkedEntity(ctx, &base.PermissionLinkedEntityRequest{ // Call the Run method recursively with a new permission request.
 			TenantId:        request.GetTenantId(),
 			EntityReference: request.GetEntityReference(),
 			Subject: &base.Subject{
@@ -263,7 +339,7 @@ func (engine *LinkedEntityEngine) l(
 				Relation: found.GetRelation(),
 			},
 			Metadata: request.GetMetadata(),
-		}, visits, publisher)
+		}, visits, publisher, false)
 	})
 	return nil
 }
@@ -2,9 +2,14 @@ package engines
 
 import (
 	"context"
+	"errors"
 	"sync"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Permify/permify/internal/invoke"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
 )
 
 // LookupEntityEngine is a struct that performs permission checks on a set of entities
@@ -16,6 +21,8 @@ type LookupEntityEngine struct {
 	linkedEntityEngine *LinkedEntityEngine
 	// concurrencyLimit is the maximum number of concurrent permission checks allowed
 	concurrencyLimit int
+	// index answers materialized permissions, if set
+	index PermissionIndex
 }
 
 // NewLookupEntityEngine creates a new LookupEntityEngine instance.
@@ -37,8 +44,22 @@ func NewLookupEntityEngine(check *CheckEngine, linked *LinkedEntityEngine, opts
 }
 
 // LookupEntity performs a permission check on a set of entities and returns a response
-// containing the IDs of the entities that have the requested permission.
+// containing the IDs of the entities that have the requested permission. If the request restricts the lookup to
+// candidate entity IDs, the allowed candidates are returned in the order they were given in. Otherwise, if the
+// request sets a page size or a continuous token, the IDs are returned in lexicographic order, one page at a time.
+//
+// A lookup with a budget is evaluated in lexicographic order as well. If it exceeds the budget, the IDs found up to
+// the last entity that was checked in order are returned and the response is marked as truncated. Its continuous
+// token resumes the lookup after that entity.
 func (engine *LookupEntityEngine) LookupEntity(ctx context.Context, request *base.PermissionLookupEntityRequest) (response *base.PermissionLookupEntityResponse, err error) {
+	if len(request.GetEntityIds()) > 0 {
+		return engine.lookupEntityCandidates(ctx, request)
+	}
+
+	if request.GetPageSize() != 0 || request.GetContinuousToken() != "" || request.GetMetadata().GetBudget() != nil {
+		return engine.lookupEntityPage(ctx, request)
+	}
+
 	// Mutex and slice for storing allowed entity IDs
 	var mu sync.Mutex
 	var entityIDs []string
@@ -51,73 +72,253 @@ func (engine *LookupEntityEngine) LookupEntity(ctx context.Context, request *bas
 		}
 	}
 
-	checker := NewBulkChecker(ctx, engine.checkEngine, callback, engine.concurrencyLimit)
-	checker.Start()
+	err = engine.lookup(ctx, request, callback)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create and start BulkPublisher
-	publisher := NewBulkPublisher(ctx, request, checker)
+	// Return response containing allowed entity IDs
+	return &base.PermissionLookupEntityResponse{
+		EntityIds: entityIDs,
+	}, nil
+}
 
-	// Create ERMap for storing visited entities
-	visits := &ERMap{}
+// lookupEntityCandidates returns the candidate entity IDs of the request that have the requested permission, in the
+// order of the request.
+func (engine *LookupEntityEngine) lookupEntityCandidates(ctx context.Context, request *base.PermissionLookupEntityRequest) (response *base.PermissionLookupEntityResponse, err error) {
+	// Set of allowed candidates
+	var mu sync.Mutex
+	allowed := map[string]struct{}{}
 
-	// Get unique entity IDs by entity type
-	err = engine.linkedEntityEngine.LinkedEntity(ctx, &base.PermissionLinkedEntityRequest{
-		TenantId: request.GetTenantId(),
-		Metadata: &base.PermissionLinkedEntityRequestMetadata{
-			SnapToken:     request.GetMetadata().GetSnapToken(),
-			SchemaVersion: request.GetMetadata().GetSchemaVersion(),
-			Depth:         request.GetMetadata().GetDepth(),
-		},
-		EntityReference: &base.RelationReference{
-			Type:     request.GetEntityType(),
-			Relation: request.GetPermission(),
-		},
-		Subject: request.GetSubject(),
-	}, visits, publisher)
-	if err != nil {
-		return nil, err
+	callback := func(entityID string, result base.PermissionCheckResponse_Result) {
+		if result == base.PermissionCheckResponse_RESULT_ALLOWED {
+			mu.Lock()
+			defer mu.Unlock()
+			allowed[entityID] = struct{}{}
+		}
 	}
 
-	// Stop input and wait for BulkChecker to finish
-	checker.Stop()
-	err = checker.Wait()
+	truncated := false
+	err = engine.lookup(ctx, request, callback)
+	if err == nil && invoke.BudgetExceeded(ctx, request.GetMetadata().GetBudget()) {
+		// The checks that were not run because the budget was exceeded are dropped without an error
+		err = ctx.Err()
+	}
 	if err != nil {
-		return
+		if !invoke.BudgetExceeded(ctx, request.GetMetadata().GetBudget()) {
+			return nil, err
+		}
+		// Candidates that are missing from a truncated response may not have been checked.
+		truncated = true
+	}
+
+	entityIDs := make([]string, 0, len(allowed))
+	for _, id := range request.GetEntityIds() {
+		if _, ok := allowed[id]; ok {
+			entityIDs = append(entityIDs, id)
+			// A candidate that is given more than once is only returned once.
+			delete(allowed, id)
+		}
 	}
 
-	// Return response containing allowed entity IDs
 	return &base.PermissionLookupEntityResponse{
 		EntityIds: entityIDs,
+		Truncated: truncated,
 	}, nil
 }
 
-// LookupEntityStream performs a permission check on a set of entities and streams the results
-// containing the IDs of the entities that have the requested permission.
-func (engine *LookupEntityEngine) LookupEntityStream(ctx context.Context, request *base.PermissionLookupEntityRequest, server base.Permission_LookupEntityStreamServer) (err error) {
-	// Define callback function for handling permission check results
-	callback := func(entityID string, result base.PermissionCheckResponse_Result) {
-		if result == base.PermissionCheckResponse_RESULT_ALLOWED {
-			err := server.Send(&base.PermissionLookupEntityStreamResponse{
-				EntityId: entityID,
-			})
-			if err != nil {
-				return
+// lookupEntityPage returns the page of allowed entity IDs that follows the continuous token of the request, all of
+// them if the request has no page size. A lookup that is resumed from a continuous token is evaluated at the snap
+// token and the schema version of its first page, so that the pages add up to the result of a single lookup.
+func (engine *LookupEntityEngine) lookupEntityPage(ctx context.Context, request *base.PermissionLookupEntityRequest) (response *base.PermissionLookupEntityResponse, err error) {
+	var after string
+	if request.GetContinuousToken() != "" {
+		var snapToken, schemaVersion string
+		snapToken, schemaVersion, after, err = invoke.ParseLookupCursor(request.GetContinuousToken())
+		if err != nil {
+			return nil, err
+		}
+
+		metadata := proto.Clone(request.GetMetadata()).(*base.PermissionLookupEntityRequestMetadata)
+		if snapToken != "" {
+			metadata.SnapToken = snapToken
+		}
+		if schemaVersion != "" {
+			metadata.SchemaVersion = schemaVersion
+		}
+		request = &base.PermissionLookupEntityRequest{
+			TenantId:   request.GetTenantId(),
+			Metadata:   metadata,
+			EntityType: request.GetEntityType(),
+			Permission: request.GetPermission(),
+			Subject:    request.GetSubject(),
+			PageSize:   request.GetPageSize(),
+		}
+	}
+
+	entityIDs, next, err := engine.lookupInOrder(ctx, request, after, int(request.GetPageSize()))
+	if err != nil {
+		if !invoke.BudgetExceeded(ctx, request.GetMetadata().GetBudget()) {
+			return nil, err
+		}
+
+		// The entity IDs up to the last entity that was checked in order are complete, so the lookup is resumed
+		// after it. A page that is full is not missing any entity ID.
+		return &base.PermissionLookupEntityResponse{
+			EntityIds:       entityIDs,
+			ContinuousToken: invoke.LookupCursor(request.GetMetadata().GetSnapToken(), request.GetMetadata().GetSchemaVersion(), next),
+			Truncated:       request.GetPageSize() == 0 || len(entityIDs) < int(request.GetPageSize()),
+		}, nil
+	}
+
+	ct := ""
+	if next != "" {
+		ct = invoke.LookupCursor(request.GetMetadata().GetSnapToken(), request.GetMetadata().GetSchemaVersion(), next)
+	}
+
+	return &base.PermissionLookupEntityResponse{
+		EntityIds:       entityIDs,
+		ContinuousToken: ct,
+	}, nil
+}
+
+// lookupInOrder reads the entities after the given entity ID in the order of their IDs, and checks them until size of
+// them are allowed, or all of them are checked if size is zero. It returns the allowed entity IDs and, if there are
+// more entities to check, the entity ID the next page starts after. The entities are read and checked in batches of
+// the concurrency limit, so that a page only reads and checks the entities up to its last one. Materialized
+// permissions are answered from the permission index instead.
+//
+// If the lookup fails, the entity IDs allowed up to the last entity that was checked in order are returned along
+// with the ID of that entity, or the given entity ID if none was.
+func (engine *LookupEntityEngine) lookupInOrder(ctx context.Context, request *base.PermissionLookupEntityRequest, after string, size int) (entityIDs []string, next string, err error) {
+	if ids, ok := engine.lookupIndex(ctx, request); ok {
+		entityIDs, next = page(ids, after, size)
+		return entityIDs, next, nil
+	}
+
+	next = after
+	for {
+		n := engine.concurrencyLimit
+		if size > 0 && size+1-len(entityIDs) < n {
+			// One more allowed entity than the page tells whether there is a next page
+			n = size + 1 - len(entityIDs)
+		}
+
+		var ids []string
+		ids, err = engine.checkEngine.relationshipReader.ReadEntityIDs(ctx, request.GetTenantId(), request.GetEntityType(), request.GetMetadata().GetSnapToken(), next, n)
+		if err != nil {
+			return entityIDs, next, err
+		}
+
+		var mu sync.Mutex
+		results := make(map[string]base.PermissionCheckResponse_Result, len(ids))
+		checker := NewBulkChecker(ctx, engine.checkEngine, func(entityID string, result base.PermissionCheckResponse_Result) {
+			mu.Lock()
+			defer mu.Unlock()
+			results[entityID] = result
+		}, engine.concurrencyLimit)
+		checker.Start()
+		publisher := NewBulkPublisher(ctx, request, checker)
+		for _, id := range ids {
+			publisher.Publish(&base.Entity{
+				Type: request.GetEntityType(),
+				Id:   id,
+			}, &base.PermissionCheckRequestMetadata{
+				SnapToken:     request.GetMetadata().GetSnapToken(),
+				SchemaVersion: request.GetMetadata().GetSchemaVersion(),
+				Depth:         request.GetMetadata().GetDepth(),
+			}, base.PermissionCheckResponse_RESULT_UNKNOWN)
+		}
+		checker.Stop()
+		err = checker.Wait()
+
+		for _, id := range ids {
+			result, ok := results[id]
+			if !ok {
+				if err == nil {
+					err = ctx.Err()
+				}
+				return entityIDs, next, err
+			}
+			if result == base.PermissionCheckResponse_RESULT_ALLOWED {
+				if size > 0 && len(entityIDs) == size {
+					return entityIDs, entityIDs[size-1], nil
+				}
+				entityIDs = append(entityIDs, id)
+			}
+			next = id
+		}
+		if err != nil {
+			return entityIDs, next, err
+		}
+		if len(ids) < n {
+			return entityIDs, "", nil
+		}
+	}
+}
+
+// lookup finds the entities that may have the requested permission, checks them, and calls the callback with the
+// result of every check. If the request restricts the lookup
+// to candidate entity IDs, only the candidates are checked, and the sub-problems they share are resolved once through
+// the invoker of the check engine. Materialized permissions are answered from the permission index instead, if it is
+// current at the schema version and snapshot of the request.
+func (engine *LookupEntityEngine) lookup(ctx context.Context, request *base.PermissionLookupEntityRequest, callback func(entityID string, result base.PermissionCheckResponse_Result)) (err error) {
+	if entityIDs, ok := engine.lookupIndex(ctx, request); ok {
+		candidates := map[string]struct{}{}
+		for _, id := range request.GetEntityIds() {
+			candidates[id] = struct{}{}
+		}
+		for _, id := range entityIDs {
+			if _, ok := candidates[id]; len(candidates) > 0 && !ok {
+				continue
 			}
+			callback(id, base.PermissionCheckResponse_RESULT_ALLOWED)
 		}
+		return nil
 	}
 
-	// Create and start BulkChecker with callback function
 	checker := NewBulkChecker(ctx, engine.checkEngine, callback, engine.concurrencyLimit)
 	checker.Start()
 
 	// Create and start BulkPublisher
 	publisher := NewBulkPublisher(ctx, request, checker)
 
+	if len(request.GetEntityIds()) > 0 {
+		// Publish every candidate once
+		candidates := map[string]struct{}{}
+		for _, id := range request.GetEntityIds() {
+			if _, ok := candidates[id]; ok {
+				continue
+			}
+			candidates[id] = struct{}{}
+			publisher.Publish(&base.Entity{
+				Type: request.GetEntityType(),
+				Id:   id,
+			}, &base.PermissionCheckRequestMetadata{
+				SnapToken:     request.GetMetadata().GetSnapToken(),
+				SchemaVersion: request.GetMetadata().GetSchemaVersion(),
+				Depth:         request.GetMetadata().GetDepth(),
+			}, base.PermissionCheckResponse_RESULT_UNKNOWN)
+		}
+	} else {
+		err = engine.linkedEntity(ctx, request, publisher)
+	}
+
+	// Stop input and wait for BulkChecker to finish, so that the callback is not called after the lookup returns
+	checker.Stop()
+	if waitErr := checker.Wait(); err == nil {
+		err = waitErr
+	}
+	return err
+}
+
+// linkedEntity publishes the entities that may have the requested permission, as they are found.
+func (engine *LookupEntityEngine) linkedEntity(ctx context.Context, request *base.PermissionLookupEntityRequest, publisher *BulkPublisher) error {
 	// Create ERMap for storing visited entities
 	visits := &ERMap{}
 
 	// Get unique entity IDs by entity type
-	err = engine.linkedEntityEngine.LinkedEntity(ctx, &base.PermissionLinkedEntityRequest{
+	return engine.linkedEntityEngine.LinkedEntity(ctx, &base.PermissionLinkedEntityRequest{
 		TenantId: request.GetTenantId(),
 		Metadata: &base.PermissionLinkedEntityRequestMetadata{
 			SnapToken:     request.GetMetadata().GetSnapToken(),
@@ -130,13 +331,65 @@ func (engine *LookupEntityEngine) LookupEntityStream(ctx context.Context, reques
 		},
 		Subject: request.GetSubject(),
 	}, visits, publisher)
+}
 
-	// Stop input and wait for BulkChecker to finish
-	checker.Stop()
-	err = checker.Wait()
-	if err != nil {
-		return
+// lookupIndex returns the IDs of the entities that have the requested permission from the permission index, if the
+// permission is materialized and the index is current at the schema version and snapshot of the request.
+func (engine *LookupEntityEngine) lookupIndex(ctx context.Context, request *base.PermissionLookupEntityRequest) ([]string, bool) {
+	if engine.index == nil || !tuple.IsSubjectUser(request.GetSubject()) {
+		return nil, false
 	}
 
-	return err
+	en, _, err := engine.checkEngine.schemaReader.ReadSchemaDefinition(ctx, request.GetTenantId(), request.GetEntityType(), request.GetMetadata().GetSchemaVersion())
+	if err != nil || !en.GetPermissions()[request.GetPermission()].GetMaterialized() {
+		return nil, false
+	}
+
+	return engine.index.LookupEntity(ctx, request.GetTenantId(), request.GetMetadata().GetSchemaVersion(), request.GetMetadata().GetSnapToken(), request.GetEntityType(), request.GetPermission(), request.GetSubject())
+}
+
+// LookupEntityStream performs a permission check on a set of entities and streams the results
+// containing the IDs of the entities that have the requested permission, as soon as they are found.
+// If the lookup exceeds the budget of the request, the last response of the stream is marked as truncated. Each
+// response is held back until the next one is found, so that the last one can be marked.
+func (engine *LookupEntityEngine) LookupEntityStream(ctx context.Context, request *base.PermissionLookupEntityRequest, server base.Permission_LookupEntityStreamServer) (err error) {
+	var mu sync.Mutex
+	var last *base.PermissionLookupEntityStreamResponse
+
+	// Define callback function for handling permission check results
+	callback := func(entityID string, result base.PermissionCheckResponse_Result) {
+		if result == base.PermissionCheckResponse_RESULT_ALLOWED {
+			mu.Lock()
+			defer mu.Unlock()
+			if last != nil {
+				if err := server.Send(last); err != nil {
+					return
+				}
+			}
+			last = &base.PermissionLookupEntityStreamResponse{
+				EntityId: entityID,
+			}
+		}
+	}
+
+	err = engine.lookup(ctx, request, callback)
+	if err == nil && invoke.BudgetExceeded(ctx, request.GetMetadata().GetBudget()) {
+		// The checks that were not run because the budget was exceeded are dropped without an error
+		err = ctx.Err()
+	}
+	if err != nil {
+		if !invoke.BudgetExceeded(ctx, request.GetMetadata().GetBudget()) {
+			return err
+		}
+		if last == nil {
+			// There is no response to mark, the budget was exceeded before an entity was found
+			return errors.New(base.ErrorCode_ERROR_CODE_CANCELLED.String())
+		}
+		// Tell the client that the entity IDs streamed so far are not complete
+		last.Truncated = true
+	}
+	if last != nil {
+		return server.Send(last)
+	}
+	return nil
 }
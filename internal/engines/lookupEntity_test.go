@@ -1,8 +1,575 @@
 package engines
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/Permify/permify/internal/invoke"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
 )
 
 var _ = Describe("lookup-entity-engine", func() {
+	const (
+		tenantID = "t1"
+		users    = 4
+		orgs     = 3
+		docs     = 8
+	)
+
+	// expression generates a random permission expression over the given leaves, with some of them excluded.
+	var expression func(r *rand.Rand, leaves []string, depth int) string
+	expression = func(r *rand.Rand, leaves []string, depth int) string {
+		if depth == 0 || r.Intn(10) < 4 {
+			leaf := leaves[r.Intn(len(leaves))]
+			if r.Intn(4) == 0 {
+				return "not " + leaf
+			}
+			return leaf
+		}
+		operator := " or "
+		if r.Intn(2) == 0 {
+			operator = " and "
+		}
+		return "(" + expression(r, leaves, depth-1) + operator + expression(r, leaves, depth-1) + ")"
+	}
+
+	// randomSchema generates a schema whose permissions are random combinations of unions, intersections and
+	// exclusions over direct relations, subject sets, tuple to user sets and other permissions.
+	randomSchema := func(r *rand.Rand) string {
+		return fmt.Sprintf(`
+entity user {}
+
+entity org {
+	relation member @user
+	relation banned @user
+
+	permission allowed = %s
+}
+
+entity doc {
+	relation org @org
+	relation owner @user
+	relation editor @user @org#member
+	relation viewer @user
+
+	permission edit = %s
+	permission view = %s
+}
+`,
+			expression(r, []string{"member", "banned"}, 2),
+			expression(r, []string{"owner", "editor", "viewer", "org.member", "org.banned", "org.allowed"}, 2),
+			expression(r, []string{"owner", "editor", "viewer", "edit", "org.member", "org.allowed"}, 3),
+		)
+	}
+
+	// randomTuples generates random relationships for the schema. Every document belongs to an organization,
+	// so that every document is known to the storage.
+	randomTuples := func(r *rand.Rand) []*base.Tuple {
+		var tuples []*base.Tuple
+		add := func(entityType string, entityID int, relation string, subject *base.Subject) {
+			tuples = append(tuples, &base.Tuple{
+				Entity:   &base.Entity{Type: entityType, Id: fmt.Sprint(entityID)},
+				Relation: relation,
+				Subject:  subject,
+			})
+		}
+		user := func(id int) *base.Subject {
+			return &base.Subject{Type: tuple.USER, Id: fmt.Sprint(id)}
+		}
+
+		for o := 0; o < orgs; o++ {
+			for u := 0; u < users; u++ {
+				for _, relation := range []string{"member", "banned"} {
+					if r.Intn(3) == 0 {
+						add("org", o, relation, user(u))
+					}
+				}
+			}
+		}
+
+		for d := 0; d < docs; d++ {
+			add("doc", d, "org", &base.Subject{Type: "org", Id: fmt.Sprint(r.Intn(orgs)), Relation: tuple.ELLIPSIS})
+			for u := 0; u < users; u++ {
+				for _, relation := range []string{"owner", "editor", "viewer"} {
+					if r.Intn(4) == 0 {
+						add("doc", d, relation, user(u))
+					}
+				}
+			}
+			if r.Intn(4) == 0 {
+				add("doc", d, "editor", &base.Subject{Type: "org", Id: fmt.Sprint(r.Intn(orgs)), Relation: "member"})
+			}
+		}
+		return tuples
+	}
+
+	Context("Random Schemas", func() {
+		It("should find the same entities as checking every entity", func() {
+			for seed := int64(0); seed < 50; seed++ {
+				r := rand.New(rand.NewSource(seed))
+				ctx := context.Background()
+
+				db, err := IMDatabase.New(migrations.Schema)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				l := logger.New("error")
+				schemaReader := memory.NewSchemaReader(db, l)
+				relationshipReader := memory.NewRelationshipReader(db, l)
+
+				// SCHEMA
+
+				definition := randomSchema(r)
+				writeSchema(ctx, db, l, tenantID, "v1", definition)
+
+				// RELATIONSHIPS
+
+				snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, tenantID, database.NewTupleCollection(randomTuples(r)...))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				// ENGINES
+
+				checkEngine := NewCheckEngine(schemaReader, relationshipReader)
+				checkEngine.SetInvoker(checkEngine)
+				lookupEntityEngine := NewLookupEntityEngine(checkEngine, NewLinkedEntityEngine(schemaReader, relationshipReader))
+
+				for _, permission := range []string{"edit", "view"} {
+					for u := 0; u < users; u++ {
+						subject := &base.Subject{Type: tuple.USER, Id: fmt.Sprint(u)}
+
+						var expected []string
+						for d := 0; d < docs; d++ {
+							res, err := checkEngine.Check(ctx, &base.PermissionCheckRequest{
+								TenantId: tenantID,
+								Metadata: &base.PermissionCheckRequestMetadata{
+									SnapToken:     snap.String(),
+									SchemaVersion: "v1",
+									Depth:         20,
+								},
+								Entity:     &base.Entity{Type: "doc", Id: fmt.Sprint(d)},
+								Permission: permission,
+								Subject:    subject,
+							})
+							Expect(err).ShouldNot(HaveOccurred())
+							if res.GetCan() == base.PermissionCheckResponse_RESULT_ALLOWED {
+								expected = append(expected, fmt.Sprint(d))
+							}
+						}
+
+						res, err := lookupEntityEngine.LookupEntity(ctx, &base.PermissionLookupEntityRequest{
+							TenantId: tenantID,
+							Metadata: &base.PermissionLookupEntityRequestMetadata{
+								SnapToken:     snap.String(),
+								SchemaVersion: "v1",
+								Depth:         20,
+							},
+							EntityType: "doc",
+							Permission: permission,
+							Subject:    subject,
+						})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						actual := res.GetEntityIds()
+						sort.Strings(actual)
+						sort.Strings(expected)
+						Expect(strings.Join(actual, ",")).Should(Equal(strings.Join(expected, ",")),
+							"seed %d, %s of user %d in schema:\n%s", seed, permission, u, definition)
+
+						// The pages of a paginated lookup add up to the same entities, in order
+						var paged []string
+						ct := ""
+						for {
+							res, err = lookupEntityEngine.LookupEntity(ctx, &base.PermissionLookupEntityRequest{
+								TenantId: tenantID,
+								Metadata: &base.PermissionLookupEntityRequestMetadata{
+									SnapToken:     snap.String(),
+									SchemaVersion: "v1",
+									Depth:         20,
+								},
+								EntityType:      "doc",
+								Permission:      permission,
+								Subject:         subject,
+								PageSize:        3,
+								ContinuousToken: ct,
+							})
+							Expect(err).ShouldNot(HaveOccurred())
+							Expect(len(res.GetEntityIds())).Should(BeNumerically("<=", 3))
+							paged = append(paged, res.GetEntityIds()...)
+							ct = res.GetContinuousToken()
+							if ct == "" {
+								break
+							}
+						}
+						Expect(strings.Join(paged, ",")).Should(Equal(strings.Join(expected, ",")),
+							"seed %d, paginated %s of user %d in schema:\n%s", seed, permission, u, definition)
+
+						// A lookup restricted to candidates returns the allowed candidates in the given order
+						var candidates []string
+						for _, d := range r.Perm(docs)[:docs/2] {
+							candidates = append(candidates, fmt.Sprint(d), fmt.Sprint(d))
+						}
+						var filtered []string
+						for _, id := range candidates {
+							for _, e := range expected {
+								if id == e && (len(filtered) == 0 || filtered[len(filtered)-1] != id) {
+									filtered = append(filtered, id)
+								}
+							}
+						}
+						res, err = lookupEntityEngine.LookupEntity(ctx, &base.PermissionLookupEntityRequest{
+							TenantId: tenantID,
+							Metadata: &base.PermissionLookupEntityRequestMetadata{
+								SnapToken:     snap.String(),
+								SchemaVersion: "v1",
+								Depth:         20,
+							},
+							EntityType: "doc",
+							Permission: permission,
+							Subject:    subject,
+							EntityIds:  candidates,
+						})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(strings.Join(res.GetEntityIds(), ",")).Should(Equal(strings.Join(filtered, ",")),
+							"seed %d, %s of user %d on candidates %v in schema:\n%s", seed, permission, u, candidates, definition)
+					}
+				}
+			}
+		})
+	})
+
+	Context("Pagination", func() {
+		It("should resume from the continuous token at the snap token and the schema version of the first page", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+			relationshipWriter := memory.NewRelationshipWriter(db, l)
+
+			writeSchema(ctx, db, l, tenantID, "v1", `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	permission view = viewer
+}
+`)
+
+			viewer := func(ids ...string) *database.TupleCollection {
+				var tuples []*base.Tuple
+				for _, id := range ids {
+					tuples = append(tuples, &base.Tuple{
+						Entity:   &base.Entity{Type: "doc", Id: id},
+						Relation: "viewer",
+						Subject:  &base.Subject{Type: tuple.USER, Id: "1"},
+					})
+				}
+				return database.NewTupleCollection(tuples...)
+			}
+
+			snap, err := relationshipWriter.WriteRelationships(ctx, tenantID, viewer("d", "b", "e", "a", "c"))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			reader := &entityIDsReader{RelationshipReader: relationshipReader}
+			checkEngine := NewCheckEngine(schemaReader, reader)
+			checkEngine.SetInvoker(checkEngine)
+			lookupEntityEngine := NewLookupEntityEngine(checkEngine, NewLinkedEntityEngine(schemaReader, reader))
+
+			request := func(ct string) *base.PermissionLookupEntityRequest {
+				return &base.PermissionLookupEntityRequest{
+					TenantId: tenantID,
+					Metadata: &base.PermissionLookupEntityRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+					},
+					EntityType:      "doc",
+					Permission:      "view",
+					Subject:         &base.Subject{Type: tuple.USER, Id: "1"},
+					PageSize:        2,
+					ContinuousToken: ct,
+				}
+			}
+
+			res, err := lookupEntityEngine.LookupEntity(ctx, request(""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"a", "b"}))
+			Expect(res.GetContinuousToken()).ShouldNot(BeEmpty())
+
+			// A page only reads its own entities, and the next one that tells whether there is a next page
+			Expect(reader.read).Should(Equal([]string{"a", "b", "c"}))
+
+			// The following pages are evaluated at the snap token and the schema version of the first page, and resumed
+			// after its last entity id
+			snapToken, schemaVersion, after, err := invoke.ParseLookupCursor(res.GetContinuousToken())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(snapToken).Should(Equal(snap.String()))
+			Expect(schemaVersion).Should(Equal("v1"))
+			Expect(after).Should(Equal("b"))
+
+			_, err = relationshipWriter.WriteRelationships(ctx, tenantID, viewer("bb"))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			next := request(res.GetContinuousToken())
+			next.Metadata.SnapToken = ""
+			next.Metadata.SchemaVersion = ""
+			res, err = lookupEntityEngine.LookupEntity(ctx, next)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"c", "d"}))
+
+			snapToken, _, after, err = invoke.ParseLookupCursor(res.GetContinuousToken())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(snapToken).Should(Equal(snap.String()))
+			Expect(after).Should(Equal("d"))
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"e"}))
+			Expect(res.GetContinuousToken()).Should(BeEmpty())
+
+			_, err = lookupEntityEngine.LookupEntity(ctx, request("invalid"))
+			Expect(err).Should(Equal(errors.New(base.ErrorCode_ERROR_CODE_INVALID_CONTINUOUS_TOKEN.String())))
+		})
+	})
+
+	Context("Budget", func() {
+		It("should return the entities found within the budget and resume from the continuous token", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+			relationshipWriter := memory.NewRelationshipWriter(db, l)
+
+			writeSchema(ctx, db, l, tenantID, "v1", `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	permission view = viewer
+}
+`)
+
+			var tuples []*base.Tuple
+			for _, id := range []string{"d", "b", "e", "a", "c"} {
+				tuples = append(tuples, &base.Tuple{
+					Entity:   &base.Entity{Type: "doc", Id: id},
+					Relation: "viewer",
+					Subject:  &base.Subject{Type: tuple.USER, Id: "1"},
+				})
+			}
+			snap, err := relationshipWriter.WriteRelationships(ctx, tenantID, database.NewTupleCollection(tuples...))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The checks of c and e do not finish within the budget
+			slowReader := &slowRelationshipReader{
+				RelationshipReader: relationshipReader,
+				slow:               map[string]struct{}{"c": {}, "e": {}},
+			}
+			slowCheckEngine := NewCheckEngine(schemaReader, slowReader)
+			slowCheckEngine.SetInvoker(slowCheckEngine)
+			slowLookupEntityEngine := NewLookupEntityEngine(slowCheckEngine, NewLinkedEntityEngine(schemaReader, relationshipReader))
+
+			checkEngine := NewCheckEngine(schemaReader, relationshipReader)
+			checkEngine.SetInvoker(checkEngine)
+			lookupEntityEngine := NewLookupEntityEngine(checkEngine, NewLinkedEntityEngine(schemaReader, relationshipReader))
+
+			budget := durationpb.New(100 * time.Millisecond)
+			request := func(pageSize uint32, ct string) *base.PermissionLookupEntityRequest {
+				return &base.PermissionLookupEntityRequest{
+					TenantId: tenantID,
+					Metadata: &base.PermissionLookupEntityRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+						Budget:        budget,
+					},
+					EntityType:      "doc",
+					Permission:      "view",
+					Subject:         &base.Subject{Type: tuple.USER, Id: "1"},
+					PageSize:        pageSize,
+					ContinuousToken: ct,
+				}
+			}
+
+			// The invoker bounds the context of a request by its budget. The entities are checked in order, so the
+			// entity ids up to the check of c that does not finish are returned.
+			budgetCtx, cancel := context.WithTimeout(ctx, budget.AsDuration())
+			res, err := slowLookupEntityEngine.LookupEntity(budgetCtx, request(0, ""))
+			cancel()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetTruncated()).Should(BeTrue())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"a", "b"}))
+
+			_, _, after, err := invoke.ParseLookupCursor(res.GetContinuousToken())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(after).Should(Equal("b"))
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(0, res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetTruncated()).Should(BeFalse())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"c", "d", "e"}))
+			Expect(res.GetContinuousToken()).Should(BeEmpty())
+
+			// A truncated page is resumed after the last entity id that was checked
+			budgetCtx, cancel = context.WithTimeout(ctx, budget.AsDuration())
+			res, err = slowLookupEntityEngine.LookupEntity(budgetCtx, request(3, ""))
+			cancel()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetTruncated()).Should(BeTrue())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"a", "b"}))
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(2, res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"c", "d"}))
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(2, res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"e"}))
+			Expect(res.GetContinuousToken()).Should(BeEmpty())
+
+			// A lookup whose budget is exceeded before it checks any entity is resumed from where it started
+			budgetCtx, cancel = context.WithTimeout(ctx, 0)
+			res, err = slowLookupEntityEngine.LookupEntity(budgetCtx, request(0, ""))
+			cancel()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetTruncated()).Should(BeTrue())
+			Expect(res.GetEntityIds()).Should(BeEmpty())
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(0, res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"a", "b", "c", "d", "e"}))
+
+			// A streamed lookup marks its last response as truncated
+			stream := &lookupEntityStreamRecorder{}
+			budgetCtx, cancel = context.WithTimeout(ctx, budget.AsDuration())
+			err = slowLookupEntityEngine.LookupEntityStream(budgetCtx, request(0, ""), stream)
+			cancel()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var streamed []string
+			for i, response := range stream.responses {
+				Expect(response.GetEntityId()).ShouldNot(BeEmpty())
+				Expect(response.GetTruncated()).Should(Equal(i == len(stream.responses)-1))
+				streamed = append(streamed, response.GetEntityId())
+			}
+			sort.Strings(streamed)
+			Expect(streamed).Should(Equal([]string{"a", "b", "d"}))
+
+			// A streamed lookup that found no entity within its budget fails
+			stream = &lookupEntityStreamRecorder{}
+			budgetCtx, cancel = context.WithTimeout(ctx, 0)
+			err = slowLookupEntityEngine.LookupEntityStream(budgetCtx, request(0, ""), stream)
+			cancel()
+			Expect(err).Should(Equal(errors.New(base.ErrorCode_ERROR_CODE_CANCELLED.String())))
+			Expect(stream.responses).Should(BeEmpty())
+		})
+	})
+
+	Context("Materialized", func() {
+		It("should return the entities of the permission index while it is current", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+
+			writeSchema(ctx, db, l, tenantID, "v1", `
+entity user {}
+
+entity doc {
+	relation viewer @user
+
+	materialized permission view = viewer
+}
+`)
+
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, tenantID, database.NewTupleCollection(&base.Tuple{
+				Entity:   &base.Entity{Type: "doc", Id: "a"},
+				Relation: "viewer",
+				Subject:  &base.Subject{Type: tuple.USER, Id: "1"},
+			}))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The index disagrees with the relationships, to tell its answers apart
+			index := &staticIndex{current: true, allowed: []string{"b", "c", "d"}}
+
+			checkEngine := NewCheckEngine(schemaReader, relationshipReader)
+			checkEngine.SetInvoker(checkEngine)
+			lookupEntityEngine := NewLookupEntityEngine(checkEngine, NewLinkedEntityEngine(schemaReader, relationshipReader), LookupEntityPermissionIndex(index))
+
+			request := func(pageSize uint32, ct string) *base.PermissionLookupEntityRequest {
+				return &base.PermissionLookupEntityRequest{
+					TenantId: tenantID,
+					Metadata: &base.PermissionLookupEntityRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+					},
+					EntityType:      "doc",
+					Permission:      "view",
+					Subject:         &base.Subject{Type: tuple.USER, Id: "1"},
+					PageSize:        pageSize,
+					ContinuousToken: ct,
+				}
+			}
+
+			res, err := lookupEntityEngine.LookupEntity(ctx, request(0, ""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"b", "c", "d"}))
+
+			// Pages of the index are resumed from the continuous token
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(2, ""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"b", "c"}))
+
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(2, res.GetContinuousToken()))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"d"}))
+
+			// An index that is not current falls back to the relationships
+			index.current = false
+			res, err = lookupEntityEngine.LookupEntity(ctx, request(0, ""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetEntityIds()).Should(Equal([]string{"a"}))
+		})
+	})
 })
+
+// entityIDsReader records the entity IDs that are read in order from the relationship reader.
+type entityIDsReader struct {
+	storage.RelationshipReader
+	read []string
+}
+
+func (r *entityIDsReader) ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) ([]string, error) {
+	ids, err := r.RelationshipReader.ReadEntityIDs(ctx, tenantID, entityType, snap, after, limit)
+	r.read = append(r.read, ids...)
+	return ids, err
+}
@@ -0,0 +1,201 @@
+package materialize
+
+import (
+	"context"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/dsl/utils"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// dependentKind is the way a relation or permission is computed from another one.
+type dependentKind int
+
+const (
+	// sameEntityDependent is computed from the other relation or permission of the same entity.
+	sameEntityDependent dependentKind = iota
+	// tupleSetDependent is computed from the other permission or relation of the entities its tuple set relation holds.
+	tupleSetDependent
+	// subjectSetDependent is a relation that holds the other relation or permission of entities as subject sets.
+	subjectSetDependent
+)
+
+// dependent is a relation or permission that is computed from another one.
+type dependent struct {
+	kind dependentKind
+	// entityType and name identify the dependent relation or permission
+	entityType string
+	name       string
+	// tupleSet is the tuple set relation of a tuple set dependent
+	tupleSet string
+}
+
+// node is a relation or permission of an entity.
+type node struct {
+	entityType string
+	entityID   string
+	name       string
+}
+
+// graph holds, for every relation and permission of a schema, the relations and permissions that are computed from
+// it, and the materialized permissions of the schema.
+type graph struct {
+	// dependents are the relations and permissions that are computed from a relation or permission, by entity type
+	// and name
+	dependents map[string][]dependent
+	// materialized are the materialized permissions, by entity type and permission
+	materialized map[string]*base.RelationReference
+}
+
+// newGraph creates the dependency graph of the schema.
+func newGraph(sc *base.SchemaDefinition) *graph {
+	g := &graph{
+		dependents:   map[string][]dependent{},
+		materialized: map[string]*base.RelationReference{},
+	}
+
+	for _, definition := range sc.GetEntityDefinitions() {
+		for _, relation := range definition.GetRelations() {
+			for _, reference := range relation.GetRelationReferences() {
+				if reference.GetRelation() == "" {
+					continue
+				}
+				g.add(reference.GetType(), reference.GetRelation(), dependent{
+					kind:       subjectSetDependent,
+					entityType: definition.GetName(),
+					name:       relation.GetName(),
+				})
+			}
+		}
+
+		for _, permission := range definition.GetPermissions() {
+			g.addChild(definition, permission.GetName(), permission.GetChild())
+			if permission.GetMaterialized() {
+				g.materialized[utils.Key(definition.GetName(), permission.GetName())] = &base.RelationReference{
+					Type:     definition.GetName(),
+					Relation: permission.GetName(),
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+// add records that the dependent is computed from the relation or permission of the entity type.
+func (g *graph) add(entityType, name string, d dependent) {
+	key := utils.Key(entityType, name)
+	g.dependents[key] = append(g.dependents[key], d)
+}
+
+// addChild records the relations and permissions the child of the permission of the entity definition is computed
+// from.
+func (g *graph) addChild(definition *base.EntityDefinition, permission string, child *base.Child) {
+	if rewrite := child.GetRewrite(); rewrite != nil {
+		for _, c := range rewrite.GetChildren() {
+			g.addChild(definition, permission, c)
+		}
+		return
+	}
+
+	switch leaf := child.GetLeaf().GetType().(type) {
+	case *base.Leaf_ComputedUserSet:
+		g.add(definition.GetName(), leaf.ComputedUserSet.GetRelation(), dependent{
+			kind:       sameEntityDependent,
+			entityType: definition.GetName(),
+			name:       permission,
+		})
+	case *base.Leaf_TupleToUserSet:
+		tupleSet := leaf.TupleToUserSet.GetTupleSet().GetRelation()
+		g.add(definition.GetName(), tupleSet, dependent{
+			kind:       sameEntityDependent,
+			entityType: definition.GetName(),
+			name:       permission,
+		})
+		for _, reference := range definition.GetRelations()[tupleSet].GetRelationReferences() {
+			if reference.GetRelation() != "" {
+				continue
+			}
+			g.add(reference.GetType(), leaf.TupleToUserSet.GetComputed().GetRelation(), dependent{
+				kind:       tupleSetDependent,
+				entityType: definition.GetName(),
+				name:       permission,
+				tupleSet:   tupleSet,
+			})
+		}
+	}
+}
+
+// affected returns the IDs of the entities, by materialized permission, whose materialized permissions depend on
+// the relations of the given relationships. The relationships that lead from the relationships to the permissions
+// are read at the snapshot.
+func (g *graph) affected(ctx context.Context, reader storage.RelationshipReader, tenantID, snap string, tuples []*base.Tuple) (map[string]map[string]struct{}, error) {
+	result := map[string]map[string]struct{}{}
+	visited := map[node]struct{}{}
+
+	var queue []node
+	push := func(n node) {
+		if _, ok := visited[n]; ok {
+			return
+		}
+		visited[n] = struct{}{}
+		queue = append(queue, n)
+	}
+
+	for _, t := range tuples {
+		push(node{entityType: t.GetEntity().GetType(), entityID: t.GetEntity().GetId(), name: t.GetRelation()})
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		key := utils.Key(current.entityType, current.name)
+		if _, ok := g.materialized[key]; ok {
+			if _, ok := result[key]; !ok {
+				result[key] = map[string]struct{}{}
+			}
+			result[key][current.entityID] = struct{}{}
+		}
+
+		for _, d := range g.dependents[key] {
+			switch d.kind {
+			case sameEntityDependent:
+				push(node{entityType: d.entityType, entityID: current.entityID, name: d.name})
+			case tupleSetDependent, subjectSetDependent:
+				filter := &base.TupleFilter{
+					Entity: &base.EntityFilter{
+						Type: d.entityType,
+						Ids:  []string{},
+					},
+					Relation: d.name,
+					Subject: &base.SubjectFilter{
+						Type:     current.entityType,
+						Ids:      []string{current.entityID},
+						Relation: current.name,
+					},
+				}
+				if d.kind == tupleSetDependent {
+					filter.Relation = d.tupleSet
+					filter.Subject.Relation = ""
+				}
+
+				it, err := reader.QueryRelationships(ctx, tenantID, filter, snap)
+				if err != nil {
+					return nil, err
+				}
+				for it.HasNext() {
+					next := it.GetNext()
+					// Only entities, rather than subject sets, are followed by tuple set relations
+					if d.kind == tupleSetDependent && next.GetSubject().GetRelation() != "" && next.GetSubject().GetRelation() != tuple.ELLIPSIS {
+						continue
+					}
+					push(node{entityType: d.entityType, entityID: next.GetEntity().GetId(), name: d.name})
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
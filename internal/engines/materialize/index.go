@@ -0,0 +1,623 @@
+package materialize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Permify/permify/internal/invoke"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/dsl/utils"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// Index is a materialized index of the permissions that are marked as materialized in the schemas of the tenants.
+// For every materialized permission it holds the subjects that have the permission on each entity, and the entities
+// each subject has the permission on. The index of a tenant is built in the background the first time one of its
+// materialized permissions is asked for. From then on it is kept up to date from the change stream of the storage,
+// which carries the writes of every node as well as restored backups and changes made to the database directly:
+// only the entities whose permissions depend on a changed relationship are evaluated again. The writes made through a
+// RelationshipWriterWithIndex are applied without waiting for the change stream.
+//
+// The index answers at a snapshot only if every write of the tenant made through this node and every streamed change
+// has been applied, and the snapshot is at or after the latest applied change, so that the index holds the permissions
+// at the snapshot. Otherwise the index lags, and the engines walk the relationships instead. Once a relationship of the tenant expires, or the change
+// stream fails, the index of the tenant is dropped and built again.
+type Index struct {
+	schemaReader       storage.SchemaReader
+	relationshipReader storage.RelationshipReader
+	// watcher streams the changes to the relationships of the tenants
+	watcher storage.Watcher
+	// expander evaluates the subjects of a permission
+	expander invoke.Expand
+	// decoder decodes the snap tokens of the requests
+	decoder token.Decoder
+
+	mu      sync.Mutex
+	tenants map[string]*tenantIndex
+	// jobs are the builds and writes that have not been applied yet, in the order they were scheduled
+	jobs []job
+	// wake signals the worker that jobs were scheduled
+	wake chan struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	l      *logger.Logger
+}
+
+// tenantIndex is the index of a tenant.
+type tenantIndex struct {
+	// schemaVersion is the version of the schema the index holds the permissions of
+	schemaVersion string
+	// building is the version of the schema a build has been scheduled for
+	building string
+	// ready reports whether the index has been built
+	ready bool
+	// snapshot is the snapshot the index is current from
+	snapshot token.SnapToken
+	// pending is the number of writes that have not been applied yet
+	pending int
+	// queued is the number of streamed changes that have not been applied yet
+	queued int
+	// expiresAt is the earliest expiration of the relationships of the tenant, zero if none of them expires
+	expiresAt time.Time
+	// graph is the dependency graph of the schema version
+	graph *graph
+	// stop stops the change stream of the index, nil if the index is not built
+	stop context.CancelFunc
+	// stream identifies the latest change stream of the index, so that a stream that was stopped does not drop it
+	stream uint64
+	// permissions are the materialized permissions, by entity type and permission
+	permissions map[string]*permissionIndex
+}
+
+// permissionIndex holds a materialized permission of an entity type.
+type permissionIndex struct {
+	// entities are the subjects that have the permission, by entity ID
+	entities map[string]map[string]struct{}
+	// subjects are the entity IDs the subject has the permission on, by subject
+	subjects map[string]map[string]struct{}
+}
+
+// job is a build of the index of a tenant, or a write to be applied to it.
+type job struct {
+	tenantID string
+	// build reports whether the job is a build
+	build bool
+	// schemaVersion is the version of the schema to build the index for, if the job is a build
+	schemaVersion string
+	// tuples are the relationships that were written or deleted, if the job is a write
+	tuples []*base.Tuple
+	// snapshot is the snapshot of the write
+	snapshot token.SnapToken
+	// invalidate reports whether the write is applied by dropping the index of the tenant, if the job is a write
+	invalidate bool
+	// watched reports whether the write comes from the change stream rather than from a pending write
+	watched bool
+}
+
+// NewIndex creates a new Index that evaluates permissions with the given expander and follows the changes streamed by
+// the watcher, and starts applying the builds and writes that are scheduled to it until the Index is closed.
+func NewIndex(schemaReader storage.SchemaReader, relationshipReader storage.RelationshipReader, watcher storage.Watcher, expander invoke.Expand, decoder token.Decoder, l *logger.Logger) *Index {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	index := &Index{
+		schemaReader:       schemaReader,
+		relationshipReader: relationshipReader,
+		watcher:            watcher,
+		expander:           expander,
+		decoder:            decoder,
+		tenants:            map[string]*tenantIndex{},
+		wake:               make(chan struct{}, 1),
+		cancel:             cancel,
+		done:               make(chan struct{}),
+		l:                  l,
+	}
+
+	go index.work(ctx)
+
+	return index
+}
+
+// Check reports whether the subject has the permission on the entity, and whether the index could answer.
+func (idx *Index) Check(_ context.Context, tenantID, schemaVersion, snapToken string, entity *base.Entity, permission string, subject *base.Subject) (allowed, ok bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	p, ok := idx.current(tenantID, schemaVersion, snapToken, entity.GetType(), permission)
+	if !ok {
+		return false, false
+	}
+	_, allowed = p.entities[entity.GetId()][tuple.SubjectToString(subject)]
+	return allowed, true
+}
+
+// LookupEntity returns the IDs of the entities of the entity type that the subject has the permission on, in
+// lexicographic order, and whether the index could answer.
+func (idx *Index) LookupEntity(_ context.Context, tenantID, schemaVersion, snapToken, entityType, permission string, subject *base.Subject) (entityIDs []string, ok bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	p, ok := idx.current(tenantID, schemaVersion, snapToken, entityType, permission)
+	if !ok {
+		return nil, false
+	}
+	ids := p.subjects[tuple.SubjectToString(subject)]
+	entityIDs = make([]string, 0, len(ids))
+	for id := range ids {
+		entityIDs = append(entityIDs, id)
+	}
+	sort.Strings(entityIDs)
+	return entityIDs, true
+}
+
+// Close stops applying builds and writes to the index.
+func (idx *Index) Close() {
+	idx.cancel()
+	<-idx.done
+}
+
+// current returns the permission of the entity type, if the index of the tenant holds it at the schema version and
+// snapshot. If the index of the tenant is not built for the schema version, a build is scheduled. The caller must
+// hold the lock.
+func (idx *Index) current(tenantID, schemaVersion, snapToken, entityType, permission string) (*permissionIndex, bool) {
+	t := idx.tenant(tenantID)
+	if t.schemaVersion != schemaVersion || !t.ready {
+		if t.building != schemaVersion {
+			t.building = schemaVersion
+			idx.schedule(job{tenantID: tenantID, build: true, schemaVersion: schemaVersion})
+		}
+		return nil, false
+	}
+
+	if t.pending > 0 || t.queued > 0 {
+		return nil, false
+	}
+
+	if !t.expiresAt.IsZero() && !time.Now().Before(t.expiresAt) {
+		t.drop()
+		if t.building != schemaVersion {
+			t.building = schemaVersion
+			idx.schedule(job{tenantID: tenantID, build: true, schemaVersion: schemaVersion})
+		}
+		return nil, false
+	}
+
+	// The index holds the permissions from the latest applied change until the next one, which is not pending
+	requested, err := idx.decoder(snapToken)
+	if err != nil || !(requested.Eg(t.snapshot) || requested.Gt(t.snapshot)) {
+		return nil, false
+	}
+
+	p, ok := t.permissions[utils.Key(entityType, permission)]
+	return p, ok
+}
+
+// tenant returns the index of the tenant, creating an empty one if there is none. The caller must hold the lock.
+func (idx *Index) tenant(tenantID string) *tenantIndex {
+	t, ok := idx.tenants[tenantID]
+	if !ok {
+		t = &tenantIndex{}
+		idx.tenants[tenantID] = t
+	}
+	return t
+}
+
+// begin marks a write of the tenant as pending, so that the index does not answer until the write is applied.
+func (idx *Index) begin(tenantID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.tenant(tenantID).pending++
+}
+
+// abort marks a pending write of the tenant, that failed, as applied.
+func (idx *Index) abort(tenantID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.tenant(tenantID).pending--
+}
+
+// commit schedules a pending write of the tenant to be applied to the index.
+func (idx *Index) commit(tenantID string, tuples []*base.Tuple, snapshot token.EncodedSnapToken) {
+	st, err := snapshot.Decode()
+	if err != nil {
+		idx.invalidate(tenantID)
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.schedule(job{tenantID: tenantID, tuples: tuples, snapshot: st})
+}
+
+// invalidate schedules a pending write of the tenant to be applied by building the index of the tenant again.
+func (idx *Index) invalidate(tenantID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.schedule(job{tenantID: tenantID, invalidate: true})
+}
+
+// schedule adds the job to the jobs of the worker. The caller must hold the lock.
+func (idx *Index) schedule(j job) {
+	idx.jobs = append(idx.jobs, j)
+	select {
+	case idx.wake <- struct{}{}:
+	default:
+	}
+}
+
+// work applies the scheduled jobs one at a time, in the order they were scheduled, until the context is cancelled.
+func (idx *Index) work(ctx context.Context) {
+	defer close(idx.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-idx.wake:
+		}
+
+		for {
+			idx.mu.Lock()
+			if len(idx.jobs) == 0 {
+				idx.mu.Unlock()
+				break
+			}
+			j := idx.jobs[0]
+			idx.jobs = idx.jobs[1:]
+			idx.mu.Unlock()
+
+			if j.build {
+				idx.build(ctx, j.tenantID, j.schemaVersion)
+			} else if j.invalidate {
+				idx.mu.Lock()
+				t := idx.tenant(j.tenantID)
+				t.pending--
+				t.drop()
+				idx.mu.Unlock()
+			} else {
+				idx.apply(ctx, j.tenantID, j.tuples, j.snapshot, j.watched)
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// build builds the index of the tenant for the schema version at the head snapshot, evaluating the materialized
+// permissions of every entity of their entity types. The index is only built for the head version of the schema,
+// so that requests at older versions do not replace it.
+func (idx *Index) build(ctx context.Context, tenantID, schemaVersion string) {
+	t, err := idx.evaluateAll(ctx, tenantID, schemaVersion)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	current := idx.tenant(tenantID)
+	if err != nil {
+		// The build is scheduled again when the index is asked for
+		if current.building == schemaVersion {
+			current.building = ""
+		}
+		idx.l.Error(fmt.Sprintf("failed to build the materialized permission index of tenant %s: %s", tenantID, err.Error()))
+		return
+	}
+	if t == nil {
+		// An older version of the schema never becomes the head version again, so the build is not scheduled
+		// again while the version is asked for
+		return
+	}
+	if current.building == schemaVersion {
+		current.building = ""
+	}
+
+	current.drop()
+	current.schemaVersion = schemaVersion
+	current.ready = true
+	current.snapshot = t.snapshot
+	current.graph = t.graph
+	current.permissions = t.permissions
+	current.expiresAt = t.expiresAt
+	idx.watch(ctx, tenantID, current)
+}
+
+// watch follows the changes to the relationships of the tenant committed after the snapshot of its index, and
+// schedules them to be applied to the index. If the change stream fails, the index of the tenant is dropped, and
+// built again when it is asked for. The caller must hold the lock.
+func (idx *Index) watch(ctx context.Context, tenantID string, t *tenantIndex) {
+	ctx, cancel := context.WithCancel(ctx)
+	t.stop = cancel
+	t.stream++
+	stream := t.stream
+
+	changes, errs := idx.watcher.Watch(ctx, tenantID, t.snapshot.Encode().String())
+
+	go func() {
+		for {
+			select {
+			case c, ok := <-changes:
+				if !ok {
+					changes = nil
+					if errs == nil {
+						idx.unwatch(ctx, tenantID, stream, nil)
+						return
+					}
+					continue
+				}
+
+				var tuples []*base.Tuple
+				for _, change := range c.GetTupleChanges() {
+					tuples = append(tuples, change.GetTuple())
+				}
+				st, err := idx.decoder(c.GetSnapToken())
+				if err != nil {
+					idx.unwatch(ctx, tenantID, stream, err)
+					return
+				}
+
+				idx.mu.Lock()
+				if idx.tenant(tenantID).stream != stream {
+					idx.mu.Unlock()
+					return
+				}
+				idx.tenant(tenantID).queued++
+				idx.schedule(job{tenantID: tenantID, tuples: tuples, snapshot: st, watched: true})
+				idx.mu.Unlock()
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					if changes == nil {
+						idx.unwatch(ctx, tenantID, stream, nil)
+						return
+					}
+					continue
+				}
+				idx.unwatch(ctx, tenantID, stream, err)
+				return
+			}
+		}
+	}()
+}
+
+// unwatch drops the index of the tenant once its change stream ends, unless the stream was stopped.
+func (idx *Index) unwatch(ctx context.Context, tenantID string, stream uint64, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	t := idx.tenant(tenantID)
+	if t.stream != stream {
+		return
+	}
+	if err != nil {
+		idx.l.Error(fmt.Sprintf("failed to watch the relationships of tenant %s: %s", tenantID, err.Error()))
+	}
+	t.drop()
+}
+
+// evaluateAll evaluates the materialized permissions of every entity of the tenant at the head snapshot. It returns
+// nil if the schema version is not the head version.
+func (idx *Index) evaluateAll(ctx context.Context, tenantID, schemaVersion string) (*tenantIndex, error) {
+	head, err := idx.schemaReader.HeadVersion(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if head != schemaVersion {
+		return nil, nil
+	}
+
+	sc, err := idx.schemaReader.ReadSchema(ctx, tenantID, schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := idx.relationshipReader.HeadSnapshot(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	snap := snapshot.Encode().String()
+
+	t := &tenantIndex{
+		snapshot:    snapshot,
+		graph:       newGraph(sc),
+		permissions: map[string]*permissionIndex{},
+	}
+
+	// Every relationship is read to find the earliest expiration, as the permissions may depend on any of them
+	it, err := idx.relationshipReader.QueryRelationships(ctx, tenantID, &base.TupleFilter{}, snap)
+	if err != nil {
+		return nil, err
+	}
+	for it.HasNext() {
+		t.expire(it.GetNext())
+	}
+
+	for _, reference := range t.graph.materialized {
+		p := &permissionIndex{
+			entities: map[string]map[string]struct{}{},
+			subjects: map[string]map[string]struct{}{},
+		}
+		t.permissions[utils.Key(reference.GetType(), reference.GetRelation())] = p
+
+		it, err := idx.relationshipReader.QueryRelationships(ctx, tenantID, &base.TupleFilter{
+			Entity: &base.EntityFilter{
+				Type: reference.GetType(),
+				Ids:  []string{},
+			},
+		}, snap)
+		if err != nil {
+			return nil, err
+		}
+
+		for it.HasNext() {
+			id := it.GetNext().GetEntity().GetId()
+			if _, ok := p.entities[id]; ok {
+				continue
+			}
+			subjects, err := idx.evaluate(ctx, tenantID, schemaVersion, snap, &base.Entity{Type: reference.GetType(), Id: id}, reference.GetRelation())
+			if err != nil {
+				return nil, err
+			}
+			p.set(id, subjects)
+		}
+	}
+
+	return t, nil
+}
+
+// apply evaluates the materialized permissions that depend on the written or deleted relationships again, and marks
+// the write as applied if it was pending. If the index of the tenant is not built, the write is already part of the
+// next build. If the write cannot be applied, the index of the tenant is dropped, and built again when it is asked
+// for. A write made through this node is applied again once it is streamed, which evaluates the same permissions.
+func (idx *Index) apply(ctx context.Context, tenantID string, tuples []*base.Tuple, snapshot token.SnapToken, watched bool) {
+	idx.mu.Lock()
+	t := idx.tenant(tenantID)
+	ready, schemaVersion, g := t.ready, t.schemaVersion, t.graph
+	idx.mu.Unlock()
+
+	var err error
+	if ready {
+		err = idx.reevaluate(ctx, tenantID, schemaVersion, g, tuples)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if watched {
+		t.queued--
+	} else {
+		t.pending--
+	}
+	if err != nil {
+		idx.l.Error(fmt.Sprintf("failed to update the materialized permission index of tenant %s: %s", tenantID, err.Error()))
+		t.drop()
+		return
+	}
+
+	for _, tup := range tuples {
+		t.expire(tup)
+	}
+
+	if t.snapshot == nil || snapshot.Gt(t.snapshot) {
+		t.snapshot = snapshot
+	}
+}
+
+// reevaluate evaluates the materialized permissions that depend on the relationships again, at the head snapshot.
+func (idx *Index) reevaluate(ctx context.Context, tenantID, schemaVersion string, g *graph, tuples []*base.Tuple) error {
+	snapshot, err := idx.relationshipReader.HeadSnapshot(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	snap := snapshot.Encode().String()
+
+	affected, err := g.affected(ctx, idx.relationshipReader, tenantID, snap, tuples)
+	if err != nil {
+		return err
+	}
+
+	for key, ids := range affected {
+		reference := g.materialized[key]
+		for id := range ids {
+			subjects, err := idx.evaluate(ctx, tenantID, schemaVersion, snap, &base.Entity{Type: reference.GetType(), Id: id}, reference.GetRelation())
+			if err != nil {
+				return err
+			}
+
+			idx.mu.Lock()
+			if p, ok := idx.tenant(tenantID).permissions[key]; ok {
+				p.set(id, subjects)
+			}
+			idx.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// drop drops the permissions of the tenant and stops following its changes, so that the index does not answer until
+// it is built again.
+func (t *tenantIndex) drop() {
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+	t.ready = false
+	t.schemaVersion = ""
+	t.permissions = nil
+	t.expiresAt = time.Time{}
+}
+
+// expire keeps the earliest expiration of the relationships of the tenant.
+func (t *tenantIndex) expire(tup *base.Tuple) {
+	if tup.GetExpiresAt() == nil {
+		return
+	}
+	if expiresAt := tup.GetExpiresAt().AsTime(); t.expiresAt.IsZero() || expiresAt.Before(t.expiresAt) {
+		t.expiresAt = expiresAt
+	}
+}
+
+// evaluate returns the subjects that have the permission on the entity at the snapshot.
+func (idx *Index) evaluate(ctx context.Context, tenantID, schemaVersion, snap string, entity *base.Entity, permission string) (map[string]struct{}, error) {
+	response, err := idx.expander.Expand(ctx, &base.PermissionExpandRequest{
+		TenantId: tenantID,
+		Metadata: &base.PermissionExpandRequestMetadata{
+			SchemaVersion: schemaVersion,
+			SnapToken:     snap,
+		},
+		Entity:     entity,
+		Permission: permission,
+		Flatten:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := response.GetTree().GetLeaf()
+	if leaf.GetExclusion() {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_NOT_SUPPORTED_MATERIALIZATION.String())
+	}
+
+	subjects := make(map[string]struct{}, len(leaf.GetSubjects()))
+	for _, subject := range leaf.GetSubjects() {
+		if tuple.IsSubjectUser(subject) {
+			subjects[tuple.SubjectToString(subject)] = struct{}{}
+		}
+	}
+	return subjects, nil
+}
+
+// set replaces the subjects that have the permission on the entity.
+func (p *permissionIndex) set(entityID string, subjects map[string]struct{}) {
+	for subject := range p.entities[entityID] {
+		if _, ok := subjects[subject]; ok {
+			continue
+		}
+		delete(p.subjects[subject], entityID)
+		if len(p.subjects[subject]) == 0 {
+			delete(p.subjects, subject)
+		}
+	}
+
+	for subject := range subjects {
+		ids, ok := p.subjects[subject]
+		if !ok {
+			ids = map[string]struct{}{}
+			p.subjects[subject] = ids
+		}
+		ids[entityID] = struct{}{}
+	}
+
+	p.entities[entityID] = subjects
+}
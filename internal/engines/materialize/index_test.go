@@ -0,0 +1,322 @@
+package materialize
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/engines"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/internal/storage/memory/snapshot"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/dsl/ast"
+	"github.com/Permify/permify/pkg/dsl/compiler"
+	"github.com/Permify/permify/pkg/dsl/parser"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+const folderSchema = `
+entity user {}
+
+entity team {
+	relation member @user
+}
+
+entity folder {
+	relation parent @folder
+	relation viewer @user @team#member
+
+	materialized permission view = viewer or parent.view
+}
+
+entity doc {
+	relation parent @folder
+	relation owner @user
+
+	materialized permission read = owner or parent.view
+	permission edit = owner
+}
+`
+
+// testIndex is an Index over a memory database, whose relationships are written through the index.
+type testIndex struct {
+	*Index
+	reader storage.RelationshipReader
+	writer storage.RelationshipWriter
+	// direct writes to the database without going through the index
+	direct storage.RelationshipWriter
+}
+
+func newTestIndex(t *testing.T) *testIndex {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("error")
+
+	sch, err := parser.NewParser(folderSchema).Parse()
+	require.NoError(t, err)
+	_, err = compiler.NewCompiler(false, sch).Compile()
+	require.NoError(t, err)
+
+	var definitions []storage.SchemaDefinition
+	for _, st := range sch.Statements {
+		definitions = append(definitions, storage.SchemaDefinition{
+			TenantID:             "t1",
+			Version:              "v1",
+			EntityType:           st.(*ast.EntityStatement).Name.Literal,
+			SerializedDefinition: []byte(st.String()),
+		})
+	}
+	require.NoError(t, memory.NewSchemaWriter(db, l).WriteSchema(ctx, definitions))
+
+	schemaReader := memory.NewSchemaReader(db, l)
+	relationshipReader := memory.NewRelationshipReader(db, l)
+
+	index := NewIndex(schemaReader, relationshipReader, memory.NewWatcher(db, l), engines.NewExpandEngine(schemaReader, relationshipReader), func(value string) (token.SnapToken, error) {
+		return snapshot.EncodedToken{Value: value}.Decode()
+	}, l)
+	t.Cleanup(index.Close)
+
+	return &testIndex{
+		Index:  index,
+		reader: relationshipReader,
+		writer: NewRelationshipWriterWithIndex(memory.NewRelationshipWriter(db, l), index),
+		direct: memory.NewRelationshipWriter(db, l),
+	}
+}
+
+// head returns the head snapshot of the tenant.
+func (i *testIndex) head(t *testing.T) string {
+	st, err := i.reader.HeadSnapshot(context.Background(), "t1")
+	require.NoError(t, err)
+	return st.Encode().String()
+}
+
+// lookup returns the IDs of the entities the user has the permission on once the index answers at the head snapshot.
+func (i *testIndex) lookup(t *testing.T, entityType, permission, userID string) []string {
+	var ids []string
+	require.Eventually(t, func() bool {
+		var ok bool
+		ids, ok = i.LookupEntity(context.Background(), "t1", "v1", i.head(t), entityType, permission, &base.Subject{Type: tuple.USER, Id: userID})
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	return ids
+}
+
+func (i *testIndex) write(t *testing.T, tuples ...string) token.EncodedSnapToken {
+	collection := database.NewTupleCollection()
+	for _, s := range tuples {
+		tup, err := tuple.Tuple(s)
+		require.NoError(t, err)
+		collection.Add(tup)
+	}
+	snap, err := i.writer.WriteRelationships(context.Background(), "t1", collection)
+	require.NoError(t, err)
+	return snap
+}
+
+func TestIndex_AnswersMaterializedPermissions(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t,
+		"folder:1#viewer@user:1",
+		"folder:2#parent@folder:1#...",
+		"folder:3#parent@folder:2#...",
+		"doc:1#parent@folder:3#...",
+		"folder:4#viewer@team:1#member",
+		"team:1#member@user:2",
+	)
+
+	assert.Equal(t, []string{"1", "2", "3"}, index.lookup(t, "folder", "view", "1"))
+	assert.Equal(t, []string{"1"}, index.lookup(t, "doc", "read", "1"))
+	assert.Equal(t, []string{"4"}, index.lookup(t, "folder", "view", "2"))
+
+	allowed, ok := index.Check(context.Background(), "t1", "v1", index.head(t), &base.Entity{Type: "doc", Id: "1"}, "read", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.True(t, ok)
+	assert.True(t, allowed)
+
+	allowed, ok = index.Check(context.Background(), "t1", "v1", index.head(t), &base.Entity{Type: "doc", Id: "1"}, "read", &base.Subject{Type: tuple.USER, Id: "2"})
+	assert.True(t, ok)
+	assert.False(t, allowed)
+
+	// Permissions that are not materialized are not held by the index
+	_, ok = index.Check(context.Background(), "t1", "v1", index.head(t), &base.Entity{Type: "doc", Id: "1"}, "edit", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.False(t, ok)
+}
+
+func TestIndex_AppliesWritesAndDeletes(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t,
+		"folder:1#viewer@user:1",
+		"folder:2#parent@folder:1#...",
+		"folder:3#parent@folder:2#...",
+		"doc:1#parent@folder:3#...",
+	)
+	assert.Equal(t, []string{"1", "2", "3"}, index.lookup(t, "folder", "view", "1"))
+
+	// A new member of a team that views a folder views its descendants
+	index.write(t,
+		"folder:2#viewer@team:1#member",
+		"team:1#member@user:2",
+	)
+	assert.Equal(t, []string{"2", "3"}, index.lookup(t, "folder", "view", "2"))
+	assert.Equal(t, []string{"1"}, index.lookup(t, "doc", "read", "2"))
+
+	// Cutting the hierarchy removes the inherited permissions below the cut only
+	_, err := index.writer.DeleteRelationships(context.Background(), "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "folder", Ids: []string{"3"}},
+		Relation: "parent",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "2"}, index.lookup(t, "folder", "view", "1"))
+	assert.Equal(t, []string{}, index.lookup(t, "doc", "read", "1"))
+	assert.Equal(t, []string{"2"}, index.lookup(t, "folder", "view", "2"))
+}
+
+func TestIndex_LagsBehindWrites(t *testing.T) {
+	index := newTestIndex(t)
+
+	before := index.write(t, "folder:1#viewer@user:1")
+	assert.Equal(t, []string{"1"}, index.lookup(t, "folder", "view", "1"))
+
+	// A write that has not been applied yet
+	index.begin("t1")
+	_, ok := index.LookupEntity(context.Background(), "t1", "v1", index.head(t), "folder", "view", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.False(t, ok)
+	index.abort("t1")
+
+	// A snapshot that is older than the latest applied write
+	index.write(t, "folder:2#viewer@user:1")
+	assert.Equal(t, []string{"1", "2"}, index.lookup(t, "folder", "view", "1"))
+	_, ok = index.LookupEntity(context.Background(), "t1", "v1", before.String(), "folder", "view", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.False(t, ok)
+
+	// A snapshot that is newer than the latest applied write, with no change pending since
+	head, err := snapshot.EncodedToken{Value: index.head(t)}.Decode()
+	require.NoError(t, err)
+	after := snapshot.NewToken(head.(snapshot.Token).Value + 1).Encode().String()
+	var ids []string
+	require.Eventually(t, func() bool {
+		ids, ok = index.LookupEntity(context.Background(), "t1", "v1", after, "folder", "view", &base.Subject{Type: tuple.USER, Id: "1"})
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1", "2"}, ids)
+
+	// A schema version that the index is not built for
+	_, ok = index.LookupEntity(context.Background(), "t1", "v0", index.head(t), "folder", "view", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.False(t, ok)
+}
+
+func TestIndex_RebuildsOnceRelationshipsExpire(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t, "folder:1#viewer@user:1")
+
+	tup, err := tuple.Tuple("folder:2#viewer@user:1")
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(200 * time.Millisecond)
+	tup.ExpiresAt = timestamppb.New(expiresAt)
+	_, err = index.writer.WriteRelationships(context.Background(), "t1", database.NewTupleCollection(tup))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "2"}, index.lookup(t, "folder", "view", "1"))
+
+	// The index does not answer with the permissions of an expired relationship
+	time.Sleep(time.Until(expiresAt))
+	_, ok := index.LookupEntity(context.Background(), "t1", "v1", index.head(t), "folder", "view", &base.Subject{Type: tuple.USER, Id: "1"})
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"1"}, index.lookup(t, "folder", "view", "1"))
+}
+
+func TestIndex_RebuildsAfterBulkImport(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t, "folder:1#viewer@user:1")
+	assert.Equal(t, []string{"1"}, index.lookup(t, "folder", "view", "1"))
+
+	imported := false
+	_, _, err := index.writer.BulkImport(context.Background(), "t1", func() (*database.TupleCollection, error) {
+		if imported {
+			return nil, io.EOF
+		}
+		imported = true
+
+		collection := database.NewTupleCollection()
+		for _, s := range []string{"folder:2#viewer@user:1", "folder:3#parent@folder:2#..."} {
+			tup, err := tuple.Tuple(s)
+			require.NoError(t, err)
+			collection.Add(tup)
+		}
+		return collection, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "2", "3"}, index.lookup(t, "folder", "view", "1"))
+}
+
+func TestIndex_AppliesTransactions(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t,
+		"folder:a#viewer@user:1",
+		"folder:b#viewer@user:2",
+		"doc:1#parent@folder:a#...",
+	)
+	assert.Equal(t, []string{"1"}, index.lookup(t, "doc", "read", "1"))
+
+	// Moving the document between folders changes the readers of the document at once
+	var operations []*base.TupleOperation
+	for op, s := range map[base.TupleOperation_Operation]string{
+		base.TupleOperation_OPERATION_DELETE: "doc:1#parent@folder:a#...",
+		base.TupleOperation_OPERATION_CREATE: "doc:1#parent@folder:b#...",
+	} {
+		tup, err := tuple.Tuple(s)
+		require.NoError(t, err)
+		operations = append(operations, &base.TupleOperation{Operation: op, Tuple: tup})
+	}
+	_, err := index.writer.TransactRelationships(context.Background(), "t1", nil, operations)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{}, index.lookup(t, "doc", "read", "1"))
+	assert.Equal(t, []string{"1"}, index.lookup(t, "doc", "read", "2"))
+}
+
+func TestIndex_FollowsChangesMadeElsewhere(t *testing.T) {
+	index := newTestIndex(t)
+
+	index.write(t,
+		"folder:1#viewer@user:1",
+		"folder:2#parent@folder:1#...",
+	)
+	assert.Equal(t, []string{"1", "2"}, index.lookup(t, "folder", "view", "1"))
+
+	// A write of another node is not answered from the index until it is streamed
+	tup, err := tuple.Tuple("folder:3#parent@folder:2#...")
+	require.NoError(t, err)
+	_, err = index.direct.WriteRelationships(context.Background(), "t1", database.NewTupleCollection(tup))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, index.lookup(t, "folder", "view", "1"))
+
+	_, err = index.direct.DeleteRelationships(context.Background(), "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "folder", Ids: []string{"2"}},
+		Relation: "parent",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, index.lookup(t, "folder", "view", "1"))
+}
@@ -0,0 +1,90 @@
+package materialize
+
+import (
+	"context"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+)
+
+// RelationshipWriterWithIndex - Materialized permission index decorator for the relationship writer. The index does
+// not answer for a tenant while a write is in flight, and the relationships that are written are applied to the
+// index without waiting for the change stream.
+type RelationshipWriterWithIndex struct {
+	delegate storage.RelationshipWriter
+	index    *Index
+}
+
+// NewRelationshipWriterWithIndex - Add the materialized permission index to the relationship writer
+func NewRelationshipWriterWithIndex(delegate storage.RelationshipWriter, index *Index) *RelationshipWriterWithIndex {
+	return &RelationshipWriterWithIndex{
+		delegate: delegate,
+		index:    index,
+	}
+}
+
+// WriteRelationships - Write relation tuples and apply them to the index
+func (r *RelationshipWriterWithIndex) WriteRelationships(ctx context.Context, tenantID string, collection *database.TupleCollection) (token.EncodedSnapToken, error) {
+	r.index.begin(tenantID)
+
+	snap, err := r.delegate.WriteRelationships(ctx, tenantID, collection)
+	if err != nil {
+		r.index.abort(tenantID)
+		return nil, err
+	}
+
+	r.index.commit(tenantID, collection.GetTuples(), snap)
+	return snap, nil
+}
+
+// DeleteRelationships - Delete relation tuples and drop the index of the tenant. Only the storage knows which relation
+// tuples matched the filter when they were deleted, so the index is built again when it is asked for instead.
+func (r *RelationshipWriterWithIndex) DeleteRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter) (token.EncodedSnapToken, error) {
+	r.index.begin(tenantID)
+
+	snap, err := r.delegate.DeleteRelationships(ctx, tenantID, filter)
+	if err != nil {
+		r.index.abort(tenantID)
+		return nil, err
+	}
+
+	r.index.invalidate(tenantID)
+	return snap, nil
+}
+
+// TransactRelationships - Apply operations to relation tuples and apply them to the index. The permissions that
+// depend on the relation tuples of the operations are evaluated again, whether the operations wrote or deleted them.
+func (r *RelationshipWriterWithIndex) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	r.index.begin(tenantID)
+
+	snap, err := r.delegate.TransactRelationships(ctx, tenantID, preconditions, operations)
+	if err != nil {
+		r.index.abort(tenantID)
+		return nil, err
+	}
+
+	tuples := make([]*base.Tuple, 0, len(operations))
+	for _, operation := range operations {
+		tuples = append(tuples, operation.GetTuple())
+	}
+
+	r.index.commit(tenantID, tuples, snap)
+	return snap, nil
+}
+
+// BulkImport - Import relation tuples and drop the index of the tenant. The imported relation tuples are not applied
+// one at a time, the index is built again when it is asked for instead.
+func (r *RelationshipWriterWithIndex) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	r.index.begin(tenantID)
+
+	snap, imported, err := r.delegate.BulkImport(ctx, tenantID, batches)
+	if err != nil {
+		r.index.abort(tenantID)
+		return nil, 0, err
+	}
+
+	r.index.invalidate(tenantID)
+	return snap, imported, nil
+}
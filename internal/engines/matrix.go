@@ -0,0 +1,213 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/Permify/permify/internal/schema"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// MatrixEngine is a struct that performs the permission checks of a set of entities against a set of
+// permissions for one subject, and returns the results as a matrix.
+type MatrixEngine struct {
+	// checkEngine is responsible for performing permission checks
+	checkEngine *CheckEngine
+	// concurrencyLimit is the maximum number of concurrent permission checks allowed
+	concurrencyLimit int
+	// bulkLimit is the maximum number of entities and of permissions of a request
+	bulkLimit int
+}
+
+// NewMatrixEngine creates a new MatrixEngine instance.
+// check: the CheckEngine to use for permission checks
+func NewMatrixEngine(check *CheckEngine, opts ...MatrixOption) *MatrixEngine {
+	engine := &MatrixEngine{
+		checkEngine:      check,
+		concurrencyLimit: _defaultConcurrencyLimit,
+		bulkLimit:        _defaultBulkLimit,
+	}
+
+	// options
+	for _, opt := range opts {
+		opt(engine)
+	}
+
+	return engine
+}
+
+// Matrix checks every permission of the request on every entity of the request in a single pass. The relations
+// the permissions depend on are read once for all entities, and the checks share these reads as well as the
+// results of their sub-problems. Every cell is checked through the invoker of the check engine, like a check request.
+func (engine *MatrixEngine) Matrix(ctx context.Context, request *base.PermissionMatrixRequest) (response *base.PermissionMatrixResponse, err error) {
+	if len(request.GetEntityIds()) > engine.bulkLimit || len(request.GetPermissions()) > engine.bulkLimit {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_VALIDATION.String())
+	}
+
+	reads := &sharedReads{}
+	ctx = contextWithSharedReads(ctx, reads)
+
+	// Read the relations the permissions depend on for all entities at once
+	err = engine.prefetch(ctx, request, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*base.PermissionMatrixRow, len(request.GetEntityIds()))
+	for i, id := range request.GetEntityIds() {
+		rows[i] = &base.PermissionMatrixRow{
+			EntityId: id,
+			Results:  make([]base.PermissionCheckResponse_Result, len(request.GetPermissions())),
+		}
+	}
+
+	var checkCount int32
+	sem := semaphore.NewWeighted(int64(engine.concurrencyLimit))
+	g, gctx := errgroup.WithContext(ctx)
+cells:
+	for i := range rows {
+		for j := range request.GetPermissions() {
+			if err = sem.Acquire(gctx, 1); err != nil {
+				break cells
+			}
+			row, j := rows[i], j
+			g.Go(func() error {
+				defer sem.Release(1)
+				res, err := engine.checkEngine.invoker.Check(gctx, &base.PermissionCheckRequest{
+					TenantId: request.GetTenantId(),
+					Metadata: &base.PermissionCheckRequestMetadata{
+						SnapToken:     request.GetMetadata().GetSnapToken(),
+						SchemaVersion: request.GetMetadata().GetSchemaVersion(),
+						Depth:         request.GetMetadata().GetDepth(),
+					},
+					Entity: &base.Entity{
+						Type: request.GetEntityType(),
+						Id:   row.GetEntityId(),
+					},
+					Permission: request.GetPermissions()[j],
+					Subject:    request.GetSubject(),
+				})
+				if err != nil {
+					return err
+				}
+				row.Results[j] = res.GetCan()
+				atomic.AddInt32(&checkCount, res.GetMetadata().GetCheckCount())
+				return nil
+			})
+		}
+	}
+
+	// The error of a failed check takes precedence over the cancellation it caused
+	if werr := g.Wait(); werr != nil {
+		return nil, werr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &base.PermissionMatrixResponse{
+		Rows: rows,
+		Metadata: &base.PermissionMatrixResponseMetadata{
+			CheckCount: checkCount,
+		},
+	}, nil
+}
+
+// prefetch reads the relations of the entity type that the permissions of the request depend on, for all entities
+// of the request, and shares them with the checks of the matrix.
+func (engine *MatrixEngine) prefetch(ctx context.Context, request *base.PermissionMatrixRequest, reads *sharedReads) error {
+	en, _, err := engine.checkEngine.schemaReader.ReadSchemaDefinition(ctx, request.GetTenantId(), request.GetEntityType(), request.GetMetadata().GetSchemaVersion())
+	if err != nil {
+		return err
+	}
+
+	relations := map[string]struct{}{}
+	for _, permission := range request.GetPermissions() {
+		collectRelations(en, permission, relations)
+	}
+
+	ids := make([]string, 0, len(request.GetEntityIds()))
+	seen := map[string]struct{}{}
+	for _, id := range request.GetEntityIds() {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	for relation := range relations {
+		it, err := engine.checkEngine.relationshipReader.QueryRelationships(ctx, request.GetTenantId(), &base.TupleFilter{
+			Entity: &base.EntityFilter{
+				Type: request.GetEntityType(),
+				Ids:  ids,
+			},
+			Relation: relation,
+		}, request.GetMetadata().GetSnapToken())
+		if err != nil {
+			return err
+		}
+
+		// Every entity is stored, so that entities without relationships are not read again.
+		tuples := make(map[string][]*base.Tuple, len(ids))
+		for _, id := range ids {
+			tuples[id] = []*base.Tuple{}
+		}
+		for it.HasNext() {
+			t := it.GetNext()
+			tuples[t.GetEntity().GetId()] = append(tuples[t.GetEntity().GetId()], t)
+		}
+		for id, t := range tuples {
+			reads.store(request.GetTenantId(), &base.Entity{Type: request.GetEntityType(), Id: id}, relation, request.GetMetadata().GetSnapToken(), t)
+		}
+	}
+	return nil
+}
+
+// collectRelations adds the relations of the entity definition that the given permission or relation depends on,
+// without following tuple to user sets to other entities.
+func collectRelations(en *base.EntityDefinition, name string, relations map[string]struct{}) {
+	if _, ok := relations[name]; ok {
+		return
+	}
+
+	tor, err := schema.GetTypeOfRelationalReferenceByNameInEntityDefinition(en, name)
+	if err != nil {
+		return
+	}
+	if tor == base.EntityDefinition_RELATIONAL_REFERENCE_RELATION {
+		relations[name] = struct{}{}
+		return
+	}
+
+	permission, err := schema.GetPermissionByNameInEntityDefinition(en, name)
+	if err != nil {
+		return
+	}
+
+	// Permissions are marked as visited too, and removed once all relations have been collected.
+	relations[name] = struct{}{}
+	defer delete(relations, name)
+
+	var walk func(child *base.Child)
+	walk = func(child *base.Child) {
+		switch child.GetType().(type) {
+		case *base.Child_Rewrite:
+			for _, c := range child.GetRewrite().GetChildren() {
+				walk(c)
+			}
+		case *base.Child_Leaf:
+			leaf := child.GetLeaf()
+			switch op := leaf.GetType().(type) {
+			case *base.Leaf_TupleToUserSet:
+				relations[op.TupleToUserSet.GetTupleSet().GetRelation()] = struct{}{}
+			case *base.Leaf_ComputedUserSet:
+				collectRelations(en, op.ComputedUserSet.GetRelation(), relations)
+			}
+		}
+	}
+	walk(permission.GetChild())
+}
@@ -0,0 +1,187 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Permify/permify/internal/invoke"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// countingRelationshipReader counts the relationship queries made through it, and how many times the relations of
+// every entity are read.
+type countingRelationshipReader struct {
+	storage.RelationshipReader
+	queries int32
+	mu      sync.Mutex
+	reads   map[string]int
+}
+
+func (r *countingRelationshipReader) QueryRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string) (*database.TupleIterator, error) {
+	atomic.AddInt32(&r.queries, 1)
+	r.mu.Lock()
+	if r.reads == nil {
+		r.reads = map[string]int{}
+	}
+	for _, id := range filter.GetEntity().GetIds() {
+		r.reads[filter.GetEntity().GetType()+":"+id+"#"+filter.GetRelation()]++
+	}
+	r.mu.Unlock()
+	return r.RelationshipReader.QueryRelationships(ctx, tenantID, filter, snap)
+}
+
+// countingInvoker counts the checks made through it.
+type countingInvoker struct {
+	checker invoke.Check
+	calls   int32
+}
+
+func (i *countingInvoker) Check(ctx context.Context, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	atomic.AddInt32(&i.calls, 1)
+	return i.checker.Check(ctx, request)
+}
+
+var _ = Describe("matrix-engine", func() {
+	matrixSchema := `
+entity user {}
+
+entity organization {
+	relation admin @user
+}
+
+entity doc {
+	relation org @organization
+	relation owner @user
+	relation editor @user
+
+	permission edit = owner or editor or org.admin
+	permission share = owner and not editor
+	permission delete = owner or org.admin
+}
+`
+
+	Context("Matrix", func() {
+		It("should check every permission on every entity and share the reads", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := &countingRelationshipReader{RelationshipReader: memory.NewRelationshipReader(db, l)}
+
+			writeSchema(ctx, db, l, "t1", "v1", matrixSchema)
+
+			tuples := []*base.Tuple{
+				{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "owner", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+				{Entity: &base.Entity{Type: "doc", Id: "2"}, Relation: "owner", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+				{Entity: &base.Entity{Type: "doc", Id: "2"}, Relation: "editor", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+				{Entity: &base.Entity{Type: "doc", Id: "3"}, Relation: "editor", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+				{Entity: &base.Entity{Type: "doc", Id: "4"}, Relation: "org", Subject: &base.Subject{Type: "organization", Id: "1", Relation: tuple.ELLIPSIS}},
+				{Entity: &base.Entity{Type: "organization", Id: "1"}, Relation: "admin", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+			}
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(tuples...))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			checkEngine := NewCheckEngine(schemaReader, relationshipReader)
+			checkEngine.SetInvoker(checkEngine)
+			matrixEngine := NewMatrixEngine(checkEngine, MatrixConcurrencyLimit(4))
+
+			res, err := matrixEngine.Matrix(ctx, &base.PermissionMatrixRequest{
+				TenantId: "t1",
+				Metadata: &base.PermissionMatrixRequestMetadata{
+					SnapToken:     snap.String(),
+					SchemaVersion: "v1",
+					Depth:         20,
+				},
+				EntityType:  "doc",
+				EntityIds:   []string{"4", "1", "2", "3", "5"},
+				Permissions: []string{"edit", "share", "delete"},
+				Subject:     &base.Subject{Type: tuple.USER, Id: "1"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			allow := base.PermissionCheckResponse_RESULT_ALLOWED
+			deny := base.PermissionCheckResponse_RESULT_DENIED
+
+			Expect(res.GetRows()).Should(HaveLen(5))
+			expected := map[string][]base.PermissionCheckResponse_Result{
+				"4": {allow, deny, allow},
+				"1": {allow, allow, allow},
+				"2": {allow, deny, allow},
+				"3": {allow, deny, deny},
+				"5": {deny, deny, deny},
+			}
+			for i, id := range []string{"4", "1", "2", "3", "5"} {
+				Expect(res.GetRows()[i].GetEntityId()).Should(Equal(id))
+				Expect(res.GetRows()[i].GetResults()).Should(Equal(expected[id]), id)
+			}
+
+			// The three relations of the documents are read once for all documents, and only the
+			// organization of document 4 is read on its own.
+			Expect(atomic.LoadInt32(&relationshipReader.queries)).Should(Equal(int32(4)))
+		})
+
+		It("should check the cells through the invoker and limit the request", func() {
+			ctx := context.Background()
+
+			db, err := IMDatabase.New(migrations.Schema)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			l := logger.New("error")
+			schemaReader := memory.NewSchemaReader(db, l)
+			relationshipReader := memory.NewRelationshipReader(db, l)
+
+			writeSchema(ctx, db, l, "t1", "v1", matrixSchema)
+
+			snap, err := memory.NewRelationshipWriter(db, l).WriteRelationships(ctx, "t1", database.NewTupleCollection(
+				&base.Tuple{Entity: &base.Entity{Type: "doc", Id: "1"}, Relation: "owner", Subject: &base.Subject{Type: tuple.USER, Id: "1"}},
+			))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			checkEngine := NewCheckEngine(schemaReader, relationshipReader)
+			invoker := &countingInvoker{checker: checkEngine}
+			checkEngine.SetInvoker(invoker)
+			matrixEngine := NewMatrixEngine(checkEngine, MatrixBulkLimit(2))
+
+			request := func(entityIDs ...string) *base.PermissionMatrixRequest {
+				return &base.PermissionMatrixRequest{
+					TenantId: "t1",
+					Metadata: &base.PermissionMatrixRequestMetadata{
+						SnapToken:     snap.String(),
+						SchemaVersion: "v1",
+						Depth:         20,
+					},
+					EntityType:  "doc",
+					EntityIds:   entityIDs,
+					Permissions: []string{"edit", "delete"},
+					Subject:     &base.Subject{Type: tuple.USER, Id: "1"},
+				}
+			}
+
+			res, err := matrixEngine.Matrix(ctx, request("1", "2"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.GetRows()[0].GetResults()).Should(Equal([]base.PermissionCheckResponse_Result{base.PermissionCheckResponse_RESULT_ALLOWED, base.PermissionCheckResponse_RESULT_ALLOWED}))
+			Expect(res.GetRows()[1].GetResults()).Should(Equal([]base.PermissionCheckResponse_Result{base.PermissionCheckResponse_RESULT_DENIED, base.PermissionCheckResponse_RESULT_DENIED}))
+
+			// Every cell is checked through the invoker, and so are the sub-problems of the cells
+			Expect(atomic.LoadInt32(&invoker.calls)).Should(BeNumerically(">=", 4))
+
+			_, err = matrixEngine.Matrix(ctx, request("1", "2", "3"))
+			Expect(err).Should(Equal(errors.New(base.ErrorCode_ERROR_CODE_VALIDATION.String())))
+		})
+	})
+})
@@ -0,0 +1,22 @@
+package engines
+
+import (
+	"sort"
+)
+
+// page returns up to size of the entity ids after the given entity id in lexicographic order, all of them if size is
+// zero, and the entity id the next page starts after if there are more of them.
+func page(entityIDs []string, after string, size int) (ids []string, next string) {
+	ids = make([]string, 0, len(entityIDs))
+	for _, id := range entityIDs {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	if size > 0 && len(ids) > size {
+		return ids[:size], ids[size-1]
+	}
+	return ids, ""
+}
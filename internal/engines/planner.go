@@ -0,0 +1,236 @@
+package engines
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Permify/permify/internal/schema"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+const (
+	// _plannerSmoothing is the weight of a new observation in the moving averages of the planner.
+	_plannerSmoothing = 0.2
+	// _plannerMaxDepth bounds how deep the planner follows permissions that reference other permissions.
+	_plannerMaxDepth = 8
+	// _defaultTupleToUserSetCost is the assumed cost of evaluating the computed user set of one related entity,
+	// whose entity definition the planner does not look at.
+	_defaultTupleToUserSetCost = 2
+	// _defaultAllowedRate is the assumed probability of a branch being allowed before it has been observed.
+	_defaultAllowedRate = 0.5
+	// _minRate keeps the planner scores finite for branches that are (almost) never allowed or denied.
+	_minRate = 0.01
+)
+
+// Planner estimates the cost of the children of a rewrite and orders them, so that an intersection
+// reaches a denied child, and a union an allowed child, with as little work as possible.
+//
+// The estimates start out from the shape of the schema and are refined with statistics that are
+// collected per tenant at runtime: the fan-out of relations, and the check count and allowed rate
+// of each branch.
+type Planner struct {
+	// tenants maps a tenant id to the statistics of its branches
+	tenants sync.Map
+}
+
+// NewPlanner creates a new Planner without any statistics.
+func NewPlanner() *Planner {
+	return &Planner{}
+}
+
+// branchStats holds the moving averages observed for a branch or a relation of a tenant.
+type branchStats struct {
+	mu sync.Mutex
+	// results is the number of observed check results
+	results int
+	// cost is the average check count of the branch
+	cost float64
+	// allowed is the rate at which the branch is allowed
+	allowed float64
+	// fanouts is the number of observed relationship reads
+	fanouts int
+	// fanout is the average number of relationships read for the relation
+	fanout float64
+}
+
+// estimate is the expected cost of a branch and the probability of it being allowed.
+type estimate struct {
+	cost    float64
+	allowed float64
+}
+
+// stats returns the statistics of the given key for the tenant, creating them if needed.
+func (planner *Planner) stats(tenantID, key string) *branchStats {
+	t, _ := planner.tenants.LoadOrStore(tenantID, &sync.Map{})
+	s, _ := t.(*sync.Map).LoadOrStore(key, &branchStats{})
+	return s.(*branchStats)
+}
+
+// observeResult records the outcome of a branch check.
+func (planner *Planner) observeResult(tenantID, key string, response *base.PermissionCheckResponse) {
+	allowed := 0.0
+	if response.GetCan() == base.PermissionCheckResponse_RESULT_ALLOWED {
+		allowed = 1
+	}
+	s := planner.stats(tenantID, key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cost = average(s.cost, float64(response.GetMetadata().GetCheckCount()), s.results)
+	s.allowed = average(s.allowed, allowed, s.results)
+	s.results++
+}
+
+// observeFanout records the number of relationships read for a relation.
+func (planner *Planner) observeFanout(tenantID, key string, fanout int) {
+	s := planner.stats(tenantID, key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fanout = average(s.fanout, float64(fanout), s.fanouts)
+	s.fanouts++
+}
+
+// average returns the exponential moving average of the value after the observation. The first
+// observation is taken as it is.
+func average(value, observation float64, observations int) float64 {
+	if observations == 0 {
+		return observation
+	}
+	return value + _plannerSmoothing*(observation-value)
+}
+
+// order returns the children of a rewrite in the order they should be evaluated in. Children of an
+// intersection are ordered by their expected cost of finding a denied child, and children of a union
+// by their expected cost of finding an allowed child. Children with the same score keep their schema order.
+func (planner *Planner) order(tenantID string, en *base.EntityDefinition, operation base.Rewrite_Operation, children []*base.Child) []*base.Child {
+	if len(children) < 2 {
+		return children
+	}
+
+	scores := make([]float64, len(children))
+	for i, child := range children {
+		e := planner.estimate(tenantID, en, child, 0)
+		rate := e.allowed
+		if operation == base.Rewrite_OPERATION_INTERSECTION {
+			rate = 1 - e.allowed
+		}
+		if rate < _minRate {
+			rate = _minRate
+		}
+		scores[i] = e.cost / rate
+	}
+
+	indexes := make([]int, len(children))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] < scores[indexes[j]]
+	})
+
+	ordered := make([]*base.Child, len(children))
+	for i, index := range indexes {
+		ordered[i] = children[index]
+	}
+	return ordered
+}
+
+// estimate returns the estimate of a child of the given entity definition.
+func (planner *Planner) estimate(tenantID string, en *base.EntityDefinition, child *base.Child, depth int) estimate {
+	switch child.GetType().(type) {
+	case *base.Child_Rewrite:
+		return planner.estimateRewrite(tenantID, en, child.GetRewrite(), depth)
+	case *base.Child_Leaf:
+		return planner.estimateLeaf(tenantID, en, child.GetLeaf(), depth)
+	default:
+		return estimate{cost: 1, allowed: _defaultAllowedRate}
+	}
+}
+
+// estimateRewrite combines the estimates of the children of a rewrite.
+func (planner *Planner) estimateRewrite(tenantID string, en *base.EntityDefinition, rewrite *base.Rewrite, depth int) estimate {
+	result := estimate{}
+	denied := 1.0
+	allowed := 1.0
+	for _, child := range rewrite.GetChildren() {
+		e := planner.estimate(tenantID, en, child, depth)
+		result.cost += e.cost
+		denied *= 1 - e.allowed
+		allowed *= e.allowed
+	}
+	if rewrite.GetRewriteOperation() == base.Rewrite_OPERATION_INTERSECTION {
+		result.allowed = allowed
+	} else {
+		result.allowed = 1 - denied
+	}
+	return result
+}
+
+// estimateLeaf returns the estimate of a leaf. Observed statistics of the leaf take precedence over
+// the estimate derived from the schema.
+func (planner *Planner) estimateLeaf(tenantID string, en *base.EntityDefinition, leaf *base.Leaf, depth int) estimate {
+	e := planner.estimateLeafFromSchema(tenantID, en, leaf, depth)
+	if leaf.GetExclusion() {
+		e.allowed = 1 - e.allowed
+	}
+
+	// The observed results already have the exclusion of the leaf applied.
+	s := planner.stats(tenantID, leafKey(en.GetName(), leaf))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.results > 0 {
+		e.cost = s.cost
+		e.allowed = s.allowed
+	}
+	return e
+}
+
+// estimateLeafFromSchema estimates a leaf from the shape of the schema and the observed fan-out of relations.
+func (planner *Planner) estimateLeafFromSchema(tenantID string, en *base.EntityDefinition, leaf *base.Leaf, depth int) estimate {
+	switch op := leaf.GetType().(type) {
+	case *base.Leaf_TupleToUserSet:
+		// One read of the tuple set, and a computed user set check for every related entity.
+		fanout := planner.fanout(tenantID, en.GetName(), op.TupleToUserSet.GetTupleSet().GetRelation())
+		return estimate{cost: 1 + fanout*_defaultTupleToUserSetCost, allowed: _defaultAllowedRate}
+	case *base.Leaf_ComputedUserSet:
+		relation := op.ComputedUserSet.GetRelation()
+		tor, err := schema.GetTypeOfRelationalReferenceByNameInEntityDefinition(en, relation)
+		if err == nil && tor == base.EntityDefinition_RELATIONAL_REFERENCE_PERMISSION && depth < _plannerMaxDepth {
+			permission, err := schema.GetPermissionByNameInEntityDefinition(en, relation)
+			if err == nil {
+				return planner.estimate(tenantID, en, permission.GetChild(), depth+1)
+			}
+		}
+		// A relation takes one read, plus a check for every subject set it contains.
+		return estimate{cost: planner.fanout(tenantID, en.GetName(), relation), allowed: _defaultAllowedRate}
+	default:
+		return estimate{cost: 1, allowed: _defaultAllowedRate}
+	}
+}
+
+// fanout returns the observed number of relationships read for a relation, or one if it has not been observed yet.
+func (planner *Planner) fanout(tenantID, entityType, relation string) float64 {
+	s := planner.stats(tenantID, relationKey(entityType, relation))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fanouts == 0 || s.fanout < 1 {
+		return 1
+	}
+	return s.fanout
+}
+
+// relationKey returns the key the fan-out of a relation is recorded under.
+func relationKey(entityType, relation string) string {
+	return entityType + "#" + relation
+}
+
+// leafKey returns the key the results of a leaf are recorded under.
+func leafKey(entityType string, leaf *base.Leaf) string {
+	switch op := leaf.GetType().(type) {
+	case *base.Leaf_TupleToUserSet:
+		return entityType + "#" + op.TupleToUserSet.GetTupleSet().GetRelation() + "." + op.TupleToUserSet.GetComputed().GetRelation()
+	case *base.Leaf_ComputedUserSet:
+		return entityType + "#" + op.ComputedUserSet.GetRelation() + "()"
+	default:
+		return entityType + "#"
+	}
+}
@@ -0,0 +1,174 @@
+package engines
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Permify/permify/internal/schema"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+var _ = Describe("planner", func() {
+	plannerSchema := `
+entity user {}
+
+entity organization {
+	relation admin @user
+}
+
+entity doc {
+	relation org @organization
+	relation owner @user
+	relation editor @user
+
+	permission update = org.admin and owner
+	permission read = owner or editor
+}
+`
+
+	var doc *base.EntityDefinition
+
+	BeforeEach(func() {
+		sch, err := schema.NewSchemaFromStringDefinitions(true, plannerSchema)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		doc, err = schema.GetEntityByName(sch, "doc")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	// children returns the children of the rewrite of the given permission of the doc entity.
+	children := func(name string) []*base.Child {
+		permission, err := schema.GetPermissionByNameInEntityDefinition(doc, name)
+		Expect(err).ShouldNot(HaveOccurred())
+		return permission.GetChild().GetRewrite().GetChildren()
+	}
+
+	// leafRelation returns the relation a computed user set leaf refers to.
+	leafRelation := func(child *base.Child) string {
+		if ttu := child.GetLeaf().GetTupleToUserSet(); ttu != nil {
+			return ttu.GetTupleSet().GetRelation() + "." + ttu.GetComputed().GetRelation()
+		}
+		return child.GetLeaf().GetComputedUserSet().GetRelation()
+	}
+
+	Context("Order", func() {
+		It("should evaluate direct relations before tuple to user sets in intersections", func() {
+			planner := NewPlanner()
+
+			ordered := planner.order("t1", doc, base.Rewrite_OPERATION_INTERSECTION, children("update"))
+			Expect([]string{leafRelation(ordered[0]), leafRelation(ordered[1])}).Should(Equal([]string{"owner", "org.admin"}))
+		})
+
+		It("should keep the schema order without statistics", func() {
+			planner := NewPlanner()
+
+			ordered := planner.order("t1", doc, base.Rewrite_OPERATION_UNION, children("read"))
+			Expect([]string{leafRelation(ordered[0]), leafRelation(ordered[1])}).Should(Equal([]string{"owner", "editor"}))
+		})
+
+		It("should evaluate the child that is most likely allowed first in unions", func() {
+			planner := NewPlanner()
+
+			read := children("read")
+			for i := 0; i < 10; i++ {
+				planner.observeResult("t1", leafKey("doc", read[0].GetLeaf()), denied(&base.PermissionCheckResponseMetadata{CheckCount: 1}))
+				planner.observeResult("t1", leafKey("doc", read[1].GetLeaf()), allowed(&base.PermissionCheckResponseMetadata{CheckCount: 1}))
+			}
+
+			ordered := planner.order("t1", doc, base.Rewrite_OPERATION_UNION, read)
+			Expect([]string{leafRelation(ordered[0]), leafRelation(ordered[1])}).Should(Equal([]string{"editor", "owner"}))
+
+			// Statistics are kept per tenant
+			ordered = planner.order("t2", doc, base.Rewrite_OPERATION_UNION, read)
+			Expect([]string{leafRelation(ordered[0]), leafRelation(ordered[1])}).Should(Equal([]string{"owner", "editor"}))
+		})
+
+		It("should take the observed fan-out of tuple sets into account", func() {
+			planner := NewPlanner()
+
+			update := children("update")
+			planner.observeResult("t1", leafKey("doc", update[1].GetLeaf()), denied(&base.PermissionCheckResponseMetadata{CheckCount: 40}))
+			planner.observeFanout("t1", relationKey("doc", "org"), 1)
+
+			ordered := planner.order("t1", doc, base.Rewrite_OPERATION_INTERSECTION, update)
+			Expect([]string{leafRelation(ordered[0]), leafRelation(ordered[1])}).Should(Equal([]string{"org.admin", "owner"}))
+		})
+	})
+
+	Context("Evaluation", func() {
+		// functions returns check functions with the given results, and the number of them that were called.
+		functions := func(results ...base.PermissionCheckResponse_Result) ([]CheckFunction, *int32) {
+			var called int32
+			fns := make([]CheckFunction, 0, len(results))
+			for _, result := range results {
+				result := result
+				fns = append(fns, func(ctx context.Context) (*base.PermissionCheckResponse, error) {
+					atomic.AddInt32(&called, 1)
+					return &base.PermissionCheckResponse{Can: result, Metadata: &base.PermissionCheckResponseMetadata{CheckCount: 1}}, nil
+				})
+			}
+			return fns, &called
+		}
+
+		// combine starts the functions staggered by a delay longer than the test, so each of them only starts once the
+		// one before it did not decide the result.
+		combine := func(combiner CheckCombiner, decision base.PermissionCheckResponse_Result, fns []CheckFunction) (*base.PermissionCheckResponse, error) {
+			return combiner(context.Background(), stagger(fns, time.Hour, decision), 100)
+		}
+
+		It("should not evaluate the children of a union after an allowed one", func() {
+			fns, called := functions(base.PermissionCheckResponse_RESULT_DENIED, base.PermissionCheckResponse_RESULT_ALLOWED, base.PermissionCheckResponse_RESULT_DENIED)
+
+			response, err := combine(checkUnion, base.PermissionCheckResponse_RESULT_ALLOWED, fns)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(response.GetMetadata().GetCheckCount()).Should(Equal(int32(2)))
+			Expect(atomic.LoadInt32(called)).Should(Equal(int32(2)))
+		})
+
+		It("should not evaluate the children of an intersection after a denied one", func() {
+			fns, called := functions(base.PermissionCheckResponse_RESULT_DENIED, base.PermissionCheckResponse_RESULT_ALLOWED)
+
+			response, err := combine(checkIntersection, base.PermissionCheckResponse_RESULT_DENIED, fns)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_DENIED))
+			Expect(atomic.LoadInt32(called)).Should(Equal(int32(1)))
+
+			fns, called = functions(base.PermissionCheckResponse_RESULT_ALLOWED, base.PermissionCheckResponse_RESULT_ALLOWED)
+			response, err = combine(checkIntersection, base.PermissionCheckResponse_RESULT_DENIED, fns)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(response.GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_ALLOWED))
+			Expect(atomic.LoadInt32(called)).Should(Equal(int32(2)))
+		})
+
+		It("should run slow children of a union concurrently", func() {
+			release := make(chan struct{})
+			var started int32
+			slow := func(ctx context.Context) (*base.PermissionCheckResponse, error) {
+				atomic.AddInt32(&started, 1)
+				select {
+				case <-release:
+				case <-ctx.Done():
+				}
+				return denied(&base.PermissionCheckResponseMetadata{}), nil
+			}
+
+			done := make(chan *base.PermissionCheckResponse)
+			go func() {
+				defer GinkgoRecover()
+				response, err := checkUnion(context.Background(), stagger([]CheckFunction{slow, slow, slow}, time.Millisecond, base.PermissionCheckResponse_RESULT_ALLOWED), 100)
+				Expect(err).ShouldNot(HaveOccurred())
+				done <- response
+			}()
+
+			// Every child is started while the ones before it are still running
+			Eventually(func() int32 { return atomic.LoadInt32(&started) }).Should(Equal(int32(3)))
+			close(release)
+			Expect((<-done).GetCan()).Should(Equal(base.PermissionCheckResponse_RESULT_DENIED))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package engines
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Permify/permify/pkg/database"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// sharedReadsKey is the context key under which the shared reads of a request are stored.
+type sharedReadsKey struct{}
+
+// sharedReads holds the relationships that have been read for a request, keyed by entity and relation,
+// so that the checks of the request read every relation of an entity only once.
+type sharedReads struct {
+	tuples sync.Map
+	// flight coalesces the concurrent reads of the same entity and relation
+	flight singleflight.Group
+}
+
+// contextWithSharedReads returns a copy of the context carrying the given shared reads.
+func contextWithSharedReads(ctx context.Context, reads *sharedReads) context.Context {
+	return context.WithValue(ctx, sharedReadsKey{}, reads)
+}
+
+// sharedReadsFromContext returns the shared reads stored in the context, or nil if there are none.
+func sharedReadsFromContext(ctx context.Context) *sharedReads {
+	reads, _ := ctx.Value(sharedReadsKey{}).(*sharedReads)
+	return reads
+}
+
+// sharedReadKey generates the key the relationships of an entity and relation are stored under.
+func sharedReadKey(tenantID string, entity *base.Entity, relation, snap string) string {
+	return tenantID + "|" + snap + "|" + entity.GetType() + ":" + entity.GetId() + "#" + relation
+}
+
+// load returns the stored relationships of the entity and relation, if they have been read.
+func (r *sharedReads) load(tenantID string, entity *base.Entity, relation, snap string) ([]*base.Tuple, bool) {
+	tuples, ok := r.tuples.Load(sharedReadKey(tenantID, entity, relation, snap))
+	if !ok {
+		return nil, false
+	}
+	return tuples.([]*base.Tuple), true
+}
+
+// store stores the relationships of the entity and relation.
+func (r *sharedReads) store(tenantID string, entity *base.Entity, relation, snap string, tuples []*base.Tuple) {
+	r.tuples.Store(sharedReadKey(tenantID, entity, relation, snap), tuples)
+}
+
+// queryRelationships reads the relationships of an entity and relation. If the context carries shared reads,
+// the relationships are read from and stored to them.
+func (engine *CheckEngine) queryRelationships(ctx context.Context, tenantID string, entity *base.Entity, relation, snap string) (*database.TupleIterator, error) {
+	reads := sharedReadsFromContext(ctx)
+	if reads != nil {
+		if tuples, ok := reads.load(tenantID, entity, relation, snap); ok {
+			return database.NewTupleIterator(tuples...), nil
+		}
+	}
+
+	query := func() (*database.TupleIterator, error) {
+		return engine.relationshipReader.QueryRelationships(ctx, tenantID, &base.TupleFilter{
+			Entity: &base.EntityFilter{
+				Type: entity.GetType(),
+				Ids:  []string{entity.GetId()},
+			},
+			Relation: relation,
+		}, snap)
+	}
+	if reads == nil {
+		return query()
+	}
+
+	// Checks that read the same relation at the same time wait for a single read
+	results := reads.flight.DoChan(sharedReadKey(tenantID, entity, relation, snap), func() (interface{}, error) {
+		// The read may have finished since the relationships were looked up
+		if tuples, ok := reads.load(tenantID, entity, relation, snap); ok {
+			return tuples, nil
+		}
+		it, err := query()
+		if err != nil {
+			return nil, err
+		}
+		tuples := []*base.Tuple{}
+		for it.HasNext() {
+			tuples = append(tuples, it.GetNext())
+		}
+		reads.store(tenantID, entity, relation, snap, tuples)
+		return tuples, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err == nil {
+			return database.NewTupleIterator(result.Val.([]*base.Tuple)...), nil
+		}
+		// The read of another check fails when that check is cancelled, so it is made again
+		if result.Shared && ctx.Err() == nil {
+			return query()
+		}
+		return nil, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
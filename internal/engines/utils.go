@@ -1,14 +1,22 @@
 package engines
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 	"github.com/Permify/permify/pkg/tuple"
 )
 
 const (
-	_defaultConcurrencyLimit = 100
+	_defaultConcurrencyLimit      = 100
+	_defaultBulkLimit             = 100
+	_defaultExpandStreamChunkSize = 1000
+
+	// _rewriteStagger is the delay after which the next child of a rewrite is started if the one before it has not
+	// finished yet
+	_rewriteStagger = 5 * time.Millisecond
 )
 
 // CheckOption - a functional option type for configuring the CheckEngine.
@@ -21,6 +29,41 @@ func CheckConcurrencyLimit(limit int) CheckOption {
 	}
 }
 
+// CheckCycleHandler - a functional option that sets the handler the CheckEngine reports cycles in the relationships to.
+func CheckCycleHandler(handler CycleHandler) CheckOption {
+	return func(c *CheckEngine) {
+		c.cycleHandler = handler
+	}
+}
+
+// CheckPermissionIndex - a functional option that sets the index the CheckEngine answers materialized permissions from.
+func CheckPermissionIndex(index PermissionIndex) CheckOption {
+	return func(c *CheckEngine) {
+		c.index = index
+	}
+}
+
+// ExpandOption - a functional option type for configuring the ExpandEngine.
+type ExpandOption func(engine *ExpandEngine)
+
+// ExpandCycleHandler - a functional option that sets the handler the ExpandEngine reports cycles in the relationships to.
+func ExpandCycleHandler(handler CycleHandler) ExpandOption {
+	return func(c *ExpandEngine) {
+		c.cycleHandler = handler
+	}
+}
+
+// ExpandStreamChunkSize - a functional option that sets the maximum number of subjects in a leaf streamed by the ExpandEngine.
+func ExpandStreamChunkSize(size int) ExpandOption {
+	return func(c *ExpandEngine) {
+		c.streamChunkSize = size
+	}
+}
+
+// CycleHandler - a function that is called with the keys of the sub-problems that form a cycle in the relationships
+// of a tenant. The first and the last key of the cycle are the same.
+type CycleHandler func(ctx context.Context, tenantID string, cycle []string)
+
 // LookupEntityOption - a functional option type for configuring the LookupEntityEngine.
 type LookupEntityOption func(engine *LookupEntityEngine)
 
@@ -31,11 +74,37 @@ func LookupEntityConcurrencyLimit(limit int) LookupEntityOption {
 	}
 }
 
+// LookupEntityPermissionIndex - a functional option that sets the index the LookupEntityEngine answers materialized
+// permissions from.
+func LookupEntityPermissionIndex(index PermissionIndex) LookupEntityOption {
+	return func(c *LookupEntityEngine) {
+		c.index = index
+	}
+}
+
+// MatrixOption - a functional option type for configuring the MatrixEngine.
+type MatrixOption func(engine *MatrixEngine)
+
+// MatrixConcurrencyLimit - a functional option that sets the concurrency limit for the MatrixEngine.
+func MatrixConcurrencyLimit(limit int) MatrixOption {
+	return func(c *MatrixEngine) {
+		c.concurrencyLimit = limit
+	}
+}
+
+// MatrixBulkLimit - a functional option that sets the maximum number of entities and of permissions of a request to
+// the MatrixEngine.
+func MatrixBulkLimit(limit int) MatrixOption {
+	return func(c *MatrixEngine) {
+		c.bulkLimit = limit
+	}
+}
+
 // joinResponseMetas - a helper function that merges multiple PermissionCheckResponseMetadata structs into one.
 func joinResponseMetas(meta ...*base.PermissionCheckResponseMetadata) *base.PermissionCheckResponseMetadata {
 	response := &base.PermissionCheckResponseMetadata{}
 	for _, m := range meta {
-		response.CheckCount += m.CheckCount
+		response.CheckCount += m.GetCheckCount()
 	}
 	return response
 }
@@ -7,39 +7,94 @@ import (
 	"github.com/Permify/permify/internal/storage/memory/migrations"
 	"github.com/Permify/permify/pkg/database"
 	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
 	PQDatabase "github.com/Permify/permify/pkg/database/postgres"
+	SLDatabase "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
 )
 
 // DatabaseFactory is a factory function that creates a database instance according to the given configuration.
-// It supports different types of databases, such as PostgreSQL and in-memory databases.
+// It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // conf: the configuration object containing the necessary information to create a database connection.
 //
 //	It should have the following properties:
-//	- Engine: the type of the database, e.g., POSTGRES or MEMORY
-//	- URI: the connection string for the database (only required for some database engines, e.g., POSTGRES)
+//	- Engine: the type of the database, e.g., POSTGRES, MYSQL, SQLITE or MEMORY
+//	- URI: the connection string for the database (only required for some database engines, e.g., POSTGRES and MYSQL, the path of the database file for SQLITE)
 //	- MaxOpenConnections: the maximum number of open connections to the database
 //	- MaxIdleConnections: the maximum number of idle connections in the connection pool
 //	- MaxConnectionIdleTime: the maximum amount of time a connection can be idle before being closed
 //	- MaxConnectionLifetime: the maximum amount of time a connection can be reused before being closed
+//	- DatabaseGarbageCollection: the window after which the history of the database is garbage collected, if enabled
+//	- Persistence: the directory the MEMORY database is kept in and restored from, and its snapshot interval, if enabled
+//
+// l: the logger the failures of the background work of the database, e.g., the snapshots of the MEMORY database, are
+// logged with.
 //
 // Returns a database.Database instance if the database connection is successfully created, or an error if the
 // creation fails or the specified database engine is unsupported.
-func DatabaseFactory(conf config.Database) (db database.Database, err error) {
+func DatabaseFactory(conf config.Database, l logger.Interface) (db database.Database, err error) {
 	switch conf.Engine {
 	case database.POSTGRES.String():
-		db, err = PQDatabase.New(conf.URI,
+		opts := []PQDatabase.Option{
 			PQDatabase.MaxOpenConnections(conf.MaxOpenConnections),
 			PQDatabase.MaxIdleConnections(conf.MaxIdleConnections),
 			PQDatabase.MaxConnectionIdleTime(conf.MaxConnectionIdleTime),
 			PQDatabase.MaxConnectionLifeTime(conf.MaxConnectionLifetime),
-		)
+		}
+		// History older than the garbage collection window cannot be read anymore
+		if conf.DatabaseGarbageCollection.Enable {
+			opts = append(opts, PQDatabase.GarbageCollectionWindow(conf.DatabaseGarbageCollection.Window))
+		}
+		db, err = PQDatabase.New(conf.URI, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return
+	case database.MYSQL.String():
+		opts := []MYDatabase.Option{
+			MYDatabase.MaxOpenConnections(conf.MaxOpenConnections),
+			MYDatabase.MaxIdleConnections(conf.MaxIdleConnections),
+			MYDatabase.MaxConnectionIdleTime(conf.MaxConnectionIdleTime),
+			MYDatabase.MaxConnectionLifeTime(conf.MaxConnectionLifetime),
+		}
+		// History older than the garbage collection window cannot be read anymore
+		if conf.DatabaseGarbageCollection.Enable {
+			opts = append(opts, MYDatabase.GarbageCollectionWindow(conf.DatabaseGarbageCollection.Window))
+		}
+		db, err = MYDatabase.New(conf.URI, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return
+	case database.SQLITE.String():
+		opts := []SLDatabase.Option{
+			SLDatabase.MaxOpenConnections(conf.MaxOpenConnections),
+			SLDatabase.MaxIdleConnections(conf.MaxIdleConnections),
+			SLDatabase.MaxConnectionIdleTime(conf.MaxConnectionIdleTime),
+			SLDatabase.MaxConnectionLifeTime(conf.MaxConnectionLifetime),
+		}
+		// History older than the garbage collection window cannot be read anymore
+		if conf.DatabaseGarbageCollection.Enable {
+			opts = append(opts, SLDatabase.GarbageCollectionWindow(conf.DatabaseGarbageCollection.Window))
+		}
+		db, err = SLDatabase.New(conf.URI, opts...)
 		if err != nil {
 			return nil, err
 		}
 		return
 	case database.MEMORY.String():
-		db, err = IMDatabase.New(migrations.Schema)
+		opts := []IMDatabase.Option{
+			IMDatabase.Logger(l),
+		}
+		// The memory database is restored from the write log and the snapshots of its directory
+		if conf.Persistence.Enabled {
+			opts = append(opts,
+				IMDatabase.Persistence(conf.Persistence.Directory),
+				IMDatabase.SnapshotInterval(conf.Persistence.SnapshotInterval),
+			)
+		}
+		db, err = IMDatabase.New(migrations.Schema, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -1,17 +1,28 @@
 package factories
 
 import (
+	"fmt"
+
 	"github.com/Permify/permify/internal/storage"
 	MMRepository "github.com/Permify/permify/internal/storage/memory"
+	MMSnapshot "github.com/Permify/permify/internal/storage/memory/snapshot"
+	MYRepository "github.com/Permify/permify/internal/storage/mysql"
+	MYSnapshot "github.com/Permify/permify/internal/storage/mysql/snapshot"
 	PQRepository "github.com/Permify/permify/internal/storage/postgres"
+	PQSnapshot "github.com/Permify/permify/internal/storage/postgres/snapshot"
+	SLRepository "github.com/Permify/permify/internal/storage/sqlite"
+	SLSnapshot "github.com/Permify/permify/internal/storage/sqlite/snapshot"
 	"github.com/Permify/permify/pkg/database"
 	MMDatabase "github.com/Permify/permify/pkg/database/memory"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
 	PQDatabase "github.com/Permify/permify/pkg/database/postgres"
+	SLDatabase "github.com/Permify/permify/pkg/database/sqlite"
 	"github.com/Permify/permify/pkg/logger"
+	"github.com/Permify/permify/pkg/token"
 )
 
 // RelationshipReaderFactory is a factory function that returns a relationship reader instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the relationship reader should be created
 // logger: the logger.Interface instance to be used by the relationship reader for logging purposes
@@ -22,6 +33,10 @@ func RelationshipReaderFactory(db database.Database, logger logger.Interface) (r
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewRelationshipReader(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewRelationshipReader(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewRelationshipReader(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewRelationshipReader(db.(*MMDatabase.Memory), logger)
 	default:
@@ -30,7 +45,7 @@ func RelationshipReaderFactory(db database.Database, logger logger.Interface) (r
 }
 
 // RelationshipWriterFactory is a factory function that returns a relationship writer instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the relationship writer should be created
 // logger: the logger.Interface instance to be used by the relationship writer for logging purposes
@@ -41,6 +56,10 @@ func RelationshipWriterFactory(db database.Database, logger logger.Interface) (r
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewRelationshipWriter(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewRelationshipWriter(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewRelationshipWriter(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewRelationshipWriter(db.(*MMDatabase.Memory), logger)
 	default:
@@ -49,7 +68,7 @@ func RelationshipWriterFactory(db database.Database, logger logger.Interface) (r
 }
 
 // SchemaReaderFactory is a factory function that returns a schema reader instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the schema reader should be created
 // logger: the logger.Interface instance to be used by the schema reader for logging purposes
@@ -60,6 +79,10 @@ func SchemaReaderFactory(db database.Database, logger logger.Interface) (repo st
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewSchemaReader(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewSchemaReader(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewSchemaReader(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewSchemaReader(db.(*MMDatabase.Memory), logger)
 	default:
@@ -68,7 +91,7 @@ func SchemaReaderFactory(db database.Database, logger logger.Interface) (repo st
 }
 
 // SchemaWriterFactory is a factory function that returns a schema writer instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the schema writer should be created
 // logger: the logger.Interface instance to be used by the schema writer for logging purposes
@@ -79,6 +102,10 @@ func SchemaWriterFactory(db database.Database, logger logger.Interface) (repo st
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewSchemaWriter(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewSchemaWriter(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewSchemaWriter(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewSchemaWriter(db.(*MMDatabase.Memory), logger)
 	default:
@@ -87,7 +114,7 @@ func SchemaWriterFactory(db database.Database, logger logger.Interface) (repo st
 }
 
 // TenantReaderFactory is a factory function that returns a tenant reader instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the tenant reader should be created
 // logger: the logger.Interface instance to be used by the tenant reader for logging purposes
@@ -98,6 +125,10 @@ func TenantReaderFactory(db database.Database, logger logger.Interface) (repo st
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewTenantReader(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewTenantReader(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewTenantReader(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewTenantReader(db.(*MMDatabase.Memory), logger)
 	default:
@@ -106,7 +137,7 @@ func TenantReaderFactory(db database.Database, logger logger.Interface) (repo st
 }
 
 // TenantWriterFactory is a factory function that returns a tenant writer instance according to the
-// given database interface. It supports different types of databases, such as PostgreSQL and in-memory databases.
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
 //
 // db: the database.Database instance for which the tenant writer should be created
 // logger: the logger.Interface instance to be used by the tenant writer for logging purposes
@@ -117,9 +148,67 @@ func TenantWriterFactory(db database.Database, logger logger.Interface) (repo st
 	switch db.GetEngineType() {
 	case "postgres":
 		return PQRepository.NewTenantWriter(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewTenantWriter(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewTenantWriter(db.(*SLDatabase.SQLite), logger)
 	case "memory":
 		return MMRepository.NewTenantWriter(db.(*MMDatabase.Memory), logger)
 	default:
 		return MMRepository.NewTenantWriter(db.(*MMDatabase.Memory), logger)
 	}
 }
+
+// WatcherFactory is a factory function that returns a watcher instance according to the
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
+//
+// db: the database.Database instance for which the watcher should be created
+// logger: the logger.Interface instance to be used by the watcher for logging purposes
+//
+// Returns a storage.Watcher instance that streams the changes to the relationships stored
+// in the given database. If the database engine type is not recognized, it defaults to an in-memory database.
+func WatcherFactory(db database.Database, logger logger.Interface) (repo storage.Watcher) {
+	switch db.GetEngineType() {
+	case "postgres":
+		return PQRepository.NewWatcher(db.(*PQDatabase.Postgres), logger)
+	case "mysql":
+		return MYRepository.NewWatcher(db.(*MYDatabase.MySQL), logger)
+	case "sqlite":
+		return SLRepository.NewWatcher(db.(*SLDatabase.SQLite), logger)
+	case "memory":
+		return MMRepository.NewWatcher(db.(*MMDatabase.Memory), logger)
+	default:
+		return MMRepository.NewWatcher(db.(*MMDatabase.Memory), logger)
+	}
+}
+
+// SnapTokenDecoderFactory is a factory function that returns a snap token decoder according to the
+// given database interface. It supports different types of databases, such as PostgreSQL, MySQL, SQLite and in-memory databases.
+//
+// db: the database.Database instance whose snap tokens should be decoded
+//
+// Returns a token.Decoder that turns the string form of a snap token issued by the given database back into a
+// comparable token.SnapToken, or an error if the database engine type is not recognized. Snap tokens of different
+// engines are not comparable, so there is no default decoder.
+func SnapTokenDecoderFactory(db database.Database) (token.Decoder, error) {
+	switch db.GetEngineType() {
+	case "postgres":
+		return func(value string) (token.SnapToken, error) {
+			return PQSnapshot.EncodedToken{Value: value}.Decode()
+		}, nil
+	case "mysql":
+		return func(value string) (token.SnapToken, error) {
+			return MYSnapshot.EncodedToken{Value: value}.Decode()
+		}, nil
+	case "sqlite":
+		return func(value string) (token.SnapToken, error) {
+			return SLSnapshot.EncodedToken{Value: value}.Decode()
+		}, nil
+	case "memory":
+		return func(value string) (token.SnapToken, error) {
+			return MMSnapshot.EncodedToken{Value: value}.Decode()
+		}, nil
+	default:
+		return nil, fmt.Errorf("%s snap tokens are unsupported", db.GetEngineType())
+	}
+}
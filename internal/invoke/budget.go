@@ -0,0 +1,22 @@
+package invoke
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// withBudget bounds the context by the budget of a request, if the request has one.
+func withBudget(ctx context.Context, budget *durationpb.Duration) (context.Context, context.CancelFunc) {
+	if budget == nil {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, budget.AsDuration())
+}
+
+// BudgetExceeded reports whether the evaluation of a request with the given budget was cut short because the
+// budget was exceeded. Requests without a budget never exceed it.
+func BudgetExceeded(ctx context.Context, budget *durationpb.Duration) bool {
+	return budget != nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
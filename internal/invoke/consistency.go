@@ -0,0 +1,169 @@
+package invoke
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+)
+
+// quantizedSnapshots holds the snapshots that were current at the start of the present quantization window. The
+// snapshots of a window are dropped when the window moves on, so they are kept for the tenants requested within it.
+type quantizedSnapshots struct {
+	mu     sync.Mutex
+	window time.Time
+	tokens map[string]token.SnapToken
+}
+
+// load returns the snapshot of the tenant for the window, if it is the present one.
+func (q *quantizedSnapshots) load(tenantID string, window time.Time) (token.SnapToken, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.window.Equal(window) {
+		return nil, false
+	}
+	st, ok := q.tokens[tenantID]
+	return st, ok
+}
+
+// store keeps the snapshot of the tenant for the window, replacing the snapshots of an earlier window.
+func (q *quantizedSnapshots) store(tenantID string, window time.Time, st token.SnapToken) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if window.After(q.window) {
+		q.window = window
+		q.tokens = make(map[string]token.SnapToken)
+	}
+	if q.window.Equal(window) {
+		q.tokens[tenantID] = st
+	}
+}
+
+// snapshot resolves the snap token a request is evaluated at according to its consistency mode. A request for a
+// point in time is evaluated at the snapshot that was current at that time, whatever its consistency mode.
+//
+//   - CONSISTENCY_MINIMIZE_LATENCY uses the quantized snapshot, so requests within the same window share cache keys.
+//   - CONSISTENCY_AT_LEAST_AS_FRESH uses the newest of the given snap token and the quantized snapshot.
+//   - CONSISTENCY_FULLY_CONSISTENT uses the head snapshot.
+//   - CONSISTENCY_UNSPECIFIED uses the given snap token, or the head snapshot if none is given.
+func (invoker *DirectInvoker) snapshot(ctx context.Context, tenantID, snap string, consistency base.Consistency, atTime *timestamppb.Timestamp) (string, error) {
+	if atTime != nil {
+		st, err := invoker.relationshipReader.SnapshotAt(ctx, tenantID, atTime.AsTime())
+		if err != nil {
+			return "", err
+		}
+		return st.Encode().String(), nil
+	}
+
+	switch consistency {
+	case base.Consistency_CONSISTENCY_MINIMIZE_LATENCY:
+		st, err := invoker.quantizedSnapshot(ctx, tenantID)
+		if err != nil {
+			return "", err
+		}
+		return st.Encode().String(), nil
+	case base.Consistency_CONSISTENCY_AT_LEAST_AS_FRESH:
+		if snap == "" {
+			st, err := invoker.quantizedSnapshot(ctx, tenantID)
+			if err != nil {
+				return "", err
+			}
+			return st.Encode().String(), nil
+		}
+		// Without a decoder the tokens cannot be compared; the head snapshot is always fresh enough.
+		if invoker.snapTokenDecoder == nil {
+			return invoker.headSnapshot(ctx, tenantID)
+		}
+		requested, err := invoker.snapTokenDecoder(snap)
+		if err != nil {
+			return "", errors.New(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+		}
+		st, err := invoker.quantizedSnapshot(ctx, tenantID)
+		if err != nil {
+			return "", err
+		}
+		if requested.Gt(st) {
+			return snap, nil
+		}
+		return st.Encode().String(), nil
+	case base.Consistency_CONSISTENCY_FULLY_CONSISTENT:
+		return invoker.headSnapshot(ctx, tenantID)
+	default:
+		if snap != "" {
+			return snap, nil
+		}
+		return invoker.headSnapshot(ctx, tenantID)
+	}
+}
+
+// requestMetadata is the metadata of the permission requests that are evaluated at a snapshot and a schema version.
+type requestMetadata interface {
+	GetSnapToken() string
+	GetSchemaVersion() string
+	GetConsistency() base.Consistency
+	GetAtTime() *timestamppb.Timestamp
+}
+
+// resolve resolves the snap token and the schema version a request of the tenant is evaluated at from its metadata.
+// They are used by all the sub-requests, so the callers clear the consistency and the point in time of the request
+// once they are set.
+func (invoker *DirectInvoker) resolve(ctx context.Context, tenantID string, metadata requestMetadata) (snap, version string, err error) {
+	snap, err = invoker.snapshot(ctx, tenantID, metadata.GetSnapToken(), metadata.GetConsistency(), metadata.GetAtTime())
+	if err != nil {
+		return "", "", err
+	}
+
+	version = metadata.GetSchemaVersion()
+	if version == "" {
+		version, err = invoker.schemaVersion(ctx, tenantID, metadata.GetAtTime())
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return snap, version, nil
+}
+
+// schemaVersion resolves the schema version a request without one is evaluated at: the version that was current at
+// the point in time of the request, or the head version.
+func (invoker *DirectInvoker) schemaVersion(ctx context.Context, tenantID string, atTime *timestamppb.Timestamp) (string, error) {
+	if atTime != nil {
+		return invoker.schemaReader.VersionAt(ctx, tenantID, atTime.AsTime())
+	}
+	return invoker.schemaReader.HeadVersion(ctx, tenantID)
+}
+
+// headSnapshot returns the encoded head snapshot of the tenant.
+func (invoker *DirectInvoker) headSnapshot(ctx context.Context, tenantID string) (string, error) {
+	st, err := invoker.relationshipReader.HeadSnapshot(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return st.Encode().String(), nil
+}
+
+// quantizedSnapshot returns the snapshot that was current at the start of the present quantization window.
+// The window is derived from the wall clock alone, so every node resolves the same snapshot for it. The result
+// is cached per tenant until the window moves on.
+func (invoker *DirectInvoker) quantizedSnapshot(ctx context.Context, tenantID string) (token.SnapToken, error) {
+	if invoker.snapshotQuantization <= 0 {
+		return invoker.relationshipReader.HeadSnapshot(ctx, tenantID)
+	}
+
+	window := time.Now().Truncate(invoker.snapshotQuantization)
+	if st, ok := invoker.quantizedSnapshots.load(tenantID, window); ok {
+		return st, nil
+	}
+
+	st, err := invoker.relationshipReader.SnapshotAt(ctx, tenantID, window)
+	if err != nil {
+		return nil, err
+	}
+
+	invoker.quantizedSnapshots.store(tenantID, window, st)
+	return st, nil
+}
@@ -0,0 +1,31 @@
+package invoke
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// LookupCursor returns the continuous token that resumes a lookup after the given entity id, at the snap token and
+// the schema version of its first page. The token only holds the last entity id, so its size does not depend on the
+// number of entity ids that have been returned.
+func LookupCursor(snapToken, schemaVersion, entityID string) string {
+	// Snap tokens are base64 encoded and schema versions are alphanumeric, so they never contain the separator.
+	return base64.StdEncoding.EncodeToString([]byte(strings.Join([]string{snapToken, schemaVersion, entityID}, ":")))
+}
+
+// ParseLookupCursor returns the snap token, the schema version and the entity id of a continuous token created by
+// LookupCursor.
+func ParseLookupCursor(token string) (snapToken, schemaVersion, entityID string, err error) {
+	b, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", "", errors.New(base.ErrorCode_ERROR_CODE_INVALID_CONTINUOUS_TOKEN.String())
+	}
+	parts := strings.SplitN(string(b), ":", 3)
+	if len(parts) != 3 {
+		return "", "", "", errors.New(base.ErrorCode_ERROR_CODE_INVALID_CONTINUOUS_TOKEN.String())
+	}
+	return parts[0], parts[1], parts[2], nil
+}
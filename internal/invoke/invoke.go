@@ -2,15 +2,23 @@ package invoke
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 
 	"github.com/Permify/permify/internal/storage"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 	"github.com/Permify/permify/pkg/token"
 )
 
-var tracer = otel.Tracer("invoke")
+var (
+	tracer = otel.Tracer("invoke")
+	meter  = global.Meter("invoke")
+)
 
 // Invoker is an interface that groups multiple permission-related interfaces.
 // It is used to define a common contract for invoking various permission operations.
@@ -18,6 +26,7 @@ type Invoker interface {
 	Check
 	Expand
 	LookupEntity
+	Matrix
 }
 
 // Check is an interface that defines a method for checking permissions.
@@ -32,6 +41,7 @@ type Check interface {
 // and returns a PermissionExpandResponse and an error if any.
 type Expand interface {
 	Expand(ctx context.Context, request *base.PermissionExpandRequest) (response *base.PermissionExpandResponse, err error)
+	ExpandStream(ctx context.Context, request *base.PermissionExpandRequest, server base.Permission_ExpandStreamServer) (err error)
 }
 
 // LookupEntity is an interface that defines a method for looking up entities with permissions.
@@ -42,6 +52,13 @@ type LookupEntity interface {
 	LookupEntityStream(ctx context.Context, request *base.PermissionLookupEntityRequest, server base.Permission_LookupEntityStreamServer) (err error)
 }
 
+// Matrix is an interface that defines a method for checking a set of permissions on a set of entities.
+// It requires an implementation of Matrix that takes a context and a PermissionMatrixRequest,
+// and returns a PermissionMatrixResponse and an error if any.
+type Matrix interface {
+	Matrix(ctx context.Context, request *base.PermissionMatrixRequest) (response *base.PermissionMatrixResponse, err error)
+}
+
 // DirectInvoker is a struct that implements the Invoker interface.
 // It holds references to various engines needed for permission-related operations.
 type DirectInvoker struct {
@@ -55,10 +72,20 @@ type DirectInvoker struct {
 	ec Expand
 	// LookupEntity engine for looking up entities with permissions
 	le LookupEntity
+	// Matrix engine for checking permissions on multiple entities
+	mx Matrix
+	// snapshotQuantization is the window that minimize latency snapshots are quantized to
+	snapshotQuantization time.Duration
+	// snapTokenDecoder decodes snap tokens so that they can be compared
+	snapTokenDecoder token.Decoder
+	// quantizedSnapshots caches the quantized snapshot of each tenant for the present window
+	quantizedSnapshots quantizedSnapshots
+	// budgetExceeded counts the requests that exceeded their budget
+	budgetExceeded metric.Int64Counter
 }
 
 // NewDirectInvoker is a constructor for DirectInvoker.
-// It takes pointers to CheckEngine, ExpandEngine, LookupSchemaEngine, and LookupEntityEngine as arguments
+// It takes pointers to CheckEngine, ExpandEngine, LookupEntityEngine, and MatrixEngine as arguments
 // and returns an Invoker instance.
 func NewDirectInvoker(
 	schemaReader storage.SchemaReader,
@@ -66,24 +93,62 @@ func NewDirectInvoker(
 	cc Check,
 	ec Expand,
 	le LookupEntity,
+	mx Matrix,
+	opts ...DirectInvokerOption,
 ) *DirectInvoker {
-	return &DirectInvoker{
-		schemaReader:       schemaReader,
-		relationshipReader: relationshipReader,
-		cc:                 cc,
-		ec:                 ec,
-		le:                 le,
+	invoker := &DirectInvoker{
+		schemaReader:         schemaReader,
+		relationshipReader:   relationshipReader,
+		cc:                   cc,
+		ec:                   ec,
+		le:                   le,
+		mx:                   mx,
+		snapshotQuantization: _defaultSnapshotQuantization,
+	}
+
+	invoker.budgetExceeded, _ = meter.Int64Counter(
+		"permission_budget_exceeded",
+		metric.WithDescription("number of permission requests that exceeded their budget"),
+	)
+
+	// options
+	for _, opt := range opts {
+		opt(invoker)
 	}
+
+	return invoker
 }
 
 // Check is a method that implements the Check interface.
 // It calls the Run method of the CheckEngine with the provided context and PermissionCheckRequest,
-// and returns a PermissionCheckResponse and an error if any.
+// and returns a PermissionCheckResponse and an error if any. A check that exceeds the budget of the
+// request is answered as unknown, with the reason of the result.
 func (invoker *DirectInvoker) Check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
 	// Start a new tracing span to measure the performance of the Check function.
 	ctx, span := tracer.Start(ctx, "permissions.check")
 	defer span.End()
 
+	// Bound the check by its budget. Sub-requests do not carry the budget, so it is applied once.
+	budget := request.GetMetadata().GetBudget()
+	ctx, cancel := withBudget(ctx, budget)
+	defer cancel()
+
+	response, err = invoker.check(ctx, request)
+	if err != nil && BudgetExceeded(ctx, budget) {
+		invoker.recordBudgetExceeded(ctx, request.GetTenantId(), "check")
+		return &base.PermissionCheckResponse{
+			Can: base.PermissionCheckResponse_RESULT_UNKNOWN,
+			Metadata: &base.PermissionCheckResponseMetadata{
+				CheckCount: 0,
+				Reason:     base.PermissionCheckResponseMetadata_REASON_BUDGET_EXCEEDED,
+			},
+		}, nil
+	}
+	return response, err
+}
+
+// check resolves the snapshot and the schema version of the request and performs the permission check.
+func (invoker *DirectInvoker) check(ctx context.Context, request *base.PermissionCheckRequest) (response *base.PermissionCheckResponse, err error) {
 	// Validate the depth of the request.
 	err = checkDepth(request)
 	if err != nil {
@@ -95,33 +160,17 @@ func (invoker *DirectInvoker) Check(ctx context.Context, request *base.Permissio
 		}, err
 	}
 
-	// Set the SnapToken if it's not provided in the request.
-	if request.GetMetadata().GetSnapToken() == "" {
-		var st token.SnapToken
-		st, err = invoker.relationshipReader.HeadSnapshot(ctx, request.GetTenantId())
-		if err != nil {
-			return &base.PermissionCheckResponse{
-				Can: base.PermissionCheckResponse_RESULT_DENIED,
-				Metadata: &base.PermissionCheckResponseMetadata{
-					CheckCount: 0,
-				},
-			}, err
-		}
-		request.Metadata.SnapToken = st.Encode().String()
-	}
-
-	// Set the SchemaVersion if it's not provided in the request.
-	if request.GetMetadata().GetSchemaVersion() == "" {
-		request.Metadata.SchemaVersion, err = invoker.schemaReader.HeadVersion(ctx, request.GetTenantId())
-		if err != nil {
-			return &base.PermissionCheckResponse{
-				Can: base.PermissionCheckResponse_RESULT_DENIED,
-				Metadata: &base.PermissionCheckResponseMetadata{
-					CheckCount: 0,
-				},
-			}, err
-		}
+	// Resolve the SnapToken and the SchemaVersion the check and all its sub-requests are evaluated at.
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return &base.PermissionCheckResponse{
+			Can: base.PermissionCheckResponse_RESULT_DENIED,
+			Metadata: &base.PermissionCheckResponseMetadata{
+				CheckCount: 0,
+			},
+		}, err
 	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
 
 	// Decrease the depth of the request metadata.
 	request.Metadata = decreaseDepth(request.GetMetadata())
@@ -149,77 +198,134 @@ func (invoker *DirectInvoker) Expand(ctx context.Context, request *base.Permissi
 	ctx, span := tracer.Start(ctx, "permissions.expand")
 	defer span.End()
 
-	if request.GetMetadata().GetSnapToken() == "" {
-		var st token.SnapToken
-		st, err = invoker.relationshipReader.HeadSnapshot(ctx, request.GetTenantId())
-		if err != nil {
-			return response, err
-		}
-		request.Metadata.SnapToken = st.Encode().String()
+	// Resolve the SnapToken and the SchemaVersion all sub-requests are evaluated at
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return response, err
 	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
 
-	if request.GetMetadata().GetSchemaVersion() == "" {
-		request.Metadata.SchemaVersion, err = invoker.schemaReader.HeadVersion(ctx, request.GetTenantId())
-		if err != nil {
-			return response, err
-		}
+	return invoker.ec.Expand(ctx, request)
+}
+
+// ExpandStream is a method that implements the Expand interface.
+// It calls the ExpandStream method of the ExpandEngine with the provided context, PermissionExpandRequest, and Permission_ExpandStreamServer,
+// and returns an error if any.
+func (invoker *DirectInvoker) ExpandStream(ctx context.Context, request *base.PermissionExpandRequest, server base.Permission_ExpandStreamServer) (err error) {
+	ctx, span := tracer.Start(ctx, "permissions.expand-stream")
+	defer span.End()
+
+	// Resolve the SnapToken and the SchemaVersion all sub-requests are evaluated at
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return err
 	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
 
-	return invoker.ec.Expand(ctx, request)
+	return invoker.ec.ExpandStream(ctx, request, server)
 }
 
 // LookupEntity is a method that implements the LookupEntity interface.
 // It calls the Run method of the LookupEntityEngine with the provided context and PermissionLookupEntityRequest,
-// and returns a PermissionLookupEntityResponse and an error if any.
+// and returns a PermissionLookupEntityResponse and an error if any. A lookup that exceeds the budget of the
+// request returns the entity IDs found so far, marked as truncated.
 func (invoker *DirectInvoker) LookupEntity(ctx context.Context, request *base.PermissionLookupEntityRequest) (response *base.PermissionLookupEntityResponse, err error) {
 	ctx, span := tracer.Start(ctx, "permissions.lookup-entity")
 	defer span.End()
 
-	// Set SnapToken if not provided
-	if request.GetMetadata().GetSnapToken() == "" { // Check if the request has a SnapToken.
-		var st token.SnapToken
-		st, err = invoker.relationshipReader.HeadSnapshot(ctx, request.GetTenantId()) // Retrieve the head snapshot from the relationship reader.
-		if err != nil {
-			return nil, err
+	// Bound the lookup by its budget
+	budget := request.GetMetadata().GetBudget()
+	ctx, cancel := withBudget(ctx, budget)
+	defer cancel()
+
+	response, err = invoker.lookupEntity(ctx, request)
+	if err != nil && BudgetExceeded(ctx, budget) {
+		// The budget was exceeded before the lookup started, so it is resumed from where the request started it
+		ct := request.GetContinuousToken()
+		if ct == "" && len(request.GetEntityIds()) == 0 {
+			ct = LookupCursor("", "", "")
 		}
-		request.Metadata.SnapToken = st.Encode().String() // Set the SnapToken in the request metadata.
+		response, err = &base.PermissionLookupEntityResponse{ContinuousToken: ct, Truncated: true}, nil
 	}
+	if response.GetTruncated() {
+		invoker.recordBudgetExceeded(ctx, request.GetTenantId(), "lookup-entity")
+	}
+	return response, err
+}
 
-	// Set SchemaVersion if not provided
-	if request.GetMetadata().GetSchemaVersion() == "" { // Check if the request has a SchemaVersion.
-		request.Metadata.SchemaVersion, err = invoker.schemaReader.HeadVersion(ctx, request.GetTenantId()) // Retrieve the head schema version from the schema reader.
-		if err != nil {
-			return nil, err
-		}
+// lookupEntity resolves the snapshot and the schema version of the request and performs the lookup.
+func (invoker *DirectInvoker) lookupEntity(ctx context.Context, request *base.PermissionLookupEntityRequest) (response *base.PermissionLookupEntityResponse, err error) {
+	// Resolve the SnapToken and the SchemaVersion all sub-requests are evaluated at
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return nil, err
 	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
 
 	return invoker.le.LookupEntity(ctx, request)
 }
 
 // LookupEntityStream is a method that implements the LookupEntityStream interface.
 // It calls the Stream method of the LookupEntityEngine with the provided context, PermissionLookupEntityRequest, and Permission_LookupEntityStreamServer,
-// and returns an error if any.
+// and returns an error if any. A lookup that exceeds the budget of the request marks the last response of the stream as
+// truncated, or fails if it found no entity.
 func (invoker *DirectInvoker) LookupEntityStream(ctx context.Context, request *base.PermissionLookupEntityRequest, server base.Permission_LookupEntityStreamServer) (err error) {
 	ctx, span := tracer.Start(ctx, "permissions.lookup-entity-stream")
 	defer span.End()
 
-	// Set SnapToken if not provided
-	if request.GetMetadata().GetSnapToken() == "" { // Check if the request has a SnapToken.
-		var st token.SnapToken
-		st, err = invoker.relationshipReader.HeadSnapshot(ctx, request.GetTenantId()) // Retrieve the head snapshot from the relationship reader.
-		if err != nil {
-			return err
-		}
-		request.Metadata.SnapToken = st.Encode().String() // Set the SnapToken in the request metadata.
+	// Bound the lookup by its budget
+	budget := request.GetMetadata().GetBudget()
+	ctx, cancel := withBudget(ctx, budget)
+	defer cancel()
+
+	err = invoker.lookupEntityStream(ctx, request, server)
+	if !BudgetExceeded(ctx, budget) {
+		return err
 	}
+	invoker.recordBudgetExceeded(ctx, request.GetTenantId(), "lookup-entity-stream")
+	if err != nil {
+		// The budget was exceeded before any entity was found
+		return errors.New(base.ErrorCode_ERROR_CODE_CANCELLED.String())
+	}
+	return nil
+}
 
-	// Set SchemaVersion if not provided
-	if request.GetMetadata().GetSchemaVersion() == "" { // Check if the request has a SchemaVersion.
-		request.Metadata.SchemaVersion, err = invoker.schemaReader.HeadVersion(ctx, request.GetTenantId()) // Retrieve the head schema version from the schema reader.
-		if err != nil {
-			return err
-		}
+// lookupEntityStream resolves the snapshot and the schema version of the request and streams the lookup.
+func (invoker *DirectInvoker) lookupEntityStream(ctx context.Context, request *base.PermissionLookupEntityRequest, server base.Permission_LookupEntityStreamServer) (err error) {
+	// Resolve the SnapToken and the SchemaVersion all sub-requests are evaluated at
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return err
 	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
 
 	return invoker.le.LookupEntityStream(ctx, request, server)
 }
+
+// Matrix is a method that implements the Matrix interface.
+// It calls the Matrix method of the MatrixEngine with the provided context and PermissionMatrixRequest,
+// and returns a PermissionMatrixResponse and an error if any.
+func (invoker *DirectInvoker) Matrix(ctx context.Context, request *base.PermissionMatrixRequest) (response *base.PermissionMatrixResponse, err error) {
+	ctx, span := tracer.Start(ctx, "permissions.matrix")
+	defer span.End()
+
+	// Resolve the SnapToken and the SchemaVersion all sub-requests are evaluated at
+	request.Metadata.SnapToken, request.Metadata.SchemaVersion, err = invoker.resolve(ctx, request.GetTenantId(), request.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	request.Metadata.Consistency, request.Metadata.AtTime = base.Consistency_CONSISTENCY_UNSPECIFIED, nil
+
+	return invoker.mx.Matrix(ctx, request)
+}
+
+// recordBudgetExceeded counts a request of the tenant that exceeded its budget.
+func (invoker *DirectInvoker) recordBudgetExceeded(ctx context.Context, tenantID, operation string) {
+	if invoker.budgetExceeded == nil {
+		return
+	}
+	invoker.budgetExceeded.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("operation", operation),
+	))
+}
@@ -0,0 +1,123 @@
+package invoke
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// pathKey is the context key under which the evaluation path is stored.
+type pathKey struct{}
+
+// Path is a node in the chain of sub-problems that led to the current evaluation. Engines push a node
+// before they recurse into a sub-problem, so that a sub-problem that is already being evaluated further
+// up the chain can be recognized as a cycle.
+type Path struct {
+	parent *Path
+	key    string
+	// cyclic is set when the evaluation below this node was cut short by a cycle
+	cyclic atomic.Bool
+}
+
+// PathFromContext returns the path stored in the context, or nil if the evaluation has just started.
+func PathFromContext(ctx context.Context) *Path {
+	p, _ := ctx.Value(pathKey{}).(*Path)
+	return p
+}
+
+// ContextWithPath returns a copy of the context carrying the given path.
+func ContextWithPath(ctx context.Context, p *Path) context.Context {
+	return context.WithValue(ctx, pathKey{}, p)
+}
+
+// NewPath returns the path of the given keys, from the top of the evaluation to the current sub-problem. It is
+// used to continue an evaluation whose path was passed on by another node.
+func NewPath(keys ...string) *Path {
+	var p *Path
+	for _, key := range keys {
+		p = p.Push(key)
+	}
+	return p
+}
+
+// Push returns a new node for the given key with the receiver as its parent. It is safe to call on a nil path.
+func (p *Path) Push(key string) *Path {
+	return &Path{parent: p, key: key}
+}
+
+// Key returns the key of the node.
+func (p *Path) Key() string {
+	if p == nil {
+		return ""
+	}
+	return p.key
+}
+
+// Cycle reports whether the key is already on the path. If it is, every node below the repeated one is
+// marked as cyclic and the keys that form the cycle are returned, starting and ending with the key.
+func (p *Path) Cycle(key string) ([]string, bool) {
+	var keys []string
+	for n := p; n != nil; n = n.parent {
+		keys = append(keys, n.key)
+		if n.key != key {
+			continue
+		}
+		// The results below the repeated node depend on it, so they are only valid for this path.
+		for m := p; m != n; m = m.parent {
+			m.cyclic.Store(true)
+		}
+		cycle := make([]string, 0, len(keys)+1)
+		for i := len(keys) - 1; i >= 0; i-- {
+			cycle = append(cycle, keys[i])
+		}
+		return append(cycle, key), true
+	}
+	return nil, false
+}
+
+// Cyclic reports whether the evaluation below this node was cut short by a cycle. Results of cyclic
+// nodes are only valid for the path they were evaluated on and must not be cached or shared.
+func (p *Path) Cyclic() bool {
+	if p == nil {
+		return false
+	}
+	return p.cyclic.Load()
+}
+
+// Depth returns the number of nodes on the path. It is safe to call on a nil path.
+func (p *Path) Depth() int {
+	depth := 0
+	for n := p; n != nil; n = n.parent {
+		depth++
+	}
+	return depth
+}
+
+// Keys returns the keys of the path, from the top of the evaluation to the node. It is safe to call on a nil path.
+func (p *Path) Keys() []string {
+	keys := make([]string, p.Depth())
+	i := len(keys) - 1
+	for n := p; n != nil; n = n.parent {
+		keys[i] = n.key
+		i--
+	}
+	return keys
+}
+
+// CyclicLength returns the number of nodes, starting from the node and going up the path, that are cyclic.
+// Cycles mark the nodes below the repeated one, so the cyclic nodes of a path are always the last ones.
+func (p *Path) CyclicLength() int {
+	length := 0
+	for n := p; n != nil && n.Cyclic(); n = n.parent {
+		length++
+	}
+	return length
+}
+
+// MarkCyclic marks the node and the nodes above it, up to the given number of nodes, as cyclic. It is used to
+// apply the cycles that were found by another node that continued the evaluation.
+func (p *Path) MarkCyclic(length int) {
+	for n := p; n != nil && length > 0; n = n.parent {
+		n.cyclic.Store(true)
+		length--
+	}
+}
@@ -2,10 +2,34 @@ package invoke
 
 import (
 	"errors"
+	"time"
 
 	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
 )
 
+const (
+	_defaultSnapshotQuantization = 5 * time.Second
+)
+
+// DirectInvokerOption - a functional option type for configuring the DirectInvoker.
+type DirectInvokerOption func(invoker *DirectInvoker)
+
+// SnapshotQuantization - a functional option that sets the window that minimize latency snapshots are quantized to.
+// A non-positive interval disables quantization and resolves those requests at the head snapshot.
+func SnapshotQuantization(interval time.Duration) DirectInvokerOption {
+	return func(invoker *DirectInvoker) {
+		invoker.snapshotQuantization = interval
+	}
+}
+
+// SnapTokenDecoder - a functional option that sets the decoder used to compare snap tokens of at least as fresh requests.
+func SnapTokenDecoder(decoder token.Decoder) DirectInvokerOption {
+	return func(invoker *DirectInvoker) {
+		invoker.snapTokenDecoder = decoder
+	}
+}
+
 // checkDepth - a helper function that returns an error if the depth in a PermissionCheckRequest is zero.
 func checkDepth(request *base.PermissionCheckRequest) error {
 	if request.GetMetadata().Depth == 0 {
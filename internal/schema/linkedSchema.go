@@ -40,12 +40,15 @@ func NewLinkedGraph(schema *base.SchemaDefinition) *LinkedSchemaGraph {
 //   - RelationLinkedEntrance: represents an entry point into a relationship object in the schema graph
 //   - TupleToUserSetLinkedEntrance: represents an entry point into a tuple-to-user-set object in the schema graph
 //   - ComputedUserSetLinkedEntrance: represents an entry point into a computed user set object in the schema graph
+//   - EntityTypeLinkedEntrance: represents an entry point into every entity of a type, used when the target can be
+//     granted by the absence of a relation (exclusion) rather than by a path from the source
 type LinkedEntranceKind string
 
 const (
 	RelationLinkedEntrance        LinkedEntranceKind = "relation"
 	TupleToUserSetLinkedEntrance  LinkedEntranceKind = "tuple_to_user_set"
 	ComputedUserSetLinkedEntrance LinkedEntranceKind = "computed_user_set"
+	EntityTypeLinkedEntrance      LinkedEntranceKind = "entity_type"
 )
 
 // LinkedEntrance represents an entry point into the LinkedSchemaGraph, which is used to resolve permissions and expand user
@@ -87,7 +90,7 @@ func (re LinkedEntrance) LinkedEntranceKind() LinkedEntranceKind {
 //   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
 //     source relation does not exist in the schema graph
 func (g *LinkedSchemaGraph) RelationshipLinkedEntrances(target, source *base.RelationReference) ([]*LinkedEntrance, error) {
-	entries, err := g.findEntrance(target, source, map[string]struct{}{})
+	entries, err := g.findEntrance(target, source, map[string]struct{}{}, false)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +98,21 @@ func (g *LinkedSchemaGraph) RelationshipLinkedEntrances(target, source *base.Rel
 	return entries, nil
 }
 
+// SubjectLinkedEntrances returns the entry points into the LinkedSchemaGraph for the specified target relation when the
+// source is the subject the target is evaluated for, as opposed to a subject set reached on the way. Such a subject has to
+// satisfy every child of an intersection itself, so only the entry points of the most selective child are returned.
+//
+// Parameters:
+//   - target: pointer to a base.RelationReference that identifies the target relation
+//   - source: pointer to a base.RelationReference that identifies the relation of the subject
+//
+// Returns:
+//   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
+//     source relation does not exist in the schema graph
+func (g *LinkedSchemaGraph) SubjectLinkedEntrances(target, source *base.RelationReference) ([]*LinkedEntrance, error) {
+	return g.findEntrance(target, source, map[string]struct{}{}, true)
+}
+
 // findEntrance is a recursive helper function that searches the LinkedSchemaGraph for all entry points that can be reached
 // from the specified target relation through the specified source relation. The function uses a depth-first search to traverse
 // the schema graph and identify entry points, marking visited nodes in a map to avoid infinite recursion. If the target or
@@ -106,11 +124,12 @@ func (g *LinkedSchemaGraph) RelationshipLinkedEntrances(target, source *base.Rel
 //   - target: pointer to a base.RelationReference that identifies the target relation
 //   - source: pointer to a base.RelationReference that identifies the source relation used to reach the target relation
 //   - visited: map used to track visited nodes and avoid infinite recursion
+//   - narrow: whether the source is the subject itself, which lets intersections be narrowed to one child
 //
 // Returns:
 //   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
 //     source relation does not exist in the schema graph
-func (g *LinkedSchemaGraph) findEntrance(target, source *base.RelationReference, visited map[string]struct{}) ([]*LinkedEntrance, error) {
+func (g *LinkedSchemaGraph) findEntrance(target, source *base.RelationReference, visited map[string]struct{}, narrow bool) ([]*LinkedEntrance, error) {
 	key := utils.Key(target.GetType(), target.GetRelation())
 	if _, ok := visited[key]; ok {
 		return nil, nil
@@ -129,11 +148,11 @@ func (g *LinkedSchemaGraph) findEntrance(target, source *base.RelationReference,
 		}
 		child := action.GetChild()
 		if child.GetRewrite() != nil {
-			return g.findEntranceRewrite(target, source, child.GetRewrite(), visited)
+			return g.findEntranceRewrite(target, source, child.GetRewrite(), visited, narrow)
 		}
-		return g.findEntranceLeaf(target, source, child.GetLeaf(), visited)
+		return g.findEntranceLeaf(target, source, child.GetLeaf(), visited, narrow)
 	}
-	return g.findRelationEntrance(target, source, visited)
+	return g.findRelationEntrance(target, source, visited, narrow)
 }
 
 // findRelationEntrance is a helper function that searches the LinkedSchemaGraph for entry points that can be reached from
@@ -146,11 +165,12 @@ func (g *LinkedSchemaGraph) findEntrance(target, source *base.RelationReference,
 //   - target: pointer to a base.RelationReference that identifies the target relation
 //   - source: pointer to a base.RelationReference that identifies the source relation used to reach the target relation
 //   - visited: map used to track visited nodes and avoid infinite recursion
+//   - narrow: whether the source is the subject itself, which lets intersections be narrowed to one child
 //
 // Returns:
 //   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
 //     source relation does not exist in the schema graph
-func (g *LinkedSchemaGraph) findRelationEntrance(target, source *base.RelationReference, visited map[string]struct{}) ([]*LinkedEntrance, error) {
+func (g *LinkedSchemaGraph) findRelationEntrance(target, source *base.RelationReference, visited map[string]struct{}, narrow bool) ([]*LinkedEntrance, error) {
 	var res []*LinkedEntrance
 
 	entity, ok := g.schema.EntityDefinitions[target.GetType()]
@@ -175,7 +195,7 @@ func (g *LinkedSchemaGraph) findRelationEntrance(target, source *base.RelationRe
 
 	for _, rel := range relation.GetRelationReferences() {
 		if rel.GetRelation() != "" {
-			entrances, err := g.findEntrance(rel, source, visited)
+			entrances, err := g.findEntrance(rel, source, visited, narrow)
 			if err != nil {
 				return nil, err
 			}
@@ -200,11 +220,23 @@ func (g *LinkedSchemaGraph) findRelationEntrance(target, source *base.RelationRe
 //   - source: pointer to a base.RelationReference that identifies the source relation used to reach the target relation
 //   - leaf: pointer to a base.Leaf object that represents the child of an action reference
 //   - visited: map used to track visited nodes and avoid infinite recursion
+//   - narrow: whether the source is the subject itself, which lets intersections be narrowed to one child
 //
 // Returns:
 //   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
 //     source relation does not exist in the schema graph
-func (g *LinkedSchemaGraph) findEntranceLeaf(target, source *base.RelationReference, leaf *base.Leaf, visited map[string]struct{}) ([]*LinkedEntrance, error) {
+func (g *LinkedSchemaGraph) findEntranceLeaf(target, source *base.RelationReference, leaf *base.Leaf, visited map[string]struct{}, narrow bool) ([]*LinkedEntrance, error) {
+	// An excluded leaf is satisfied by the absence of a path from the source, so no relationship of the source
+	// leads to the target. Every entity of the target type is a candidate instead.
+	if leaf.GetExclusion() {
+		return []*LinkedEntrance{
+			{
+				Kind:           EntityTypeLinkedEntrance,
+				TargetEntrance: target,
+			},
+		}, nil
+	}
+
 	switch t := leaf.GetType().(type) {
 	case *base.Leaf_TupleToUserSet:
 		tupleSet := t.TupleToUserSet.GetTupleSet().GetRelation()
@@ -237,6 +269,7 @@ func (g *LinkedSchemaGraph) findEntranceLeaf(target, source *base.RelationRefere
 				},
 				source,
 				visited,
+				narrow,
 			)
 			if err != nil {
 				return nil, err
@@ -262,6 +295,7 @@ func (g *LinkedSchemaGraph) findEntranceLeaf(target, source *base.RelationRefere
 			},
 			source,
 			visited,
+			narrow,
 		)
 		if err != nil {
 			return nil, err
@@ -283,33 +317,116 @@ func (g *LinkedSchemaGraph) findEntranceLeaf(target, source *base.RelationRefere
 // only returns entry points that can be reached from the target relation using the specified source relation. If the target or
 // source relation does not exist in the schema graph, the function returns an error.
 //
+// The entry points of a union are the entry points of all of its children, intersections are handled by
+// findEntranceIntersection.
+//
 // Parameters:
 //   - target: pointer to a base.RelationReference that identifies the target relation
 //   - source: pointer to a base.RelationReference that identifies the source relation used to reach the target relation
 //   - rewrite: pointer to a base.Rewrite object that represents the child of an action reference
 //   - visited: map used to track visited nodes and avoid infinite recursion
+//   - narrow: whether the source is the subject itself, which lets intersections be narrowed to one child
 //
 // Returns:
 //   - slice of LinkedEntrance objects that represent entry points into the LinkedSchemaGraph, or an error if the target or
 //     source relation does not exist in the schema graph
-func (g *LinkedSchemaGraph) findEntranceRewrite(target *base.RelationReference, source *base.RelationReference, rewrite *base.Rewrite, visited map[string]struct{}) (results []*LinkedEntrance, err error) {
+func (g *LinkedSchemaGraph) findEntranceRewrite(target *base.RelationReference, source *base.RelationReference, rewrite *base.Rewrite, visited map[string]struct{}, narrow bool) (results []*LinkedEntrance, err error) {
+	if rewrite.GetRewriteOperation() == base.Rewrite_OPERATION_INTERSECTION {
+		return g.findEntranceIntersection(target, source, rewrite, visited, narrow)
+	}
+
 	var res []*LinkedEntrance
 	for _, child := range rewrite.GetChildren() {
-		switch child.GetType().(type) {
-		case *base.Child_Rewrite:
-			results, err = g.findEntranceRewrite(target, source, child.GetRewrite(), visited)
-			if err != nil {
-				return nil, err
-			}
-		case *base.Child_Leaf:
-			results, err = g.findEntranceLeaf(target, source, child.GetLeaf(), visited)
+		results, err = g.findEntranceChild(target, source, child, visited, narrow)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, results...)
+	}
+	return res, nil
+}
+
+// findEntranceIntersection returns the entry points of an intersection. Excluded children never lead from the source to
+// a candidate and are skipped; if every child is excluded, every entity of the target type is a candidate. When narrow is
+// set, the source has to satisfy every child itself, so only the entry points of the most selective child are returned.
+// Each child is then searched with its own copy of the visited nodes, and only the nodes visited by the chosen child are
+// kept. Otherwise the source may take part in any of the children, and the entry points of all of them are returned.
+func (g *LinkedSchemaGraph) findEntranceIntersection(target *base.RelationReference, source *base.RelationReference, rewrite *base.Rewrite, visited map[string]struct{}, narrow bool) ([]*LinkedEntrance, error) {
+	var res []*LinkedEntrance
+	var resVisited map[string]struct{}
+	for _, child := range rewrite.GetChildren() {
+		if child.GetLeaf().GetExclusion() {
+			continue
+		}
+
+		if !narrow {
+			results, err := g.findEntranceChild(target, source, child, visited, narrow)
 			if err != nil {
 				return nil, err
 			}
-		default:
-			return nil, errors.New("undefined child type")
+			res = append(res, results...)
+			resVisited = visited
+			continue
+		}
+
+		v := make(map[string]struct{}, len(visited))
+		for key := range visited {
+			v[key] = struct{}{}
+		}
+
+		results, err := g.findEntranceChild(target, source, child, v, narrow)
+		if err != nil {
+			return nil, err
+		}
+
+		if resVisited == nil || moreSelective(results, res) {
+			res, resVisited = results, v
 		}
-		res = append(res, results...)
+	}
+
+	if resVisited == nil {
+		return []*LinkedEntrance{
+			{
+				Kind:           EntityTypeLinkedEntrance,
+				TargetEntrance: target,
+			},
+		}, nil
+	}
+
+	for key := range resVisited {
+		visited[key] = struct{}{}
 	}
 	return res, nil
 }
+
+// findEntranceChild searches the entry points of a child of a rewrite.
+func (g *LinkedSchemaGraph) findEntranceChild(target *base.RelationReference, source *base.RelationReference, child *base.Child, visited map[string]struct{}, narrow bool) ([]*LinkedEntrance, error) {
+	switch child.GetType().(type) {
+	case *base.Child_Rewrite:
+		return g.findEntranceRewrite(target, source, child.GetRewrite(), visited, narrow)
+	case *base.Child_Leaf:
+		return g.findEntranceLeaf(target, source, child.GetLeaf(), visited, narrow)
+	default:
+		return nil, errors.New("undefined child type")
+	}
+}
+
+// moreSelective reports whether the entry points a lead to fewer candidates than the entry points b. Entity type entry
+// points read every entity of a type, so they are weighed first.
+func moreSelective(a, b []*LinkedEntrance) bool {
+	ae, be := countEntityTypeEntrances(a), countEntityTypeEntrances(b)
+	if ae != be {
+		return ae < be
+	}
+	return len(a) < len(b)
+}
+
+// countEntityTypeEntrances returns the number of entity type entry points.
+func countEntityTypeEntrances(entrances []*LinkedEntrance) (count int) {
+	for _, entrance := range entrances {
+		if entrance.Kind == EntityTypeLinkedEntrance {
+			count++
+		}
+	}
+	return count
+}
@@ -147,14 +147,6 @@ var _ = Describe("connected schema", func() {
 					},
 					TupleSetRelation: "",
 				},
-				{
-					Kind: RelationLinkedEntrance,
-					TargetEntrance: &base.RelationReference{
-						Type:     "document",
-						Relation: "owner",
-					},
-					TupleSetRelation: "",
-				},
 			}))
 		})
 
@@ -910,5 +902,166 @@ var _ = Describe("connected schema", func() {
 				},
 			}))
 		})
+
+		It("Case 18", func() {
+			sch, err := parser.NewParser(`
+			entity user {}
+			entity document {
+				relation viewer @user
+				relation banned @user
+				action view = viewer or not banned
+			}
+			`).Parse()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			c := compiler.NewCompiler(false, sch)
+			a, err := c.Compile()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			g := NewLinkedGraph(NewSchemaFromEntityDefinitions(a...))
+
+			ent, err := g.RelationshipLinkedEntrances(&base.RelationReference{
+				Type:     "document",
+				Relation: "view",
+			}, &base.RelationReference{
+				Type:     "user",
+				Relation: "",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ent).Should(Equal([]*LinkedEntrance{
+				{
+					Kind: RelationLinkedEntrance,
+					TargetEntrance: &base.RelationReference{
+						Type:     "document",
+						Relation: "viewer",
+					},
+					TupleSetRelation: "",
+				},
+				{
+					Kind: EntityTypeLinkedEntrance,
+					TargetEntrance: &base.RelationReference{
+						Type:     "document",
+						Relation: "view",
+					},
+					TupleSetRelation: "",
+				},
+			}))
+		})
+
+		It("Case 19", func() {
+			sch, err := parser.NewParser(`
+			entity user {}
+			entity organization {
+				relation admin @user
+				relation member @user
+			}
+			entity document {
+				relation org @organization
+				relation owner @user
+				relation banned @user
+				action view = org.member and owner and not banned
+			}
+			`).Parse()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			c := compiler.NewCompiler(false, sch)
+			a, err := c.Compile()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			g := NewLinkedGraph(NewSchemaFromEntityDefinitions(a...))
+
+			ent, err := g.SubjectLinkedEntrances(&base.RelationReference{
+				Type:     "document",
+				Relation: "view",
+			}, &base.RelationReference{
+				Type:     "user",
+				Relation: "",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ent).Should(Equal([]*LinkedEntrance{
+				{
+					Kind: RelationLinkedEntrance,
+					TargetEntrance: &base.RelationReference{
+						Type:     "organization",
+						Relation: "member",
+					},
+					TupleSetRelation: "",
+				},
+			}))
+
+			ent, err = g.SubjectLinkedEntrances(&base.RelationReference{
+				Type:     "document",
+				Relation: "view",
+			}, &base.RelationReference{
+				Type:     "organization",
+				Relation: "admin",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ent).Should(BeEmpty())
+
+			ent, err = g.RelationshipLinkedEntrances(&base.RelationReference{
+				Type:     "document",
+				Relation: "view",
+			}, &base.RelationReference{
+				Type:     "organization",
+				Relation: "member",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ent).Should(Equal([]*LinkedEntrance{
+				{
+					Kind: TupleToUserSetLinkedEntrance,
+					TargetEntrance: &base.RelationReference{
+						Type:     "document",
+						Relation: "view",
+					},
+					TupleSetRelation: "org",
+				},
+			}))
+		})
+
+		It("Case 20", func() {
+			sch, err := parser.NewParser(`
+			entity user {}
+			entity document {
+				relation banned @user
+				relation archived @user
+				action view = not banned and not archived
+			}
+			`).Parse()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			c := compiler.NewCompiler(false, sch)
+			a, err := c.Compile()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			g := NewLinkedGraph(NewSchemaFromEntityDefinitions(a...))
+
+			ent, err := g.SubjectLinkedEntrances(&base.RelationReference{
+				Type:     "document",
+				Relation: "view",
+			}, &base.RelationReference{
+				Type:     "user",
+				Relation: "",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ent).Should(Equal([]*LinkedEntrance{
+				{
+					Kind: EntityTypeLinkedEntrance,
+					TargetEntrance: &base.RelationReference{
+						Type:     "document",
+						Relation: "view",
+					},
+					TupleSetRelation: "",
+				},
+			}))
+		})
 	})
 })
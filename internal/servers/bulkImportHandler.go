@@ -0,0 +1,87 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcV1 "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// bulkImportPath is the HTTP route of the bulk import, its body is the stream of the requests as JSON objects.
+const bulkImportPath = "/v1/tenants/{tenant_id}/relationships/bulk-import"
+
+// RegisterBulkImportHandler - Registers the HTTP route of the bulk import on the gateway. The gateway cannot bind path
+// parameters to client streams, so the route is served by this handler instead of a generated one, which sets the
+// tenant of the path on every request of the stream.
+func RegisterBulkImportHandler(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	client := grpcV1.NewRelationshipClient(conn)
+	return mux.HandlePath(http.MethodPost, bulkImportPath, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		ctx, err := runtime.AnnotateContext(ctx, mux, req, "/base.v1.Relationship/BulkImport", runtime.WithHTTPPathPattern(bulkImportPath))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		resp, md, err := bulkImport(ctx, inboundMarshaler, client, req, pathParams["tenant_id"])
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
+}
+
+// bulkImport - Streams the requests of the body to the bulk import of the tenant and returns its summary.
+func bulkImport(ctx context.Context, marshaler runtime.Marshaler, client grpcV1.RelationshipClient, req *http.Request, tenantID string) (*grpcV1.RelationshipBulkImportResponse, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+
+	stream, err := client.BulkImport(ctx)
+	if err != nil {
+		return nil, metadata, err
+	}
+
+	dec := marshaler.NewDecoder(req.Body)
+	for {
+		var request grpcV1.RelationshipBulkImportRequest
+		err = dec.Decode(&request)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		request.TenantId = tenantID
+		if err = stream.Send(&request); err != nil {
+			// The server ended the stream, the error it ended it with is returned by CloseAndRecv
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, metadata, err
+		}
+	}
+
+	// The server only answers once the stream is closed, so its header is read after the response
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, metadata, err
+	}
+	metadata.HeaderMD, err = stream.Header()
+	if err != nil {
+		return nil, metadata, err
+	}
+	metadata.TrailerMD = stream.Trailer()
+	return resp, metadata, nil
+}
@@ -0,0 +1,35 @@
+package servers
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/Permify/permify/internal/engines/consistent"
+	"github.com/Permify/permify/pkg/logger"
+	v1 "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// ClusterServer - Structure for Cluster Server
+type ClusterServer struct {
+	v1.UnimplementedClusterServer
+
+	membership *consistent.Membership
+	logger     logger.Interface
+}
+
+// NewClusterServer - Creates new Cluster Server
+func NewClusterServer(m *consistent.Membership, l logger.Interface) *ClusterServer {
+	return &ClusterServer{
+		membership: m,
+		logger:     l,
+	}
+}
+
+// Members - Lists the members of the cluster and the share of the hash ring each of them owns
+func (r *ClusterServer) Members(ctx context.Context, _ *v1.ClusterMembersRequest) (*v1.ClusterMembersResponse, error) {
+	_, span := tracer.Start(ctx, "cluster.members")
+	defer span.End()
+
+	return &v1.ClusterMembersResponse{
+		Members: r.membership.Members(),
+	}, nil
+}
@@ -0,0 +1,66 @@
+package servers
+
+import (
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+
+	"github.com/Permify/permify/internal/invoke"
+	"github.com/Permify/permify/pkg/logger"
+	v1 "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// DispatchServer - Structure for Dispatch Server
+type DispatchServer struct {
+	v1.UnimplementedDispatchServer
+
+	checker invoke.Check
+	logger  logger.Interface
+}
+
+// NewDispatchServer - Creates new Dispatch Server, that evaluates the dispatched sub-problems with the given checker
+func NewDispatchServer(c invoke.Check, l logger.Interface) *DispatchServer {
+	return &DispatchServer{
+		checker: c,
+		logger:  l,
+	}
+}
+
+// DispatchCheck - Evaluates a sub-problem of a permission check that is owned by this node
+func (r *DispatchServer) DispatchCheck(ctx context.Context, request *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
+	ctx, span := tracer.Start(ctx, "dispatch.check")
+	defer span.End()
+
+	v := request.Validate()
+	if v != nil {
+		return nil, v
+	}
+
+	// Continue the evaluation path of the node that dispatched the sub-problem
+	path := invoke.NewPath(request.GetMetadata().GetPath()...)
+
+	response, err := r.checker.Check(invoke.ContextWithPath(ctx, path), &v1.PermissionCheckRequest{
+		TenantId: request.GetTenantId(),
+		Metadata: &v1.PermissionCheckRequestMetadata{
+			SchemaVersion: request.GetMetadata().GetSchemaVersion(),
+			SnapToken:     request.GetMetadata().GetSnapToken(),
+			Exclusion:     request.GetMetadata().GetExclusion(),
+			Depth:         request.GetMetadata().GetRemainingDepth(),
+		},
+		Entity:     request.GetEntity(),
+		Permission: request.GetPermission(),
+		Subject:    request.GetSubject(),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return nil, status.Error(GetStatus(err), err.Error())
+	}
+
+	return &v1.DispatchCheckResponse{
+		Can:          response.GetCan(),
+		Metadata:     response.GetMetadata(),
+		CyclicLength: int32(path.CyclicLength()),
+	}, nil
+}
@@ -13,6 +13,8 @@ func GetStatus(err error) codes.Code {
 		return codes.Internal
 	}
 	switch {
+	case code == int32(base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED):
+		return codes.FailedPrecondition
 	case code > 999 && code < 1999:
 		return codes.Unauthenticated
 	case code > 1999 && code < 2999:
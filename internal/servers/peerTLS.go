@@ -0,0 +1,67 @@
+package servers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/Permify/permify/internal/config"
+)
+
+// PeerServerCredentials - Returns the credentials of the dispatch server, which only accepts the nodes that present a
+// certificate signed by the authority of the cluster. The nodes of the cluster are not clients of the API, so they
+// are authenticated by their certificates rather than by the authentication method of the API.
+func PeerServerCredentials(peer config.PeerTLS) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadPeerTLS(peer)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// PeerClientCredentials - Returns the credentials the nodes dial the dispatch servers of the other nodes with, they
+// present the certificate of the node and only trust the servers whose certificates are signed by the authority of
+// the cluster.
+func PeerClientCredentials(peer config.PeerTLS) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadPeerTLS(peer)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// loadPeerTLS - Loads the certificate of the node and the certificate pool of the authority of the cluster
+func loadPeerTLS(peer config.PeerTLS) (tls.Certificate, *x509.CertPool, error) {
+	if peer.CertPath == "" || peer.KeyPath == "" || peer.CAPath == "" {
+		return tls.Certificate{}, nil, errors.New("the dispatch server requires the certificate, the key and the certificate authority of the mutual tls between the nodes")
+	}
+
+	cert, err := tls.LoadX509KeyPair(peer.CertPath, peer.KeyPath)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	var ca []byte
+	ca, err = os.ReadFile(peer.CAPath)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, errors.New("failed to parse the certificate authority of the mutual tls between the nodes")
+	}
+
+	return cert, pool, nil
+}
@@ -68,6 +68,27 @@ func (r *PermissionServer) Expand(ctx context.Context, request *v1.PermissionExp
 	return response, nil
 }
 
+// ExpandStream -
+func (r *PermissionServer) ExpandStream(request *v1.PermissionExpandRequest, server v1.Permission_ExpandStreamServer) error {
+	ctx, span := tracer.Start(server.Context(), "permissions.expand-stream")
+	defer span.End()
+
+	v := request.Validate()
+	if v != nil {
+		return v
+	}
+
+	err := r.invoker.ExpandStream(ctx, request, server)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return status.Error(GetStatus(err), err.Error())
+	}
+
+	return nil
+}
+
 // LookupEntity -
 func (r *PermissionServer) LookupEntity(ctx context.Context, request *v1.PermissionLookupEntityRequest) (*v1.PermissionLookupEntityResponse, error) {
 	ctx, span := tracer.Start(ctx, "permissions.lookup-entity")
@@ -109,3 +130,24 @@ func (r *PermissionServer) LookupEntityStream(request *v1.PermissionLookupEntity
 
 	return nil
 }
+
+// Matrix -
+func (r *PermissionServer) Matrix(ctx context.Context, request *v1.PermissionMatrixRequest) (*v1.PermissionMatrixResponse, error) {
+	ctx, span := tracer.Start(ctx, "permissions.matrix")
+	defer span.End()
+
+	v := request.Validate()
+	if v != nil {
+		return nil, v
+	}
+
+	response, err := r.invoker.Matrix(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return nil, status.Error(GetStatus(err), err.Error())
+	}
+
+	return response, nil
+}
@@ -1,6 +1,11 @@
 package servers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	otelCodes "go.opentelemetry.io/otel/codes"
@@ -11,9 +16,15 @@ import (
 	"github.com/Permify/permify/pkg/database"
 	"github.com/Permify/permify/pkg/logger"
 	v1 "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
 	"github.com/Permify/permify/pkg/tuple"
 )
 
+const (
+	// _bulkImportProgressInterval is the number of relation tuples received between the progress logs of a bulk import
+	_bulkImportProgressInterval = 10000
+)
+
 // RelationshipServer - Structure for Relationship Server
 type RelationshipServer struct {
 	v1.UnimplementedRelationshipServer
@@ -108,28 +119,42 @@ func (r *RelationshipServer) Write(ctx context.Context, request *v1.Relationship
 
 		subject := tuple.SetSubjectRelationToEllipsisIfNonUserAndNoRelation(tup.GetSubject())
 
-		definition, _, err := r.sr.ReadSchemaDefinition(ctx, request.GetTenantId(), tup.GetEntity().GetType(), version)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(otelCodes.Error, err.Error())
-			return nil, err
-		}
+		// Relation tuples that are deleted do not have to be valid in the schema, they may have been written with an
+		// older version of it
+		if request.GetMode() != v1.WriteMode_WRITE_MODE_ENSURE_DELETED {
+			definition, _, err := r.sr.ReadSchemaDefinition(ctx, request.GetTenantId(), tup.GetEntity().GetType(), version)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelCodes.Error, err.Error())
+				return nil, err
+			}
 
-		err = validation.ValidateTuple(definition, tup)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(otelCodes.Error, err.Error())
-			return nil, err
+			err = validation.ValidateTuple(definition, tup)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelCodes.Error, err.Error())
+				return nil, err
+			}
 		}
 
 		relationships = append(relationships, &v1.Tuple{
-			Entity:   tup.GetEntity(),
-			Relation: tup.GetRelation(),
-			Subject:  subject,
+			Entity:    tup.GetEntity(),
+			Relation:  tup.GetRelation(),
+			Subject:   subject,
+			ExpiresAt: tup.GetExpiresAt(),
 		})
 	}
 
-	snap, err := r.rw.WriteRelationships(ctx, request.GetTenantId(), database.NewTupleCollection(relationships...))
+	var snap token.EncodedSnapToken
+	var err error
+	switch request.GetMode() {
+	case v1.WriteMode_WRITE_MODE_TOUCH:
+		snap, err = r.rw.TransactRelationships(ctx, request.GetTenantId(), nil, writeOperations(v1.TupleOperation_OPERATION_TOUCH, relationships, request.GetClearExpiration()))
+	case v1.WriteMode_WRITE_MODE_ENSURE_DELETED:
+		snap, err = r.rw.TransactRelationships(ctx, request.GetTenantId(), nil, writeOperations(v1.TupleOperation_OPERATION_DELETE, relationships, false))
+	default:
+		snap, err = r.rw.WriteRelationships(ctx, request.GetTenantId(), database.NewTupleCollection(relationships...))
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(otelCodes.Error, err.Error())
@@ -142,6 +167,27 @@ func (r *RelationshipServer) Write(ctx context.Context, request *v1.Relationship
 	}, nil
 }
 
+// writeOperations - Returns the operations of a write of the given mode. Touching or deleting a relation tuple is
+// idempotent, so the relation tuples that are written more than once are applied once.
+func writeOperations(operation v1.TupleOperation_Operation, tuples []*v1.Tuple, clearExpiration bool) []*v1.TupleOperation {
+	operations := make([]*v1.TupleOperation, 0, len(tuples))
+	seen := make(map[string]struct{}, len(tuples))
+	for _, tup := range tuples {
+		key := tupleKey(tup)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		operations = append(operations, &v1.TupleOperation{Operation: operation, Tuple: tup, ClearExpiration: clearExpiration})
+	}
+	return operations
+}
+
+// tupleKey - Returns the string that identifies the relation tuple
+func tupleKey(tup *v1.Tuple) string {
+	return tuple.EntityAndRelationToString(&v1.EntityAndRelation{Entity: tup.GetEntity(), Relation: tup.GetRelation()}) + "@" + tuple.SubjectToString(tup.GetSubject())
+}
+
 // Delete - Delete relation tuples to writeDB
 func (r *RelationshipServer) Delete(ctx context.Context, request *v1.RelationshipDeleteRequest) (*v1.RelationshipDeleteResponse, error) {
 	ctx, span := tracer.Start(ctx, "relationships.delete")
@@ -164,3 +210,252 @@ func (r *RelationshipServer) Delete(ctx context.Context, request *v1.Relationshi
 		SnapToken: snap.String(),
 	}, nil
 }
+
+// Transact - Apply write and delete operations to relation tuples atomically, if all the preconditions hold
+func (r *RelationshipServer) Transact(ctx context.Context, request *v1.RelationshipTransactRequest) (*v1.RelationshipTransactResponse, error) {
+	ctx, span := tracer.Start(ctx, "relationships.transact")
+	defer span.End()
+
+	v := request.Validate()
+	if v != nil {
+		return nil, v
+	}
+
+	version := request.GetMetadata().GetSchemaVersion()
+	if version == "" {
+		v, err := r.sr.HeadVersion(ctx, request.GetTenantId())
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, err
+		}
+		version = v
+	}
+
+	operations := make([]*v1.TupleOperation, 0, len(request.GetOperations()))
+
+	// A relation tuple can be changed by one operation at most, so the result does not depend on the order of the
+	// operations
+	changed := make(map[string]struct{}, len(request.GetOperations()))
+
+	for _, operation := range request.GetOperations() {
+		tup := operation.GetTuple()
+
+		subject := tuple.SetSubjectRelationToEllipsisIfNonUserAndNoRelation(tup.GetSubject())
+
+		// Relation tuples that are deleted do not have to be valid in the schema, they may have been written with an
+		// older version of it
+		if operation.GetOperation() != v1.TupleOperation_OPERATION_DELETE {
+			definition, _, err := r.sr.ReadSchemaDefinition(ctx, request.GetTenantId(), tup.GetEntity().GetType(), version)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelCodes.Error, err.Error())
+				return nil, err
+			}
+
+			err = validation.ValidateTuple(definition, tup)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelCodes.Error, err.Error())
+				return nil, err
+			}
+		}
+
+		key := tupleKey(&v1.Tuple{Entity: tup.GetEntity(), Relation: tup.GetRelation(), Subject: subject})
+		if _, ok := changed[key]; ok {
+			err := errors.New(v1.ErrorCode_ERROR_CODE_VALIDATION.String())
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, status.Error(GetStatus(err), err.Error())
+		}
+		changed[key] = struct{}{}
+
+		operations = append(operations, &v1.TupleOperation{
+			Operation: operation.GetOperation(),
+			Tuple: &v1.Tuple{
+				Entity:    tup.GetEntity(),
+				Relation:  tup.GetRelation(),
+				Subject:   subject,
+				ExpiresAt: tup.GetExpiresAt(),
+			},
+			ClearExpiration: operation.GetClearExpiration(),
+		})
+	}
+
+	snap, err := r.rw.TransactRelationships(ctx, request.GetTenantId(), request.GetPreconditions(), operations)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return nil, status.Error(GetStatus(err), err.Error())
+	}
+
+	return &v1.RelationshipTransactResponse{
+		SnapToken: snap.String(),
+	}, nil
+}
+
+// BulkImport - Import the relation tuples streamed by the client in a single write. Every relation tuple is validated
+// against the schema, the ones that are not valid are rejected instead of failing the import. A summary with the snap
+// token and the rejections is sent back once the relation tuples are written.
+func (r *RelationshipServer) BulkImport(server v1.Relationship_BulkImportServer) error {
+	ctx, span := tracer.Start(server.Context(), "relationships.bulk-import")
+	defer span.End()
+
+	request, err := server.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, v1.ErrorCode_ERROR_CODE_MISSING_TENANT_ID.String())
+		}
+		return err
+	}
+
+	v := request.Validate()
+	if v != nil {
+		return v
+	}
+
+	tenantID := request.GetTenantId()
+
+	version := request.GetMetadata().GetSchemaVersion()
+	if version == "" {
+		version, err = r.sr.HeadVersion(ctx, tenantID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return err
+		}
+	}
+
+	importer := &bulkImporter{
+		server:      r,
+		tenantID:    tenantID,
+		version:     version,
+		definitions: map[string]*v1.EntityDefinition{},
+	}
+
+	snap, imported, err := r.rw.BulkImport(ctx, tenantID, func() (*database.TupleCollection, error) {
+		if request == nil {
+			request, importer.err = server.Recv()
+			if importer.err != nil {
+				return nil, importer.err
+			}
+			if importer.err = request.Validate(); importer.err != nil {
+				return nil, importer.err
+			}
+			if request.GetTenantId() != tenantID {
+				importer.err = status.Error(codes.InvalidArgument, v1.ErrorCode_ERROR_CODE_VALIDATION.String())
+				return nil, importer.err
+			}
+		}
+
+		collection, err := importer.batch(ctx, request.GetTuples())
+		if err != nil {
+			importer.err = err
+			return nil, err
+		}
+		request = nil
+		return collection, nil
+	})
+	if importer.err != nil && !errors.Is(importer.err, io.EOF) {
+		span.RecordError(importer.err)
+		span.SetStatus(otelCodes.Error, importer.err.Error())
+		return importer.err
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return status.Error(GetStatus(err), err.Error())
+	}
+
+	r.logger.Info(fmt.Sprintf("bulk import of tenant %s finished: %d relationships imported, %d rejected", tenantID, imported, len(importer.rejections)))
+
+	return server.SendAndClose(&v1.RelationshipBulkImportResponse{
+		SnapToken:     snap.String(),
+		ImportedCount: uint64(imported),
+		RejectedCount: uint64(len(importer.rejections)),
+		Rejections:    importer.rejections,
+		ReceivedCount: importer.received,
+	})
+}
+
+// bulkImporter validates the relation tuples of a bulk import and keeps the ones it rejects.
+type bulkImporter struct {
+	server   *RelationshipServer
+	tenantID string
+	version  string
+	// definitions are the entity definitions read so far, by entity type
+	definitions map[string]*v1.EntityDefinition
+	// received is the number of relation tuples received so far
+	received uint64
+	// rejections are the relation tuples rejected so far
+	rejections []*v1.RelationshipBulkImportRejection
+	// err is the error the stream failed with, if any
+	err error
+}
+
+// batch returns the valid relation tuples of a request, and keeps the rejected ones for the summary.
+func (b *bulkImporter) batch(ctx context.Context, tuples []*v1.Tuple) (*database.TupleCollection, error) {
+	collection := database.NewTupleCollection()
+
+	for _, tup := range tuples {
+		index := b.received
+		b.received++
+
+		reason, err := b.validate(ctx, tup)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			b.rejections = append(b.rejections, &v1.RelationshipBulkImportRejection{
+				Index:  index,
+				Tuple:  tup,
+				Reason: reason,
+			})
+			continue
+		}
+
+		collection.Add(&v1.Tuple{
+			Entity:    tup.GetEntity(),
+			Relation:  tup.GetRelation(),
+			Subject:   tuple.SetSubjectRelationToEllipsisIfNonUserAndNoRelation(tup.GetSubject()),
+			ExpiresAt: tup.GetExpiresAt(),
+		})
+	}
+
+	if b.received/_bulkImportProgressInterval != (b.received-uint64(len(tuples)))/_bulkImportProgressInterval {
+		b.server.logger.Info(fmt.Sprintf("bulk import of tenant %s: %d relationships received, %d rejected", b.tenantID, b.received, len(b.rejections)))
+	}
+
+	return collection, nil
+}
+
+// validate returns the reason the relation tuple is rejected for, or an empty string if it is valid.
+func (b *bulkImporter) validate(ctx context.Context, tup *v1.Tuple) (string, error) {
+	if err := tup.Validate(); err != nil {
+		return err.Error(), nil
+	}
+
+	definition, ok := b.definitions[tup.GetEntity().GetType()]
+	if !ok {
+		var err error
+		definition, _, err = b.server.sr.ReadSchemaDefinition(ctx, b.tenantID, tup.GetEntity().GetType(), b.version)
+		if err != nil {
+			if err.Error() != v1.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String() {
+				return "", err
+			}
+			// Entity types that are not defined are rejected without reading the schema again
+			definition = nil
+		}
+		b.definitions[tup.GetEntity().GetType()] = definition
+	}
+	if definition == nil {
+		return v1.ErrorCode_ERROR_CODE_ENTITY_DEFINITION_NOT_FOUND.String(), nil
+	}
+
+	if err := validation.ValidateTuple(definition, tup); err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
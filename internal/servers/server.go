@@ -28,6 +28,7 @@ import (
 	"github.com/Permify/permify/internal/authn/oidc"
 	"github.com/Permify/permify/internal/authn/preshared"
 	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/engines/consistent"
 	"github.com/Permify/permify/internal/invoke"
 	"github.com/Permify/permify/internal/middleware"
 	"github.com/Permify/permify/internal/storage"
@@ -55,11 +56,17 @@ type Container struct {
 	TR storage.TenantReader
 	// TenantWriter for writing tenant information to storage
 	TW storage.TenantWriter
+	// Watcher for streaming the changes to relationships from storage, if supported
+	W storage.Watcher
+	// Dispatcher for evaluating the sub-problems that other nodes dispatch to this node, if distributed
+	Dispatcher invoke.Check
+	// Membership of the cluster this node is a member of, if distributed
+	Membership *consistent.Membership
 }
 
 // NewContainer is a constructor for the Container struct.
 // It takes an Invoker, RelationshipReader, RelationshipWriter, SchemaReader, SchemaWriter,
-// TenantReader, and TenantWriter as arguments, and returns a pointer to a Container instance.
+// TenantReader, TenantWriter, and an optional Watcher, Dispatcher and Membership as arguments, and returns a pointer to a Container instance.
 func NewContainer(
 	invoker invoke.Invoker,
 	rr storage.RelationshipReader,
@@ -68,26 +75,34 @@ func NewContainer(
 	sw storage.SchemaWriter,
 	tr storage.TenantReader,
 	tw storage.TenantWriter,
+	w storage.Watcher,
+	dispatcher invoke.Check,
+	membership *consistent.Membership,
 ) *Container {
 	return &Container{
-		Invoker: invoker,
-		RR:      rr,
-		RW:      rw,
-		SR:      sr,
-		SW:      sw,
-		TR:      tr,
-		TW:      tw,
+		Invoker:    invoker,
+		RR:         rr,
+		RW:         rw,
+		SR:         sr,
+		SW:         sw,
+		TR:         tr,
+		TW:         tw,
+		W:          w,
+		Dispatcher: dispatcher,
+		Membership: membership,
 	}
 }
 
 // Run is a method that starts the Container and its services, including the gRPC server,
 // an optional HTTP server, and an optional profiler server. It also sets up authentication,
-// TLS configurations, and interceptors as needed.
+// TLS configurations, and interceptors as needed. If the node dispatches checks to the other
+// nodes of a distributed deployment, the dispatch server is started on an internal listener.
 func (s *Container) Run(
 	ctx context.Context,
 	cfg *config.Server,
 	authentication *config.Authn,
 	profiler *config.Profiler,
+	distributed *config.Distributed,
 	l *logger.Logger,
 ) error {
 	var err error
@@ -148,6 +163,12 @@ func (s *Container) Run(
 	grpcV1.RegisterSchemaServer(grpcServer, NewSchemaServer(s.SW, s.SR, l))
 	grpcV1.RegisterRelationshipServer(grpcServer, NewRelationshipServer(s.RR, s.RW, s.SR, l))
 	grpcV1.RegisterTenancyServer(grpcServer, NewTenancyServer(s.TR, s.TW, l))
+	if s.W != nil {
+		grpcV1.RegisterWatchServer(grpcServer, NewWatchServer(s.W, s.RR, l))
+	}
+	if s.Membership != nil {
+		grpcV1.RegisterClusterServer(grpcServer, NewClusterServer(s.Membership, l))
+	}
 	health.RegisterHealthServer(grpcServer, NewHealthServer())
 	reflection.Register(grpcServer)
 
@@ -186,6 +207,42 @@ func (s *Container) Run(
 
 	l.Info(fmt.Sprintf("🚀 grpc server successfully started: %s", cfg.GRPC.Port))
 
+	// Start the dispatch server on its own listener. The nodes of the cluster are not clients of the API, so they
+	// authenticate each other with mutual TLS instead, and the dispatch server does not start without it.
+	var dispatchServer *grpc.Server
+	if s.Dispatcher != nil {
+		var c credentials.TransportCredentials
+		c, err = PeerServerCredentials(distributed.TLS)
+		if err != nil {
+			return err
+		}
+		dispatchOpts := []grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(
+				grpcValidator.UnaryServerInterceptor(),
+				grpcRecovery.UnaryServerInterceptor(),
+			),
+			grpc.Creds(c),
+		}
+
+		dispatchServer = grpc.NewServer(dispatchOpts...)
+		grpcV1.RegisterDispatchServer(dispatchServer, NewDispatchServer(s.Dispatcher, l))
+		health.RegisterHealthServer(dispatchServer, NewHealthServer())
+
+		var dispatchLis net.Listener
+		dispatchLis, err = net.Listen("tcp", ":"+distributed.Port)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := dispatchServer.Serve(dispatchLis); err != nil {
+				l.Error("failed to start dispatch server", err)
+			}
+		}()
+
+		l.Info(fmt.Sprintf("🚀 dispatch server successfully started: %s", distributed.Port))
+	}
+
 	var httpServer *http.Server
 
 	// Start the optional HTTP server with CORS and optional TLS configurations.
@@ -245,9 +302,18 @@ func (s *Container) Run(
 		if err = grpcV1.RegisterRelationshipHandler(ctx, mux, conn); err != nil {
 			return err
 		}
+		if err = RegisterBulkImportHandler(mux, conn); err != nil {
+			return err
+		}
 		if err = grpcV1.RegisterTenancyHandler(ctx, mux, conn); err != nil {
 			return err
 		}
+		if err = grpcV1.RegisterWatchHandler(ctx, mux, conn); err != nil {
+			return err
+		}
+		if err = grpcV1.RegisterClusterHandler(ctx, mux, conn); err != nil {
+			return err
+		}
 
 		httpServer = &http.Server{
 			Addr: ":" + cfg.HTTP.Port,
@@ -293,8 +359,11 @@ func (s *Container) Run(
 		}
 	}
 
-	// Gracefully stop the gRPC server.
+	// Gracefully stop the gRPC servers.
 	grpcServer.GracefulStop()
+	if dispatchServer != nil {
+		dispatchServer.GracefulStop()
+	}
 
 	l.Info("gracefully shutting down")
 
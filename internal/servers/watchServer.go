@@ -0,0 +1,72 @@
+package servers
+
+import (
+	"context"
+
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/logger"
+	v1 "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// WatchServer - Structure for Watch Server
+type WatchServer struct {
+	v1.UnimplementedWatchServer
+
+	w      storage.Watcher
+	rr     storage.RelationshipReader
+	logger logger.Interface
+}
+
+// NewWatchServer - Creates new Watch Server
+func NewWatchServer(w storage.Watcher, rr storage.RelationshipReader, l logger.Interface) *WatchServer {
+	return &WatchServer{
+		w:      w,
+		rr:     rr,
+		logger: l,
+	}
+}
+
+// Watch - Streams the changes to the relation tuples of a tenant committed after the given snap token
+func (r *WatchServer) Watch(request *v1.WatchRequest, server v1.Watch_WatchServer) error {
+	ctx, span := tracer.Start(server.Context(), "watch.watch")
+	defer span.End()
+
+	v := request.Validate()
+	if v != nil {
+		return v
+	}
+
+	snap := request.GetSnapToken()
+	if snap == "" {
+		st, err := r.rr.HeadSnapshot(ctx, request.GetTenantId())
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			r.logger.Error(err.Error())
+			return status.Error(GetStatus(err), err.Error())
+		}
+		snap = st.Encode().String()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	changes, errs := r.w.Watch(ctx, request.GetTenantId(), snap)
+	for c := range changes {
+		if err := server.Send(&v1.WatchResponse{Changes: c}); err != nil {
+			return err
+		}
+	}
+
+	if err, ok := <-errs; ok {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		r.logger.Error(err.Error())
+		return status.Error(GetStatus(err), err.Error())
+	}
+
+	return nil
+}
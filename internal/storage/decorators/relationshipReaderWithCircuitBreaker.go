@@ -3,6 +3,7 @@ package decorators
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/afex/hystrix-go/hystrix"
 
@@ -73,6 +74,31 @@ func (r *RelationshipReaderWithCircuitBreaker) ReadRelationships(ctx context.Con
 	}
 }
 
+// ReadEntityIDs - Reads the ids of the entities of the type from the repository, up to limit of them after the given id.
+func (r *RelationshipReaderWithCircuitBreaker) ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) ([]string, error) {
+	type circuitBreakerResponse struct {
+		IDs   []string
+		Error error
+	}
+
+	output := make(chan circuitBreakerResponse, 1)
+	hystrix.ConfigureCommand("relationshipReader.readEntityIDs", hystrix.CommandConfig{Timeout: 1000})
+	bErrors := hystrix.Go("relationshipReader.readEntityIDs", func() error {
+		ids, err := r.delegate.ReadEntityIDs(ctx, tenantID, entityType, snap, after, limit)
+		output <- circuitBreakerResponse{IDs: ids, Error: err}
+		return nil
+	}, func(err error) error {
+		return nil
+	})
+
+	select {
+	case out := <-output:
+		return out.IDs, out.Error
+	case <-bErrors:
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
+	}
+}
+
 // HeadSnapshot - Reads the latest version of the snapshot from the repository.
 func (r *RelationshipReaderWithCircuitBreaker) HeadSnapshot(ctx context.Context, tenantID string) (token.SnapToken, error) {
 	type circuitBreakerResponse struct {
@@ -97,3 +123,28 @@ func (r *RelationshipReaderWithCircuitBreaker) HeadSnapshot(ctx context.Context,
 		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
 	}
 }
+
+// SnapshotAt - Reads the latest version of the snapshot committed at or before the given time from the repository.
+func (r *RelationshipReaderWithCircuitBreaker) SnapshotAt(ctx context.Context, tenantID string, timestamp time.Time) (token.SnapToken, error) {
+	type circuitBreakerResponse struct {
+		Token token.SnapToken
+		Error error
+	}
+
+	output := make(chan circuitBreakerResponse, 1)
+	hystrix.ConfigureCommand("relationshipReader.snapshotAt", hystrix.CommandConfig{Timeout: 1000})
+	bErrors := hystrix.Go("relationshipReader.snapshotAt", func() error {
+		tok, err := r.delegate.SnapshotAt(ctx, tenantID, timestamp)
+		output <- circuitBreakerResponse{Token: tok, Error: err}
+		return nil
+	}, func(err error) error {
+		return nil
+	})
+
+	select {
+	case out := <-output:
+		return out.Token, out.Error
+	case <-bErrors:
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
+	}
+}
@@ -73,3 +73,35 @@ func (r *RelationshipWriterWithCircuitBreaker) DeleteRelationships(ctx context.C
 		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
 	}
 }
+
+// TransactRelationships - Apply operations to the relation tuples of the repository atomically
+func (r *RelationshipWriterWithCircuitBreaker) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	type circuitBreakerResponse struct {
+		Token token.EncodedSnapToken
+		Error error
+	}
+
+	output := make(chan circuitBreakerResponse, 1)
+
+	hystrix.ConfigureCommand("relationshipWriter.transactRelationships", hystrix.CommandConfig{Timeout: 1000})
+	bErrors := hystrix.Go("relationshipWriter.transactRelationships", func() error {
+		t, err := r.delegate.TransactRelationships(ctx, tenantID, preconditions, operations)
+		output <- circuitBreakerResponse{Token: t, Error: err}
+		return nil
+	}, func(err error) error {
+		return nil
+	})
+
+	select {
+	case out := <-output:
+		return out.Token, out.Error
+	case <-bErrors:
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
+	}
+}
+
+// BulkImport - Import relation tuples to the repository. An import runs for as long as its batches are received, so
+// it is not limited by the timeout of the circuit breaker.
+func (r *RelationshipWriterWithCircuitBreaker) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	return r.delegate.BulkImport(ctx, tenantID, batches)
+}
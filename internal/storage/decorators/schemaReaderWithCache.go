@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/Permify/permify/internal/storage"
 	"github.com/Permify/permify/pkg/cache"
@@ -57,3 +58,13 @@ func (r *SchemaReaderWithCache) ReadSchemaDefinition(ctx context.Context, tenant
 func (r *SchemaReaderWithCache) HeadVersion(ctx context.Context, tenantID string) (version string, err error) {
 	return r.delegate.HeadVersion(ctx, tenantID)
 }
+
+// VersionAt - Finds the latest version of the schema written at or before the given time.
+func (r *SchemaReaderWithCache) VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (version string, err error) {
+	return r.delegate.VersionAt(ctx, tenantID, timestamp)
+}
+
+// ListSchemaDefinitions - Reads the definitions of all the versions of the schema.
+func (r *SchemaReaderWithCache) ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []storage.SchemaDefinition, err error) {
+	return r.delegate.ListSchemaDefinitions(ctx, tenantID)
+}
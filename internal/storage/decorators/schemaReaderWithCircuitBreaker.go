@@ -3,6 +3,7 @@ package decorators
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/afex/hystrix-go/hystrix"
 
@@ -98,3 +99,55 @@ func (r *SchemaReaderWithCircuitBreaker) HeadVersion(ctx context.Context, tenant
 		return "", errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
 	}
 }
+
+// VersionAt - Finds the latest version of the schema written at or before the given time.
+func (r *SchemaReaderWithCircuitBreaker) VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (version string, err error) {
+	type circuitBreakerResponse struct {
+		Version string
+		Error   error
+	}
+
+	output := make(chan circuitBreakerResponse, 1)
+
+	hystrix.ConfigureCommand("schemaReader.versionAt", hystrix.CommandConfig{Timeout: 1000})
+	bErrors := hystrix.Go("schemaReader.versionAt", func() error {
+		v, err := r.delegate.VersionAt(ctx, tenantID, timestamp)
+		output <- circuitBreakerResponse{Version: v, Error: err}
+		return nil
+	}, func(err error) error {
+		return nil
+	})
+
+	select {
+	case out := <-output:
+		return out.Version, out.Error
+	case <-bErrors:
+		return "", errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
+	}
+}
+
+// ListSchemaDefinitions - Reads the definitions of all the versions of the schema.
+func (r *SchemaReaderWithCircuitBreaker) ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []storage.SchemaDefinition, err error) {
+	type circuitBreakerResponse struct {
+		Definitions []storage.SchemaDefinition
+		Error       error
+	}
+
+	output := make(chan circuitBreakerResponse, 1)
+
+	hystrix.ConfigureCommand("schemaReader.listSchemaDefinitions", hystrix.CommandConfig{Timeout: 1000})
+	bErrors := hystrix.Go("schemaReader.listSchemaDefinitions", func() error {
+		defs, err := r.delegate.ListSchemaDefinitions(ctx, tenantID)
+		output <- circuitBreakerResponse{Definitions: defs, Error: err}
+		return nil
+	}, func(err error) error {
+		return nil
+	})
+
+	select {
+	case out := <-output:
+		return out.Definitions, out.Error
+	case <-bErrors:
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_CIRCUIT_BREAKER.String())
+	}
+}
@@ -1,7 +1,20 @@
 package memory
 
+import (
+	"time"
+)
+
 const (
 	RelationTuplesTable    = "relation_tuples"
 	SchemaDefinitionsTable = "schema_definitions"
 	TenantsTable           = "tenants"
+	ChangesTable           = "changes"
+	TransactionsTable      = "transactions"
+)
+
+const (
+	// _defaultGarbageCollectionInterval and _defaultGarbageCollectionWindow are used to collect the history of the
+	// memory database if garbage collection is not configured, so that it does not grow without bound
+	_defaultGarbageCollectionInterval = 3 * time.Minute
+	_defaultGarbageCollectionWindow   = time.Hour
 )
@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage"
+	db "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// GarbageCollector - Structure for GarbageCollector
+type GarbageCollector struct {
+	database *db.Memory
+	// logger
+	logger logger.Interface
+	// context to manage goroutines and cancellation
+	ctx    context.Context
+	cancel context.CancelFunc
+	// errgroup for managing multiple goroutines
+	g *errgroup.Group
+	// interval for garbage collection
+	interval time.Duration
+	// window for garbage collection
+	window time.Duration
+}
+
+// NewGarbageCollector creates a new GarbageCollector instance.
+// ctx: context for managing goroutines and cancellation
+// The history of the memory database is only kept in memory, so it is collected at the default interval and window
+// if they are not configured.
+func NewGarbageCollector(ctx context.Context, db *db.Memory, logger logger.Interface, cfg config.DatabaseGarbageCollection) *GarbageCollector {
+	if cfg.Interval <= 0 {
+		cfg.Interval = _defaultGarbageCollectionInterval
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = _defaultGarbageCollectionWindow
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &GarbageCollector{
+		g:        &errgroup.Group{},
+		interval: cfg.Interval,
+		window:   cfg.Window,
+		database: db,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start begins collecting the history of the tenants that is older than the window at every interval, until the
+// context is done or the GarbageCollector is stopped.
+func (c *GarbageCollector) Start() error {
+	c.g.Go(func() error {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.ctx.Done():
+				c.logger.Info("garbage collector stopped")
+				return nil
+			case <-ticker.C:
+				c.logger.Info("garbage collector started")
+
+				tenants, err := c.getTenants()
+				if err != nil {
+					c.logger.Error("garbage collector failed with error: " + err.Error())
+					continue
+				}
+
+				for _, tenantID := range tenants {
+					if err = c.executeCollector(tenantID); err != nil {
+						c.logger.Error("garbage collector failed for tenant: " + tenantID + " with error: " + err.Error())
+						continue
+					}
+					c.logger.Info("garbage collector finished for tenant: " + tenantID)
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// Stop stops the GarbageCollector by cancelling its context.
+func (c *GarbageCollector) Stop() {
+	c.cancel()
+}
+
+// Wait waits for all goroutines in the errgroup to finish.
+// Returns an error if any of the goroutines encounter an error.
+func (c *GarbageCollector) Wait() error {
+	if err := c.g.Wait(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getTenants returns the tenants that have transactions, whether or not they have been created.
+func (c *GarbageCollector) getTenants() ([]string, error) {
+	txn := c.database.DB.Txn(false)
+	defer txn.Abort()
+
+	it, err := txn.Get(TransactionsTable, "tenant")
+	if err != nil {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	tenants := make([]string, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		t, ok := obj.(Transaction)
+		if !ok {
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+		}
+		// The transactions are ordered by tenant
+		if len(tenants) == 0 || tenants[len(tenants)-1] != t.TenantID {
+			tenants = append(tenants, t.TenantID)
+		}
+	}
+	return tenants, nil
+}
+
+// executeCollector deletes the expired relation tuples of the tenant, and then removes its history up to the latest
+// transaction committed before the window: the deleted versions of the relation tuples, the transactions before it
+// and their changes. The snapshots before that transaction cannot be read or watched anymore.
+func (c *GarbageCollector) executeCollector(tenantID string) error {
+	if err := expireRelationships(c.database, tenantID); err != nil {
+		return err
+	}
+
+	txn := c.database.Txn(true)
+	defer txn.Abort()
+
+	horizon, err := transactionAt(txn, tenantID, time.Now().Add(-c.window))
+	if err != nil || horizon == 0 {
+		return err
+	}
+
+	tuples, err := txn.Get(RelationTuplesTable, "expired-index", tenantID, true)
+	if err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	var garbage []interface{}
+	for obj := tuples.Next(); obj != nil; obj = tuples.Next() {
+		if obj.(storage.RelationTuple).ExpiredTxID <= horizon {
+			garbage = append(garbage, obj)
+		}
+	}
+
+	transactions, err := txn.Get(TransactionsTable, "tenant", tenantID)
+	if err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	var collected []interface{}
+	for obj := transactions.Next(); obj != nil && obj.(Transaction).ID < horizon; obj = transactions.Next() {
+		collected = append(collected, obj)
+	}
+
+	changes, err := txn.Get(ChangesTable, "id_prefix", tenantID, "")
+	if err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	var watched []interface{}
+	for obj := changes.Next(); obj != nil && obj.(Change).Revision < revision(horizon); obj = changes.Next() {
+		watched = append(watched, obj)
+	}
+
+	for _, deletion := range []struct {
+		table   string
+		objects []interface{}
+	}{
+		{table: RelationTuplesTable, objects: garbage},
+		{table: TransactionsTable, objects: collected},
+		{table: ChangesTable, objects: watched},
+	} {
+		for _, obj := range deletion.objects {
+			if err = txn.Delete(deletion.table, obj); err != nil {
+				return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+			}
+		}
+	}
+
+	if err = c.database.Commit(txn); err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return nil
+}
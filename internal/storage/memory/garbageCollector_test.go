@@ -0,0 +1,94 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestGarbageCollector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+
+	collection := tuples(t, "organization:1#member@user:1", "organization:1#member@user:2", "organization:1#member@user:3")
+	collection.GetTuples()[2].ExpiresAt = timestamppb.New(time.Now().Add(100 * time.Millisecond))
+	written, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+	_, err = writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:  &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+
+	gc := memory.NewGarbageCollector(ctx, db, l, config.DatabaseGarbageCollection{
+		Interval: 50 * time.Millisecond,
+		Window:   200 * time.Millisecond,
+	})
+	require.NoError(t, gc.Start())
+
+	// The snapshots are kept for the window
+	it, err := reader.QueryRelationships(ctx, "t1", filter, written.String())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "user:3"}, subjects(it))
+
+	// The relation tuple deleted before the window is removed, along with the expired one
+	count := func() int {
+		txn := db.DB.Txn(false)
+		defer txn.Abort()
+		rows, err := txn.Get(memory.RelationTuplesTable, "id")
+		require.NoError(t, err)
+		n := 0
+		for obj := rows.Next(); obj != nil; obj = rows.Next() {
+			n++
+		}
+		return n
+	}
+	require.Eventually(t, func() bool {
+		return count() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// The snapshots before the window cannot be read or watched anymore
+	_, err = reader.QueryRelationships(ctx, "t1", filter, written.String())
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+	_, err = reader.SnapshotAt(ctx, "t1", time.Now().Add(-time.Hour))
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+
+	_, errs := memory.NewWatcher(db, l).Watch(ctx, "t1", written.String())
+	select {
+	case err = <-errs:
+		require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no error received")
+	}
+
+	it, err = reader.QueryRelationships(ctx, "t1", filter, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:2"}, subjects(it))
+
+	// Stopping the garbage collector ends it without canceling its context
+	gc.Stop()
+	require.NoError(t, gc.Wait())
+}
@@ -1,11 +1,24 @@
 package migrations
 
 import (
+	"encoding/gob"
+	"fmt"
+
 	"github.com/hashicorp/go-memdb"
 
+	"github.com/Permify/permify/internal/storage"
 	"github.com/Permify/permify/internal/storage/memory"
 )
 
+func init() {
+	// The objects of the tables are gob encoded in the write log and the snapshots of a persistent memory db
+	gob.Register(storage.SchemaDefinition{})
+	gob.Register(storage.RelationTuple{})
+	gob.Register(storage.Tenant{})
+	gob.Register(memory.Change{})
+	gob.Register(memory.Transaction{})
+}
+
 // Schema - Database schema for memory db
 var Schema = &memdb.DBSchema{
 	Tables: map[string]*memdb.TableSchema{
@@ -58,6 +71,9 @@ var Schema = &memdb.DBSchema{
 							&memdb.StringFieldIndex{Field: "Relation"},
 							&memdb.StringFieldIndex{Field: "SubjectType"},
 							&memdb.StringFieldIndex{Field: "SubjectID"},
+							// The deleted versions of a relation tuple are kept along with its live version, until they are
+							// garbage collected. It precedes the subject relation, which is missing if it is empty.
+							&memdb.UintFieldIndex{Field: "ExpiredTxID"},
 							&memdb.StringFieldIndex{Field: "SubjectRelation"},
 						},
 						AllowMissing: true,
@@ -108,6 +124,77 @@ var Schema = &memdb.DBSchema{
 						},
 					},
 				},
+				"expiring-index": {
+					Name:   "expiring-index",
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.ConditionalIndex{Conditional: func(obj interface{}) (bool, error) {
+								t, ok := obj.(storage.RelationTuple)
+								if !ok {
+									return false, fmt.Errorf("unexpected type %T", obj)
+								}
+								return !t.ExpiresAt.IsZero() && t.ExpiredTxID == 0, nil
+							}},
+						},
+					},
+				},
+				"expired-index": {
+					Name:   "expired-index",
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.ConditionalIndex{Conditional: func(obj interface{}) (bool, error) {
+								t, ok := obj.(storage.RelationTuple)
+								if !ok {
+									return false, fmt.Errorf("unexpected type %T", obj)
+								}
+								return t.ExpiredTxID != 0, nil
+							}},
+						},
+					},
+				},
+			},
+		},
+		memory.ChangesTable: {
+			Name: memory.ChangesTable,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:   "id",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "Revision"},
+						},
+					},
+				},
+			},
+		},
+		memory.TransactionsTable: {
+			Name: memory.TransactionsTable,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:   "id",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.UintFieldIndex{Field: "ID"},
+						},
+					},
+				},
+				"tenant": {
+					Name:   "tenant",
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+						},
+					},
+				},
 			},
 		},
 		memory.TenantsTable: {
@@ -0,0 +1,110 @@
+package memory_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestPersistence_RestoresAfterRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	l := logger.New("fatal")
+
+	open := func() *IMDatabase.Memory {
+		db, err := IMDatabase.New(migrations.Schema, IMDatabase.Persistence(dir), IMDatabase.SnapshotInterval(time.Hour))
+		require.NoError(t, err)
+		return db
+	}
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+	check := func(db *IMDatabase.Memory, version string, members ...string) {
+		tenants, _, err := memory.NewTenantReader(db, l).ListTenants(ctx, database.NewPagination())
+		require.NoError(t, err)
+		require.Len(t, tenants, 1)
+		assert.Equal(t, "example tenant", tenants[0].GetName())
+
+		head, err := memory.NewSchemaReader(db, l).HeadVersion(ctx, "t1")
+		require.NoError(t, err)
+		assert.Equal(t, version, head)
+
+		it, err := memory.NewRelationshipReader(db, l).QueryRelationships(ctx, "t1", filter, "")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, members, subjects(it))
+	}
+
+	db := open()
+	_, err := memory.NewTenantWriter(db, l).CreateTenant(ctx, "t1", "example tenant")
+	require.NoError(t, err)
+
+	version := xid.New().String()
+	require.NoError(t, memory.NewSchemaWriter(db, l).WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: version},
+	}))
+
+	writer := memory.NewRelationshipWriter(db, l)
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"))
+	require.NoError(t, err)
+	_, err = writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization"}, Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}}})
+	require.NoError(t, err)
+
+	// Without a snapshot, the memory db is restored from the write log
+	restarted := open()
+	check(restarted, version, "user:2")
+
+	// The changes are restored too, they can still be watched
+	wctx, wcancel := context.WithCancel(ctx)
+	changes, errs := memory.NewWatcher(restarted, l).Watch(wctx, "t1", written.String())
+	assert.Equal(t, []string{"OPERATION_DELETE user:1"}, operations(next(t, changes, errs)))
+
+	// The watcher is stopped before the memory db is closed
+	wcancel()
+	for range changes {
+	}
+
+	_, err = memory.NewRelationshipWriter(restarted, l).WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:3"))
+	require.NoError(t, err)
+	require.NoError(t, restarted.Close())
+
+	// Closing snapshots the memory db and removes the write logs before the snapshot
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Contains(t, names, "snapshot")
+	assert.Len(t, names, 2)
+
+	restarted = open()
+	check(restarted, version, "user:2", "user:3")
+	_, err = memory.NewRelationshipWriter(restarted, l).WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:4"))
+	require.NoError(t, err)
+
+	// A record torn by a crash at the end of the write log is discarded
+	logs, err := filepath.Glob(filepath.Join(dir, "log.*"))
+	require.NoError(t, err)
+	f, err := os.OpenFile(logs[len(logs)-1], os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 1, 0, 42})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	check(open(), version, "user:2", "user:3", "user:4")
+}
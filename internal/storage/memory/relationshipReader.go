@@ -34,11 +34,18 @@ func NewRelationshipReader(database *db.Memory, logger logger.Interface) *Relati
 	}
 }
 
-// QueryRelationships - Reads relation tuples from the repository.
-func (r *RelationshipReader) QueryRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, _ string) (it *database.TupleIterator, err error) {
+// QueryRelationships - Reads the relation tuples that are visible at the snapshot from the repository, the head
+// snapshot if none is given.
+func (r *RelationshipReader) QueryRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string) (it *database.TupleIterator, err error) {
 	txn := r.database.DB.Txn(false)
 	defer txn.Abort()
 
+	var rev uint64
+	rev, err = snapshotRevision(txn, tenantID, snap)
+	if err != nil {
+		return nil, err
+	}
+
 	collection := database.NewTupleCollection()
 
 	index, args := utils.GetIndexNameAndArgsByFilters(tenantID, filter)
@@ -49,23 +56,41 @@ func (r *RelationshipReader) QueryRelationships(ctx context.Context, tenantID st
 		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
 	}
 
-	fit := memdb.NewFilterIterator(result, utils.FilterQuery(filter))
+	// The relation tuples are read as they were until the next transaction of the tenant, so the reads at a snapshot
+	// that is not the head are repeatable
+	var at time.Time
+	at, err = expirationTime(txn, tenantID, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	fit := memdb.NewFilterIterator(result, utils.FilterQuery(tenantID, filter))
 	for obj := fit.Next(); obj != nil; obj = fit.Next() {
 		t, ok := obj.(storage.RelationTuple)
 		if !ok {
 			return nil, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
 		}
+		if !utils.IsVisible(t, rev) || t.IsExpired(at) {
+			continue
+		}
 		collection.Add(t.ToTuple())
 	}
 
 	return collection.CreateTupleIterator(), nil
 }
 
-// ReadRelationships - Gets all relationships for a given filter
-func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, _ string, pagination database.Pagination) (collection *database.TupleCollection, ct database.EncodedContinuousToken, err error) {
+// ReadRelationships - Gets all relationships for a given filter that are visible at the snapshot, the head snapshot
+// if none is given
+func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string, pagination database.Pagination) (collection *database.TupleCollection, ct database.EncodedContinuousToken, err error) {
 	txn := r.database.DB.Txn(false)
 	defer txn.Abort()
 
+	var rev uint64
+	rev, err = snapshotRevision(txn, tenantID, snap)
+	if err != nil {
+		return nil, utils.NewNoopContinuousToken().Encode(), err
+	}
+
 	var lowerBound uint64
 	if pagination.Token() != "" {
 		var t database.ContinuousToken
@@ -87,13 +112,22 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 		return nil, utils.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
 	}
 
+	var at time.Time
+	at, err = expirationTime(txn, tenantID, rev)
+	if err != nil {
+		return nil, utils.NewNoopContinuousToken().Encode(), err
+	}
+
 	tup := make([]storage.RelationTuple, 0, 10)
-	fit := memdb.NewFilterIterator(result, utils.FilterQuery(filter))
+	fit := memdb.NewFilterIterator(result, utils.FilterQuery(tenantID, filter))
 	for obj := fit.Next(); obj != nil; obj = fit.Next() {
 		t, ok := obj.(storage.RelationTuple)
 		if !ok {
 			return nil, utils.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
 		}
+		if !utils.IsVisible(t, rev) || t.IsExpired(at) {
+			continue
+		}
 		tup = append(tup, t)
 	}
 
@@ -115,7 +149,86 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 	return database.NewTupleCollection(tuples...), utils.NewNoopContinuousToken().Encode(), nil
 }
 
-// HeadSnapshot - Reads the latest version of the snapshot from the repository.
-func (r *RelationshipReader) HeadSnapshot(ctx context.Context, _ string) (token.SnapToken, error) {
-	return snapshot.NewToken(time.Now()), nil
+// ReadEntityIDs - Reads the distinct ids of the entities of the type that have relation tuples visible at the
+// snapshot, up to limit of them after the given id in the byte order of the ids
+func (r *RelationshipReader) ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) (ids []string, err error) {
+	txn := r.database.DB.Txn(false)
+	defer txn.Abort()
+
+	var rev uint64
+	rev, err = snapshotRevision(txn, tenantID, snap)
+	if err != nil {
+		return nil, err
+	}
+
+	var at time.Time
+	at, err = expirationTime(txn, tenantID, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entity index orders the relation tuples of an entity type by their entity ids, so the read starts at the
+	// given id and stops at the next entity type
+	var result memdb.ResultIterator
+	result, err = txn.LowerBound(RelationTuplesTable, "entity-index", tenantID, entityType, after, "")
+	if err != nil {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	ids = make([]string, 0, limit)
+	for obj := result.Next(); obj != nil && len(ids) < limit; obj = result.Next() {
+		t, ok := obj.(storage.RelationTuple)
+		if !ok {
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+		}
+		if t.TenantID != tenantID || t.EntityType != entityType {
+			break
+		}
+		if t.EntityID <= after || (len(ids) > 0 && ids[len(ids)-1] == t.EntityID) {
+			continue
+		}
+		if !utils.IsVisible(t, rev) || t.IsExpired(at) {
+			continue
+		}
+		ids = append(ids, t.EntityID)
+	}
+
+	return ids, nil
+}
+
+// HeadSnapshot - Reads the latest version of the snapshot from the repository, its value is the id of the latest
+// transaction of the tenant. The relation tuples that have expired are left out of the reads at it, they are marked
+// as deleted by the garbage collector.
+func (r *RelationshipReader) HeadSnapshot(ctx context.Context, tenantID string) (token.SnapToken, error) {
+	txn := r.database.DB.Txn(false)
+	defer txn.Abort()
+
+	id, err := headTransaction(txn, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewToken(id), nil
+}
+
+// SnapshotAt - Reads the latest version of the snapshot committed at or before the given time from the repository.
+// If the relation tuples that were visible at the given time may have been garbage collected already, an error is
+// returned instead.
+func (r *RelationshipReader) SnapshotAt(ctx context.Context, tenantID string, timestamp time.Time) (token.SnapToken, error) {
+	txn := r.database.DB.Txn(false)
+	defer txn.Abort()
+
+	id, err := transactionAt(txn, tenantID, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired bool
+	expired, err = collected(txn, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+	}
+	return snapshot.NewToken(id), nil
 }
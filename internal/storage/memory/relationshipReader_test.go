@@ -0,0 +1,216 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/internal/storage/memory/snapshot"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+func TestRelationshipReader_TenantIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+	_, err = writer.WriteRelationships(ctx, "t2", tuples(t, "organization:1#member@user:2", "document:1#owner@user:2"))
+	require.NoError(t, err)
+
+	// A filter without an entity type is read from the index of all the tenants, only the tuples of the tenant are
+	// returned
+	it, err := reader.QueryRelationships(ctx, "t1", &base.TupleFilter{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, subjects(it))
+
+	collection, _, err := reader.ReadRelationships(ctx, "t1", &base.TupleFilter{}, "", database.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, collection.GetTuples(), 1)
+	assert.Equal(t, "user:1", tuple.SubjectToString(collection.GetTuples()[0].GetSubject()))
+
+	// Deleting the tuples of a tenant leaves the tuples of the other tenants
+	_, err = writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{Subject: &base.SubjectFilter{Type: "user"}})
+	require.NoError(t, err)
+
+	it, err = reader.QueryRelationships(ctx, "t2", &base.TupleFilter{}, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:2", "user:2"}, subjects(it))
+}
+
+func TestRelationshipReader_SnapshotIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+	members := func(snap string) []string {
+		it, err := reader.QueryRelationships(ctx, "t1", filter, snap)
+		require.NoError(t, err)
+		return subjects(it)
+	}
+
+	empty, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	before := time.Now()
+
+	first, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+	second, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:2"))
+	require.NoError(t, err)
+	deleted, err := writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:  &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+	recreated, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+
+	// Writes to other tenants do not change the snapshots of the tenant
+	_, err = writer.WriteRelationships(ctx, "t2", tuples(t, "organization:1#member@user:3"))
+	require.NoError(t, err)
+
+	// Each snapshot reads the relation tuples that were committed at or before it
+	assert.Empty(t, members(empty.Encode().String()))
+	assert.Equal(t, []string{"user:1"}, members(first.String()))
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(second.String()))
+	assert.Equal(t, []string{"user:2"}, members(deleted.String()))
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(recreated.String()))
+
+	collection, _, err := reader.ReadRelationships(ctx, "t1", filter, deleted.String(), database.NewPagination())
+	require.NoError(t, err)
+	require.Len(t, collection.GetTuples(), 1)
+	assert.Equal(t, "user:2", tuple.SubjectToString(collection.GetTuples()[0].GetSubject()))
+
+	// Writing a relation tuple that exists fails without a new snapshot, and keeps it in the snapshots it was
+	// created before
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:2"))
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(second.String()))
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	last, err := snapshot.EncodedToken{Value: recreated.String()}.Decode()
+	require.NoError(t, err)
+	assert.True(t, head.Eg(last))
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(""))
+
+	at, err := reader.SnapshotAt(ctx, "t1", before)
+	require.NoError(t, err)
+	assert.True(t, at.Eg(empty))
+	at, err = reader.SnapshotAt(ctx, "t1", time.Now())
+	require.NoError(t, err)
+	assert.True(t, at.Eg(head))
+
+	_, err = reader.QueryRelationships(ctx, "t1", filter, "noop")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+}
+
+func TestRelationshipReader_ExpiresAtSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+	members := func(snap string) []string {
+		it, err := reader.QueryRelationships(ctx, "t1", filter, snap)
+		require.NoError(t, err)
+		return subjects(it)
+	}
+
+	collection := tuples(t, "organization:1#member@user:1", "organization:1#member@user:2")
+	collection.GetTuples()[1].ExpiresAt = timestamppb.New(time.Now().Add(100 * time.Millisecond))
+	written, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+
+	// A later write ends the snapshot before the relation tuple expires
+	later, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:2#member@user:1"))
+	require.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+
+	// The relation tuples are read as they were until the snapshot ended, after they have expired as well
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(written.String()))
+	collection, _, err = reader.ReadRelationships(ctx, "t1", filter, written.String(), database.NewPagination())
+	require.NoError(t, err)
+	assert.Len(t, collection.GetTuples(), 2)
+
+	// The head snapshot is read at the current time, without a transaction for the expiration
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, later.String(), head.Encode().String())
+	assert.Equal(t, []string{"user:1"}, members(head.Encode().String()))
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, members(written.String()))
+}
+
+func TestRelationshipReader_ReadEntityIDs(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	first, err := writer.WriteRelationships(ctx, "t1", tuples(t,
+		"repository:b#owner@user:1",
+		"repository:b#member@user:2",
+		"repository:a#owner@user:1",
+		"repository:ab#owner@user:1",
+		"organization:c#member@user:1",
+	))
+	require.NoError(t, err)
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "repository:c#owner@user:1"))
+	require.NoError(t, err)
+	_, err = writer.WriteRelationships(ctx, "t2", tuples(t, "repository:aa#owner@user:1"))
+	require.NoError(t, err)
+
+	// The ids of an entity type are read once each, in order, a page at a time
+	ids, err := reader.ReadEntityIDs(ctx, "t1", "repository", "", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "ab"}, ids)
+	ids, err = reader.ReadEntityIDs(ctx, "t1", "repository", "", "ab", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, ids)
+	ids, err = reader.ReadEntityIDs(ctx, "t1", "repository", "", "c", 2)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// The entities are read at the snapshot
+	ids, err = reader.ReadEntityIDs(ctx, "t1", "repository", first.String(), "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "ab", "b"}, ids)
+}
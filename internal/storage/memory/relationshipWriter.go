@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/hashicorp/go-memdb"
@@ -40,55 +41,387 @@ func (r *RelationshipWriter) WriteRelationships(ctx context.Context, tenantID st
 		return token.NewNoopToken().Encode(), nil
 	}
 
-	txn := r.database.DB.Txn(true)
+	txn := r.database.Txn(true)
 	defer txn.Abort()
+	txn.TrackChanges()
+
+	var id uint64
+	id, err = beginTransaction(txn, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expired relation tuples are deleted first, so that they are created again if they are written
+	if _, err = expire(txn, tenantID, id, time.Now()); err != nil {
+		return nil, err
+	}
 
 	for iterator.HasNext() {
-		bt := iterator.GetNext()
-		t := storage.RelationTuple{
-			ID:              utils.RelationTuplesID.ID(),
-			TenantID:        tenantID,
-			EntityType:      bt.GetEntity().GetType(),
-			EntityID:        bt.GetEntity().GetId(),
-			Relation:        bt.GetRelation(),
-			SubjectType:     bt.GetSubject().GetType(),
-			SubjectID:       bt.GetSubject().GetId(),
-			SubjectRelation: bt.GetSubject().GetRelation(),
-		}
-		if err = txn.Insert(RelationTuplesTable, t); err != nil {
-			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		if err = write(txn, relationTuple(tenantID, iterator.GetNext(), id), false); err != nil {
+			return nil, err
 		}
 	}
 
-	txn.Commit()
-	return snapshot.NewToken(time.Now()).Encode(), nil
+	var st snapshot.Token
+	st, err = commit(r.database, txn, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return st.Encode(), nil
 }
 
 // DeleteRelationships - Delete relationship from repository
 func (r *RelationshipWriter) DeleteRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter) (token.EncodedSnapToken, error) {
 	var err error
-	txn := r.database.DB.Txn(true)
+	txn := r.database.Txn(true)
 	defer txn.Abort()
+	txn.TrackChanges()
+
+	var id uint64
+	id, err = beginTransaction(txn, tenantID)
+	if err != nil {
+		return nil, err
+	}
 
+	if _, err = expire(txn, tenantID, id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var deleted []storage.RelationTuple
+	deleted, err = live(txn, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range deleted {
+		if err = remove(txn, t, id); err != nil {
+			return nil, err
+		}
+	}
+
+	var st snapshot.Token
+	st, err = commit(r.database, txn, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return st.Encode(), nil
+}
+
+// BulkImport - Write the relation tuples of the batches to repository in a single transaction. The relation tuples
+// are staged while the batches are received, so that other writers are not blocked until the last batch. They are
+// created by the transaction once it begins, the ones that are already written are skipped and not counted as imported.
+func (r *RelationshipWriter) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	staged := make([]storage.RelationTuple, 0)
+	for {
+		collection, err := batches()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, bt := range collection.GetTuples() {
+			staged = append(staged, relationTuple(tenantID, bt, 0))
+		}
+	}
+
+	if len(staged) == 0 {
+		return token.NewNoopToken().Encode(), 0, nil
+	}
+
+	txn := r.database.Txn(true)
+	defer txn.Abort()
+	txn.TrackChanges()
+
+	id, err := beginTransaction(txn, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err = expire(txn, tenantID, id, time.Now()); err != nil {
+		return nil, 0, err
+	}
+
+	var imported int64
+	for _, t := range staged {
+		var existing *storage.RelationTuple
+		existing, err = find(txn, tenantID, t.ToTuple())
+		if err != nil {
+			return nil, 0, err
+		}
+		if existing != nil {
+			continue
+		}
+		t.CreatedTxID = id
+		if err = write(txn, t, false); err != nil {
+			return nil, 0, err
+		}
+		imported++
+	}
+
+	st, err := commit(r.database, txn, tenantID, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return st.Encode(), imported, nil
+}
+
+// TransactRelationships - Apply the operations to the relation tuples of repository in a single transaction, if all
+// the preconditions hold
+func (r *RelationshipWriter) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	var err error
+	txn := r.database.Txn(true)
+	defer txn.Abort()
+	txn.TrackChanges()
+
+	var id uint64
+	id, err = beginTransaction(txn, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expired relation tuples are deleted first, so that they do not match the preconditions
+	if _, err = expire(txn, tenantID, id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	for _, precondition := range preconditions {
+		var matched bool
+		matched, err = matches(txn, tenantID, precondition.GetFilter())
+		if err != nil {
+			return nil, err
+		}
+		if matched != (precondition.GetOperation() == base.TuplePrecondition_OPERATION_MUST_MATCH) {
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+		}
+	}
+
+	for _, operation := range operations {
+		var existing *storage.RelationTuple
+		existing, err = find(txn, tenantID, operation.GetTuple())
+		if err != nil {
+			return nil, err
+		}
+
+		t := relationTuple(tenantID, operation.GetTuple(), id)
+
+		switch operation.GetOperation() {
+		case base.TupleOperation_OPERATION_DELETE:
+			if existing == nil {
+				continue
+			}
+			if err = remove(txn, *existing, id); err != nil {
+				return nil, err
+			}
+		case base.TupleOperation_OPERATION_TOUCH:
+			// A touched relation tuple that exists is kept with its original created_tx_id, unless the operation
+			// changes its expiration. A new version of it is written then, that snapshots and watchers see.
+			if existing != nil && ((operation.GetTuple().GetExpiresAt() == nil && !operation.GetClearExpiration()) || existing.ExpiresAt.Equal(t.ExpiresAt)) {
+				continue
+			}
+			if err = write(txn, t, true); err != nil {
+				return nil, err
+			}
+		default:
+			if err = write(txn, t, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var st snapshot.Token
+	st, err = commit(r.database, txn, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return st.Encode(), nil
+}
+
+// matches - Reports whether any live relation tuple of the tenant matches the filter
+func matches(txn *memdb.Txn, tenantID string, filter *base.TupleFilter) (bool, error) {
+	tuples, err := live(txn, tenantID, filter)
+	if err != nil {
+		return false, err
+	}
+	return len(tuples) > 0, nil
+}
+
+// live - Returns the live relation tuples of the tenant that match the filter
+func live(txn *memdb.Txn, tenantID string, filter *base.TupleFilter) ([]storage.RelationTuple, error) {
 	index, args := utils.GetIndexNameAndArgsByFilters(tenantID, filter)
-	var it memdb.ResultIterator
-	it, err = txn.Get(RelationTuplesTable, index, args...)
+	it, err := txn.Get(RelationTuplesTable, index, args...)
 	if err != nil {
 		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
 	}
 
-	fit := memdb.NewFilterIterator(it, utils.FilterQuery(filter))
+	var tuples []storage.RelationTuple
+	fit := memdb.NewFilterIterator(it, utils.FilterQuery(tenantID, filter))
 	for obj := fit.Next(); obj != nil; obj = fit.Next() {
 		t, ok := obj.(storage.RelationTuple)
 		if !ok {
 			return nil, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
 		}
-		err = txn.Delete(RelationTuplesTable, t)
-		if err != nil {
-			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		if t.ExpiredTxID == 0 {
+			tuples = append(tuples, t)
+		}
+	}
+	return tuples, nil
+}
+
+// find - Returns the live relation tuple of the tenant that is equal to the given tuple, nil if there is none
+func find(txn *memdb.Txn, tenantID string, bt *base.Tuple) (*storage.RelationTuple, error) {
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: bt.GetEntity().GetType(), Ids: []string{bt.GetEntity().GetId()}},
+		Relation: bt.GetRelation(),
+		Subject:  &base.SubjectFilter{Type: bt.GetSubject().GetType(), Ids: []string{bt.GetSubject().GetId()}, Relation: bt.GetSubject().GetRelation()},
+	}
+
+	tuples, err := live(txn, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tuples {
+		// An empty subject relation does not filter the subject relations
+		if t.SubjectRelation == bt.GetSubject().GetRelation() {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+// write - Inserts the relation tuple created by the transaction. Writing a relation tuple that is live already fails,
+// unless it is touched: its live version is deleted by the transaction then and the relation tuple is inserted as a
+// new version of it, so that each version stays visible at the snapshots it was live at.
+func write(txn *memdb.Txn, t storage.RelationTuple, touch bool) error {
+	existing, err := find(txn, t.TenantID, t.ToTuple())
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if !touch {
+			return errors.New(base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+		}
+		if err = remove(txn, *existing, t.CreatedTxID); err != nil {
+			return err
+		}
+	}
+	if err = txn.Insert(RelationTuplesTable, t); err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return nil
+}
+
+// remove - Deletes the live relation tuple by the transaction. Its deleted version is kept for the snapshots before
+// the transaction until it is garbage collected, unless it was created by the same transaction.
+func remove(txn *memdb.Txn, t storage.RelationTuple, id uint64) error {
+	if err := txn.Delete(RelationTuplesTable, t); err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	if t.CreatedTxID == id {
+		return nil
+	}
+	t.ExpiredTxID = id
+	if err := txn.Insert(RelationTuplesTable, t); err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return nil
+}
+
+// relationTuple - Creates the relation tuple of the tenant to be stored from the given tuple, created by the
+// transaction of the given id
+func relationTuple(tenantID string, bt *base.Tuple, id uint64) storage.RelationTuple {
+	t := storage.RelationTuple{
+		ID:              utils.RelationTuplesID.ID(),
+		TenantID:        tenantID,
+		EntityType:      bt.GetEntity().GetType(),
+		EntityID:        bt.GetEntity().GetId(),
+		Relation:        bt.GetRelation(),
+		SubjectType:     bt.GetSubject().GetType(),
+		SubjectID:       bt.GetSubject().GetId(),
+		SubjectRelation: bt.GetSubject().GetRelation(),
+		CreatedTxID:     id,
+	}
+	if bt.GetExpiresAt() != nil {
+		t.ExpiresAt = bt.GetExpiresAt().AsTime()
+	}
+	return t
+}
+
+// expire - Deletes the live relation tuples of the tenant that expired by the given time by the transaction, so
+// their deletion is recorded with the changes of the transaction. It returns the number of deleted relation tuples.
+func expire(txn *memdb.Txn, tenantID string, id uint64, now time.Time) (int, error) {
+	it, err := txn.Get(RelationTuplesTable, "expiring-index", tenantID, true)
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	var expired []storage.RelationTuple
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		t, ok := obj.(storage.RelationTuple)
+		if !ok {
+			return 0, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+		}
+		if t.IsExpired(now) {
+			expired = append(expired, t)
 		}
 	}
 
-	txn.Commit()
-	return snapshot.NewToken(time.Now()).Encode(), nil
+	for _, t := range expired {
+		if err = remove(txn, t, id); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// expireRelationships - Deletes the expired relation tuples of the tenant in a transaction of their own, it is only
+// begun if any relation tuple has expired
+func expireRelationships(database *db.Memory, tenantID string) error {
+	rtxn := database.DB.Txn(false)
+	next, err := nextExpiration(rtxn, tenantID)
+	rtxn.Abort()
+	if err != nil {
+		return err
+	}
+	if next.IsZero() || next.After(time.Now()) {
+		return nil
+	}
+
+	txn := database.Txn(true)
+	defer txn.Abort()
+	txn.TrackChanges()
+
+	id, err := beginTransaction(txn, tenantID)
+	if err != nil {
+		return err
+	}
+
+	var n int
+	n, err = expire(txn, tenantID, id, time.Now())
+	if err != nil || n == 0 {
+		return err
+	}
+
+	_, err = commit(database, txn, tenantID, id)
+	return err
+}
+
+// nextExpiration - Returns the earliest expiration of the relation tuples of the tenant, zero if none of them expires
+func nextExpiration(txn *memdb.Txn, tenantID string) (time.Time, error) {
+	it, err := txn.Get(RelationTuplesTable, "expiring-index", tenantID, true)
+	if err != nil {
+		return time.Time{}, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	var next time.Time
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		t, ok := obj.(storage.RelationTuple)
+		if !ok {
+			return time.Time{}, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+		}
+		if next.IsZero() || t.ExpiresAt.Before(next) {
+			next = t.ExpiresAt
+		}
+	}
+	return next, nil
 }
@@ -0,0 +1,208 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// operation returns the operation on the tuple of the given string.
+func operation(t *testing.T, op base.TupleOperation_Operation, value string) *base.TupleOperation {
+	tup, err := tuple.Tuple(value)
+	require.NoError(t, err)
+	return &base.TupleOperation{Operation: op, Tuple: tup}
+}
+
+func TestRelationshipWriter_TransactRelationships(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "document:1#parent@folder:a#..."))
+	require.NoError(t, err)
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "document", Ids: []string{"1"}},
+		Relation: "parent",
+	}
+	parents := func(snap string) []string {
+		it, err := reader.QueryRelationships(ctx, "t1", filter, snap)
+		require.NoError(t, err)
+		return subjects(it)
+	}
+
+	// Moving the document between folders
+	moved, err := writer.TransactRelationships(ctx, "t1", []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_MATCH,
+		Filter: &base.TupleFilter{
+			Entity:   &base.EntityFilter{Type: "document", Ids: []string{"1"}},
+			Relation: "parent",
+			Subject:  &base.SubjectFilter{Type: "folder", Ids: []string{"a"}},
+		},
+	}}, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_DELETE, "document:1#parent@folder:a#..."),
+		operation(t, base.TupleOperation_OPERATION_CREATE, "document:1#parent@folder:b#..."),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"folder:b#..."}, parents(moved.String()))
+
+	// The document is not in folder a anymore, so it cannot be moved from there again
+	_, err = writer.TransactRelationships(ctx, "t1", []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_MATCH,
+		Filter: &base.TupleFilter{
+			Entity:   &base.EntityFilter{Type: "document", Ids: []string{"1"}},
+			Relation: "parent",
+			Subject:  &base.SubjectFilter{Type: "folder", Ids: []string{"a"}},
+		},
+	}}, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_DELETE, "document:1#parent@folder:a#..."),
+		operation(t, base.TupleOperation_OPERATION_CREATE, "document:1#parent@folder:c#..."),
+	})
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+
+	_, err = writer.TransactRelationships(ctx, "t1", []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_NOT_MATCH,
+		Filter:    filter,
+	}}, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_CREATE, "document:1#parent@folder:c#..."),
+	})
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+
+	// Creating a relation tuple that exists fails the whole transaction
+	_, err = writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_CREATE, "document:1#parent@folder:c#..."),
+		operation(t, base.TupleOperation_OPERATION_CREATE, "document:1#parent@folder:b#..."),
+	})
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	// Touching writes the relation tuple whether it exists or not
+	touched, err := writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_TOUCH, "document:1#parent@folder:b#..."),
+		operation(t, base.TupleOperation_OPERATION_TOUCH, "document:1#parent@folder:c#..."),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"folder:b#...", "folder:c#..."}, parents(touched.String()))
+}
+
+func TestRelationshipWriter_WriteExistingTuples(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+
+	// Writing a relation tuple that exists fails, and leaves the existing one as it was
+	collection := tuples(t, "organization:1#member@user:1")
+	collection.GetTuples()[0].ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+	_, err = writer.WriteRelationships(ctx, "t1", collection)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, written.String(), head.Encode().String())
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+	it, err := reader.QueryRelationships(ctx, "t1", filter, written.String())
+	require.NoError(t, err)
+	assert.Nil(t, it.GetNext().GetExpiresAt())
+
+	// Touching it with an expiration writes a new version, the snapshot before the touch still sees the first one
+	touch := operation(t, base.TupleOperation_OPERATION_TOUCH, "organization:1#member@user:1")
+	touch.Tuple.ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+	touched, err := writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{touch})
+	require.NoError(t, err)
+
+	it, err = reader.QueryRelationships(ctx, "t1", filter, written.String())
+	require.NoError(t, err)
+	assert.Nil(t, it.GetNext().GetExpiresAt())
+	it, err = reader.QueryRelationships(ctx, "t1", filter, touched.String())
+	require.NoError(t, err)
+	assert.True(t, it.GetNext().GetExpiresAt().AsTime().Equal(touch.GetTuple().GetExpiresAt().AsTime()))
+}
+
+func TestRelationshipWriter_TouchKeepsExistingTuples(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+	watcher := memory.NewWatcher(db, l)
+
+	collection := tuples(t, "organization:1#member@user:1")
+	expiresAt := time.Now().Add(200 * time.Millisecond)
+	collection.GetTuples()[0].ExpiresAt = timestamppb.New(expiresAt)
+
+	written, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+
+	changes, errs := watcher.Watch(ctx, "t1", written.String())
+
+	touched, err := writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{
+		operation(t, base.TupleOperation_OPERATION_TOUCH, "organization:1#member@user:1"),
+		operation(t, base.TupleOperation_OPERATION_TOUCH, "organization:1#member@user:2"),
+	})
+	require.NoError(t, err)
+
+	// The existing tuple is kept with its expiration, so only the new one is created
+	c := next(t, changes, errs)
+	assert.Equal(t, touched.String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_CREATE user:2"}, operations(c))
+
+	// Clearing the expiration writes a new version of the existing tuple
+	clear := operation(t, base.TupleOperation_OPERATION_TOUCH, "organization:1#member@user:1")
+	clear.ClearExpiration = true
+	cleared, err := writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{clear})
+	require.NoError(t, err)
+
+	c = next(t, changes, errs)
+	assert.Equal(t, cleared.String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_DELETE user:1", "OPERATION_CREATE user:1"}, operations(c))
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+	it, err := reader.QueryRelationships(ctx, "t1", filter, touched.String())
+	require.NoError(t, err)
+	assert.True(t, it.GetNext().GetExpiresAt().AsTime().Equal(expiresAt))
+	it, err = reader.QueryRelationships(ctx, "t1", filter, cleared.String())
+	require.NoError(t, err)
+	assert.Nil(t, it.GetNext().GetExpiresAt())
+
+	// The existing tuple does not expire anymore
+	time.Sleep(time.Until(expiresAt))
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	it, err = reader.QueryRelationships(ctx, "t1", filter, head.Encode().String())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, subjects(it))
+}
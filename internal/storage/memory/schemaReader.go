@@ -3,6 +3,8 @@ package memory
 import (
 	"context"
 	"errors"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 
@@ -93,3 +95,45 @@ func (r *SchemaReader) HeadVersion(ctx context.Context, tenantID string) (string
 	}
 	return "", errors.New(base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
 }
+
+// VersionAt - Reads the latest version written at or before the given time from the repository.
+func (r *SchemaReader) VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (string, error) {
+	var err error
+	txn := r.database.DB.Txn(false)
+	defer txn.Abort()
+	var it memdb.ResultIterator
+	it, err = txn.Get(SchemaDefinitionsTable, "tenant", tenantID)
+	if err != nil {
+		return "", errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	bound := storage.VersionBound(timestamp)
+	version := ""
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		v := obj.(storage.SchemaDefinition).Version
+		if v < bound && v > version {
+			version = v
+		}
+	}
+	if version == "" {
+		return "", errors.New(base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
+	}
+	return version, nil
+}
+
+// ListSchemaDefinitions - Reads the definitions of all the versions of the schema from the repository, ordered by version.
+func (r *SchemaReader) ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []storage.SchemaDefinition, err error) {
+	txn := r.database.DB.Txn(false)
+	defer txn.Abort()
+	var it memdb.ResultIterator
+	it, err = txn.Get(SchemaDefinitionsTable, "tenant", tenantID)
+	if err != nil {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		definitions = append(definitions, obj.(storage.SchemaDefinition))
+	}
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return definitions[i].Version < definitions[j].Version
+	})
+	return definitions, nil
+}
@@ -3,13 +3,13 @@ package snapshot
 import (
 	"encoding/base64"
 	"encoding/binary"
-	"time"
+	"errors"
 
 	"github.com/Permify/permify/pkg/token"
 )
 
 type (
-	// Token - Structure for Token
+	// Token - Structure for Token, its value is the id of a transaction of the tenant
 	Token struct {
 		Value uint64
 	}
@@ -20,9 +20,9 @@ type (
 )
 
 // NewToken - Creates a new snapshot token
-func NewToken(value time.Time) token.SnapToken {
+func NewToken(value uint64) token.SnapToken {
 	return Token{
-		Value: uint64(value.UnixNano()),
+		Value: value,
 	}
 }
 
@@ -59,6 +59,9 @@ func (t EncodedToken) Decode() (token.SnapToken, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(b) != 8 {
+		return nil, errors.New("invalid snapshot token length")
+	}
 	return Token{
 		Value: binary.LittleEndian.Uint64(b),
 	}, nil
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/Permify/permify/internal/storage/memory/snapshot"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// Transaction - Structure for the transactions that wrote the relation tuples of a tenant. Writers are serialized,
+// so the ids of the transactions of a tenant are assigned in commit order and they are the revisions of its snapshots.
+type Transaction struct {
+	TenantID  string
+	ID        uint64
+	Timestamp time.Time
+}
+
+// beginTransaction - Records a new transaction of the tenant in the write transaction, and returns its id
+func beginTransaction(txn *memdb.Txn, tenantID string) (uint64, error) {
+	head, err := headTransaction(txn, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	id := head + 1
+	if err = txn.Insert(TransactionsTable, Transaction{
+		TenantID:  tenantID,
+		ID:        id,
+		Timestamp: time.Now(),
+	}); err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return id, nil
+}
+
+// headTransaction - Returns the id of the latest transaction of the tenant, zero if there is none
+func headTransaction(txn *memdb.Txn, tenantID string) (uint64, error) {
+	raw, err := txn.Last(TransactionsTable, "tenant", tenantID)
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	if raw == nil {
+		return 0, nil
+	}
+	t, ok := raw.(Transaction)
+	if !ok {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+	}
+	return t.ID, nil
+}
+
+// transactionAt - Returns the id of the latest transaction of the tenant committed at or before the given time,
+// zero if there is none
+func transactionAt(txn *memdb.Txn, tenantID string, timestamp time.Time) (uint64, error) {
+	it, err := txn.GetReverse(TransactionsTable, "tenant", tenantID)
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		t, ok := obj.(Transaction)
+		if !ok {
+			return 0, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+		}
+		if !t.Timestamp.After(timestamp) {
+			return t.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// expirationTime - Returns the time the expiration of the relation tuples read at the snapshot of the transaction of
+// the tenant with the given id is checked at. A snapshot holds until the next transaction of the tenant begins, so it
+// is the time of that transaction, which keeps the reads at older snapshots repeatable, or the current time if there
+// is none.
+func expirationTime(txn *memdb.Txn, tenantID string, id uint64) (time.Time, error) {
+	raw, err := txn.First(TransactionsTable, "id", tenantID, id+1)
+	if err != nil {
+		return time.Time{}, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	if raw == nil {
+		return time.Now(), nil
+	}
+	t, ok := raw.(Transaction)
+	if !ok {
+		return time.Time{}, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+	}
+	return t.Timestamp, nil
+}
+
+// collected - Reports whether the history of the tenant after the given revision may have been garbage collected.
+// The transactions before the garbage collection horizon are removed, so it is the oldest one that is kept, and
+// transaction ids start at one.
+func collected(txn *memdb.Txn, tenantID string, revision uint64) (bool, error) {
+	raw, err := txn.First(TransactionsTable, "tenant", tenantID)
+	if err != nil {
+		return false, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	if raw == nil {
+		return false, nil
+	}
+	t, ok := raw.(Transaction)
+	if !ok {
+		return false, errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+	}
+	return t.ID > 1 && revision < t.ID, nil
+}
+
+// snapshotRevision - Returns the revision the relation tuples of the tenant are read at in the given snapshot, the
+// head revision if no snapshot is given. An error is returned if the snapshot has been garbage collected.
+func snapshotRevision(txn *memdb.Txn, tenantID, snap string) (uint64, error) {
+	if snap == "" {
+		return headTransaction(txn, tenantID)
+	}
+
+	st, err := snapshot.EncodedToken{Value: snap}.Decode()
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+	}
+	revision := st.(snapshot.Token).Value
+
+	expired, err := collected(txn, tenantID, revision)
+	if err != nil {
+		return 0, err
+	}
+	if expired {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+	}
+	return revision, nil
+}
@@ -8,14 +8,16 @@ import (
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
-// FilterQuery - Filter relation tuples according to given filter
-func FilterQuery(filter *base.TupleFilter) memdb.FilterFunc {
+// FilterQuery - Filter relation tuples of the tenant according to given filter
+func FilterQuery(tenantID string, filter *base.TupleFilter) memdb.FilterFunc {
 	return func(tupleRaw interface{}) bool {
 		tuple, ok := tupleRaw.(storage.RelationTuple)
 		if !ok {
 			return true
 		}
 		switch {
+		case tuple.TenantID != tenantID:
+			return true
 		case filter.GetEntity().GetType() != "" && tuple.EntityType != filter.GetEntity().GetType():
 			return true
 		case len(filter.GetEntity().GetIds()) > 0 && !slices.Contains(filter.GetEntity().GetIds(), tuple.EntityID):
@@ -32,3 +34,10 @@ func FilterQuery(filter *base.TupleFilter) memdb.FilterFunc {
 		return false
 	}
 }
+
+// IsVisible - Reports whether the relation tuple is visible at the given revision. Writers are serialized, so
+// revisions are assigned in commit order, and a relation tuple is visible if it was created at or before the
+// revision and it was not deleted at or before the revision.
+func IsVisible(tuple storage.RelationTuple, revision uint64) bool {
+	return tuple.CreatedTxID <= revision && (tuple.ExpiredTxID == 0 || tuple.ExpiredTxID > revision)
+}
@@ -0,0 +1,225 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/memory/snapshot"
+	db "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// Change - Structure for the changes to the relation tuples of a tenant that were committed together
+type Change struct {
+	TenantID string
+	// Revision is the snapshot value in fixed width hex, so the index orders the changes by their snapshot
+	Revision string
+	Changes  *base.TupleChanges
+}
+
+// revision - Returns the fixed width representation of the snapshot value
+func revision(value uint64) string {
+	return fmt.Sprintf("%016x", value)
+}
+
+// commit - Records the relation tuple changes tracked by the write transaction of the given transaction id and
+// commits it. A relation tuple is deleted by inserting its deleted version, and the live version it replaces is not
+// recorded as a change.
+func commit(database *db.Memory, txn *memdb.Txn, tenantID string, id uint64) (snapshot.Token, error) {
+	st := snapshot.NewToken(id).(snapshot.Token)
+
+	changes := &base.TupleChanges{
+		SnapToken: st.Encode().String(),
+	}
+	for _, change := range txn.Changes() {
+		if change.Table != RelationTuplesTable {
+			continue
+		}
+		switch {
+		case change.Created():
+			t := change.After.(storage.RelationTuple)
+			operation := base.TupleChange_OPERATION_CREATE
+			if t.ExpiredTxID != 0 {
+				operation = base.TupleChange_OPERATION_DELETE
+			}
+			changes.TupleChanges = append(changes.TupleChanges, &base.TupleChange{
+				Operation: operation,
+				Tuple:     t.ToTuple(),
+			})
+		case change.Updated():
+			// A relation tuple that is deleted and created again by the transaction replaces its live version
+			if change.After.(storage.RelationTuple).CreatedTxID != change.Before.(storage.RelationTuple).CreatedTxID {
+				changes.TupleChanges = append(changes.TupleChanges, &base.TupleChange{
+					Operation: base.TupleChange_OPERATION_CREATE,
+					Tuple:     change.After.(storage.RelationTuple).ToTuple(),
+				})
+			}
+		}
+	}
+
+	if len(changes.GetTupleChanges()) > 0 {
+		if err := txn.Insert(ChangesTable, Change{
+			TenantID: tenantID,
+			Revision: revision(st.Value),
+			Changes:  changes,
+		}); err != nil {
+			return snapshot.Token{}, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+	}
+
+	if err := database.Commit(txn); err != nil {
+		return snapshot.Token{}, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return st, nil
+}
+
+// Watcher - Structure for Watcher
+type Watcher struct {
+	database *db.Memory
+	// logger
+	logger logger.Interface
+}
+
+// NewWatcher - Creates a new Watcher
+func NewWatcher(database *db.Memory, logger logger.Interface) *Watcher {
+	return &Watcher{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// Watch - Streams the changes to the relation tuples of the tenant committed after the given snapshot
+func (w *Watcher) Watch(ctx context.Context, tenantID, snap string) (<-chan *base.TupleChanges, <-chan error) {
+	changes := make(chan *base.TupleChanges, 1)
+	errs := make(chan error, 1)
+
+	st, err := snapshot.EncodedToken{Value: snap}.Decode()
+	if err != nil {
+		errs <- errors.New(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+		close(changes)
+		close(errs)
+		return changes, errs
+	}
+
+	go func() {
+		defer close(changes)
+		defer close(errs)
+
+		cp := st.(snapshot.Token).Value
+		for {
+			// The watch stops once the database is closed
+			select {
+			case <-w.database.Done():
+				errs <- errors.New(base.ErrorCode_ERROR_CODE_CANCELLED.String())
+				return
+			default:
+			}
+
+			ws := memdb.NewWatchSet()
+			ws.Add(w.database.Done())
+
+			txn := w.database.DB.Txn(false)
+			// The changes after the checkpoint are missing if they have been garbage collected
+			expired, err := collected(txn, tenantID, cp)
+			if err != nil {
+				txn.Abort()
+				errs <- err
+				return
+			}
+			if expired {
+				txn.Abort()
+				errs <- errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+				return
+			}
+
+			// The iterator over all the changes of the tenant is only used to be notified of the new ones.
+			all, err := txn.Get(ChangesTable, "id_prefix", tenantID, "")
+			if err != nil {
+				txn.Abort()
+				errs <- errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+				return
+			}
+			ws.Add(all.WatchCh())
+
+			it, err := txn.LowerBound(ChangesTable, "id", tenantID, revision(cp+1))
+			if err != nil {
+				txn.Abort()
+				errs <- errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+				return
+			}
+
+			var pending []Change
+			for obj := it.Next(); obj != nil; obj = it.Next() {
+				c, ok := obj.(Change)
+				if !ok {
+					txn.Abort()
+					errs <- errors.New(base.ErrorCode_ERROR_CODE_TYPE_CONVERSATION.String())
+					return
+				}
+				if c.TenantID != tenantID {
+					break
+				}
+				pending = append(pending, c)
+			}
+
+			next, err := nextExpiration(txn, tenantID)
+			txn.Abort()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, c := range pending {
+				select {
+				case changes <- c.Changes:
+				case <-ctx.Done():
+					return
+				}
+				ct, err := snapshot.EncodedToken{Value: c.Changes.GetSnapToken()}.Decode()
+				if err != nil {
+					errs <- errors.New(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+					return
+				}
+				cp = ct.(snapshot.Token).Value
+			}
+
+			if len(pending) > 0 {
+				continue
+			}
+
+			if next.IsZero() {
+				if err = ws.WatchCtx(ctx); err != nil {
+					return
+				}
+				continue
+			}
+
+			// The expired relation tuples are deleted once the earliest of them expires, so their deletion is streamed
+			// even if the tenant is not written to.
+			wctx, cancel := context.WithDeadline(ctx, next)
+			err = ws.WatchCtx(wctx)
+			cancel()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if err = w.expire(tenantID); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}
+	}()
+
+	return changes, errs
+}
+
+// expire - Deletes the expired relation tuples of the tenant and records their deletion
+func (w *Watcher) expire(tenantID string) error {
+	return expireRelationships(w.database, tenantID)
+}
@@ -0,0 +1,206 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/memory/migrations"
+	"github.com/Permify/permify/pkg/database"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+func tuples(t *testing.T, values ...string) *database.TupleCollection {
+	collection := database.NewTupleCollection()
+	for _, v := range values {
+		tup, err := tuple.Tuple(v)
+		require.NoError(t, err)
+		collection.Add(tup)
+	}
+	return collection
+}
+
+// next receives the next changes from the watcher.
+func next(t *testing.T, changes <-chan *base.TupleChanges, errs <-chan error) *base.TupleChanges {
+	select {
+	case c := <-changes:
+		require.NotNil(t, c)
+		return c
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no changes received")
+	}
+	return nil
+}
+
+// operations returns the operations of the changes with the subjects of their tuples.
+func operations(c *base.TupleChanges) []string {
+	var values []string
+	for _, change := range c.GetTupleChanges() {
+		values = append(values, change.GetOperation().String()+" "+tuple.SubjectToString(change.GetTuple().GetSubject()))
+	}
+	return values
+}
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+	watcher := memory.NewWatcher(db, l)
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+
+	changes, errs := watcher.Watch(ctx, "t1", head.Encode().String())
+
+	snaps := make([]token.EncodedSnapToken, 0, 3)
+	for _, v := range []string{"organization:1#member@user:1", "organization:1#member@user:2"} {
+		snap, err := writer.WriteRelationships(ctx, "t1", tuples(t, v))
+		require.NoError(t, err)
+		snaps = append(snaps, snap)
+	}
+
+	// Changes of other tenants are not streamed
+	_, err = writer.WriteRelationships(ctx, "t2", tuples(t, "organization:1#member@user:3"))
+	require.NoError(t, err)
+
+	snap, err := writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+		Subject:  &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+	snaps = append(snaps, snap)
+
+	c := next(t, changes, errs)
+	assert.Equal(t, snaps[0].String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_CREATE user:1"}, operations(c))
+
+	c = next(t, changes, errs)
+	assert.Equal(t, snaps[1].String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_CREATE user:2"}, operations(c))
+
+	c = next(t, changes, errs)
+	assert.Equal(t, snaps[2].String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_DELETE user:1"}, operations(c))
+
+	// Watching from the snap token of a change resumes after it
+	rctx, rcancel := context.WithCancel(context.Background())
+	defer rcancel()
+	resumed, rerrs := watcher.Watch(rctx, "t1", snaps[1].String())
+
+	c = next(t, resumed, rerrs)
+	assert.Equal(t, snaps[2].String(), c.GetSnapToken())
+
+	rcancel()
+	for range resumed {
+	}
+	_, ok := <-rerrs
+	assert.False(t, ok)
+}
+
+func TestWatcher_StopsWhenClosed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+	watcher := memory.NewWatcher(db, l)
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+
+	changes, errs := watcher.Watch(ctx, "t1", head.Encode().String())
+
+	require.NoError(t, db.Close())
+
+	select {
+	case err = <-errs:
+		assert.EqualError(t, err, base.ErrorCode_ERROR_CODE_CANCELLED.String())
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "watcher did not stop")
+	}
+	for range changes {
+	}
+
+	// Writes are rejected once the database is closed
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	assert.Error(t, err)
+
+	ready, err := db.IsReady(ctx)
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, IMDatabase.ErrClosed)
+}
+
+func TestWatcher_ExpiredTuples(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := IMDatabase.New(migrations.Schema)
+	require.NoError(t, err)
+
+	l := logger.New("fatal")
+	reader := memory.NewRelationshipReader(db, l)
+	writer := memory.NewRelationshipWriter(db, l)
+	watcher := memory.NewWatcher(db, l)
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+
+	collection := tuples(t, "organization:1#member@user:1", "organization:1#member@user:2")
+	collection.GetTuples()[0].ExpiresAt = timestamppb.New(time.Now().Add(200 * time.Millisecond))
+
+	snap, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+
+	filter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	}
+
+	it, err := reader.QueryRelationships(ctx, "t1", filter, snap.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1", "user:2"}, subjects(it))
+
+	changes, errs := watcher.Watch(ctx, "t1", head.Encode().String())
+
+	c := next(t, changes, errs)
+	assert.Equal(t, []string{"OPERATION_CREATE user:1", "OPERATION_CREATE user:2"}, operations(c))
+
+	// The expired tuple is deleted without another write to the tenant
+	c = next(t, changes, errs)
+	assert.Equal(t, []string{"OPERATION_DELETE user:1"}, operations(c))
+
+	it, err = reader.QueryRelationships(ctx, "t1", filter, c.GetSnapToken())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:2"}, subjects(it))
+}
+
+// subjects returns the subjects of the tuples of the iterator.
+func subjects(it *database.TupleIterator) []string {
+	var values []string
+	for it.HasNext() {
+		values = append(values, tuple.SubjectToString(it.GetNext().GetSubject()))
+	}
+	return values
+}
@@ -9,16 +9,26 @@ import (
 
 	"github.com/Permify/permify/internal/config"
 	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	SLDatabase "github.com/Permify/permify/pkg/database/sqlite"
 	"github.com/Permify/permify/pkg/logger"
 )
 
 const (
 	postgresMigrationDir = "postgres/migrations"
+	mysqlMigrationDir    = "mysql/migrations"
+	sqliteMigrationDir   = "sqlite/migrations"
 )
 
 //go:embed postgres/migrations/*.sql
 var postgresMigrations embed.FS
 
+//go:embed mysql/migrations/*.sql
+var mysqlMigrations embed.FS
+
+//go:embed sqlite/migrations/*.sql
+var sqliteMigrations embed.FS
+
 // Migrate - migrate the database
 func Migrate(conf config.Database, l logger.Interface) (err error) {
 	switch conf.Engine {
@@ -48,6 +58,66 @@ func Migrate(conf config.Database, l logger.Interface) (err error) {
 			return err
 		}
 
+		return nil
+	case database.MYSQL.String():
+
+		var dsn string
+		dsn, err = MYDatabase.DSN(conf.URI)
+		if err != nil {
+			return err
+		}
+
+		var db *sql.DB
+		db, err = sql.Open("mysql", dsn)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err = db.Close(); err != nil {
+				l.Fatal("failed to close the db", err)
+			}
+		}()
+
+		goose.SetTableName("migrations")
+
+		if err = goose.SetDialect("mysql"); err != nil {
+			l.Fatal("failed to initialize the migrate command", err)
+		}
+
+		goose.SetBaseFS(mysqlMigrations)
+
+		if err = goose.Up(db, mysqlMigrationDir); err != nil {
+			return err
+		}
+
+		return nil
+	case database.SQLITE.String():
+
+		var db *sql.DB
+		db, err = sql.Open("sqlite", SLDatabase.DSN(conf.URI))
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err = db.Close(); err != nil {
+				l.Fatal("failed to close the db", err)
+			}
+		}()
+
+		goose.SetTableName("migrations")
+
+		if err = goose.SetDialect("sqlite3"); err != nil {
+			l.Fatal("failed to initialize the migrate command", err)
+		}
+
+		goose.SetBaseFS(sqliteMigrations)
+
+		if err = goose.Up(db, sqliteMigrationDir); err != nil {
+			return err
+		}
+
 		return nil
 	case database.MEMORY.String():
 		return nil
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -97,6 +98,33 @@ func (_m *RelationshipReader) GetUniqueEntityIDsByEntityType(ctx context.Context
 	return r0, r1
 }
 
+// ReadEntityIDs - Reads the ids of the entities of the type from the repository, up to limit of them after the given id.
+func (_m *RelationshipReader) ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) ([]string, error) {
+	ret := _m.Called(tenantID, entityType, snap, after, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) []string); ok {
+		r0 = rf(ctx, tenantID, entityType, snap, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, int) error); ok {
+		r1 = rf(ctx, tenantID, entityType, snap, after, limit)
+	} else {
+		if e, ok := ret.Get(1).(error); ok {
+			r1 = e
+		} else {
+			r1 = nil
+		}
+	}
+
+	return r0, r1
+}
+
 // HeadSnapshot - Reads the latest version of the snapshot from the repository.
 func (_m *RelationshipReader) HeadSnapshot(ctx context.Context, tenantID string) (token.SnapToken, error) {
 	ret := _m.Called(tenantID)
@@ -121,3 +149,28 @@ func (_m *RelationshipReader) HeadSnapshot(ctx context.Context, tenantID string)
 
 	return r0, r1
 }
+
+// SnapshotAt - Reads the latest version of the snapshot committed at or before the given time from the repository.
+func (_m *RelationshipReader) SnapshotAt(ctx context.Context, tenantID string, timestamp time.Time) (token.SnapToken, error) {
+	ret := _m.Called(tenantID, timestamp)
+
+	var r0 token.SnapToken
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) token.SnapToken); ok {
+		r0 = rf(ctx, tenantID, timestamp)
+	} else {
+		r0 = ret.Get(0).(token.SnapToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, timestamp)
+	} else {
+		if e, ok := ret.Get(1).(error); ok {
+			r1 = e
+		} else {
+			r1 = nil
+		}
+	}
+
+	return r0, r1
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/Permify/permify/internal/storage"
 	"github.com/Permify/permify/pkg/database"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 	"github.com/Permify/permify/pkg/token"
@@ -64,3 +65,60 @@ func (_m *SchemaReader) DeleteRelationships(ctx context.Context, tenantID string
 
 	return r0, r1
 }
+
+// BulkImport - Import relation tuples to repository
+func (_m *RelationshipWriter) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	ret := _m.Called(tenantID, batches)
+
+	var r0 token.EncodedSnapToken
+	if rf, ok := ret.Get(0).(func(context.Context, string, storage.TupleBatches) token.EncodedSnapToken); ok {
+		r0 = rf(ctx, tenantID, batches)
+	} else {
+		r0 = ret.Get(0).(token.EncodedSnapToken)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, storage.TupleBatches) int64); ok {
+		r1 = rf(ctx, tenantID, batches)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, storage.TupleBatches) error); ok {
+		r2 = rf(ctx, tenantID, batches)
+	} else {
+		if e, ok := ret.Get(2).(error); ok {
+			r2 = e
+		} else {
+			r2 = nil
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// TransactRelationships - Apply operations to the relation tuples of repository
+func (_m *RelationshipWriter) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	ret := _m.Called(tenantID, preconditions, operations)
+
+	var r0 token.EncodedSnapToken
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*base.TuplePrecondition, []*base.TupleOperation) token.EncodedSnapToken); ok {
+		r0 = rf(ctx, tenantID, preconditions, operations)
+	} else {
+		r0 = ret.Get(0).(token.EncodedSnapToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*base.TuplePrecondition, []*base.TupleOperation) error); ok {
+		r1 = rf(ctx, tenantID, preconditions, operations)
+	} else {
+		if e, ok := ret.Get(1).(error); ok {
+			r1 = e
+		} else {
+			r1 = nil
+		}
+	}
+
+	return r0, r1
+}
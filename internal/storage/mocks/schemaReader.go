@@ -1,9 +1,12 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/net/context"
 
+	"github.com/Permify/permify/internal/storage"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
@@ -93,3 +96,55 @@ func (_m *SchemaReader) HeadVersion(ctx context.Context, tenantID string) (versi
 
 	return r0, r1
 }
+
+// VersionAt - Reads the latest version written at or before the given time from the repository.
+func (_m *SchemaReader) VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (version string, err error) {
+	ret := _m.Called(tenantID, timestamp)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) string); ok {
+		r0 = rf(ctx, tenantID, timestamp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, timestamp)
+	} else {
+		if e, ok := ret.Get(1).(error); ok {
+			r1 = e
+		} else {
+			r1 = nil
+		}
+	}
+
+	return r0, r1
+}
+
+// ListSchemaDefinitions - Reads the definitions of all the versions of the schema from the repository.
+func (_m *SchemaReader) ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []storage.SchemaDefinition, err error) {
+	ret := _m.Called(tenantID)
+
+	var r0 []storage.SchemaDefinition
+	if rf, ok := ret.Get(0).(func(context.Context, string) []storage.SchemaDefinition); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.SchemaDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		if e, ok := ret.Get(1).(error); ok {
+			r1 = e
+		} else {
+			r1 = nil
+		}
+	}
+
+	return r0, r1
+}
@@ -18,11 +18,17 @@ type RelationTuple struct {
 	SubjectType     string
 	SubjectID       string
 	SubjectRelation string
+	// ExpiresAt is the time the relation tuple expires at, it never expires if it is zero
+	ExpiresAt time.Time
+	// CreatedTxID and ExpiredTxID are the transactions that created and deleted the relation tuple, ExpiredTxID is
+	// zero while it is not deleted. They are only set by the memory storage, the sql storages keep them in columns.
+	CreatedTxID uint64
+	ExpiredTxID uint64
 }
 
 // ToTuple - Convert database relation tuple to base relation tuple
 func (r RelationTuple) ToTuple() *base.Tuple {
-	return &base.Tuple{
+	t := &base.Tuple{
 		Entity: &base.Entity{
 			Type: r.EntityType,
 			Id:   r.EntityID,
@@ -34,6 +40,15 @@ func (r RelationTuple) ToTuple() *base.Tuple {
 			Relation: r.SubjectRelation,
 		},
 	}
+	if !r.ExpiresAt.IsZero() {
+		t.ExpiresAt = timestamppb.New(r.ExpiresAt)
+	}
+	return t
+}
+
+// IsExpired - Checks whether the relation tuple has expired at the given time
+func (r RelationTuple) IsExpired(t time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !r.ExpiresAt.After(t)
 }
 
 // SchemaDefinition - Structure for Schema Definition
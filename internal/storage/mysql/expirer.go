@@ -0,0 +1,26 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Permify/permify/internal/storage/mysql/utils"
+	"github.com/Permify/permify/internal/storage/relational"
+	db "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// newExpirer - Creates the expirer of the relationships of the tenants. The expirations are recorded like the writes
+// are, after the row of the tenant is locked.
+func newExpirer(database *db.MySQL, logger logger.Interface) *relational.Expirer {
+	return &relational.Expirer{
+		DB:      database.DB,
+		Builder: database.Builder,
+		Dialect: utils.Dialect{},
+		Begin: func(ctx context.Context, tx *sql.Tx, tenantID string) (uint64, error) {
+			return beginTransaction(ctx, database, tx, tenantID)
+		},
+		TxOptions: sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: false},
+		Logger:    logger,
+	}
+}
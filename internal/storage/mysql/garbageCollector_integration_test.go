@@ -0,0 +1,83 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+func TestGarbageCollector_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mysql := db.(*MYDatabase.MySQL)
+
+	_, err = NewTenantWriter(mysql, l).CreateTenant(ctx, "gc", "Garbage Collection")
+	require.NoError(t, err)
+
+	writer := NewRelationshipWriter(mysql, l)
+	reader := NewRelationshipReader(mysql, l)
+
+	tup, err := tuple.Tuple("organization:1#member@user:1")
+	require.NoError(t, err)
+	tup.ExpiresAt = timestamppb.New(time.Now().Add(time.Second))
+	written, err := writer.WriteRelationships(ctx, "gc", database.NewTupleCollection(tup))
+	require.NoError(t, err)
+
+	// Nothing is expired or collected before the relationship expires
+	gc := NewGarbageCollector(ctx, mysql, l, config.DatabaseGarbageCollection{Window: -time.Minute})
+	require.NoError(t, gc.Collect(ctx, "gc"))
+
+	head, err := reader.HeadSnapshot(ctx, "gc")
+	require.NoError(t, err)
+	assert.Equal(t, written.String(), head.Encode().String())
+	assert.Equal(t, 1, count(t, mysql, "gc"))
+
+	time.Sleep(time.Until(tup.GetExpiresAt().AsTime()))
+
+	// The expiration is recorded in a transaction of its own, and the expired relationship is kept within the window
+	gc = NewGarbageCollector(ctx, mysql, l, config.DatabaseGarbageCollection{Window: time.Hour})
+	require.NoError(t, gc.Collect(ctx, "gc"))
+
+	head, err = reader.HeadSnapshot(ctx, "gc")
+	require.NoError(t, err)
+	assert.NotEqual(t, written.String(), head.Encode().String())
+	assert.Equal(t, 1, count(t, mysql, "gc"))
+
+	// The relationship is deleted once it was expired before the window
+	gc = NewGarbageCollector(ctx, mysql, l, config.DatabaseGarbageCollection{Window: -time.Minute})
+	require.NoError(t, gc.Collect(ctx, "gc"))
+	assert.Equal(t, 0, count(t, mysql, "gc"))
+}
+
+// count returns the number of rows of the relation tuples table of the tenant, whether they are expired or not.
+func count(t *testing.T, db *MYDatabase.MySQL, tenantID string) (n int) {
+	require.NoError(t, db.DB.QueryRow("SELECT COUNT(*) FROM "+RelationTuplesTable+" WHERE tenant_id = ?", tenantID).Scan(&n))
+	return n
+}
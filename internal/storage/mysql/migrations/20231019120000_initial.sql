@@ -0,0 +1,52 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenants (
+    id         VARCHAR(128) NOT NULL,
+    name       VARCHAR(255) NOT NULL,
+    created_at DATETIME(6)  NOT NULL DEFAULT (UTC_TIMESTAMP(6)),
+    CONSTRAINT pk_tenants PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+INSERT INTO tenants (id, name) VALUES ('t1', 'example tenant');
+
+-- Transaction ids are handed out by AUTO_INCREMENT, writers of a tenant lock its row in the tenants table
+-- first, so the ids of a tenant are assigned in commit order and can be used as snapshots.
+CREATE TABLE IF NOT EXISTS transactions (
+    id        BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    tenant_id VARCHAR(128)    NOT NULL,
+    timestamp DATETIME(6)     NOT NULL DEFAULT (UTC_TIMESTAMP(6)),
+    CONSTRAINT pk_transaction PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE INDEX idx_transactions_tenant ON transactions (tenant_id, timestamp);
+
+CREATE TABLE IF NOT EXISTS relation_tuples (
+    id               BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    tenant_id        VARCHAR(128)    NOT NULL,
+    entity_type      VARCHAR(64)     NOT NULL,
+    entity_id        VARCHAR(128)    NOT NULL,
+    relation         VARCHAR(64)     NOT NULL,
+    subject_type     VARCHAR(64)     NOT NULL,
+    subject_id       VARCHAR(128)    NOT NULL,
+    subject_relation VARCHAR(64)     NOT NULL,
+    created_tx_id    BIGINT UNSIGNED NOT NULL,
+    expired_tx_id    BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    CONSTRAINT pk_relation_tuple PRIMARY KEY (id),
+    CONSTRAINT uq_relation_tuple_not_expired UNIQUE (tenant_id, entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expired_tx_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+CREATE INDEX idx_tuples_subject ON relation_tuples (tenant_id, subject_type, subject_id, subject_relation, entity_type, relation);
+CREATE INDEX idx_tuples_entity ON relation_tuples (tenant_id, entity_type, entity_id, relation);
+
+CREATE TABLE IF NOT EXISTS schema_definitions (
+    tenant_id             VARCHAR(128) NOT NULL,
+    entity_type           VARCHAR(64)  NOT NULL,
+    serialized_definition BLOB         NOT NULL,
+    version               CHAR(20)     NOT NULL,
+    CONSTRAINT pk_schema_definition PRIMARY KEY (tenant_id, entity_type, version)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_bin;
+
+-- +goose Down
+DROP TABLE IF EXISTS schema_definitions;
+DROP TABLE IF EXISTS relation_tuples;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX idx_tuples_created_tx ON relation_tuples (tenant_id, created_tx_id);
+CREATE INDEX idx_tuples_expired_tx ON relation_tuples (tenant_id, expired_tx_id);
+
+-- +goose Down
+DROP INDEX idx_tuples_created_tx ON relation_tuples;
+DROP INDEX idx_tuples_expired_tx ON relation_tuples;
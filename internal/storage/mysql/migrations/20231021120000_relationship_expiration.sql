@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE relation_tuples ADD COLUMN expires_at DATETIME(6) NULL;
+
+CREATE INDEX idx_tuples_expires_at ON relation_tuples (tenant_id, expires_at);
+
+-- +goose Down
+DROP INDEX idx_tuples_expires_at ON relation_tuples;
+
+ALTER TABLE relation_tuples DROP COLUMN expires_at;
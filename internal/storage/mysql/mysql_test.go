@@ -0,0 +1,80 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	mysqlContainer testcontainers.Container
+	mysqlDSN       string
+	cfg            config.Database
+)
+
+func TestMain(m *testing.M) {
+	mysqlDSN = GetMySQL()
+
+	exitCode := m.Run()
+
+	TeardownMySQL()
+
+	os.Exit(exitCode)
+}
+
+func GetMySQL() string {
+	ctx := context.Background()
+	req := testcontainers.ContainerRequest{
+		Image:        "mysql:8",
+		ExposedPorts: []string{"3306/tcp"},
+		WaitingFor:   wait.ForLog("port: 3306  MySQL Community Server"),
+		Env:          map[string]string{"MYSQL_ROOT_PASSWORD": "mysql", "MYSQL_DATABASE": "permify"},
+	}
+
+	var err error
+	mysqlContainer, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		fmt.Println("Error starting MySQL container:", err)
+		os.Exit(1)
+	}
+
+	host, err := mysqlContainer.Host(ctx)
+	if err != nil {
+		fmt.Println("Error getting MySQL container host:", err)
+		os.Exit(1)
+	}
+
+	port, err := mysqlContainer.MappedPort(ctx, "3306")
+	if err != nil {
+		fmt.Println("Error getting MySQL container port:", err)
+		os.Exit(1)
+	}
+	mysqlDSN = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", "root", "mysql", host, port.Port(), "permify")
+	cfg = config.Database{
+		Engine:                "mysql",
+		URI:                   mysqlDSN,
+		AutoMigrate:           true,
+		MaxOpenConnections:    20,
+		MaxIdleConnections:    1,
+		MaxConnectionLifetime: 300,
+		MaxConnectionIdleTime: 60,
+	}
+
+	return mysqlDSN
+}
+
+func TeardownMySQL() {
+	if mysqlContainer != nil {
+		mysqlContainer.Terminate(context.Background())
+	}
+}
@@ -0,0 +1,118 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage/mysql/snapshot"
+	"github.com/Permify/permify/internal/storage/relational"
+	"github.com/Permify/permify/pkg/database"
+	MYRepository "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func newRelationshipReader(t *testing.T) (*RelationshipReader, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	return NewRelationshipReader(my, logger.New("debug")), mock
+}
+
+func TestRelationshipReader_QueryRelationships(t *testing.T) {
+	reader, mock := newRelationshipReader(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at FROM relation_tuples WHERE tenant_id = ? AND entity_id IN (?) AND entity_type = ? AND relation = ? AND created_tx_id <= ? AND (expired_tx_id = ? OR expired_tx_id > ?) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = ? AND id > ?), UTC_TIMESTAMP(6)))")).
+		WithArgs("t1", "abc", "organization", "admin", uint64(4), 0, uint64(4), "t1", uint64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at"}).
+			AddRow("organization", "abc", "admin", "user", "jack", "", nil).
+			AddRow("organization", "abc", "admin", "user", "john", "", nil))
+	mock.ExpectCommit()
+
+	it, err := reader.QueryRelationships(context.Background(), "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"abc"}},
+		Relation: "admin",
+	}, snapshot.NewToken(4).Encode().String())
+	require.NoError(t, err)
+
+	var subjects []string
+	for it.HasNext() {
+		subjects = append(subjects, it.GetNext().GetSubject().GetId())
+	}
+	assert.Equal(t, []string{"jack", "john"}, subjects)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipReader_ReadRelationships(t *testing.T) {
+	reader, mock := newRelationshipReader(t)
+
+	columns := []string{"id", "entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at FROM relation_tuples WHERE tenant_id = ? AND entity_type = ? AND created_tx_id <= ? AND (expired_tx_id = ? OR expired_tx_id > ?) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = ? AND id > ?), UTC_TIMESTAMP(6))) ORDER BY id LIMIT 2")).
+		WithArgs("t1", "organization", uint64(4), 0, uint64(4), "t1", uint64(4)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "organization", "abc", "admin", "user", "jack", "", nil).
+			AddRow(2, "organization", "abc", "admin", "user", "john", "", nil))
+	mock.ExpectCommit()
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization"}}
+
+	collection, ct, err := reader.ReadRelationships(context.Background(), "t1", filter, snapshot.NewToken(4).Encode().String(), database.NewPagination(database.Size(1)))
+	require.NoError(t, err)
+	require.Len(t, collection.GetTuples(), 1)
+	assert.Equal(t, "jack", collection.GetTuples()[0].GetSubject().GetId())
+	assert.Equal(t, relational.NewContinuousToken(strconv.FormatUint(2, 10)).Encode(), ct)
+
+	// The next page starts at the id of the continuous token
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at FROM relation_tuples WHERE tenant_id = ? AND entity_type = ? AND created_tx_id <= ? AND (expired_tx_id = ? OR expired_tx_id > ?) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = ? AND id > ?), UTC_TIMESTAMP(6))) AND id >= ? ORDER BY id LIMIT 2")).
+		WithArgs("t1", "organization", uint64(4), 0, uint64(4), "t1", uint64(4), uint64(2)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(2, "organization", "abc", "admin", "user", "john", "", nil))
+	mock.ExpectCommit()
+
+	collection, ct, err = reader.ReadRelationships(context.Background(), "t1", filter, snapshot.NewToken(4).Encode().String(), database.NewPagination(database.Size(1), database.Token(ct.String())))
+	require.NoError(t, err)
+	require.Len(t, collection.GetTuples(), 1)
+	assert.Equal(t, "john", collection.GetTuples()[0].GetSubject().GetId())
+	assert.Equal(t, relational.NewNoopContinuousToken().Encode(), ct)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipReader_HeadSnapshot(t *testing.T) {
+	reader, mock := newRelationshipReader(t)
+
+	query := regexp.QuoteMeta("SELECT id FROM transactions WHERE tenant_id = ? ORDER BY id DESC LIMIT 1")
+
+	mock.ExpectQuery(query).WithArgs("t1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+	st, err := reader.HeadSnapshot(context.Background(), "t1")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(12), st)
+
+	// A tenant without transactions is at the zero snapshot
+	mock.ExpectQuery(query).WithArgs("t2").WillReturnError(sql.ErrNoRows)
+
+	st, err = reader.HeadSnapshot(context.Background(), "t2")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(0), st)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
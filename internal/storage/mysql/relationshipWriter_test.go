@@ -0,0 +1,289 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/mysql/snapshot"
+	"github.com/Permify/permify/pkg/database"
+	MYRepository "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+const (
+	lockTenantQuery        = "SELECT id FROM tenants WHERE id = ? FOR UPDATE"
+	insertTransactionQuery = "INSERT INTO transactions (tenant_id) VALUES (?)"
+	expireWrittenQuery     = "UPDATE relation_tuples SET expired_tx_id = ? WHERE expired_tx_id = ? AND tenant_id = ? AND expires_at <= UTC_TIMESTAMP(6) AND (entity_id = ? AND entity_type = ? AND relation = ? AND subject_id = ? AND subject_relation = ? AND subject_type = ? OR entity_id = ? AND entity_type = ? AND relation = ? AND subject_id = ? AND subject_relation = ? AND subject_type = ?)"
+)
+
+func newRelationshipWriter(t *testing.T) (*RelationshipWriter, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	return NewRelationshipWriter(my, logger.New("debug")), mock
+}
+
+func TestRelationshipWriter_WriteRelationships(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+	expiresAt := time.Date(2023, 10, 21, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(regexp.QuoteMeta(expireWrittenQuery)).
+		WithArgs(uint64(7), 0, "t1", "abc", "organization", "admin", "jack", "", "user", "abc", "organization", "member", "john", "", "user").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at) VALUES (?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?)")).
+		WithArgs("organization", "abc", "admin", "user", "jack", "", "t1", uint64(7), nil, "organization", "abc", "member", "user", "john", "", "t1", uint64(7), expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	collection := database.NewTupleCollection(&base.Tuple{
+		Entity:   &base.Entity{Type: "organization", Id: "abc"},
+		Relation: "admin",
+		Subject:  &base.Subject{Type: "user", Id: "jack"},
+	}, &base.Tuple{
+		Entity:    &base.Entity{Type: "organization", Id: "abc"},
+		Relation:  "member",
+		Subject:   &base.Subject{Type: "user", Id: "john"},
+		ExpiresAt: timestamppb.New(expiresAt),
+	})
+
+	token, err := writer.WriteRelationships(context.Background(), "t1", collection)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(7).Encode(), token)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipWriter_WriteRelationshipsRetriesDeadlocks(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	collection := database.NewTupleCollection(&base.Tuple{
+		Entity:   &base.Entity{Type: "organization", Id: "abc"},
+		Relation: "admin",
+		Subject:  &base.Subject{Type: "user", Id: "jack"},
+	})
+
+	token, err := writer.WriteRelationships(context.Background(), "t1", collection)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(8).Encode(), token)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipWriter_WriteRelationshipsErrors(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+
+	collection := database.NewTupleCollection(&base.Tuple{
+		Entity:   &base.Entity{Type: "organization", Id: "abc"},
+		Relation: "admin",
+		Subject:  &base.Subject{Type: "user", Id: "jack"},
+	})
+
+	// A tenant that does not exist cannot be written to
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t2").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := writer.WriteRelationships(context.Background(), "t2", collection)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_TENANT_NOT_FOUND.String())
+
+	// A relationship that already exists is a unique constraint violation
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	mock.ExpectRollback()
+
+	_, err = writer.WriteRelationships(context.Background(), "t1", collection)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipWriter_DeleteRelationships(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ? WHERE expired_tx_id = ? AND tenant_id = ? AND entity_id IN (?) AND entity_type = ? AND relation = ?")).
+		WithArgs(uint64(10), 0, "t1", "abc", "organization", "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	token, err := writer.DeleteRelationships(context.Background(), "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"abc"}},
+		Relation: "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(10).Encode(), token)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipWriter_BulkImport(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TEMPORARY TABLE relation_tuples_import")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples_import (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs("organization", "abc", "admin", "user", "jack", "", nil, "organization", "abc", "member", "user", "john", "", nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples_import (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs("organization", "abc", "member", "user", "jane", "", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ? WHERE expired_tx_id = ? AND tenant_id = ? AND expires_at <= UTC_TIMESTAMP(6) AND (entity_type, entity_id, relation, subject_type, subject_id, subject_relation) IN (SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation FROM relation_tuples_import)")).
+		WithArgs(uint64(9), 0, "t1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at) SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, ?, ?, expires_at FROM relation_tuples_import")).
+		WithArgs("t1", uint64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TEMPORARY TABLE IF EXISTS relation_tuples_import")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	batches := []*database.TupleCollection{
+		database.NewTupleCollection(&base.Tuple{
+			Entity:   &base.Entity{Type: "organization", Id: "abc"},
+			Relation: "admin",
+			Subject:  &base.Subject{Type: "user", Id: "jack"},
+		}, &base.Tuple{
+			Entity:   &base.Entity{Type: "organization", Id: "abc"},
+			Relation: "member",
+			Subject:  &base.Subject{Type: "user", Id: "john"},
+		}),
+		database.NewTupleCollection(&base.Tuple{
+			Entity:   &base.Entity{Type: "organization", Id: "abc"},
+			Relation: "member",
+			Subject:  &base.Subject{Type: "user", Id: "jane"},
+		}),
+	}
+
+	token, imported, err := writer.BulkImport(context.Background(), "t1", func() (*database.TupleCollection, error) {
+		if len(batches) == 0 {
+			return nil, io.EOF
+		}
+		batch := batches[0]
+		batches = batches[1:]
+		return batch, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(9).Encode(), token)
+	// The relation tuple that was already written is ignored by the insert
+	assert.Equal(t, int64(2), imported)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelationshipWriter_TransactRelationships(t *testing.T) {
+	writer, mock := newRelationshipWriter(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM relation_tuples WHERE expired_tx_id = ? AND tenant_id = ? AND entity_id IN (?) AND entity_type = ? AND relation = ? AND subject_id IN (?) AND subject_type = ? AND (expires_at IS NULL OR expires_at > UTC_TIMESTAMP(6)) LIMIT 1")).
+		WithArgs(0, "t1", "1", "document", "parent", "a", "folder").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ? WHERE expired_tx_id = ? AND tenant_id = ? AND (entity_id = ? AND entity_type = ? AND relation = ? AND subject_id = ? AND subject_relation = ? AND subject_type = ?)")).
+		WithArgs(uint64(10), 0, "t1", "1", "document", "parent", "a", "...", "folder").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE relation_tuples SET expired_tx_id = ? WHERE expired_tx_id = ? AND tenant_id = ? AND expires_at <= UTC_TIMESTAMP(6) AND (entity_id = ? AND entity_type = ? AND relation = ? AND subject_id = ? AND subject_relation = ? AND subject_type = ?)")).
+		WithArgs(uint64(10), 0, "t1", "1", "document", "parent", "b", "...", "folder").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at) VALUES (?,?,?,?,?,?,?,?,?)")).
+		WithArgs("document", "1", "parent", "folder", "b", "...", "t1", uint64(10), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	preconditions := []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_MATCH,
+		Filter: &base.TupleFilter{
+			Entity:   &base.EntityFilter{Type: "document", Ids: []string{"1"}},
+			Relation: "parent",
+			Subject:  &base.SubjectFilter{Type: "folder", Ids: []string{"a"}},
+		},
+	}}
+	operations := []*base.TupleOperation{{
+		Operation: base.TupleOperation_OPERATION_DELETE,
+		Tuple: &base.Tuple{
+			Entity:   &base.Entity{Type: "document", Id: "1"},
+			Relation: "parent",
+			Subject:  &base.Subject{Type: "folder", Id: "a", Relation: "..."},
+		},
+	}, {
+		Operation: base.TupleOperation_OPERATION_CREATE,
+		Tuple: &base.Tuple{
+			Entity:   &base.Entity{Type: "document", Id: "1"},
+			Relation: "parent",
+			Subject:  &base.Subject{Type: "folder", Id: "b", Relation: "..."},
+		},
+	}}
+
+	token, err := writer.TransactRelationships(context.Background(), "t1", preconditions, operations)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(10).Encode(), token)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The transaction is rolled back when a precondition does not hold
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockTenantQuery)).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	mock.ExpectExec(regexp.QuoteMeta(insertTransactionQuery)).WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(11, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM relation_tuples")).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectRollback()
+
+	_, err = writer.TransactRelationships(context.Background(), "t1", preconditions, operations)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
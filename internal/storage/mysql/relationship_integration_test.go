@@ -0,0 +1,109 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+func TestRelationships_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = NewTenantWriter(db.(*MYDatabase.MySQL), l).CreateTenant(ctx, "relationships", "Relationships")
+	require.NoError(t, err)
+
+	writer := NewRelationshipWriter(db.(*MYDatabase.MySQL), l)
+	reader := NewRelationshipReader(db.(*MYDatabase.MySQL), l)
+
+	collection := database.NewTupleCollection()
+	for _, s := range []string{"organization:1#admin@user:1", "organization:1#member@user:2"} {
+		tup, err := tuple.Tuple(s)
+		require.NoError(t, err)
+		collection.Add(tup)
+	}
+
+	written, err := writer.WriteRelationships(ctx, "relationships", collection)
+	require.NoError(t, err)
+
+	// Writing a relationship that exists violates the unique constraint
+	_, err = writer.WriteRelationships(ctx, "relationships", collection)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}}
+
+	deleted, err := writer.DeleteRelationships(ctx, "relationships", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+	})
+	require.NoError(t, err)
+
+	head, err := reader.HeadSnapshot(ctx, "relationships")
+	require.NoError(t, err)
+	assert.Equal(t, deleted.String(), head.Encode().String())
+
+	// The relationships are read as of the snapshot they are asked at
+	it, err := reader.QueryRelationships(ctx, "relationships", filter, written.String())
+	require.NoError(t, err)
+	assert.Len(t, relations(it), 2)
+
+	it, err = reader.QueryRelationships(ctx, "relationships", filter, deleted.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, relations(it))
+
+	// A deleted relationship can be written again
+	tup, err := tuple.Tuple("organization:1#member@user:2")
+	require.NoError(t, err)
+	_, err = writer.WriteRelationships(ctx, "relationships", database.NewTupleCollection(tup))
+	require.NoError(t, err)
+
+	// Relationships cannot be written to tenants that do not exist
+	_, err = writer.WriteRelationships(ctx, "unknown", collection)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_TENANT_NOT_FOUND.String())
+
+	// The garbage collector removes the relationships that were expired before the window
+	gc := NewGarbageCollector(ctx, db.(*MYDatabase.MySQL), l, config.DatabaseGarbageCollection{Window: -time.Minute})
+	require.NoError(t, gc.Collect(ctx, "relationships"))
+
+	it, err = reader.QueryRelationships(ctx, "relationships", filter, deleted.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, relations(it))
+
+	collection, _, err = reader.ReadRelationships(ctx, "relationships", filter, written.String(), database.NewPagination(database.Size(10)))
+	require.NoError(t, err)
+	assert.Len(t, collection.GetTuples(), 1)
+}
+
+// relations returns the relations of the tuples of the iterator.
+func relations(it *database.TupleIterator) (relations []string) {
+	for it.HasNext() {
+		relations = append(relations, it.GetNext().GetRelation())
+	}
+	return relations
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+func TestSchemaReaderHeadVersion_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+		MYDatabase.MaxConnectionIdleTime(cfg.MaxConnectionIdleTime),
+		MYDatabase.MaxConnectionLifeTime(cfg.MaxConnectionLifetime),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	schemaWriter := NewSchemaWriter(db.(*MYDatabase.MySQL), l)
+	schemaReader := NewSchemaReader(db.(*MYDatabase.MySQL), l)
+
+	v := xid.New().String()
+	schemas := []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: v},
+	}
+
+	err = schemaWriter.WriteSchema(ctx, schemas)
+	require.NoError(t, err)
+
+	version, err := schemaReader.HeadVersion(ctx, "t1")
+	require.NoError(t, err)
+	require.Equal(t, v, version)
+
+	definition, _, err := schemaReader.ReadSchemaDefinition(ctx, "t1", "user", v)
+	require.NoError(t, err)
+	require.Equal(t, "user", definition.GetName())
+}
@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	MYRepository "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+const userSchema = "entity user {}"
+
+func TestSchemaReader_HeadVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	log := logger.New("debug")
+
+	writer := NewSchemaWriter(my, log)
+	reader := NewSchemaReader(my, log)
+
+	ctx := context.Background()
+
+	version := xid.New().String()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_definitions (entity_type, serialized_definition, version, tenant_id) VALUES (?,?,?,?)")).
+		WithArgs("user", []byte(userSchema), version, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = writer.WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "1", EntityType: "user", SerializedDefinition: []byte(userSchema), Version: version},
+	})
+	require.NoError(t, err)
+
+	query := regexp.QuoteMeta("SELECT version FROM schema_definitions WHERE tenant_id = ? ORDER BY version DESC LIMIT 1")
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+
+	v, err := reader.HeadVersion(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, version, v)
+
+	mock.ExpectQuery(query).WithArgs("2").WillReturnError(sql.ErrNoRows)
+
+	_, err = reader.HeadVersion(ctx, "2")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaReader_ReadSchemaDefinition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	reader := NewSchemaReader(my, logger.New("debug"))
+
+	version := xid.New().String()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, serialized_definition, version FROM schema_definitions WHERE entity_type = ? AND tenant_id = ? AND version = ? LIMIT 1")).
+		WithArgs("user", "1", version).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "serialized_definition", "version"}).AddRow("user", []byte(userSchema), version))
+
+	definition, v, err := reader.ReadSchemaDefinition(context.Background(), "1", "user", version)
+	require.NoError(t, err)
+	require.Equal(t, "user", definition.GetName())
+	require.Equal(t, version, v)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaReader_ListSchemaDefinitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	reader := NewSchemaReader(my, logger.New("debug"))
+
+	first := xid.New().String()
+	second := xid.New().String()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, serialized_definition, version FROM schema_definitions WHERE tenant_id = ? ORDER BY version, entity_type")).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "serialized_definition", "version"}).
+			AddRow("user", []byte(userSchema), first).
+			AddRow("user", []byte(userSchema), second))
+
+	definitions, err := reader.ListSchemaDefinitions(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, []storage.SchemaDefinition{
+		{TenantID: "1", EntityType: "user", SerializedDefinition: []byte(userSchema), Version: first},
+		{TenantID: "1", EntityType: "user", SerializedDefinition: []byte(userSchema), Version: second},
+	}, definitions)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, serialized_definition, version FROM schema_definitions WHERE tenant_id = ? ORDER BY version, entity_type")).
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "serialized_definition", "version"}))
+
+	definitions, err = reader.ListSchemaDefinitions(context.Background(), "2")
+	require.NoError(t, err)
+	require.Empty(t, definitions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+func TestSchemaWriter_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+		MYDatabase.MaxConnectionIdleTime(cfg.MaxConnectionIdleTime),
+		MYDatabase.MaxConnectionLifeTime(cfg.MaxConnectionLifetime),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	schemaWriter := NewSchemaWriter(db.(*MYDatabase.MySQL), l)
+	schemaReader := NewSchemaReader(db.(*MYDatabase.MySQL), l)
+
+	schemas := []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "entity3", SerializedDefinition: []byte("entity entity3 {}"), Version: "v3"},
+	}
+	err = schemaWriter.WriteSchema(ctx, schemas)
+	require.NoError(t, err)
+
+	definitions, err := schemaReader.ListSchemaDefinitions(ctx, "t1")
+	require.NoError(t, err)
+	assert.Contains(t, definitions, schemas[0])
+}
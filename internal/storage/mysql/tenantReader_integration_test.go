@@ -0,0 +1,65 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+func TestTenantReader_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+		MYDatabase.MaxConnectionIdleTime(cfg.MaxConnectionIdleTime),
+		MYDatabase.MaxConnectionLifeTime(cfg.MaxConnectionLifetime),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	tenantWriter := NewTenantWriter(db.(*MYDatabase.MySQL), l)
+	tenantReader := NewTenantReader(db.(*MYDatabase.MySQL), l)
+
+	createdTenant, err := tenantWriter.CreateTenant(ctx, "2", "Test Tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "2", createdTenant.Id)
+	assert.Equal(t, "Test Tenant", createdTenant.Name)
+
+	tenants, _, err := tenantReader.ListTenants(ctx, database.NewPagination())
+	require.NoError(t, err)
+
+	names := map[string]string{}
+	for _, tenant := range tenants {
+		names[tenant.Id] = tenant.Name
+	}
+	assert.Equal(t, "example tenant", names["t1"])
+	assert.Equal(t, "Test Tenant", names["2"])
+
+	// The tenants are listed in pages ordered by their ids
+	page, ct, err := tenantReader.ListTenants(ctx, database.NewPagination(database.Size(1)))
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, tenants[0].Id, page[0].Id)
+
+	page, _, err = tenantReader.ListTenants(ctx, database.NewPagination(database.Size(1), database.Token(ct.String())))
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, tenants[1].Id, page[0].Id)
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/database"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+func TestTenantWriter(t *testing.T) {
+	ctx := context.Background()
+
+	l := logger.New("fatal")
+
+	err := storage.Migrate(cfg, l)
+	require.NoError(t, err)
+
+	var db database.Database
+	db, err = MYDatabase.New(cfg.URI,
+		MYDatabase.MaxOpenConnections(cfg.MaxOpenConnections),
+		MYDatabase.MaxIdleConnections(cfg.MaxIdleConnections),
+		MYDatabase.MaxConnectionIdleTime(cfg.MaxConnectionIdleTime),
+		MYDatabase.MaxConnectionLifeTime(cfg.MaxConnectionLifetime),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	tenantWriter := NewTenantWriter(db.(*MYDatabase.MySQL), l)
+
+	createdTenant, err := tenantWriter.CreateTenant(ctx, "4", "Test Tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "4", createdTenant.Id)
+	assert.Equal(t, "Test Tenant", createdTenant.Name)
+
+	deletedTenant, err := tenantWriter.DeleteTenant(ctx, "4")
+	require.NoError(t, err)
+	assert.Equal(t, "4", deletedTenant.Id)
+	assert.Equal(t, "Test Tenant", deletedTenant.Name)
+	assert.True(t, createdTenant.CreatedAt.AsTime().Equal(deletedTenant.CreatedAt.AsTime()))
+}
@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	MYRepository "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestTenantWriter_CreateTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	writer := NewTenantWriter(my, logger.New("debug"))
+
+	query := regexp.QuoteMeta("INSERT INTO tenants (id, name, created_at) VALUES (?,?,?)")
+
+	mock.ExpectExec(query).WithArgs("2", "tenant_1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tenant, err := writer.CreateTenant(context.Background(), "2", "tenant_1")
+	require.NoError(t, err)
+	assert.Equal(t, "2", tenant.Id)
+	assert.Equal(t, "tenant_1", tenant.Name)
+	assert.WithinDuration(t, time.Now(), tenant.CreatedAt.AsTime(), time.Minute)
+
+	mock.ExpectExec(query).WithArgs("2", "tenant_1", sqlmock.AnyArg()).WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	_, err = writer.CreateTenant(context.Background(), "2", "tenant_1")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantWriter_DeleteTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	my := &MYRepository.MySQL{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+
+	writer := NewTenantWriter(my, logger.New("debug"))
+
+	createdAt := time.Now().UTC()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, created_at FROM tenants WHERE id = ? FOR UPDATE")).WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "created_at"}).AddRow("tenant_1", createdAt))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM tenants WHERE id = ?")).WithArgs("2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tenant, err := writer.DeleteTenant(context.Background(), "2")
+	require.NoError(t, err)
+	assert.Equal(t, "2", tenant.Id)
+	assert.Equal(t, "tenant_1", tenant.Name)
+	assert.True(t, createdAt.Equal(tenant.CreatedAt.AsTime()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
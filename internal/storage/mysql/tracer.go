@@ -0,0 +1,7 @@
+package mysql
+
+import (
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("storage.mysql")
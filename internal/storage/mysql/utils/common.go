@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+const (
+	// errDuplicateEntry is the error number of a violated unique key
+	errDuplicateEntry = 1062
+	// errLockWaitTimeout is the error number of a lock wait that timed out
+	errLockWaitTimeout = 1205
+	// errLockDeadlock is the error number of a transaction that was rolled back to resolve a deadlock
+	errLockDeadlock = 1213
+)
+
+// SnapshotQuery - Filters the relationships that are visible at the given transaction. Transaction ids of a
+// tenant are assigned in commit order, so a relationship is visible if it was created at or before the
+// transaction and it was not expired at or before the transaction.
+func SnapshotQuery(sl squirrel.SelectBuilder, revision uint64) squirrel.SelectBuilder {
+	return sl.Where(squirrel.LtOrEq{"created_tx_id": revision}).Where(squirrel.Or{
+		squirrel.Eq{"expired_tx_id": 0},
+		squirrel.Gt{"expired_tx_id": revision},
+	})
+}
+
+// Dialect - The MySQL dialect of the queries shared by the SQL storage engines
+type Dialect struct{}
+
+// TxID - Returns the SQL of the transaction id
+func (Dialect) TxID(id uint64) squirrel.Sqlizer {
+	return squirrel.Expr("?", id)
+}
+
+// SnapshotQuery - Filters the relationships that are visible at the given transaction
+func (Dialect) SnapshotQuery(sl squirrel.SelectBuilder, id uint64) squirrel.SelectBuilder {
+	return SnapshotQuery(sl, id)
+}
+
+// Now - Returns the SQL of the current time, the timestamps are stored in UTC
+func (Dialect) Now() string {
+	return "UTC_TIMESTAMP(6)"
+}
+
+// Timestamp - Returns the value of the time for the timestamp columns, the timestamps are stored in UTC
+func (Dialect) Timestamp(t time.Time) interface{} {
+	return t.UTC()
+}
+
+// CommittedQuery - Returns the query as is, the writers of a tenant lock its row in the tenants table before they
+// insert their transaction, so the transactions of a tenant are visible in commit order
+func (Dialect) CommittedQuery(sl squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return sl
+}
+
+// Binary - Returns the SQL of the column to compare and order its values by their bytes,
+// the text columns have a binary collation
+func (Dialect) Binary(column string) string {
+	return column
+}
+
+// ExpiresAt - Returns the value of the expires_at column of the tuple, the timestamps are stored in UTC
+func ExpiresAt(t *base.Tuple) interface{} {
+	if t.GetExpiresAt() == nil {
+		return nil
+	}
+	return t.GetExpiresAt().AsTime().UTC()
+}
+
+// Rollback - Rollbacks a transaction and logs the error
+func Rollback(tx *sql.Tx, logger logger.Interface) {
+	if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) && err != nil {
+		logger.Error("failed to rollback transaction", err)
+	}
+}
+
+// IsRetryable - Reports whether the transaction failed because of a lock conflict and can be retried
+func IsRetryable(err error) bool {
+	var e *mysql.MySQLError
+	return errors.As(err, &e) && (e.Number == errLockDeadlock || e.Number == errLockWaitTimeout)
+}
+
+// IsDuplicate - Reports whether the statement failed because it violates a unique key
+func IsDuplicate(err error) bool {
+	var e *mysql.MySQLError
+	return errors.As(err, &e) && e.Number == errDuplicateEntry
+}
@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/mysql/utils"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestSnapshotQuery(t *testing.T) {
+	sl := squirrel.Select("column").From("table")
+	revision := uint64(42)
+
+	query := utils.SnapshotQuery(sl, revision)
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT column FROM table WHERE created_tx_id <= ? AND (expired_tx_id = ? OR expired_tx_id > ?)"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{revision, 0, revision}, args)
+}
+
+func TestErrors(t *testing.T) {
+	assert.True(t, utils.IsRetryable(&mysql.MySQLError{Number: 1213}))
+	assert.True(t, utils.IsRetryable(&mysql.MySQLError{Number: 1205}))
+	assert.False(t, utils.IsRetryable(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, utils.IsRetryable(errors.New("deadlock")))
+
+	assert.True(t, utils.IsDuplicate(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, utils.IsDuplicate(&mysql.MySQLError{Number: 1213}))
+}
+
+func TestDialect(t *testing.T) {
+	d := utils.Dialect{}
+
+	sql, args, err := squirrel.Expr("id = ?", d.TxID(42)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "id = ?", sql)
+	assert.Equal(t, []interface{}{uint64(42)}, args)
+
+	assert.Equal(t, "UTC_TIMESTAMP(6)", d.Now())
+
+	at := time.Date(2023, 10, 21, 12, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, time.Date(2023, 10, 21, 9, 30, 0, 0, time.UTC), d.Timestamp(at))
+}
+
+func TestExpiresAt(t *testing.T) {
+	assert.Nil(t, utils.ExpiresAt(&base.Tuple{}))
+
+	expiresAt := time.Date(2023, 10, 21, 12, 30, 0, 500000000, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, time.Date(2023, 10, 21, 9, 30, 0, 500000000, time.UTC), utils.ExpiresAt(&base.Tuple{ExpiresAt: timestamppb.New(expiresAt)}))
+}
@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/Permify/permify/internal/storage/mysql/snapshot"
+	"github.com/Permify/permify/internal/storage/mysql/utils"
+	"github.com/Permify/permify/internal/storage/relational"
+	db "github.com/Permify/permify/pkg/database/mysql"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// Watcher streams the changes to the relation tuples of a tenant. Writers of a tenant lock its row in the tenants
+// table before they insert their transaction, so the transaction IDs of a tenant are visible in commit order.
+type Watcher struct {
+	relational.Watcher
+}
+
+// NewWatcher creates a new instance of the Watcher struct with the given database and logger instances.
+func NewWatcher(database *db.MySQL, logger logger.Interface) *Watcher {
+	return &Watcher{
+		Watcher: relational.Watcher{
+			DB:           database.DB,
+			Builder:      database.Builder,
+			Dialect:      utils.Dialect{},
+			TxOptions:    sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true},
+			Window:       database.GetGarbageCollectionWindow(),
+			BatchSize:    _defaultWatchBatchSize,
+			PollInterval: _defaultWatchPollInterval,
+			Expirer:      newExpirer(database, logger),
+			Decode: func(snap string) (uint64, error) {
+				st, err := snapshot.EncodedToken{Value: snap}.Decode()
+				if err != nil {
+					return 0, err
+				}
+				return st.(snapshot.Token).Value, nil
+			},
+			Encode: func(id uint64) string {
+				return snapshot.Token{Value: id}.Encode().String()
+			},
+			Logger: logger,
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Permify/permify/internal/storage/postgres/types"
+	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
+	db "github.com/Permify/permify/pkg/database/postgres"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// newExpirer - Creates the expirer of the relationships of the tenants. The transaction of the expirations is the
+// current transaction, which is recorded before it expires anything.
+func newExpirer(database *db.Postgres, logger logger.Interface) *relational.Expirer {
+	return &relational.Expirer{
+		DB:      database.DB,
+		Builder: database.Builder,
+		Dialect: utils.Dialect{},
+		Begin: func(ctx context.Context, tx *sql.Tx, tenantID string) (uint64, error) {
+			var xid types.XID8
+			err := database.Builder.Insert(TransactionsTable).
+				Columns("tenant_id").
+				Values(tenantID).
+				Suffix("RETURNING id").RunWith(tx).QueryRowContext(ctx).Scan(&xid)
+			return xid.Uint, err
+		},
+		TxOptions: sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: false},
+		Logger:    logger,
+	}
+}
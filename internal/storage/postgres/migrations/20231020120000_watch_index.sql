@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_tuples_created_tx ON relation_tuples (tenant_id, created_tx_id);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_tuples_expired_tx ON relation_tuples (tenant_id, expired_tx_id);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_transactions_tenant ON transactions (tenant_id, id);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_tuples_created_tx;
+DROP INDEX CONCURRENTLY IF EXISTS idx_tuples_expired_tx;
+DROP INDEX CONCURRENTLY IF EXISTS idx_transactions_tenant;
@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TABLE relation_tuples ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NULL;
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_tuples_expires_at ON relation_tuples (tenant_id, expires_at) WHERE expires_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_tuples_expires_at;
+
+ALTER TABLE relation_tuples DROP COLUMN IF EXISTS expires_at;
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/Permify/permify/internal/storage/postgres/snapshot"
 	"github.com/Permify/permify/internal/storage/postgres/types"
 	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
 	"github.com/Permify/permify/pkg/database"
 	db "github.com/Permify/permify/pkg/database/postgres"
 	"github.com/Permify/permify/pkg/logger"
@@ -102,9 +104,7 @@ func (r *RelationshipReader) QueryRelationships(ctx context.Context, tenantID st
 
 	// Build the relationships query based on the provided filter and snapshot value.
 	var args []interface{}
-	builder := r.database.Builder.Select("entity_type, entity_id, relation, subject_type, subject_id, subject_relation").From(RelationTuplesTable).Where(squirrel.Eq{"tenant_id": tenantID})
-	builder = utils.FilterQueryForSelectBuilder(builder, filter)
-	builder = utils.SnapshotQuery(builder, st.(snapshot.Token).Value.Uint)
+	builder := relational.RelationTuplesQuery(r.database.Builder, utils.Dialect{}, relational.TupleColumns, tenantID, filter, st.(snapshot.Token).Value.Uint)
 
 	// Generate the SQL query and arguments.
 	var query string
@@ -130,12 +130,14 @@ func (r *RelationshipReader) QueryRelationships(ctx context.Context, tenantID st
 	collection := database.NewTupleCollection()
 	for rows.Next() {
 		rt := storage.RelationTuple{}
-		err = rows.Scan(&rt.EntityType, &rt.EntityID, &rt.Relation, &rt.SubjectType, &rt.SubjectID, &rt.SubjectRelation)
+		var expiresAt sql.NullTime
+		err = rows.Scan(&rt.EntityType, &rt.EntityID, &rt.Relation, &rt.SubjectType, &rt.SubjectID, &rt.SubjectRelation, &expiresAt)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, err
 		}
+		rt.ExpiresAt = expiresAt.Time
 		collection.Add(rt.ToTuple())
 	}
 	if err = rows.Err(); err != nil {
@@ -199,21 +201,19 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 	defer utils.Rollback(tx, r.logger)
 
 	// Build the relationships query based on the provided filter, snapshot value, and pagination settings.
-	builder := r.database.Builder.Select("id, entity_type, entity_id, relation, subject_type, subject_id, subject_relation").From(RelationTuplesTable).Where(squirrel.Eq{"tenant_id": tenantID})
-	builder = utils.FilterQueryForSelectBuilder(builder, filter)
-	builder = utils.SnapshotQuery(builder, st.(snapshot.Token).Value.Uint)
+	builder := relational.RelationTuplesQuery(r.database.Builder, utils.Dialect{}, "id, "+relational.TupleColumns, tenantID, filter, st.(snapshot.Token).Value.Uint)
 
 	// Apply the pagination token and limit to the query.
 	if pagination.Token() != "" {
 		var t database.ContinuousToken
-		t, err = utils.EncodedContinuousToken{Value: pagination.Token()}.Decode()
+		t, err = relational.EncodedContinuousToken{Value: pagination.Token()}.Decode()
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, nil, err
 		}
 		var v uint64
-		v, err = strconv.ParseUint(t.(utils.ContinuousToken).Value, 10, 64)
+		v, err = strconv.ParseUint(t.(relational.ContinuousToken).Value, 10, 64)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
@@ -231,7 +231,7 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, utils.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		return nil, relational.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
 	}
 
 	// Execute the query and retrieve the rows.
@@ -240,7 +240,7 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, utils.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		return nil, relational.NewNoopContinuousToken().Encode(), errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
 	}
 	defer rows.Close()
 
@@ -250,12 +250,14 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 	tuples := make([]*base.Tuple, 0, pagination.PageSize()+1)
 	for rows.Next() {
 		rt := storage.RelationTuple{}
-		err = rows.Scan(&rt.ID, &rt.EntityType, &rt.EntityID, &rt.Relation, &rt.SubjectType, &rt.SubjectID, &rt.SubjectRelation)
+		var expiresAt sql.NullTime
+		err = rows.Scan(&rt.ID, &rt.EntityType, &rt.EntityID, &rt.Relation, &rt.SubjectType, &rt.SubjectID, &rt.SubjectRelation, &expiresAt)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, nil, err
 		}
+		rt.ExpiresAt = expiresAt.Time
 		lastID = rt.ID
 		tuples = append(tuples, rt.ToTuple())
 	}
@@ -276,10 +278,82 @@ func (r *RelationshipReader) ReadRelationships(ctx context.Context, tenantID str
 
 	// Return the results and encoded continuous token for pagination.
 	if len(tuples) > int(pagination.PageSize()) {
-		return database.NewTupleCollection(tuples[:pagination.PageSize()]...), utils.NewContinuousToken(strconv.FormatUint(lastID, 10)).Encode(), nil
+		return database.NewTupleCollection(tuples[:pagination.PageSize()]...), relational.NewContinuousToken(strconv.FormatUint(lastID, 10)).Encode(), nil
 	}
 
-	return database.NewTupleCollection(tuples...), utils.NewNoopContinuousToken().Encode(), nil
+	return database.NewTupleCollection(tuples...), relational.NewNoopContinuousToken().Encode(), nil
+}
+
+// ReadEntityIDs retrieves the distinct IDs of the entities of the given type that have relationships at the
+// snapshot, in the byte order of the IDs. It returns up to limit of the IDs that come after the given ID, so that
+// the entities can be read one page at a time.
+//
+// Parameters:
+// - ctx:        The context used for tracing and cancellation.
+// - tenantID:   The tenant ID for which the entity IDs should be read.
+// - entityType: The type of the entities.
+// - snap:       A string representing the snapshot value to be used for the query.
+// - after:      The entity ID the read starts after, or an empty string to start with the first one.
+// - limit:      The maximum number of entity IDs to read.
+//
+// Returns:
+// - ids: The entity IDs, in the byte order of the IDs.
+// - err: An error, if any occurred during the execution of the query.
+func (r *RelationshipReader) ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) (ids []string, err error) {
+	// Start a new trace span and end it when the function exits.
+	ctx, span := tracer.Start(ctx, "relationship-reader.read-entity-ids")
+	defer span.End()
+
+	// Decode the snapshot value.
+	var st token.SnapToken
+	st, err = snapshot.EncodedToken{Value: snap}.Decode()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Build the query of the entity IDs after the given one.
+	builder := relational.EntityIDsQuery(r.database.Builder, utils.Dialect{}, tenantID, entityType, after, st.(snapshot.Token).Value.Uint, uint64(limit))
+
+	// Generate the SQL query and arguments.
+	var query string
+	var args []interface{}
+	query, args, err = builder.ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	// Execute the query and retrieve the rows.
+	var rows *sql.Rows
+	rows, err = r.database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer rows.Close()
+
+	// Iterate through the rows and collect the entity IDs.
+	ids = make([]string, 0, limit)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return ids, nil
 }
 
 // HeadSnapshot retrieves the latest snapshot token for a given tenant ID.
@@ -300,7 +374,7 @@ func (r *RelationshipReader) HeadSnapshot(ctx context.Context, tenantID string)
 	var xid types.XID8
 
 	// Build the query to find the highest transaction ID associated with the tenant.
-	builder := r.database.Builder.Select("id").From(TransactionsTable).Where(squirrel.Eq{"tenant_id": tenantID}).OrderBy("id DESC").Limit(1)
+	builder := relational.HeadTransactionQuery(r.database.Builder, tenantID)
 	query, args, err := builder.ToSql()
 	if err != nil {
 		span.RecordError(err)
@@ -324,3 +398,48 @@ func (r *RelationshipReader) HeadSnapshot(ctx context.Context, tenantID string)
 	// Return the latest snapshot token associated with the tenant.
 	return snapshot.Token{Value: xid}, nil
 }
+
+// SnapshotAt retrieves the latest snapshot token committed at or before the given time for the specified tenant.
+// Snapshots are resolved through the timestamps recorded in the transactions table, so every node that asks for
+// the same point in time observes the same snapshot. If the relationships that were visible at the given time
+// may have been garbage collected already, an error is returned instead.
+func (r *RelationshipReader) SnapshotAt(ctx context.Context, tenantID string, timestamp time.Time) (token.SnapToken, error) {
+	// Start a new trace span and end it when the function exits.
+	ctx, span := tracer.Start(ctx, "relationship-reader.snapshot-at")
+	defer span.End()
+
+	// The history older than the garbage collection window is not kept.
+	if window := r.database.GetGarbageCollectionWindow(); window > 0 && timestamp.Before(time.Now().Add(-window)) {
+		err := errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var xid types.XID8
+
+	// Build the query to find the highest transaction ID committed at or before the given time for the tenant.
+	builder := relational.TransactionAtQuery(r.database.Builder, utils.Dialect{}, tenantID, timestamp)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	// Execute the query and retrieve the transaction ID.
+	row := r.database.DB.QueryRowContext(ctx, query, args...)
+	err = row.Scan(&xid)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		// If no rows are found, return a snapshot token with a value of 0.
+		if errors.Is(err, sql.ErrNoRows) {
+			return snapshot.Token{Value: types.XID8{Uint: 0}}, nil
+		}
+		return nil, err
+	}
+
+	// Return the snapshot token that was current at the given time.
+	return snapshot.Token{Value: xid}, nil
+}
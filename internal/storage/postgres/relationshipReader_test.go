@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"regexp"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Masterminds/squirrel"
@@ -47,19 +48,20 @@ var _ = Describe("RelationshipReader", func() {
 	})
 
 	Context("QueryRelationships", func() {
-		columns := []string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation"}
+		columns := []string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at"}
 
 		It("should be same queries", func() {
 			rows := sqlmock.NewRows(columns).
-				AddRow("organization", "abc", "admin", "user", "jack", "").
-				AddRow("organization", "abc", "admin", "user", "john", "")
+				AddRow("organization", "abc", "admin", "user", "jack", "", nil).
+				AddRow("organization", "abc", "admin", "user", "john", "", nil)
 
 			mock.ExpectBegin()
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at
 			 FROM relation_tuples WHERE tenant_id = $1 AND entity_id IN ($2) AND entity_type = $3 AND relation = $4 AND (pg_visible_in_snapshot(created_tx_id, 
 				(select snapshot from transactions where id = '4'::xid8)) = true OR created_tx_id = '4'::xid8) AND ((pg_visible_in_snapshot(expired_tx_id, 
-					(select snapshot from transactions where id = '4'::xid8)) = false OR expired_tx_id = '0'::xid8) AND expired_tx_id <> '4'::xid8)`)).
-				WithArgs("noop", "abc", "organization", "admin").
+					(select snapshot from transactions where id = '4'::xid8)) = false OR expired_tx_id = '0'::xid8) AND expired_tx_id <> '4'::xid8)
+						AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = $5 AND id > '4'::xid8), (now() AT TIME ZONE 'UTC')))`)).
+				WithArgs("noop", "abc", "organization", "admin", "noop").
 				WillReturnRows(rows)
 			mock.ExpectCommit()
 
@@ -100,4 +102,65 @@ var _ = Describe("RelationshipReader", func() {
 			}...)))
 		})
 	})
+
+	Context("ReadEntityIDs", func() {
+		It("should read the entity ids after the given one in byte order", func() {
+			rows := sqlmock.NewRows([]string{"entity_id"}).
+				AddRow("b").
+				AddRow("c")
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT entity_id COLLATE "C" FROM relation_tuples WHERE tenant_id = $1 AND entity_type = $2 AND (pg_visible_in_snapshot(created_tx_id, (select snapshot from transactions where id = '4'::xid8)) = true OR created_tx_id = '4'::xid8) AND ((pg_visible_in_snapshot(expired_tx_id, (select snapshot from transactions where id = '4'::xid8)) = false OR expired_tx_id = '0'::xid8) AND expired_tx_id <> '4'::xid8) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = $3 AND id > '4'::xid8), (now() AT TIME ZONE 'UTC'))) AND entity_id COLLATE "C" > $4 ORDER BY entity_id COLLATE "C" LIMIT 2`)).
+				WithArgs("noop", "organization", "noop", "a").
+				WillReturnRows(rows)
+
+			ids, err := relationshipReader.ReadEntityIDs(context.Background(), "noop", "organization", snapshot.NewToken(types.XID8{Uint: 4, Status: pgtype.Present}).Encode().String(), "a", 2)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ids).Should(Equal([]string{"b", "c"}))
+		})
+	})
+
+	Context("SnapshotAt", func() {
+		It("should return the latest transaction committed at or before the given time", func() {
+			at := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM transactions WHERE tenant_id = $1 AND timestamp <= $2 ORDER BY id DESC LIMIT 1`)).
+				WithArgs("noop", at).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+
+			value, err := relationshipReader.SnapshotAt(context.Background(), "noop", at)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(value.Encode().String()).Should(Equal(snapshot.NewToken(types.XID8{Uint: 12, Status: pgtype.Present}).Encode().String()))
+		})
+
+		It("should return the zero snapshot when there are no transactions before the given time", func() {
+			at := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM transactions WHERE tenant_id = $1 AND timestamp <= $2 ORDER BY id DESC LIMIT 1`)).
+				WithArgs("noop", at).
+				WillReturnError(sql.ErrNoRows)
+
+			value, err := relationshipReader.SnapshotAt(context.Background(), "noop", at)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(value.Encode().String()).Should(Equal(snapshot.NewToken(types.XID8{Uint: 0}).Encode().String()))
+		})
+
+		It("should return an error for a time older than the garbage collection window", func() {
+			postgres.GarbageCollectionWindow(time.Hour)(relationshipReader.database)
+
+			_, err := relationshipReader.SnapshotAt(context.Background(), "noop", time.Now().Add(-2*time.Hour))
+			Expect(err.Error()).Should(Equal(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String()))
+
+			at := time.Now().Add(-30 * time.Minute)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM transactions WHERE tenant_id = $1 AND timestamp <= $2 ORDER BY id DESC LIMIT 1`)).
+				WithArgs("noop", at.UTC()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+
+			value, err := relationshipReader.SnapshotAt(context.Background(), "noop", at)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(value.Encode().String()).Should(Equal(snapshot.NewToken(types.XID8{Uint: 12, Status: pgtype.Present}).Encode().String()))
+		})
+	})
 })
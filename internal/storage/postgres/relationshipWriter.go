@@ -4,14 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 
 	"github.com/Masterminds/squirrel"
 	otelCodes "go.opentelemetry.io/otel/codes"
 
+	"github.com/Permify/permify/internal/storage"
 	"github.com/Permify/permify/internal/storage/postgres/snapshot"
 	"github.com/Permify/permify/internal/storage/postgres/types"
 	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
 	"github.com/Permify/permify/pkg/database"
 	db "github.com/Permify/permify/pkg/database/postgres"
 	"github.com/Permify/permify/pkg/logger"
@@ -26,6 +30,8 @@ type RelationshipWriter struct {
 	txOptions         sql.TxOptions
 	maxTuplesPerWrite int
 	maxRetries        int
+	// copyFrom copies rows into a table of the connection
+	copyFrom func(ctx context.Context, conn *sql.Conn, table string, columns []string, rows [][]interface{}) (int64, error)
 	// logger
 	logger logger.Interface
 }
@@ -37,6 +43,7 @@ func NewRelationshipWriter(database *db.Postgres, logger logger.Interface) *Rela
 		txOptions:         sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: false},
 		maxTuplesPerWrite: _defaultMaxTuplesPerWrite,
 		maxRetries:        _defaultMaxRetries,
+		copyFrom:          utils.CopyFrom,
 		logger:            logger,
 	}
 }
@@ -59,17 +66,48 @@ func (w *RelationshipWriter) WriteRelationships(ctx context.Context, tenantID st
 			return nil, err
 		}
 
-		insertBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id")
+		insertBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at")
+
+		// The relationships that have expired but are not marked as deleted yet are marked first, so that they can
+		// be written again.
+		written := squirrel.Or{}
 
 		iter := collection.CreateTupleIterator()
 		for iter.HasNext() {
 			t := iter.GetNext()
-			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID)
+			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, utils.ExpiresAt(t))
+			written = append(written, squirrel.Eq{
+				"entity_type":      t.GetEntity().GetType(),
+				"entity_id":        t.GetEntity().GetId(),
+				"relation":         t.GetRelation(),
+				"subject_type":     t.GetSubject().GetType(),
+				"subject_id":       t.GetSubject().GetId(),
+				"subject_relation": t.GetSubject().GetRelation(),
+			})
 		}
 
 		var query string
 		var args []interface{}
 
+		query, args, err = relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, squirrel.Expr("pg_current_xact_id()")).Where(written).ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			}
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
 		query, args, err = insertBuilder.ToSql()
 		if err != nil {
 			utils.Rollback(tx, w.logger)
@@ -140,7 +178,7 @@ func (w *RelationshipWriter) DeleteRelationships(ctx context.Context, tenantID s
 		}
 
 		builder := w.database.Builder.Update(RelationTuplesTable).Set("expired_tx_id", squirrel.Expr("pg_current_xact_id()")).Where(squirrel.Eq{"expired_tx_id": "0"})
-		builder = utils.FilterQueryForUpdateBuilder(builder, filter)
+		builder = relational.FilterQueryForUpdateBuilder(builder, filter)
 
 		var query string
 		var args []interface{}
@@ -197,3 +235,329 @@ func (w *RelationshipWriter) DeleteRelationships(ctx context.Context, tenantID s
 
 	return nil, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
 }
+
+// TransactRelationships - Applies the operations to the relation tuples in a single transaction, if all the
+// preconditions hold. The transaction is serializable, so it fails and is retried if another write changes the result
+// of the preconditions before it is committed.
+func (w *RelationshipWriter) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.transact-relationships")
+	defer span.End()
+
+	if len(operations) > w.maxTuplesPerWrite {
+		return nil, errors.New("max tuples per write exceeded")
+	}
+
+	var err error
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = w.database.DB.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, err
+		}
+
+		err = w.transact(ctx, tx, tenantID, preconditions, operations)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			} else if strings.Contains(err.Error(), "duplicate key value") {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+			} else if _, ok := base.ErrorCode_value[err.Error()]; ok {
+				return nil, err
+			} else {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+			}
+		}
+
+		transaction := w.database.Builder.Insert("transactions").
+			Columns("tenant_id").
+			Values(tenantID).
+			Suffix("RETURNING id").RunWith(tx)
+
+		var xid types.XID8
+		err = transaction.QueryRowContext(ctx).Scan(&xid)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			}
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		return snapshot.NewToken(xid).Encode(), nil
+	}
+
+	return nil, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
+
+// transact - Checks the preconditions and applies the operations in the transaction. The errors of
+// the database are returned as they are, so that the caller can tell the ones to retry.
+func (w *RelationshipWriter) transact(ctx context.Context, tx *sql.Tx, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) error {
+	for _, precondition := range preconditions {
+		builder := w.database.Builder.Select("1").From(RelationTuplesTable).Where(squirrel.Eq{"tenant_id": tenantID, "expired_tx_id": "0"})
+		builder = relational.ExpirationQuery(relational.FilterQueryForSelectBuilder(builder, precondition.GetFilter()), utils.Dialect{}).Limit(1)
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		var matched int
+		err = tx.QueryRowContext(ctx, query, args...).Scan(&matched)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if (err == nil) != (precondition.GetOperation() == base.TuplePrecondition_OPERATION_MUST_MATCH) {
+			return errors.New(base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+		}
+	}
+
+	insertBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at")
+	touchBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at").
+		Suffix("ON CONFLICT ON CONSTRAINT uq_relation_tuple_not_expired DO NOTHING")
+	inserted, touched := false, false
+
+	// The relation tuples that are deleted are marked as deleted. The ones that are created or touched are marked as
+	// deleted only if they have expired, so that creating a relation tuple that exists fails, and touching it keeps it
+	// with its original created_tx_id. A touch that changes the expiration marks the relation tuple as deleted and
+	// writes it again, so that the change is a new version of it that snapshots and watchers see.
+	removed := squirrel.Or{}
+	created := squirrel.Or{}
+
+	for _, operation := range operations {
+		t := operation.GetTuple()
+		eq := squirrel.Eq{
+			"entity_type":      t.GetEntity().GetType(),
+			"entity_id":        t.GetEntity().GetId(),
+			"relation":         t.GetRelation(),
+			"subject_type":     t.GetSubject().GetType(),
+			"subject_id":       t.GetSubject().GetId(),
+			"subject_relation": t.GetSubject().GetRelation(),
+		}
+		switch operation.GetOperation() {
+		case base.TupleOperation_OPERATION_DELETE:
+			removed = append(removed, eq)
+		case base.TupleOperation_OPERATION_TOUCH:
+			if t.GetExpiresAt() != nil || operation.GetClearExpiration() {
+				removed = append(removed, squirrel.And{eq, relational.ExpirationChangedQuery(utils.ExpiresAt(t))})
+			}
+			created = append(created, eq)
+			touchBuilder = touchBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, utils.ExpiresAt(t))
+			touched = true
+		default:
+			created = append(created, eq)
+			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, utils.ExpiresAt(t))
+			inserted = true
+		}
+	}
+
+	var statements []squirrel.Sqlizer
+	if len(removed) > 0 {
+		statements = append(statements, w.database.Builder.Update(RelationTuplesTable).Set("expired_tx_id", squirrel.Expr("pg_current_xact_id()")).Where(squirrel.Eq{"tenant_id": tenantID, "expired_tx_id": "0"}).Where(removed))
+	}
+	if len(created) > 0 {
+		statements = append(statements, relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, squirrel.Expr("pg_current_xact_id()")).Where(created))
+	}
+	if inserted {
+		statements = append(statements, insertBuilder)
+	}
+	if touched {
+		statements = append(statements, touchBuilder)
+	}
+
+	for _, statement := range statements {
+		query, args, err := statement.ToSql()
+		if err != nil {
+			return errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BulkImport - Imports the relation tuples of the batches. The batches are copied into a temporary staging table as
+// they are received, and the staged relation tuples are written in a single transaction after the last batch, so
+// they become visible at once. Relation tuples that are already written are skipped and not counted as imported.
+func (w *RelationshipWriter) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.bulk-import")
+	defer span.End()
+
+	var err error
+
+	// Temporary tables only exist on the connection that created them
+	var conn *sql.Conn
+	conn, err = w.database.DB.Conn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("CREATE TEMPORARY TABLE %s (entity_type VARCHAR NOT NULL, entity_id VARCHAR NOT NULL, relation VARCHAR NOT NULL, subject_type VARCHAR NOT NULL, subject_id VARCHAR NOT NULL, subject_relation VARCHAR NOT NULL, expires_at TIMESTAMP NULL)", ImportTable))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), fmt.Sprintf("DROP TABLE IF EXISTS pg_temp.%s", ImportTable)); err != nil {
+			w.logger.Error("failed to drop the staging table of the bulk import", err)
+		}
+	}()
+
+	staged := 0
+	for {
+		var collection *database.TupleCollection
+		collection, err = batches()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, err
+		}
+
+		rows := make([][]interface{}, 0, len(collection.GetTuples()))
+		for _, t := range collection.GetTuples() {
+			var expiresAt interface{}
+			if t.GetExpiresAt() != nil {
+				expiresAt = t.GetExpiresAt().AsTime().UTC()
+			}
+			rows = append(rows, []interface{}{t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), expiresAt})
+		}
+
+		if _, err = w.copyFrom(ctx, conn, ImportTable, []string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at"}, rows); err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+		staged += len(rows)
+	}
+
+	if staged == 0 {
+		return token.NewNoopToken().Encode(), 0, nil
+	}
+
+	// The planner needs the statistics of the staging table to join it with the relation tuples
+	if _, err = conn.ExecContext(ctx, fmt.Sprintf("ANALYZE pg_temp.%s", ImportTable)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	columns := "entity_type, entity_id, relation, subject_type, subject_id, subject_relation"
+
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = conn.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, err
+		}
+
+		var query string
+		var args []interface{}
+
+		// The staged relationships that have expired but are not marked as deleted yet are marked first, so that
+		// they can be written again.
+		query, args, err = relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, squirrel.Expr("pg_current_xact_id()")).
+			Where(squirrel.Expr(fmt.Sprintf("(%s) IN (SELECT %s FROM pg_temp.%s)", columns, columns, ImportTable))).
+			ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		query, args, err = w.database.Builder.Insert(RelationTuplesTable).
+			Columns(columns + ", tenant_id, expires_at").
+			Select(squirrel.Select(columns).Column(squirrel.Expr("?::varchar", tenantID)).Column("expires_at").From("pg_temp." + ImportTable)).
+			Suffix("ON CONFLICT DO NOTHING").
+			ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		var result sql.Result
+		result, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		// The relation tuples that were already written are skipped by the insert, so they are not counted
+		var imported int64
+		imported, err = result.RowsAffected()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		var xid types.XID8
+		err = w.database.Builder.Insert(TransactionsTable).
+			Columns("tenant_id").
+			Values(tenantID).
+			Suffix("RETURNING id").RunWith(tx).QueryRowContext(ctx).Scan(&xid)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if strings.Contains(err.Error(), "could not serialize") {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		return snapshot.NewToken(xid).Encode(), imported, nil
+	}
+
+	return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
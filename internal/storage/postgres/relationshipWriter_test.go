@@ -5,13 +5,21 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"io"
 	"regexp"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgtype"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/postgres/snapshot"
+	"github.com/Permify/permify/internal/storage/postgres/types"
 	"github.com/Permify/permify/pkg/database"
 	"github.com/Permify/permify/pkg/database/postgres"
 	"github.com/Permify/permify/pkg/logger"
@@ -96,4 +104,222 @@ var _ = Describe("RelationshipWriter", func() {
 			// TODO: can we write a helper function to fetch the recently inserted record? as we are just creating a mock! any comments? Will think about it!
 		})
 	})
+
+	Context("Bulk Import", func() {
+		var copied [][]interface{}
+
+		BeforeEach(func() {
+			copied = nil
+			relationshipWriter.copyFrom = func(ctx context.Context, conn *sql.Conn, table string, columns []string, rows [][]interface{}) (int64, error) {
+				Expect(table).Should(Equal(ImportTable))
+				Expect(columns).Should(Equal([]string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at"}))
+				copied = append(copied, rows...)
+				return int64(len(rows)), nil
+			}
+		})
+
+		// batches returns the collections one at a time, and then io.EOF.
+		batches := func(collections ...*database.TupleCollection) storage.TupleBatches {
+			return func() (*database.TupleCollection, error) {
+				if len(collections) == 0 {
+					return nil, io.EOF
+				}
+				c := collections[0]
+				collections = collections[1:]
+				return c, nil
+			}
+		}
+
+		tuple := func(entityID, subjectID string) *basev1.Tuple {
+			return &basev1.Tuple{
+				Entity:   &basev1.Entity{Type: "organization", Id: entityID},
+				Relation: "member",
+				Subject:  &basev1.Subject{Type: "user", Id: subjectID},
+			}
+		}
+
+		It("should stage the batches and write them in a single transaction", func() {
+			expiresAt := time.Date(2023, 10, 21, 12, 0, 0, 0, time.UTC)
+			expiring := tuple("1", "3")
+			expiring.ExpiresAt = timestamppb.New(expiresAt)
+
+			mock.ExpectExec(regexp.QuoteMeta(`CREATE TEMPORARY TABLE relation_tuples_import`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`ANALYZE pg_temp.relation_tuples_import`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') AND (entity_type, entity_id, relation, subject_type, subject_id, subject_relation) IN (SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation FROM pg_temp.relation_tuples_import)`)).
+				WithArgs("t1").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at) SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, $1::varchar, expires_at FROM pg_temp.relation_tuples_import ON CONFLICT DO NOTHING`)).
+				WithArgs("t1").
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO transactions (tenant_id) VALUES ($1) RETURNING id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+			mock.ExpectCommit()
+			mock.ExpectExec(regexp.QuoteMeta(`DROP TABLE IF EXISTS pg_temp.relation_tuples_import`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			token, imported, err := relationshipWriter.BulkImport(context.Background(), "t1", batches(
+				database.NewTupleCollection(tuple("1", "1"), tuple("1", "2")),
+				database.NewTupleCollection(expiring),
+			))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token).Should(Equal(snapshot.NewToken(types.XID8{Uint: 12, Status: pgtype.Present}).Encode()))
+			// The relation tuple that was already written is skipped by the insert
+			Expect(imported).Should(Equal(int64(2)))
+
+			Expect(copied).Should(Equal([][]interface{}{
+				{"organization", "1", "member", "user", "1", "", nil},
+				{"organization", "1", "member", "user", "2", "", nil},
+				{"organization", "1", "member", "user", "3", "", expiresAt},
+			}))
+		})
+
+		It("should not write the staged batches if a batch fails", func() {
+			mock.ExpectExec(regexp.QuoteMeta(`CREATE TEMPORARY TABLE relation_tuples_import`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`DROP TABLE IF EXISTS pg_temp.relation_tuples_import`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			failed := errors.New("stream closed")
+			received := false
+			_, _, err := relationshipWriter.BulkImport(context.Background(), "t1", func() (*database.TupleCollection, error) {
+				if received {
+					return nil, failed
+				}
+				received = true
+				return database.NewTupleCollection(tuple("1", "1")), nil
+			})
+			Expect(err).Should(Equal(failed))
+			Expect(copied).Should(HaveLen(1))
+		})
+	})
+
+	Context("Transact Relationships", func() {
+		preconditions := []*basev1.TuplePrecondition{{
+			Operation: basev1.TuplePrecondition_OPERATION_MUST_MATCH,
+			Filter: &basev1.TupleFilter{
+				Entity:   &basev1.EntityFilter{Type: "document", Ids: []string{"1"}},
+				Relation: "parent",
+				Subject:  &basev1.SubjectFilter{Type: "folder", Ids: []string{"a"}},
+			},
+		}}
+
+		operations := []*basev1.TupleOperation{{
+			Operation: basev1.TupleOperation_OPERATION_DELETE,
+			Tuple: &basev1.Tuple{
+				Entity:   &basev1.Entity{Type: "document", Id: "1"},
+				Relation: "parent",
+				Subject:  &basev1.Subject{Type: "folder", Id: "a", Relation: "..."},
+			},
+		}, {
+			Operation: basev1.TupleOperation_OPERATION_CREATE,
+			Tuple: &basev1.Tuple{
+				Entity:   &basev1.Entity{Type: "document", Id: "1"},
+				Relation: "parent",
+				Subject:  &basev1.Subject{Type: "folder", Id: "b", Relation: "..."},
+			},
+		}}
+
+		precondition := `SELECT 1 FROM relation_tuples WHERE expired_tx_id = $1 AND tenant_id = $2 AND entity_id IN ($3) AND entity_type = $4 AND relation = $5 AND subject_id IN ($6) AND subject_type = $7 AND (expires_at IS NULL OR expires_at > (now() AT TIME ZONE 'UTC')) LIMIT 1`
+
+		It("should apply the operations in a single transaction", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(precondition)).
+				WithArgs("0", "t1", "1", "document", "parent", "a", "folder").
+				WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = $1 AND tenant_id = $2 AND (entity_id = $3 AND entity_type = $4 AND relation = $5 AND subject_id = $6 AND subject_relation = $7 AND subject_type = $8)`)).
+				WithArgs("0", "t1", "1", "document", "parent", "a", "...", "folder").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') AND (entity_id = $2 AND entity_type = $3 AND relation = $4 AND subject_id = $5 AND subject_relation = $6 AND subject_type = $7)`)).
+				WithArgs("t1", "1", "document", "parent", "b", "...", "folder").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).
+				WithArgs("document", "1", "parent", "folder", "b", "...", "t1", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO transactions (tenant_id) VALUES ($1) RETURNING id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(14)))
+			mock.ExpectCommit()
+
+			token, err := relationshipWriter.TransactRelationships(context.Background(), "t1", preconditions, operations)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token).Should(Equal(snapshot.NewToken(types.XID8{Uint: 14, Status: pgtype.Present}).Encode()))
+		})
+
+		It("should retry serialization failures and fail if a precondition does not hold", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(precondition)).
+				WillReturnError(errors.New("ERROR: could not serialize access due to read/write dependencies among transactions (SQLSTATE 40001)"))
+			mock.ExpectRollback()
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(precondition)).
+				WithArgs("0", "t1", "1", "document", "parent", "a", "folder").
+				WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+			mock.ExpectRollback()
+
+			_, err := relationshipWriter.TransactRelationships(context.Background(), "t1", preconditions, operations)
+			Expect(err).Should(MatchError(basev1.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String()))
+		})
+
+		It("should keep the touched relation tuples that exist", func() {
+			touch := []*basev1.TupleOperation{{
+				Operation: basev1.TupleOperation_OPERATION_TOUCH,
+				Tuple: &basev1.Tuple{
+					Entity:   &basev1.Entity{Type: "document", Id: "1"},
+					Relation: "parent",
+					Subject:  &basev1.Subject{Type: "folder", Id: "b", Relation: "..."},
+				},
+			}}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') AND (entity_id = $2 AND entity_type = $3 AND relation = $4 AND subject_id = $5 AND subject_relation = $6 AND subject_type = $7)`)).
+				WithArgs("t1", "1", "document", "parent", "b", "...", "folder").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ON CONSTRAINT uq_relation_tuple_not_expired DO NOTHING`)).
+				WithArgs("document", "1", "parent", "folder", "b", "...", "t1", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO transactions (tenant_id) VALUES ($1) RETURNING id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(15)))
+			mock.ExpectCommit()
+
+			token, err := relationshipWriter.TransactRelationships(context.Background(), "t1", nil, touch)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token).Should(Equal(snapshot.NewToken(types.XID8{Uint: 15, Status: pgtype.Present}).Encode()))
+		})
+
+		It("should write a new version of the touched relation tuples whose expiration changes", func() {
+			touch := []*basev1.TupleOperation{{
+				Operation: basev1.TupleOperation_OPERATION_TOUCH,
+				Tuple: &basev1.Tuple{
+					Entity:    &basev1.Entity{Type: "document", Id: "1"},
+					Relation:  "parent",
+					Subject:   &basev1.Subject{Type: "folder", Id: "b", Relation: "..."},
+					ExpiresAt: timestamppb.New(time.Date(2023, 10, 21, 12, 0, 0, 0, time.UTC)),
+				},
+			}}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = $1 AND tenant_id = $2 AND ((entity_id = $3 AND entity_type = $4 AND relation = $5 AND subject_id = $6 AND subject_relation = $7 AND subject_type = $8 AND (expires_at IS NULL OR expires_at <> $9)))`)).
+				WithArgs("0", "t1", "1", "document", "parent", "b", "...", "folder", "2023-10-21 12:00:00").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE relation_tuples SET expired_tx_id = pg_current_xact_id() WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') AND (entity_id = $2 AND entity_type = $3 AND relation = $4 AND subject_id = $5 AND subject_relation = $6 AND subject_type = $7)`)).
+				WithArgs("t1", "1", "document", "parent", "b", "...", "folder").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO relation_tuples (entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ON CONSTRAINT uq_relation_tuple_not_expired DO NOTHING`)).
+				WithArgs("document", "1", "parent", "folder", "b", "...", "t1", "2023-10-21 12:00:00").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO transactions (tenant_id) VALUES ($1) RETURNING id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(16)))
+			mock.ExpectCommit()
+
+			token, err := relationshipWriter.TransactRelationships(context.Background(), "t1", nil, touch)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token).Should(Equal(snapshot.NewToken(types.XID8{Uint: 16, Status: pgtype.Present}).Encode()))
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"go.opentelemetry.io/otel/codes"
@@ -160,3 +161,76 @@ func (r *SchemaReader) HeadVersion(ctx context.Context, tenantID string) (versio
 
 	return version, nil
 }
+
+// VersionAt - Finds the latest version of the schema written at or before the given time.
+func (r *SchemaReader) VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (version string, err error) {
+	ctx, span := tracer.Start(ctx, "schema-reader.version-at")
+	defer span.End()
+
+	var query string
+	var args []interface{}
+	query, args, err = r.database.Builder.
+		Select("version").From(SchemaDefinitionTable).Where(squirrel.Eq{"tenant_id": tenantID}).Where(squirrel.Lt{"version": storage.VersionBound(timestamp)}).OrderBy("version DESC").Limit(1).
+		ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+	row := r.database.DB.QueryRowContext(ctx, query, args...)
+	err = row.Scan(&version)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.New(base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
+		}
+		return "", err
+	}
+
+	return version, nil
+}
+
+// ListSchemaDefinitions - Reads the definitions of all the versions of the schema, ordered by version.
+func (r *SchemaReader) ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []storage.SchemaDefinition, err error) {
+	ctx, span := tracer.Start(ctx, "schema-reader.list-schema-definitions")
+	defer span.End()
+
+	var query string
+	var args []interface{}
+	query, args, err = r.database.Builder.
+		Select("entity_type, serialized_definition, version").From(SchemaDefinitionTable).Where(squirrel.Eq{"tenant_id": tenantID}).OrderBy("version", "entity_type").
+		ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	var rows *sql.Rows
+	rows, err = r.database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sd := storage.SchemaDefinition{TenantID: tenantID}
+		err = rows.Scan(&sd.EntityType, &sd.SerializedDefinition, &sd.Version)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_SCAN.String())
+		}
+		definitions = append(definitions, sd)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	return definitions, nil
+}
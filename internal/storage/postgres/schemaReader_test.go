@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Masterminds/squirrel"
@@ -14,6 +16,7 @@ import (
 
 	PQRepository "github.com/Permify/permify/pkg/database/postgres"
 	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
 var schemaExample = "entity user {}\\n\\nentity organization {\\n\\n    // relations\\n    relation admin @user\\n    relation member @user\\n\\n    // actions\\n    action create_repository = (admin or member)\\n    action delete = admin\\n}\\n\\nentity repository {\\n\\n    // relations\\n    relation owner @user @organization#member\\n    relation parent @organization\\n\\n    // actions\\n    action push = owner\\n    action read = (owner and (parent.admin and not parent.member))\\n    \\n    // parent.create_repository means user should be\\n    // organization admin or organization member\\n    action delete = (owner or (parent.create_repository))\\n}"
@@ -63,6 +66,38 @@ func TestHeadVersion_Test(t *testing.T) {
 	require.Equal(t, version, versionActual)
 }
 
+func TestVersionAt_Test(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	pg := &PQRepository.Postgres{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+
+	reader := NewSchemaReader(pg, logger.New("debug"))
+
+	at := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	version := xid.NewWithTime(at).String()
+
+	versionQuery := "SELECT version FROM schema_definitions WHERE tenant_id = \\$1 AND version < \\$2 ORDER BY version DESC LIMIT 1$"
+	mock.ExpectQuery(versionQuery).WithArgs("1", storage.VersionBound(at)).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+
+	versionActual, err := reader.VersionAt(context.Background(), "1", at)
+	require.NoError(t, err)
+	require.Equal(t, version, versionActual)
+
+	// A version written at the given time is below the bound, a version written a second later is not.
+	require.Less(t, version, storage.VersionBound(at))
+	require.GreaterOrEqual(t, xid.NewWithTime(at.Add(time.Second)).String(), storage.VersionBound(at))
+
+	mock.ExpectQuery(versionQuery).WithArgs("1", storage.VersionBound(at)).WillReturnError(sql.ErrNoRows)
+
+	_, err = reader.VersionAt(context.Background(), "1", at)
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
+}
+
 func TestReadSchema_Test(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -155,3 +190,36 @@ func TestReadSchemaDefinition_Test(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, version, v)
 }
+
+func TestListSchemaDefinitions_Test(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	pg := &PQRepository.Postgres{
+		DB:      db,
+		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+
+	reader := NewSchemaReader(pg, logger.New("debug"))
+
+	first := xid.New().String()
+	second := xid.New().String()
+
+	expectedQuery := "SELECT entity_type, serialized_definition, version FROM schema_definitions WHERE tenant_id = \\$1 ORDER BY version, entity_type$"
+	expectedRows := sqlmock.NewRows([]string{"entity_type", "serialized_definition", "version"}).
+		AddRow("user", []byte("entity user {}"), first).
+		AddRow("organization", []byte("entity organization {}"), second).
+		AddRow("user", []byte("entity user {}"), second)
+
+	mock.ExpectQuery(expectedQuery).WithArgs("1").WillReturnRows(expectedRows)
+
+	definitions, err := reader.ListSchemaDefinitions(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, []storage.SchemaDefinition{
+		{TenantID: "1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: first},
+		{TenantID: "1", EntityType: "organization", SerializedDefinition: []byte("entity organization {}"), Version: second},
+		{TenantID: "1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: second},
+	}, definitions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -9,7 +9,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 
 	"github.com/Permify/permify/internal/storage"
-	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
 	"github.com/Permify/permify/pkg/database"
 	db "github.com/Permify/permify/pkg/database/postgres"
 	"github.com/Permify/permify/pkg/logger"
@@ -41,13 +41,13 @@ func (r *TenantReader) ListTenants(ctx context.Context, pagination database.Pagi
 	builder := r.database.Builder.Select("id, name, created_at").From(TenantsTable)
 	if pagination.Token() != "" {
 		var t database.ContinuousToken
-		t, err = utils.EncodedContinuousToken{Value: pagination.Token()}.Decode()
+		t, err = relational.EncodedContinuousToken{Value: pagination.Token()}.Decode()
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, nil, err
 		}
-		builder = builder.Where(squirrel.GtOrEq{"id": t.(utils.ContinuousToken).Value})
+		builder = builder.Where(squirrel.GtOrEq{"id": t.(relational.ContinuousToken).Value})
 	}
 
 	builder = builder.OrderBy("id").Limit(uint64(pagination.PageSize() + 1))
@@ -91,8 +91,8 @@ func (r *TenantReader) ListTenants(ctx context.Context, pagination database.Pagi
 	}
 
 	if len(tenants) > int(pagination.PageSize()) {
-		return tenants[:pagination.PageSize()], utils.NewContinuousToken(lastID).Encode(), nil
+		return tenants[:pagination.PageSize()], relational.NewContinuousToken(lastID).Encode(), nil
 	}
 
-	return tenants, utils.NewNoopContinuousToken().Encode(), nil
+	return tenants, relational.NewNoopContinuousToken().Encode(), nil
 }
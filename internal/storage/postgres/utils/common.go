@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -8,10 +9,16 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
+// TimestampFormat - Format of the timestamps passed to the timestamp columns
+const TimestampFormat = "2006-01-02 15:04:05.999999"
+
 // SnapshotQuery -
 func SnapshotQuery(sl squirrel.SelectBuilder, revision uint64) squirrel.SelectBuilder {
 	return sl.Where(squirrel.Or{
@@ -26,17 +33,60 @@ func SnapshotQuery(sl squirrel.SelectBuilder, revision uint64) squirrel.SelectBu
 	})
 }
 
-// GarbageCollectQuery -
-func GarbageCollectQuery(window time.Duration, tenantID string) squirrel.DeleteBuilder {
-	return squirrel.Delete("relation_tuples").
-		Where(squirrel.Expr(fmt.Sprintf("created_tx_id IN (SELECT id FROM transactions WHERE timestamp < '%v')", time.Now().Add(-window).Format(time.RFC3339)))).
-		Where(squirrel.And{
-			squirrel.Or{
-				squirrel.Expr("expired_tx_id = '0'::xid8"),
-				squirrel.Expr(fmt.Sprintf("expired_tx_id IN (SELECT id FROM transactions WHERE timestamp < '%v')", time.Now().Add(-window).Format(time.RFC3339))),
-			},
-			squirrel.Expr(fmt.Sprintf("tenant_id = '%v'", tenantID)),
-		})
+// Dialect - The PostgreSQL dialect of the queries shared by the SQL storage engines
+type Dialect struct{}
+
+// TxID - Returns the SQL of the transaction id
+func (Dialect) TxID(id uint64) squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("'%v'::xid8", id))
+}
+
+// SnapshotQuery - Filters the relationships that are visible at the given transaction
+func (Dialect) SnapshotQuery(sl squirrel.SelectBuilder, id uint64) squirrel.SelectBuilder {
+	return SnapshotQuery(sl, id)
+}
+
+// Now - Returns the SQL of the current time, the timestamps are stored in UTC
+func (Dialect) Now() string {
+	return "(now() AT TIME ZONE 'UTC')"
+}
+
+// Timestamp - Returns the value of the time for the timestamp columns, the timestamps are stored in UTC
+func (Dialect) Timestamp(t time.Time) interface{} {
+	return t.UTC()
+}
+
+// CommittedQuery - Filters the transactions whose ids are below the xmin of the current snapshot, every transaction
+// with a lower id has finished, so the transactions are selected in commit order without serializing the writers
+func (Dialect) CommittedQuery(sl squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return sl.Where("id < pg_snapshot_xmin(pg_current_snapshot())")
+}
+
+// Binary - Returns the SQL of the column in the C collation, to compare and order its values by their bytes
+func (Dialect) Binary(column string) string {
+	return column + " COLLATE \"C\""
+}
+
+// ExpiresAt - Returns the value of the expires_at column of the tuple, the timestamps are stored in UTC
+func ExpiresAt(t *base.Tuple) interface{} {
+	if t.GetExpiresAt() == nil {
+		return nil
+	}
+	return t.GetExpiresAt().AsTime().UTC().Format(TimestampFormat)
+}
+
+// CopyFrom - Copies the rows into the table on the connection with the COPY protocol
+func CopyFrom(ctx context.Context, conn *sql.Conn, table string, columns []string, rows [][]interface{}) (int64, error) {
+	var copied int64
+	err := conn.Raw(func(driverConn interface{}) (err error) {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("copy is not supported by the database driver")
+		}
+		copied, err = c.Conn().CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+		return err
+	})
+	return copied, err
 }
 
 // Rollback - Rollbacks a transaction and logs the error
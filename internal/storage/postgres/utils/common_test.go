@@ -1,13 +1,14 @@
 package utils_test
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/Permify/permify/internal/storage/postgres/utils"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,17 +25,23 @@ func TestSnapshotQuery(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql)
 }
 
-func TestGarbageCollectQuery(t *testing.T) {
-	window := 24 * time.Hour
-	tenantID := "testTenant"
-
-	query := utils.GarbageCollectQuery(window, tenantID)
-	sql, _, err := query.ToSql()
+func TestDialect(t *testing.T) {
+	d := utils.Dialect{}
 
+	sql, args, err := squirrel.Expr("id = ?", d.TxID(42)).ToSql()
 	assert.NoError(t, err)
+	assert.Equal(t, "id = '42'::xid8", sql)
+	assert.Empty(t, args)
 
-	now := time.Now()
-	arg := fmt.Sprintf("'%s'", now.Add(-window).Format(time.RFC3339))
-	expectedSQL := "DELETE FROM relation_tuples WHERE created_tx_id IN (SELECT id FROM transactions WHERE timestamp < " + arg + ") AND ((expired_tx_id = '0'::xid8 OR expired_tx_id IN (SELECT id FROM transactions WHERE timestamp < " + arg + ")) AND tenant_id = 'testTenant')"
-	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, "(now() AT TIME ZONE 'UTC')", d.Now())
+
+	at := time.Date(2023, 10, 21, 12, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, time.Date(2023, 10, 21, 9, 30, 0, 0, time.UTC), d.Timestamp(at))
+}
+
+func TestExpiresAt(t *testing.T) {
+	assert.Nil(t, utils.ExpiresAt(&base.Tuple{}))
+
+	expiresAt := time.Date(2023, 10, 21, 12, 30, 0, 500000000, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, "2023-10-21 09:30:00.5", utils.ExpiresAt(&base.Tuple{ExpiresAt: timestamppb.New(expiresAt)}))
 }
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jackc/pgtype"
+
+	"github.com/Permify/permify/internal/storage/postgres/snapshot"
+	"github.com/Permify/permify/internal/storage/postgres/types"
+	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
+	db "github.com/Permify/permify/pkg/database/postgres"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// Watcher streams the changes to the relation tuples of a tenant. Writers run concurrently, so a transaction ID may
+// commit after a higher one. The watcher only reads the transactions below the xmin of its snapshot, which have all
+// finished, so the transaction IDs are read in commit order. A long transaction holds back the changes committed
+// after it began until it finishes.
+type Watcher struct {
+	relational.Watcher
+}
+
+// NewWatcher creates a new instance of the Watcher struct with the given database and logger instances.
+func NewWatcher(database *db.Postgres, logger logger.Interface) *Watcher {
+	return &Watcher{
+		Watcher: relational.Watcher{
+			DB:           database.DB,
+			Builder:      database.Builder,
+			Dialect:      utils.Dialect{},
+			TxOptions:    sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true},
+			Window:       database.GetGarbageCollectionWindow(),
+			BatchSize:    _defaultWatchBatchSize,
+			PollInterval: _defaultWatchPollInterval,
+			Expirer:      newExpirer(database, logger),
+			Decode: func(snap string) (uint64, error) {
+				st, err := snapshot.EncodedToken{Value: snap}.Decode()
+				if err != nil {
+					return 0, err
+				}
+				return st.(snapshot.Token).Value.Uint, nil
+			},
+			Encode: func(id uint64) string {
+				return snapshot.Token{Value: types.XID8{Uint: id, Status: pgtype.Present}}.Encode().String()
+			},
+			Logger: logger,
+		},
+	}
+}
@@ -0,0 +1,124 @@
+//go:build !integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Permify/permify/internal/storage/postgres/snapshot"
+	"github.com/Permify/permify/internal/storage/postgres/types"
+	"github.com/Permify/permify/pkg/database/postgres"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+var _ = Describe("Watcher", func() {
+	var watcher *Watcher
+	var mock sqlmock.Sqlmock
+
+	BeforeEach(func() {
+		l := logger.New("debug")
+
+		var db *sql.DB
+		var err error
+
+		db, mock, err = sqlmock.New()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		pg := &postgres.Postgres{
+			DB:      db,
+			Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+		}
+
+		watcher = NewWatcher(pg, l)
+		watcher.PollInterval = time.Hour
+	})
+
+	AfterEach(func() {
+		err := mock.ExpectationsWereMet()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("Watch", func() {
+		columns := []string{"entity_type", "entity_id", "relation", "subject_type", "subject_id", "subject_relation", "expires_at", "created_tx_id"}
+
+		It("should stream the changes of the committed transactions in order", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM relation_tuples WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') LIMIT 1`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"1"}))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM transactions WHERE tenant_id = $1 AND id > '4'::xid8 AND id < pg_snapshot_xmin(pg_current_snapshot()) ORDER BY id LIMIT 100`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)).AddRow(int64(6)).AddRow(int64(7)))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at, created_tx_id FROM relation_tuples WHERE tenant_id = $1 AND (created_tx_id = '5'::xid8 OR expired_tx_id = '5'::xid8) ORDER BY id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("organization", "abc", "admin", "user", "jack", "", nil, int64(5)).
+					AddRow("organization", "abc", "admin", "user", "john", "", nil, int64(2)))
+			// A transaction that did not change any relation tuple
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at, created_tx_id FROM relation_tuples WHERE tenant_id = $1 AND (created_tx_id = '6'::xid8 OR expired_tx_id = '6'::xid8) ORDER BY id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows(columns))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at, created_tx_id FROM relation_tuples WHERE tenant_id = $1 AND (created_tx_id = '7'::xid8 OR expired_tx_id = '7'::xid8) ORDER BY id`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("organization", "abc", "admin", "user", "jack", "", nil, int64(5)))
+			mock.ExpectCommit()
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT 1 FROM relation_tuples WHERE expired_tx_id = '0'::xid8 AND tenant_id = $1 AND expires_at <= (now() AT TIME ZONE 'UTC') LIMIT 1`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"1"}))
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM transactions WHERE tenant_id = $1 AND id > '7'::xid8 AND id < pg_snapshot_xmin(pg_current_snapshot()) ORDER BY id LIMIT 100`)).
+				WithArgs("t1").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectCommit()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			changes, errs := watcher.Watch(ctx, "t1", snapshot.NewToken(types.XID8{Uint: 4, Status: pgtype.Present}).Encode().String())
+
+			var c *base.TupleChanges
+			Eventually(changes).Should(Receive(&c))
+			Expect(c.GetSnapToken()).Should(Equal(snapshot.NewToken(types.XID8{Uint: 5}).Encode().String()))
+			Expect(c.GetTupleChanges()).Should(HaveLen(2))
+			Expect(c.GetTupleChanges()[0].GetOperation()).Should(Equal(base.TupleChange_OPERATION_CREATE))
+			Expect(c.GetTupleChanges()[0].GetTuple().GetSubject().GetId()).Should(Equal("jack"))
+			Expect(c.GetTupleChanges()[1].GetOperation()).Should(Equal(base.TupleChange_OPERATION_DELETE))
+			Expect(c.GetTupleChanges()[1].GetTuple().GetSubject().GetId()).Should(Equal("john"))
+
+			Eventually(changes).Should(Receive(&c))
+			Expect(c.GetSnapToken()).Should(Equal(snapshot.NewToken(types.XID8{Uint: 7}).Encode().String()))
+			Expect(c.GetTupleChanges()).Should(HaveLen(1))
+			Expect(c.GetTupleChanges()[0].GetOperation()).Should(Equal(base.TupleChange_OPERATION_DELETE))
+
+			// The watcher waits for new transactions once all the finished ones are read
+			Eventually(func() error {
+				return mock.ExpectationsWereMet()
+			}).ShouldNot(HaveOccurred())
+			Consistently(errs).ShouldNot(Receive())
+
+			cancel()
+			Eventually(errs).Should(BeClosed())
+		})
+
+		It("should not watch from an invalid snap token", func() {
+			changes, errs := watcher.Watch(context.Background(), "t1", "not a snap token")
+
+			var err error
+			Eventually(errs).Should(Receive(&err))
+			Expect(err.Error()).Should(Equal(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String()))
+			Eventually(changes).Should(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,36 @@
+// Package relational holds the queries shared by the SQL storage engines. The engines store relation tuples and
+// transactions in the same tables, and differ in how transaction ids are compared and how timestamps are written,
+// which is described by a Dialect.
+package relational
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	relationTuplesTable = "relation_tuples"
+	transactionsTable   = "transactions"
+	tenantsTable        = "tenants"
+)
+
+// TupleColumns are the columns of a relation tuple, in the order they are scanned into a storage.RelationTuple
+const TupleColumns = "entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at"
+
+// Dialect - The parts of the queries that differ between the SQL storage engines
+type Dialect interface {
+	// TxID returns the SQL of the transaction id, to compare the id columns of transactions and relation tuples with
+	TxID(id uint64) squirrel.Sqlizer
+	// SnapshotQuery filters the relation tuples that are visible at the given transaction
+	SnapshotQuery(sl squirrel.SelectBuilder, id uint64) squirrel.SelectBuilder
+	// Now returns the SQL of the current time, in the time zone and layout of the timestamp columns
+	Now() string
+	// Timestamp returns the value of the time, in the time zone and layout of the timestamp columns
+	Timestamp(t time.Time) interface{}
+	// CommittedQuery filters the transactions that committed before every transaction that is still in progress,
+	// so that the transactions are read in commit order
+	CommittedQuery(sl squirrel.SelectBuilder) squirrel.SelectBuilder
+	// Binary returns the SQL of the text column, to compare and order its values by their bytes
+	Binary(column string) string
+}
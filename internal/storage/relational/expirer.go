@@ -0,0 +1,121 @@
+package relational
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// Begin - Records a new transaction of the tenant within the database transaction and returns its id
+type Begin func(ctx context.Context, tx *sql.Tx, tenantID string) (uint64, error)
+
+// Expirer marks the relationships of a tenant that have expired as deleted in a transaction of their own. The
+// expiration advances the snapshot of the tenant, so the reads at the snapshots before it stay repeatable, the
+// results cached by snapshot are not served once a relationship has expired, and the expired relationships are
+// streamed as deletions to the watchers.
+type Expirer struct {
+	// DB is the database the relationships are expired in.
+	DB *sql.DB
+
+	// Builder builds the queries in the placeholder format of the engine.
+	Builder squirrel.StatementBuilderType
+
+	// Dialect holds the parts of the queries that differ between the engines.
+	Dialect Dialect
+
+	// Begin records the transactions the expirations are made in.
+	Begin Begin
+
+	// TxOptions holds the configuration for the transactions the expirations are made in.
+	TxOptions sql.TxOptions
+
+	// Logger is an instance of a logger that implements the logger.Interface.
+	Logger logger.Interface
+}
+
+// Expire marks the relationships of the tenant that have expired as deleted. A transaction is only recorded if any
+// relationship has expired, so reading the head snapshot does not write anything otherwise.
+func (e *Expirer) Expire(ctx context.Context, tenantID string) error {
+	ctx, span := tracer.Start(ctx, "expirer.expire")
+	defer span.End()
+
+	due, err := e.due(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if !due {
+		return nil
+	}
+
+	if err = e.expire(ctx, tenantID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return nil
+}
+
+// due reports whether the tenant has relationships that have expired but are not marked as deleted yet.
+func (e *Expirer) due(ctx context.Context, tenantID string) (bool, error) {
+	query, args, err := DueExpirationQuery(e.Builder, e.Dialect, tenantID).ToSql()
+	if err != nil {
+		return false, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	var found int
+	err = e.DB.QueryRowContext(ctx, query, args...).Scan(&found)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	return true, nil
+}
+
+// expire marks the expired relationships of the tenant as deleted by a new transaction. The transaction is rolled
+// back if another one has expired them in the meantime.
+func (e *Expirer) expire(ctx context.Context, tenantID string) error {
+	tx, err := e.DB.BeginTx(ctx, &e.TxOptions)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) && err != nil {
+			e.Logger.Error("failed to rollback transaction", err)
+		}
+	}()
+
+	id, err := e.Begin(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := ExpireQuery(e.Builder, e.Dialect, tenantID, e.Dialect.TxID(id)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return nil
+	}
+
+	return tx.Commit()
+}
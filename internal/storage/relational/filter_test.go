@@ -1,4 +1,4 @@
-package utils_test
+package relational_test
 
 import (
 	"testing"
@@ -6,7 +6,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
 )
 
@@ -26,7 +26,7 @@ func TestFilterQueryForSelectBuilder(t *testing.T) {
 		},
 	}
 
-	sl = utils.FilterQueryForSelectBuilder(sl, filter)
+	sl = relational.FilterQueryForSelectBuilder(sl, filter)
 
 	expectedSql := "SELECT * FROM test_table WHERE entity_id IN (?,?) AND entity_type = ? AND relation = ? AND subject_id IN (?,?) AND subject_relation = ? AND subject_type = ?"
 	expectedArgs := []interface{}{"1", "2", "entity_type", "relation", "3", "4", "subject_relation", "subject_type"}
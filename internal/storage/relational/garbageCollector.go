@@ -0,0 +1,182 @@
+package relational
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// GarbageCollector - Structure for GarbageCollector
+type GarbageCollector struct {
+	// expirer expires the relationships before they are collected, and holds the database they are collected from
+	expirer *Expirer
+	// logger
+	logger logger.Interface
+	// context to manage goroutines and cancellation
+	ctx    context.Context
+	cancel context.CancelFunc
+	// errgroup for managing multiple goroutines
+	g *errgroup.Group
+	// limit for concurrent permission checks
+	concurrencyLimit int
+	// interval for garbage collection
+	interval time.Duration
+	// timeout for garbage collection
+	timeout time.Duration
+	// window for garbage collection
+	window time.Duration
+}
+
+// NewGarbageCollector creates a new GarbageCollector instance.
+// ctx: context for managing goroutines and cancellation
+// concurrencyLimit: the maximum number of concurrent garbage collection
+func NewGarbageCollector(ctx context.Context, expirer *Expirer, cfg config.DatabaseGarbageCollection) *GarbageCollector {
+	ctx, cancel := context.WithCancel(ctx)
+	return &GarbageCollector{
+		expirer:          expirer,
+		g:                &errgroup.Group{},
+		concurrencyLimit: cfg.NumberOfThreads,
+		interval:         cfg.Interval,
+		timeout:          cfg.Timeout,
+		window:           cfg.Window,
+		logger:           expirer.Logger,
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+}
+
+// Start begins processing permission check requests from the RequestChan.
+// It starts an errgroup that manages multiple goroutines for garbage collector check.
+func (c *GarbageCollector) Start() error {
+	c.g.Go(func() error {
+		sem := semaphore.NewWeighted(int64(c.concurrencyLimit))
+		// for loop time ticker
+
+		// tracer start
+		ctx, span := tracer.Start(c.ctx, "garbage-collector.start")
+		defer span.End()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				c.logger.Info("garbage collector stopped")
+				// wait for all remaining semaphore resources to be released
+				if err := sem.Acquire(context.Background(), int64(c.concurrencyLimit)); err != nil {
+					return err
+				}
+				return nil
+			case <-ticker.C:
+				c.logger.Info("garbage collector started")
+				// acquire a semaphore before processing a request
+				if err := sem.Acquire(ctx, 1); err != nil {
+					continue
+				}
+
+				tenants, err := c.getTenants(ctx)
+				if err != nil {
+					sem.Release(1)
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+					return err
+				}
+
+				// run the permission check in a separate goroutine
+				c.g.Go(func() error {
+					defer sem.Release(1)
+
+					for i := range tenants {
+						err := c.Collect(ctx, tenants[i].Id)
+						if err != nil {
+							span.RecordError(err)
+							span.SetStatus(codes.Error, err.Error())
+							c.logger.Error("garbage collector failed for tenant: " + tenants[i].Id + " with error: " + err.Error())
+
+							return err
+						}
+						c.logger.Info("garbage collector finished for tenant: " + tenants[i].Id)
+					}
+
+					time.Sleep(c.timeout)
+
+					return nil
+				})
+			}
+		}
+	})
+
+	return nil
+}
+
+// Stop stops the GarbageCollector by cancelling its context.
+func (c *GarbageCollector) Stop() {
+	c.cancel()
+}
+
+// Wait waits for all goroutines in the errgroup to finish.
+// Returns an error if any of the goroutines encounter an error.
+func (c *GarbageCollector) Wait() error {
+	if err := c.g.Wait(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *GarbageCollector) getTenants(ctx context.Context) ([]*base.Tenant, error) {
+	// get all tenants
+	query, args, err := TenantsQuery(c.expirer.Builder).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = c.expirer.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// close rows
+	defer rows.Close()
+
+	// iterate over rows and convert to tenant
+	tenants := make([]*base.Tenant, 0)
+
+	for rows.Next() {
+		sd := storage.Tenant{}
+		err = rows.Scan(&sd.ID, &sd.Name, &sd.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, sd.ToTenant())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// Collect expires the relationships of the tenant that are due, and deletes the relationships that were expired
+// before the garbage collection window.
+func (c *GarbageCollector) Collect(ctx context.Context, tenantID string) error {
+	if err := c.expirer.Expire(ctx, tenantID); err != nil {
+		return err
+	}
+
+	query, args, err := GarbageCollectQuery(c.expirer.Builder, c.expirer.Dialect, c.window, tenantID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.expirer.DB.ExecContext(ctx, query, args...)
+	return err
+}
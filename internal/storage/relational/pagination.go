@@ -1,4 +1,4 @@
-package utils
+package relational
 
 import (
 	"encoding/base64"
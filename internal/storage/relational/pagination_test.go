@@ -1,16 +1,16 @@
-package utils_test
+package relational_test
 
 import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/Permify/permify/internal/storage/postgres/utils"
+	"github.com/Permify/permify/internal/storage/relational"
 )
 
 func TestContinuousToken(t *testing.T) {
 	tokenValue := "test_token"
-	token := utils.NewContinuousToken(tokenValue)
+	token := relational.NewContinuousToken(tokenValue)
 
 	// Test Encode
 	encodedToken := token.Encode()
@@ -19,14 +19,14 @@ func TestContinuousToken(t *testing.T) {
 	// Test Decode
 	decodedToken, err := encodedToken.Decode()
 	assert.NoError(t, err)
-	assert.Equal(t, tokenValue, decodedToken.(utils.ContinuousToken).Value)
+	assert.Equal(t, tokenValue, decodedToken.(relational.ContinuousToken).Value)
 
 	// Test Encode and Decode
-	assert.Equal(t, tokenValue, decodedToken.(utils.ContinuousToken).Value)
+	assert.Equal(t, tokenValue, decodedToken.(relational.ContinuousToken).Value)
 }
 
 func TestNoopContinuousToken(t *testing.T) {
-	token := utils.NewNoopContinuousToken()
+	token := relational.NewNoopContinuousToken()
 
 	// Test Encode
 	encodedToken := token.Encode()
@@ -35,8 +35,8 @@ func TestNoopContinuousToken(t *testing.T) {
 	// Test Decode
 	decodedToken, err := encodedToken.Decode()
 	assert.NoError(t, err)
-	assert.Empty(t, decodedToken.(utils.NoopContinuousToken).Value)
+	assert.Empty(t, decodedToken.(relational.NoopContinuousToken).Value)
 
 	// Test Encode and Decode
-	assert.Empty(t, decodedToken.(utils.NoopContinuousToken).Value)
+	assert.Empty(t, decodedToken.(relational.NoopContinuousToken).Value)
 }
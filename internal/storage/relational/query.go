@@ -0,0 +1,133 @@
+package relational
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// RelationTuplesQuery - Selects the columns of the relation tuples of the tenant that match the filter and are
+// visible and not expired at the given transaction
+func RelationTuplesQuery(builder squirrel.StatementBuilderType, dialect Dialect, columns, tenantID string, filter *base.TupleFilter, id uint64) squirrel.SelectBuilder {
+	sl := builder.Select(columns).From(relationTuplesTable).Where(squirrel.Eq{"tenant_id": tenantID})
+	sl = FilterQueryForSelectBuilder(sl, filter)
+	sl = dialect.SnapshotQuery(sl, id)
+	return ExpirationAtQuery(sl, dialect, tenantID, id)
+}
+
+// EntityIDsQuery - Selects the distinct ids of the entities of the type that have relation tuples visible and not
+// expired at the given transaction, up to limit of them after the given id in the byte order of the ids
+func EntityIDsQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID, entityType, after string, id, limit uint64) squirrel.SelectBuilder {
+	column := dialect.Binary("entity_id")
+	return RelationTuplesQuery(builder, dialect, "DISTINCT "+column, tenantID, &base.TupleFilter{Entity: &base.EntityFilter{Type: entityType}}, id).
+		Where(squirrel.Expr(column+" > ?", after)).
+		OrderBy(column).
+		Limit(limit)
+}
+
+// ExpirationQuery - Filters the relationships that have not expired
+func ExpirationQuery(sl squirrel.SelectBuilder, dialect Dialect) squirrel.SelectBuilder {
+	return sl.Where(squirrel.Or{
+		squirrel.Eq{"expires_at": nil},
+		squirrel.Expr("expires_at > " + dialect.Now()),
+	})
+}
+
+// ExpirationAtQuery - Filters the relationships that had not expired at the given transaction. A snapshot holds until
+// the next transaction of the tenant begins, so the expiration is read at the time of that transaction, which keeps
+// the reads at older snapshots repeatable, and at the current time for the head snapshot. The relationships that
+// have expired are marked as deleted by the garbage collector.
+func ExpirationAtQuery(sl squirrel.SelectBuilder, dialect Dialect, tenantID string, id uint64) squirrel.SelectBuilder {
+	return sl.Where(squirrel.Or{
+		squirrel.Eq{"expires_at": nil},
+		squirrel.Expr("expires_at > COALESCE((SELECT MIN(timestamp) FROM "+transactionsTable+" WHERE tenant_id = ? AND id > ?), "+dialect.Now()+")", tenantID, dialect.TxID(id)),
+	})
+}
+
+// ExpirationChangedQuery - Matches the relationships whose expiration is not the given one, which is nil for the
+// relationships that never expire
+func ExpirationChangedQuery(expiresAt interface{}) squirrel.Sqlizer {
+	if expiresAt == nil {
+		return squirrel.NotEq{"expires_at": nil}
+	}
+	return squirrel.Or{
+		squirrel.Eq{"expires_at": nil},
+		squirrel.NotEq{"expires_at": expiresAt},
+	}
+}
+
+// DueExpirationQuery - Selects whether the tenant has relationships that have expired but are not marked as
+// deleted yet
+func DueExpirationQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID string) squirrel.SelectBuilder {
+	return builder.Select("1").From(relationTuplesTable).
+		Where(squirrel.Expr("expired_tx_id = ?", dialect.TxID(0))).
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		Where(squirrel.Expr("expires_at <= " + dialect.Now())).
+		Limit(1)
+}
+
+// ExpireQuery - Marks the relationships of the tenant that have expired as deleted by the given transaction
+func ExpireQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID string, by squirrel.Sqlizer) squirrel.UpdateBuilder {
+	return builder.Update(relationTuplesTable).
+		Set("expired_tx_id", by).
+		Where(squirrel.Expr("expired_tx_id = ?", dialect.TxID(0))).
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		Where(squirrel.Expr("expires_at <= " + dialect.Now()))
+}
+
+// GarbageCollectQuery - Deletes the relationships of the tenant that were expired before the window
+func GarbageCollectQuery(builder squirrel.StatementBuilderType, dialect Dialect, window time.Duration, tenantID string) squirrel.DeleteBuilder {
+	return builder.Delete(relationTuplesTable).
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		Where(squirrel.Expr("expired_tx_id <> ?", dialect.TxID(0))).
+		Where(squirrel.Expr("expired_tx_id IN (SELECT id FROM "+transactionsTable+" WHERE tenant_id = ? AND timestamp < ?)", tenantID, dialect.Timestamp(time.Now().Add(-window))))
+}
+
+// HeadTransactionQuery - Selects the id of the latest transaction of the tenant
+func HeadTransactionQuery(builder squirrel.StatementBuilderType, tenantID string) squirrel.SelectBuilder {
+	return builder.Select("id").From(transactionsTable).Where(squirrel.Eq{"tenant_id": tenantID}).OrderBy("id DESC").Limit(1)
+}
+
+// TransactionAtQuery - Selects the id of the latest transaction of the tenant committed at or before the given time
+func TransactionAtQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID string, timestamp time.Time) squirrel.SelectBuilder {
+	return builder.Select("id").From(transactionsTable).Where(squirrel.Eq{"tenant_id": tenantID}).Where(squirrel.LtOrEq{"timestamp": dialect.Timestamp(timestamp)}).OrderBy("id DESC").Limit(1)
+}
+
+// CollectedTransactionQuery - Selects the given transaction if it is older than the garbage collection window, in
+// which case the changes after it may have been garbage collected
+func CollectedTransactionQuery(builder squirrel.StatementBuilderType, dialect Dialect, id uint64, window time.Duration) squirrel.SelectBuilder {
+	return builder.Select("id").From(transactionsTable).
+		Where(squirrel.Expr("id = ?", dialect.TxID(id))).
+		Where(squirrel.Lt{"timestamp": dialect.Timestamp(time.Now().Add(-window))})
+}
+
+// TransactionsAfterQuery - Selects the ids of the transactions of the tenant after the given one, in order. Only the
+// transactions that committed before every transaction in progress are selected, so that a transaction that commits
+// later is never given a lower id than the ones that were selected.
+func TransactionsAfterQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID string, id, limit uint64) squirrel.SelectBuilder {
+	sl := builder.Select("id").From(transactionsTable).
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		Where(squirrel.Expr("id > ?", dialect.TxID(id)))
+	return dialect.CommittedQuery(sl).
+		OrderBy("id").
+		Limit(limit)
+}
+
+// TransactionChangesQuery - Selects the relation tuples of the tenant the given transaction created or deleted, with
+// the transaction that created them
+func TransactionChangesQuery(builder squirrel.StatementBuilderType, dialect Dialect, tenantID string, id uint64) squirrel.SelectBuilder {
+	return builder.Select(TupleColumns + ", created_tx_id").From(relationTuplesTable).
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		Where(squirrel.Or{
+			squirrel.Expr("created_tx_id = ?", dialect.TxID(id)),
+			squirrel.Expr("expired_tx_id = ?", dialect.TxID(id)),
+		}).
+		OrderBy("id")
+}
+
+// TenantsQuery - Selects the tenants
+func TenantsQuery(builder squirrel.StatementBuilderType) squirrel.SelectBuilder {
+	return builder.Select("id, name, created_at").From(tenantsTable)
+}
@@ -0,0 +1,128 @@
+package relational_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Permify/permify/internal/storage/relational"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// dialect compares transaction ids as literals, and writes timestamps as strings in UTC.
+type dialect struct{}
+
+func (dialect) TxID(id uint64) squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("'%d'::xid", id))
+}
+
+func (dialect) SnapshotQuery(sl squirrel.SelectBuilder, id uint64) squirrel.SelectBuilder {
+	return sl.Where(squirrel.Expr("visible(?)", dialect{}.TxID(id)))
+}
+
+func (dialect) Now() string {
+	return "now()"
+}
+
+func (dialect) Timestamp(t time.Time) interface{} {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (dialect) CommittedQuery(sl squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return sl.Where("committed(id)")
+}
+
+func (dialect) Binary(column string) string {
+	return column + " COLLATE binary"
+}
+
+func TestRelationTuplesQuery(t *testing.T) {
+	query := relational.RelationTuplesQuery(squirrel.StatementBuilder, dialect{}, relational.TupleColumns, "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "admin",
+	}, 42)
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at FROM relation_tuples WHERE tenant_id = ? AND entity_id IN (?) AND entity_type = ? AND relation = ? AND visible('42'::xid) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = ? AND id > '42'::xid), now()))", sql)
+	assert.Equal(t, []interface{}{"t1", "1", "organization", "admin", "t1"}, args)
+}
+
+func TestEntityIDsQuery(t *testing.T) {
+	query := relational.EntityIDsQuery(squirrel.StatementBuilder, dialect{}, "t1", "repository", "5", 42, 10)
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT DISTINCT entity_id COLLATE binary FROM relation_tuples WHERE tenant_id = ? AND entity_type = ? AND visible('42'::xid) AND (expires_at IS NULL OR expires_at > COALESCE((SELECT MIN(timestamp) FROM transactions WHERE tenant_id = ? AND id > '42'::xid), now())) AND entity_id COLLATE binary > ? ORDER BY entity_id COLLATE binary LIMIT 10", sql)
+	assert.Equal(t, []interface{}{"t1", "repository", "t1", "5"}, args)
+}
+
+func TestExpireQuery(t *testing.T) {
+	query := relational.ExpireQuery(squirrel.StatementBuilder, dialect{}, "t1", dialect{}.TxID(7))
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE relation_tuples SET expired_tx_id = '7'::xid WHERE expired_tx_id = '0'::xid AND tenant_id = ? AND expires_at <= now()", sql)
+	assert.Equal(t, []interface{}{"t1"}, args)
+}
+
+func TestExpirationChangedQuery(t *testing.T) {
+	sql, args, err := relational.ExpirationChangedQuery(nil).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "expires_at IS NOT NULL", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = relational.ExpirationChangedQuery("2023-10-21 12:00:00").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(expires_at IS NULL OR expires_at <> ?)", sql)
+	assert.Equal(t, []interface{}{"2023-10-21 12:00:00"}, args)
+}
+
+func TestDueExpirationQuery(t *testing.T) {
+	query := relational.DueExpirationQuery(squirrel.StatementBuilder, dialect{}, "t1")
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM relation_tuples WHERE expired_tx_id = '0'::xid AND tenant_id = ? AND expires_at <= now() LIMIT 1", sql)
+	assert.Equal(t, []interface{}{"t1"}, args)
+}
+
+func TestGarbageCollectQuery(t *testing.T) {
+	query := relational.GarbageCollectQuery(squirrel.StatementBuilder, dialect{}, time.Hour, "t1")
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM relation_tuples WHERE tenant_id = ? AND expired_tx_id <> '0'::xid AND expired_tx_id IN (SELECT id FROM transactions WHERE tenant_id = ? AND timestamp < ?)", sql)
+	assert.Len(t, args, 3)
+	assert.Equal(t, "t1", args[1])
+}
+
+func TestTransactionQueries(t *testing.T) {
+	sql, args, err := relational.HeadTransactionQuery(squirrel.StatementBuilder, "t1").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM transactions WHERE tenant_id = ? ORDER BY id DESC LIMIT 1", sql)
+	assert.Equal(t, []interface{}{"t1"}, args)
+
+	at := time.Date(2023, 10, 21, 12, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	sql, args, err = relational.TransactionAtQuery(squirrel.StatementBuilder, dialect{}, "t1", at).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM transactions WHERE tenant_id = ? AND timestamp <= ? ORDER BY id DESC LIMIT 1", sql)
+	assert.Equal(t, []interface{}{"t1", "2023-10-21T09:30:00Z"}, args)
+
+	sql, args, err = relational.TransactionsAfterQuery(squirrel.StatementBuilder, dialect{}, "t1", 4, 100).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM transactions WHERE tenant_id = ? AND id > '4'::xid AND committed(id) ORDER BY id LIMIT 100", sql)
+	assert.Equal(t, []interface{}{"t1"}, args)
+
+	sql, args, err = relational.TransactionChangesQuery(squirrel.StatementBuilder, dialect{}, "t1", 5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expires_at, created_tx_id FROM relation_tuples WHERE tenant_id = ? AND (created_tx_id = '5'::xid OR expired_tx_id = '5'::xid) ORDER BY id", sql)
+	assert.Equal(t, []interface{}{"t1"}, args)
+
+	sql, _, err = relational.CollectedTransactionQuery(squirrel.StatementBuilder, dialect{}, 5, time.Hour).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM transactions WHERE id = '5'::xid AND timestamp < ?", sql)
+}
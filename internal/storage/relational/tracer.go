@@ -0,0 +1,7 @@
+package relational
+
+import (
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("storage.relational")
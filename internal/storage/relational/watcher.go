@@ -0,0 +1,262 @@
+package relational
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// Watcher streams the changes to the relation tuples of a tenant. The transaction ids of a tenant are read in commit
+// order, which the engines ensure by serializing the writers of a tenant or by reading only the transactions that
+// committed before every transaction in progress, so the changes of a transaction can be read through the
+// created_tx_id and expired_tx_id columns of the relation tuples once its id is read.
+type Watcher struct {
+	// DB is the database the changes are read from.
+	DB *sql.DB
+
+	// Builder builds the queries in the placeholder format of the engine.
+	Builder squirrel.StatementBuilderType
+
+	// Dialect holds the parts of the queries that differ between the engines.
+	Dialect Dialect
+
+	// TxOptions holds the configuration for the transactions the changes are read in.
+	TxOptions sql.TxOptions
+
+	// Window is the garbage collection window, 0 if the history is kept.
+	Window time.Duration
+
+	// BatchSize is the maximum number of transactions read at once.
+	BatchSize uint64
+
+	// PollInterval is the time waited before reading the transactions again when there is no new one.
+	PollInterval time.Duration
+
+	// Expirer records the expiration of the relationships of the tenant, so that their deletion is streamed even if
+	// the tenant is not written to.
+	Expirer *Expirer
+
+	// Decode returns the transaction id of a snap token, and Encode returns the snap token of a transaction id.
+	Decode func(snap string) (uint64, error)
+	Encode func(id uint64) string
+
+	// Logger is an instance of a logger that implements the logger.Interface.
+	Logger logger.Interface
+}
+
+// Watch streams the changes to the relation tuples of the tenant committed after the given snapshot. Each
+// TupleChanges holds the changes of one transaction and the snap token of that transaction, so a client that
+// disconnects can resume by watching from the last snap token it received. The error channel receives at most
+// one error, after which both channels are closed.
+func (w *Watcher) Watch(ctx context.Context, tenantID, snap string) (<-chan *base.TupleChanges, <-chan error) {
+	changes := make(chan *base.TupleChanges, w.BatchSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(changes)
+		defer close(errs)
+
+		cp, err := w.Decode(snap)
+		if err != nil {
+			errs <- errors.New(base.ErrorCode_ERROR_CODE_INVALID_SNAP_TOKEN.String())
+			return
+		}
+
+		if err = w.checkExpiration(ctx, cp); err != nil {
+			errs <- err
+			return
+		}
+
+		for {
+			prev := cp
+
+			if err = w.Expirer.Expire(ctx, tenantID); err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			var batch []*base.TupleChanges
+			batch, cp, err = w.getChanges(ctx, tenantID, cp)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			for _, c := range batch {
+				select {
+				case changes <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// Wait for new transactions only once all the finished ones are read.
+			if cp != prev {
+				continue
+			}
+
+			select {
+			case <-time.After(w.PollInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, errs
+}
+
+// checkExpiration returns an error if the changes after the given transaction may have been garbage collected.
+func (w *Watcher) checkExpiration(ctx context.Context, cp uint64) error {
+	if w.Window <= 0 || cp == 0 {
+		return nil
+	}
+
+	query, args, err := CollectedTransactionQuery(w.Builder, w.Dialect, cp, w.Window).ToSql()
+	if err != nil {
+		return errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	var id uint64
+	err = w.DB.QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	return errors.New(base.ErrorCode_ERROR_CODE_SNAPSHOT_EXPIRED.String())
+}
+
+// getChanges reads the changes of the transactions of the tenant after the given one. It returns the changes in
+// the order of the transactions and the last transaction that was read.
+func (w *Watcher) getChanges(ctx context.Context, tenantID string, cp uint64) (result []*base.TupleChanges, last uint64, err error) {
+	ctx, span := tracer.Start(ctx, "watcher.get-changes")
+	defer span.End()
+
+	last = cp
+
+	var tx *sql.Tx
+	tx, err = w.DB.BeginTx(ctx, &w.TxOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, last, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer func() {
+		if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) && err != nil {
+			w.Logger.Error("failed to rollback transaction", err)
+		}
+	}()
+
+	var query string
+	var args []interface{}
+	query, args, err = TransactionsAfterQuery(w.Builder, w.Dialect, tenantID, cp, w.BatchSize).ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, last, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	var rows *sql.Rows
+	rows, err = tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, last, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, last, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, last, err
+	}
+
+	for _, id := range ids {
+		var changes *base.TupleChanges
+		changes, err = w.getTransactionChanges(ctx, tx, tenantID, id)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, last, err
+		}
+		last = id
+		// Transactions that did not change any relation tuple are skipped.
+		if len(changes.GetTupleChanges()) > 0 {
+			result = append(result, changes)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, cp, err
+	}
+
+	return result, last, nil
+}
+
+// getTransactionChanges reads the relation tuples the given transaction created or deleted.
+func (w *Watcher) getTransactionChanges(ctx context.Context, tx *sql.Tx, tenantID string, id uint64) (*base.TupleChanges, error) {
+	query, args, err := TransactionChangesQuery(w.Builder, w.Dialect, tenantID, id).ToSql()
+	if err != nil {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer rows.Close()
+
+	changes := &base.TupleChanges{
+		SnapToken: w.Encode(id),
+	}
+	for rows.Next() {
+		rt := storage.RelationTuple{}
+		var created uint64
+		var expiresAt sql.NullTime
+		if err = rows.Scan(&rt.EntityType, &rt.EntityID, &rt.Relation, &rt.SubjectType, &rt.SubjectID, &rt.SubjectRelation, &expiresAt, &created); err != nil {
+			return nil, err
+		}
+		rt.ExpiresAt = expiresAt.Time
+		operation := base.TupleChange_OPERATION_DELETE
+		if created == id {
+			operation = base.TupleChange_OPERATION_CREATE
+		}
+		changes.TupleChanges = append(changes.TupleChanges, &base.TupleChange{
+			Operation: operation,
+			Tuple:     rt.ToTuple(),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"time"
+)
+
+const (
+	RelationTuplesTable   = "relation_tuples"
+	SchemaDefinitionTable = "schema_definitions"
+	TransactionsTable     = "transactions"
+	TenantsTable          = "tenants"
+	// ImportTable is the temporary table relation tuples are staged in during a bulk import
+	ImportTable = "relation_tuples_import"
+)
+
+const (
+	_defaultMaxTuplesPerWrite = 100
+	_defaultMaxRetries        = 10
+	_defaultWatchBatchSize    = 100
+	_defaultWatchPollInterval = 100 * time.Millisecond
+)
@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Permify/permify/internal/storage/relational"
+	"github.com/Permify/permify/internal/storage/sqlite/utils"
+	db "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// newExpirer - Creates the expirer of the relationships of the tenants. The expirations are recorded like the writes
+// are, after the write lock of the database is taken.
+func newExpirer(database *db.SQLite, logger logger.Interface) *relational.Expirer {
+	return &relational.Expirer{
+		DB:      database.DB,
+		Builder: database.Builder,
+		Dialect: utils.Dialect{},
+		Begin: func(ctx context.Context, tx *sql.Tx, tenantID string) (uint64, error) {
+			return beginTransaction(ctx, database, tx, tenantID)
+		},
+		TxOptions: sql.TxOptions{ReadOnly: false},
+		Logger:    logger,
+	}
+}
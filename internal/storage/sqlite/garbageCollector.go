@@ -0,0 +1,24 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage/relational"
+	db "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// GarbageCollector - Structure for GarbageCollector
+type GarbageCollector struct {
+	*relational.GarbageCollector
+}
+
+// NewGarbageCollector creates a new GarbageCollector instance.
+// ctx: context for managing goroutines and cancellation
+// concurrencyLimit: the maximum number of concurrent garbage collection
+func NewGarbageCollector(ctx context.Context, db *db.SQLite, logger logger.Interface, cfg config.DatabaseGarbageCollection) *GarbageCollector {
+	return &GarbageCollector{
+		GarbageCollector: relational.NewGarbageCollector(ctx, newExpirer(db, logger), cfg),
+	}
+}
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_tuples_created_tx ON relation_tuples (tenant_id, created_tx_id);
+CREATE INDEX IF NOT EXISTS idx_tuples_expired_tx ON relation_tuples (tenant_id, expired_tx_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tuples_created_tx;
+DROP INDEX IF EXISTS idx_tuples_expired_tx;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE relation_tuples ADD COLUMN expires_at DATETIME NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tuples_expires_at ON relation_tuples (tenant_id, expires_at) WHERE expires_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tuples_expires_at;
+
+ALTER TABLE relation_tuples DROP COLUMN expires_at;
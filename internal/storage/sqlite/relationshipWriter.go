@@ -0,0 +1,574 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/squirrel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/internal/storage/relational"
+	"github.com/Permify/permify/internal/storage/sqlite/snapshot"
+	"github.com/Permify/permify/internal/storage/sqlite/utils"
+	"github.com/Permify/permify/pkg/database"
+	db "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+)
+
+// RelationshipWriter - Structure for Relationship Writer
+type RelationshipWriter struct {
+	database *db.SQLite
+	// options
+	txOptions         sql.TxOptions
+	maxTuplesPerWrite int
+	maxRetries        int
+	// logger
+	logger logger.Interface
+}
+
+// NewRelationshipWriter - Creates a new RelationshipWriter
+func NewRelationshipWriter(database *db.SQLite, logger logger.Interface) *RelationshipWriter {
+	return &RelationshipWriter{
+		database:          database,
+		txOptions:         sql.TxOptions{ReadOnly: false},
+		maxTuplesPerWrite: _defaultMaxTuplesPerWrite,
+		maxRetries:        _defaultMaxRetries,
+		logger:            logger,
+	}
+}
+
+// WriteRelationships - Writes a collection of relationships to the database
+func (w *RelationshipWriter) WriteRelationships(ctx context.Context, tenantID string, collection *database.TupleCollection) (token token.EncodedSnapToken, err error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.write-relationships")
+	defer span.End()
+
+	if len(collection.GetTuples()) > w.maxTuplesPerWrite {
+		return nil, errors.New("max tuples per write exceeded")
+	}
+
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = w.database.DB.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, err
+		}
+
+		var id uint64
+		id, err = beginTransaction(ctx, w.database, tx, tenantID)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		insertBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at")
+
+		// The relationships that have expired but are not marked as deleted yet are marked first, so that they can
+		// be written again.
+		written := squirrel.Or{}
+
+		iter := collection.CreateTupleIterator()
+		for iter.HasNext() {
+			t := iter.GetNext()
+			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, id, utils.ExpiresAt(t))
+			written = append(written, squirrel.Eq{
+				"entity_type":      t.GetEntity().GetType(),
+				"entity_id":        t.GetEntity().GetId(),
+				"relation":         t.GetRelation(),
+				"subject_type":     t.GetSubject().GetType(),
+				"subject_id":       t.GetSubject().GetId(),
+				"subject_relation": t.GetSubject().GetRelation(),
+			})
+		}
+
+		var query string
+		var args []interface{}
+
+		query, args, err = relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, utils.Dialect{}.TxID(id)).Where(written).ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		query, args, err = insertBuilder.ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			} else if utils.IsDuplicate(err) {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+			} else {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+			}
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		return snapshot.NewToken(id).Encode(), nil
+	}
+
+	return nil, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
+
+// DeleteRelationships - Deletes a collection of relationships to the database
+func (w *RelationshipWriter) DeleteRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter) (token token.EncodedSnapToken, err error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.delete-relationships")
+	defer span.End()
+
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = w.database.DB.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, err
+		}
+
+		var id uint64
+		id, err = beginTransaction(ctx, w.database, tx, tenantID)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		builder := w.database.Builder.Update(RelationTuplesTable).Set("expired_tx_id", id).Where(squirrel.Eq{"tenant_id": tenantID, "expired_tx_id": 0})
+		builder = relational.FilterQueryForUpdateBuilder(builder, filter)
+
+		var query string
+		var args []interface{}
+
+		query, args, err = builder.ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			} else {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+			}
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		return snapshot.NewToken(id).Encode(), nil
+	}
+
+	return nil, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
+
+// TransactRelationships - Applies the operations to the relation tuples in a single transaction, if all the
+// preconditions hold. The preconditions are checked while the transaction holds the write lock of the database, so no
+// other write can change their result before the transaction is committed.
+func (w *RelationshipWriter) TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token.EncodedSnapToken, error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.transact-relationships")
+	defer span.End()
+
+	if len(operations) > w.maxTuplesPerWrite {
+		return nil, errors.New("max tuples per write exceeded")
+	}
+
+	var err error
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = w.database.DB.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, err
+		}
+
+		var id uint64
+		id, err = beginTransaction(ctx, w.database, tx, tenantID)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		err = w.transact(ctx, tx, tenantID, id, preconditions, operations)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			} else if utils.IsDuplicate(err) {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+			} else if _, ok := base.ErrorCode_value[err.Error()]; ok {
+				return nil, err
+			} else {
+				return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+			}
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		return snapshot.NewToken(id).Encode(), nil
+	}
+
+	return nil, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
+
+// transact - Checks the preconditions and applies the operations in the transaction with the given ID. The errors of
+// the database are returned as they are, so that the caller can tell the ones to retry.
+func (w *RelationshipWriter) transact(ctx context.Context, tx *sql.Tx, tenantID string, id uint64, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) error {
+	for _, precondition := range preconditions {
+		builder := w.database.Builder.Select("1").From(RelationTuplesTable).Where(squirrel.Eq{"tenant_id": tenantID, "expired_tx_id": 0})
+		builder = relational.ExpirationQuery(relational.FilterQueryForSelectBuilder(builder, precondition.GetFilter()), utils.Dialect{}).Limit(1)
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		var matched int
+		err = tx.QueryRowContext(ctx, query, args...).Scan(&matched)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if (err == nil) != (precondition.GetOperation() == base.TuplePrecondition_OPERATION_MUST_MATCH) {
+			return errors.New(base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+		}
+	}
+
+	insertBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at")
+	touchBuilder := w.database.Builder.Insert(RelationTuplesTable).Columns("entity_type, entity_id, relation, subject_type, subject_id, subject_relation, tenant_id, created_tx_id, expires_at").
+		Suffix("ON CONFLICT (tenant_id, entity_type, entity_id, relation, subject_type, subject_id, subject_relation, expired_tx_id) DO NOTHING")
+	inserted, touched := false, false
+
+	// The relation tuples that are deleted are marked as deleted. The ones that are created or touched are marked as
+	// deleted only if they have expired, so that creating a relation tuple that exists fails, and touching it keeps it
+	// with its original created_tx_id. A touch that changes the expiration marks the relation tuple as deleted and
+	// writes it again, so that the change is a new version of it that snapshots and watchers see.
+	removed := squirrel.Or{}
+	created := squirrel.Or{}
+
+	for _, operation := range operations {
+		t := operation.GetTuple()
+		eq := squirrel.Eq{
+			"entity_type":      t.GetEntity().GetType(),
+			"entity_id":        t.GetEntity().GetId(),
+			"relation":         t.GetRelation(),
+			"subject_type":     t.GetSubject().GetType(),
+			"subject_id":       t.GetSubject().GetId(),
+			"subject_relation": t.GetSubject().GetRelation(),
+		}
+		switch operation.GetOperation() {
+		case base.TupleOperation_OPERATION_DELETE:
+			removed = append(removed, eq)
+		case base.TupleOperation_OPERATION_TOUCH:
+			if t.GetExpiresAt() != nil || operation.GetClearExpiration() {
+				removed = append(removed, squirrel.And{eq, relational.ExpirationChangedQuery(utils.ExpiresAt(t))})
+			}
+			created = append(created, eq)
+			touchBuilder = touchBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, id, utils.ExpiresAt(t))
+			touched = true
+		default:
+			created = append(created, eq)
+			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), tenantID, id, utils.ExpiresAt(t))
+			inserted = true
+		}
+	}
+
+	var statements []squirrel.Sqlizer
+	if len(removed) > 0 {
+		statements = append(statements, w.database.Builder.Update(RelationTuplesTable).Set("expired_tx_id", id).Where(squirrel.Eq{"tenant_id": tenantID, "expired_tx_id": 0}).Where(removed))
+	}
+	if len(created) > 0 {
+		statements = append(statements, relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, utils.Dialect{}.TxID(id)).Where(created))
+	}
+	if inserted {
+		statements = append(statements, insertBuilder)
+	}
+	if touched {
+		statements = append(statements, touchBuilder)
+	}
+
+	for _, statement := range statements {
+		query, args, err := statement.ToSql()
+		if err != nil {
+			return errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BulkImport - Imports the relation tuples of the batches. The batches are inserted into a temporary staging table
+// as they are received, and the staged relation tuples are written in a single transaction after the last batch, so
+// they become visible at once. Relation tuples that are already written are skipped and not counted as imported.
+func (w *RelationshipWriter) BulkImport(ctx context.Context, tenantID string, batches storage.TupleBatches) (token.EncodedSnapToken, int64, error) {
+	ctx, span := tracer.Start(ctx, "relationship-writer.bulk-import")
+	defer span.End()
+
+	// Temporary tables only exist on the connection that created them
+	conn, err := w.database.DB.Conn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("CREATE TEMPORARY TABLE %s (entity_type TEXT NOT NULL, entity_id TEXT NOT NULL, relation TEXT NOT NULL, subject_type TEXT NOT NULL, subject_id TEXT NOT NULL, subject_relation TEXT NOT NULL, expires_at DATETIME NULL)", ImportTable))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), fmt.Sprintf("DROP TABLE IF EXISTS temp.%s", ImportTable)); err != nil {
+			w.logger.Error("failed to drop the staging table of the bulk import", err)
+		}
+	}()
+
+	columns := "entity_type, entity_id, relation, subject_type, subject_id, subject_relation"
+
+	staged := 0
+	for {
+		var collection *database.TupleCollection
+		collection, err = batches()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, err
+		}
+		if len(collection.GetTuples()) == 0 {
+			continue
+		}
+
+		insertBuilder := w.database.Builder.Insert("temp." + ImportTable).Columns(columns + ", expires_at")
+		for _, t := range collection.GetTuples() {
+			insertBuilder = insertBuilder.Values(t.GetEntity().GetType(), t.GetEntity().GetId(), t.GetRelation(), t.GetSubject().GetType(), t.GetSubject().GetId(), t.GetSubject().GetRelation(), utils.ExpiresAt(t))
+		}
+
+		var query string
+		var args []interface{}
+
+		query, args, err = insertBuilder.ToSql()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		if _, err = conn.ExecContext(ctx, query, args...); err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+		staged += len(collection.GetTuples())
+	}
+
+	if staged == 0 {
+		return token.NewNoopToken().Encode(), 0, nil
+	}
+
+	for i := 0; i <= w.maxRetries; i++ {
+		var tx *sql.Tx
+		tx, err = conn.BeginTx(ctx, &w.txOptions)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, err
+		}
+
+		var id uint64
+		id, err = beginTransaction(ctx, w.database, tx, tenantID)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, 0, err
+		}
+
+		var query string
+		var args []interface{}
+
+		// The staged relationships that have expired but are not marked as deleted yet are marked first, so that
+		// they can be written again.
+		query, args, err = relational.ExpireQuery(w.database.Builder, utils.Dialect{}, tenantID, utils.Dialect{}.TxID(id)).
+			Where(squirrel.Expr(fmt.Sprintf("(%s) IN (SELECT %s FROM %s)", columns, columns, "temp."+ImportTable))).
+			ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		query, args, err = w.database.Builder.Insert(RelationTuplesTable).Options("OR IGNORE").
+			Columns(columns + ", tenant_id, created_tx_id, expires_at").
+			Select(squirrel.Select(columns).Column(squirrel.Expr("?", tenantID)).Column(squirrel.Expr("?", id)).Column("expires_at").From("temp." + ImportTable)).
+			ToSql()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+		}
+
+		var result sql.Result
+		result, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		// The relation tuples that were already written are skipped by the insert, so they are not counted
+		var imported int64
+		imported, err = result.RowsAffected()
+		if err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		if err = tx.Commit(); err != nil {
+			utils.Rollback(tx, w.logger)
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, err.Error())
+			if utils.IsRetryable(err) {
+				continue
+			}
+			return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+		}
+
+		return snapshot.NewToken(id).Encode(), imported, nil
+	}
+
+	return nil, 0, errors.New(base.ErrorCode_ERROR_CODE_ERROR_MAX_RETRIES.String())
+}
+
+// beginTransaction - Records a new transaction for the tenant, returning the id of the transaction. Read-write
+// transactions hold the write lock of the database from the moment they begin, so the AUTOINCREMENT ids of
+// transactions follow commit order.
+func beginTransaction(ctx context.Context, database *db.SQLite, tx *sql.Tx, tenantID string) (id uint64, err error) {
+	var query string
+	var args []interface{}
+
+	query, args, err = database.Builder.Insert(TransactionsTable).Columns("tenant_id").Values(tenantID).ToSql()
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	var result sql.Result
+	result, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		if utils.IsRetryable(err) {
+			return 0, err
+		}
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	var last int64
+	last, err = result.LastInsertId()
+	if err != nil {
+		return 0, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	return uint64(last), nil
+}
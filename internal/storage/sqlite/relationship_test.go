@@ -0,0 +1,445 @@
+package sqlite
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage/sqlite/snapshot"
+	"github.com/Permify/permify/pkg/database"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+func tuples(t *testing.T, values ...string) *database.TupleCollection {
+	collection := database.NewTupleCollection()
+	for _, v := range values {
+		tup, err := tuple.Tuple(v)
+		require.NoError(t, err)
+		collection.Add(tup)
+	}
+	return collection
+}
+
+func subjects(t *testing.T, reader *RelationshipReader, filter *base.TupleFilter, snap token.EncodedSnapToken) []string {
+	it, err := reader.QueryRelationships(context.Background(), "t1", filter, snap.String())
+	require.NoError(t, err)
+
+	ids := make([]string, 0)
+	for it.HasNext() {
+		ids = append(ids, it.GetNext().GetSubject().GetId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRelationships_Snapshots(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(0), head)
+
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"))
+	require.NoError(t, err)
+
+	// Writing a relationship that exists violates the unique constraint
+	_, err = writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	deleted, err := writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+		Subject:  &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+
+	rewritten, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:3"))
+	require.NoError(t, err)
+
+	head, err = reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, rewritten.String(), head.Encode().String())
+
+	// Each snapshot sees the relationships as they were when it was taken
+	assert.Equal(t, []string{"1", "2"}, subjects(t, reader, filter, written))
+	assert.Equal(t, []string{"2"}, subjects(t, reader, filter, deleted))
+	assert.Equal(t, []string{"1", "2", "3"}, subjects(t, reader, filter, rewritten))
+
+	// Other tenants are not affected
+	head, err = reader.HeadSnapshot(ctx, "t2")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(0), head)
+}
+
+func TestRelationships_ReadRelationships(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	snap, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2", "organization:1#member@user:3"))
+	require.NoError(t, err)
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization"}}
+
+	var ids []string
+	pagination := database.NewPagination(database.Size(2))
+	for {
+		collection, ct, err := reader.ReadRelationships(ctx, "t1", filter, snap.String(), pagination)
+		require.NoError(t, err)
+		for _, tup := range collection.GetTuples() {
+			ids = append(ids, tup.GetSubject().GetId())
+		}
+		if ct.String() == "" {
+			break
+		}
+		pagination = database.NewPagination(database.Size(2), database.Token(ct.String()))
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestRelationships_ConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	var wg sync.WaitGroup
+	snaps := make([]token.EncodedSnapToken, 10)
+	for i := range snaps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			snaps[i], err = writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:"+string(rune('a'+i))))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Every write is visible in its own snapshot together with exactly the writes that committed before it
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}}
+	for _, snap := range snaps {
+		st, err := snapshot.EncodedToken{Value: snap.String()}.Decode()
+		require.NoError(t, err)
+		assert.Len(t, subjects(t, reader, filter, snap), int(st.(snapshot.Token).Value))
+	}
+}
+
+func TestRelationships_SnapshotAt(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	before := time.Now()
+
+	snap, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+
+	st, err := reader.SnapshotAt(ctx, "t1", before.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.NewToken(0), st)
+
+	st, err = reader.SnapshotAt(ctx, "t1", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, snap.String(), st.Encode().String())
+}
+
+func TestGarbageCollector_RemovesExpiredRelationships(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+
+	_, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"))
+	require.NoError(t, err)
+
+	_, err = writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:  &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+
+	count := func() (n int) {
+		require.NoError(t, db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM relation_tuples").Scan(&n))
+		return n
+	}
+
+	// Relationships expired within the window are kept
+	gc := NewGarbageCollector(ctx, db, l, config.DatabaseGarbageCollection{Window: time.Hour})
+	require.NoError(t, gc.Collect(ctx, "t1"))
+	assert.Equal(t, 2, count())
+
+	gc = NewGarbageCollector(ctx, db, l, config.DatabaseGarbageCollection{Window: -time.Minute})
+	require.NoError(t, gc.Collect(ctx, "t1"))
+	assert.Equal(t, 1, count())
+}
+
+func TestRelationships_ExpiresAt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+	watcher := NewWatcher(db, l)
+	watcher.PollInterval = 10 * time.Millisecond
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+
+	collection := tuples(t, "organization:1#member@user:1", "organization:1#member@user:2", "organization:1#member@user:3")
+	expiresAt := time.Now().Add(100 * time.Millisecond)
+	collection.GetTuples()[0].ExpiresAt = timestamppb.New(expiresAt)
+	collection.GetTuples()[1].ExpiresAt = timestamppb.New(expiresAt)
+
+	written, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, subjects(t, reader, filter, written))
+
+	it, err := reader.QueryRelationships(ctx, "t1", filter, written.String())
+	require.NoError(t, err)
+	assert.True(t, it.GetNext().GetExpiresAt().AsTime().Equal(expiresAt.Truncate(time.Microsecond)))
+
+	// A later transaction ends the snapshot of the write before the expiration
+	later, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:2#member@user:1"))
+	require.NoError(t, err)
+
+	// The current time of the database has millisecond precision
+	time.Sleep(time.Until(expiresAt.Add(time.Millisecond)))
+
+	// Reads at a snapshot that ended before the expiration are repeatable
+	assert.Equal(t, []string{"1", "2", "3"}, subjects(t, reader, filter, written))
+
+	// The head snapshot is read at the current time, no transaction is recorded for the expiration
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, later.String(), head.Encode().String())
+	assert.Equal(t, []string{"3"}, subjects(t, reader, filter, head.Encode()))
+
+	// An expired relationship can be written again
+	rewritten, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, subjects(t, reader, filter, rewritten))
+
+	// Collecting marks the rest of the expired relationships as deleted in a transaction of their own
+	gc := NewGarbageCollector(ctx, db, l, config.DatabaseGarbageCollection{Window: time.Hour})
+	require.NoError(t, gc.Collect(ctx, "t1"))
+	collected, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.NotEqual(t, rewritten.String(), collected.Encode().String())
+	assert.Equal(t, []string{"1", "3"}, subjects(t, reader, filter, collected.Encode()))
+
+	// The expirations are streamed to the watchers as deletes
+	changes, errs := watcher.Watch(ctx, "t1", later.String())
+
+	c := next(t, changes, errs)
+	assert.Equal(t, rewritten.String(), c.GetSnapToken())
+	assert.Equal(t, []string{
+		"OPERATION_DELETE organization:1#member@user:1",
+		"OPERATION_CREATE organization:1#member@user:1",
+	}, operations(c))
+
+	c = next(t, changes, errs)
+	assert.Equal(t, collected.Encode().String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_DELETE organization:1#member@user:2"}, operations(c))
+
+	// Collecting again records no transaction when nothing else has expired
+	require.NoError(t, gc.Collect(ctx, "t1"))
+	again, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, collected.Encode().String(), again.Encode().String())
+}
+
+func TestRelationships_BulkImport(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1"))
+	require.NoError(t, err)
+
+	batches := []*database.TupleCollection{
+		tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"),
+		tuples(t, "organization:1#member@user:3"),
+	}
+	snap, imported, err := writer.BulkImport(ctx, "t1", func() (*database.TupleCollection, error) {
+		if len(batches) == 0 {
+			return nil, io.EOF
+		}
+		batch := batches[0]
+		batches = batches[1:]
+		return batch, nil
+	})
+	require.NoError(t, err)
+
+	// The imported relationships become visible at once, and the ones that exist are skipped and not counted
+	assert.Equal(t, int64(2), imported)
+	assert.Equal(t, []string{"1"}, subjects(t, reader, filter, written))
+	assert.Equal(t, []string{"1", "2", "3"}, subjects(t, reader, filter, snap))
+
+	// The staging table is dropped once the import is done, so another import can run
+	snap, imported, err = writer.BulkImport(ctx, "t1", func() (*database.TupleCollection, error) {
+		if batches == nil {
+			return nil, io.EOF
+		}
+		batches = nil
+		return tuples(t, "organization:1#member@user:4"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), imported)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, subjects(t, reader, filter, snap))
+
+	// An import without relationships does not write a transaction
+	empty, imported, err := writer.BulkImport(ctx, "t1", func() (*database.TupleCollection, error) {
+		return nil, io.EOF
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), imported)
+	assert.Equal(t, token.NewNoopToken().Encode(), empty)
+}
+
+func TestRelationships_TransactRelationships(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+	operation := func(op base.TupleOperation_Operation, value string) *base.TupleOperation {
+		tup, err := tuple.Tuple(value)
+		require.NoError(t, err)
+		return &base.TupleOperation{Operation: op, Tuple: tup}
+	}
+
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"))
+	require.NoError(t, err)
+
+	transacted, err := writer.TransactRelationships(ctx, "t1", []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_MATCH,
+		Filter:    &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member", Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}}},
+	}, {
+		Operation: base.TuplePrecondition_OPERATION_MUST_NOT_MATCH,
+		Filter:    &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "admin"},
+	}}, []*base.TupleOperation{
+		operation(base.TupleOperation_OPERATION_DELETE, "organization:1#member@user:1"),
+		operation(base.TupleOperation_OPERATION_TOUCH, "organization:1#member@user:2"),
+		operation(base.TupleOperation_OPERATION_CREATE, "organization:1#member@user:3"),
+	})
+	require.NoError(t, err)
+
+	// The operations are visible together, from a single snapshot
+	assert.Equal(t, []string{"1", "2"}, subjects(t, reader, filter, written))
+	assert.Equal(t, []string{"2", "3"}, subjects(t, reader, filter, transacted))
+
+	// A precondition that does not hold fails the transaction without applying any operation
+	_, err = writer.TransactRelationships(ctx, "t1", []*base.TuplePrecondition{{
+		Operation: base.TuplePrecondition_OPERATION_MUST_MATCH,
+		Filter:    &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member", Subject: &base.SubjectFilter{Type: "user", Ids: []string{"1"}}},
+	}}, []*base.TupleOperation{
+		operation(base.TupleOperation_OPERATION_CREATE, "organization:1#member@user:4"),
+	})
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_PRECONDITION_FAILED.String())
+
+	// Creating a relation tuple that exists fails the transaction
+	_, err = writer.TransactRelationships(ctx, "t1", nil, []*base.TupleOperation{
+		operation(base.TupleOperation_OPERATION_CREATE, "organization:1#member@user:4"),
+		operation(base.TupleOperation_OPERATION_CREATE, "organization:1#member@user:3"),
+	})
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	head, err := reader.HeadSnapshot(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, subjects(t, reader, filter, head.Encode()))
+}
+
+func TestRelationships_TouchKeepsExistingTuples(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	reader := NewRelationshipReader(db, l)
+
+	filter := &base.TupleFilter{Entity: &base.EntityFilter{Type: "organization", Ids: []string{"1"}}, Relation: "member"}
+
+	collection := tuples(t, "organization:1#member@user:1")
+	expiresAt := time.Now().Add(time.Second)
+	collection.GetTuples()[0].ExpiresAt = timestamppb.New(expiresAt)
+
+	written, err := writer.WriteRelationships(ctx, "t1", collection)
+	require.NoError(t, err)
+
+	tup, err := tuple.Tuple("organization:1#member@user:1")
+	require.NoError(t, err)
+
+	touch := []*base.TupleOperation{{Operation: base.TupleOperation_OPERATION_TOUCH, Tuple: tup}}
+	_, err = writer.TransactRelationships(ctx, "t1", nil, touch)
+	require.NoError(t, err)
+
+	// Touching again is not a conflict
+	touched, err := writer.TransactRelationships(ctx, "t1", nil, touch)
+	require.NoError(t, err)
+
+	// The existing row is kept, with its creation transaction and its expiration
+	var count int
+	require.NoError(t, db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM relation_tuples").Scan(&count))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"1"}, subjects(t, reader, filter, written))
+
+	it, err := reader.QueryRelationships(ctx, "t1", filter, touched.String())
+	require.NoError(t, err)
+	assert.True(t, it.GetNext().GetExpiresAt().AsTime().Equal(expiresAt.Truncate(time.Microsecond)))
+
+	// Clearing the expiration writes a new version of the row
+	touch[0].ClearExpiration = true
+	cleared, err := writer.TransactRelationships(ctx, "t1", nil, touch)
+	require.NoError(t, err)
+
+	require.NoError(t, db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM relation_tuples").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	it, err = reader.QueryRelationships(ctx, "t1", filter, cleared.String())
+	require.NoError(t, err)
+	assert.Nil(t, it.GetNext().GetExpiresAt())
+
+	// The snapshots before the touch still read the expiration, and the relationship does not expire anymore
+	time.Sleep(time.Until(expiresAt))
+	assert.Equal(t, []string{"1"}, subjects(t, reader, filter, touched))
+	assert.Equal(t, []string{"1"}, subjects(t, reader, filter, cleared))
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestSchemas(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewSchemaWriter(db, l)
+	reader := NewSchemaReader(db, l)
+
+	_, err := reader.HeadVersion(ctx, "t1")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_SCHEMA_NOT_FOUND.String())
+
+	version := xid.New().String()
+	err = writer.WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: version},
+		{TenantID: "t1", EntityType: "organization", SerializedDefinition: []byte("entity organization {\n relation member @user\n}"), Version: version},
+	})
+	require.NoError(t, err)
+
+	head, err := reader.HeadVersion(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, version, head)
+
+	sch, err := reader.ReadSchema(ctx, "t1", version)
+	require.NoError(t, err)
+	assert.Len(t, sch.GetEntityDefinitions(), 2)
+
+	definition, v, err := reader.ReadSchemaDefinition(ctx, "t1", "organization", version)
+	require.NoError(t, err)
+	assert.Equal(t, version, v)
+	assert.Contains(t, definition.GetRelations(), "member")
+
+	next := xid.New().String()
+	err = writer.WriteSchema(ctx, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: next},
+	})
+	require.NoError(t, err)
+
+	definitions, err := reader.ListSchemaDefinitions(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, []storage.SchemaDefinition{
+		{TenantID: "t1", EntityType: "organization", SerializedDefinition: []byte("entity organization {\n relation member @user\n}"), Version: version},
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: version},
+		{TenantID: "t1", EntityType: "user", SerializedDefinition: []byte("entity user {}"), Version: next},
+	}, definitions)
+}
@@ -0,0 +1,72 @@
+package snapshot
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Permify/permify/pkg/token"
+)
+
+// TestToken -
+func TestToken(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "token-suite")
+}
+
+var _ = Describe("token", func() {
+	Context("Encode", func() {
+		It("Case 1: Success", func() {
+			tests := []struct {
+				target   token.SnapToken
+				expected string
+			}{
+				{NewToken(4), "BAAAAAAAAAA="},
+				{NewToken(12), "DAAAAAAAAAA="},
+				{NewToken(43242), "6qgAAAAAAAA="},
+				{NewToken(54342345), "yTI9AwAAAAA="},
+				{NewToken(87648723472386), "AhAHT7dPAAA="},
+				{NewToken(2349875239487420823), "lzkihBRvnCA="},
+			}
+
+			for _, tt := range tests {
+				Expect(tt.target.Encode().String()).Should(Equal(tt.expected))
+			}
+		})
+	})
+
+	Context("Decode", func() {
+		It("Case 1: Success", func() {
+			tests := []struct {
+				target   token.EncodedSnapToken
+				expected token.SnapToken
+			}{
+				{EncodedToken{Value: "BAAAAAAAAAA="}, NewToken(4)},
+				{EncodedToken{Value: "DAAAAAAAAAA="}, NewToken(12)},
+				{EncodedToken{Value: "6qgAAAAAAAA="}, NewToken(43242)},
+				{EncodedToken{Value: "lzkihBRvnCA="}, NewToken(2349875239487420823)},
+			}
+
+			for _, tt := range tests {
+				t, err := tt.target.Decode()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(t).Should(Equal(tt.expected))
+			}
+		})
+
+		It("Case 2: Fail", func() {
+			_, err := EncodedToken{Value: "not base64"}.Decode()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Compare", func() {
+		It("Case 1: Success", func() {
+			Expect(NewToken(4).Eg(NewToken(4))).Should(BeTrue())
+			Expect(NewToken(5).Gt(NewToken(4))).Should(BeTrue())
+			Expect(NewToken(4).Lt(NewToken(5))).Should(BeTrue())
+			Expect(NewToken(4).Gt(NewToken(4))).Should(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/config"
+	"github.com/Permify/permify/internal/storage"
+	db "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// newDatabase creates a migrated database in a temporary file.
+func newDatabase(t *testing.T) *db.SQLite {
+	cfg := config.Database{
+		Engine: "sqlite",
+		URI:    filepath.Join(t.TempDir(), "permify.db"),
+	}
+
+	require.NoError(t, storage.Migrate(cfg, logger.New("fatal")))
+
+	database, err := db.New(cfg.URI)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	return database
+}
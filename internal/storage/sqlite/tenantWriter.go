@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/sqlite/utils"
+	db "github.com/Permify/permify/pkg/database/sqlite"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// TenantWriter - Structure for Tenant Writer
+type TenantWriter struct {
+	database *db.SQLite
+	// options
+	txOptions sql.TxOptions
+	// logger
+	logger logger.Interface
+}
+
+// NewTenantWriter - Creates a new TenantWriter
+func NewTenantWriter(database *db.SQLite, logger logger.Interface) *TenantWriter {
+	return &TenantWriter{
+		database:  database,
+		txOptions: sql.TxOptions{ReadOnly: false},
+		logger:    logger,
+	}
+}
+
+// CreateTenant - Creates a new Tenant
+func (w *TenantWriter) CreateTenant(ctx context.Context, id, name string) (result *base.Tenant, err error) {
+	ctx, span := tracer.Start(ctx, "tenant-writer.create-tenant")
+	defer span.End()
+
+	// The creation time is set here, so it is stored in the layout of the timestamp columns.
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	var query string
+	var args []interface{}
+
+	query, args, err = w.database.Builder.Insert(TenantsTable).Columns("id, name, created_at").Values(id, name, utils.Timestamp(createdAt)).ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	_, err = w.database.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		if utils.IsDuplicate(err) {
+			return nil, errors.New(base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+		}
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	return &base.Tenant{
+		Id:        id,
+		Name:      name,
+		CreatedAt: timestamppb.New(createdAt),
+	}, nil
+}
+
+// DeleteTenant - Deletes a Tenant
+func (w *TenantWriter) DeleteTenant(ctx context.Context, tenantID string) (result *base.Tenant, err error) {
+	ctx, span := tracer.Start(ctx, "tenant-writer.delete-tenant")
+	defer span.End()
+
+	var tx *sql.Tx
+	tx, err = w.database.DB.BeginTx(ctx, &w.txOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, err
+	}
+
+	defer utils.Rollback(tx, w.logger)
+
+	var name string
+	var createdAt time.Time
+
+	// The tenant is read within the transaction that deletes it, which holds the write lock of the database.
+	var query string
+	var args []interface{}
+
+	query, args, err = w.database.Builder.Select("name, created_at").From(TenantsTable).Where(squirrel.Eq{"id": tenantID}).ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&name, &createdAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	query, args, err = w.database.Builder.Delete(TenantsTable).Where(squirrel.Eq{"id": tenantID}).ToSql()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_SQL_BUILDER.String())
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	if err = tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+		return nil, errors.New(base.ErrorCode_ERROR_CODE_EXECUTION.String())
+	}
+
+	return &base.Tenant{
+		Id:        tenantID,
+		Name:      name,
+		CreatedAt: timestamppb.New(createdAt),
+	}, nil
+}
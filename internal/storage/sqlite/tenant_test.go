@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/pkg/database"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestTenants(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewTenantWriter(db, l)
+	reader := NewTenantReader(db, l)
+
+	created, err := writer.CreateTenant(ctx, "t2", "Test Tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "t2", created.Id)
+
+	_, err = writer.CreateTenant(ctx, "t2", "Test Tenant")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_UNIQUE_CONSTRAINT.String())
+
+	tenants, ct, err := reader.ListTenants(ctx, database.NewPagination(database.Size(10)))
+	require.NoError(t, err)
+	assert.Empty(t, ct.String())
+	require.Len(t, tenants, 2)
+	assert.Equal(t, "t1", tenants[0].Id)
+	assert.Equal(t, "t2", tenants[1].Id)
+	assert.True(t, created.CreatedAt.AsTime().Equal(tenants[1].CreatedAt.AsTime()))
+
+	deleted, err := writer.DeleteTenant(ctx, "t2")
+	require.NoError(t, err)
+	assert.Equal(t, "Test Tenant", deleted.Name)
+	assert.True(t, created.CreatedAt.AsTime().Equal(deleted.CreatedAt.AsTime()))
+
+	_, err = writer.DeleteTenant(ctx, "t2")
+	require.EqualError(t, err, base.ErrorCode_ERROR_CODE_EXECUTION.String())
+}
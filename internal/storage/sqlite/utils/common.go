@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+// TimestampFormat is the layout timestamps are stored in. It has a fixed width, so timestamps in this layout
+// compare in the same order as strings and as times, and it matches the defaults of the timestamp columns.
+const TimestampFormat = "2006-01-02 15:04:05.000000"
+
+// Timestamp - Formats the given time the way timestamps are stored
+func Timestamp(t time.Time) string {
+	return t.UTC().Format(TimestampFormat)
+}
+
+// SnapshotQuery - Filters the relationships that are visible at the given transaction. Writers are serialized,
+// so transaction ids are assigned in commit order, and a relationship is visible if it was created at or before
+// the transaction and it was not expired at or before the transaction.
+func SnapshotQuery(sl squirrel.SelectBuilder, revision uint64) squirrel.SelectBuilder {
+	return sl.Where(squirrel.LtOrEq{"created_tx_id": revision}).Where(squirrel.Or{
+		squirrel.Eq{"expired_tx_id": 0},
+		squirrel.Gt{"expired_tx_id": revision},
+	})
+}
+
+// Dialect - The SQLite dialect of the queries shared by the SQL storage engines
+type Dialect struct{}
+
+// TxID - Returns the SQL of the transaction id
+func (Dialect) TxID(id uint64) squirrel.Sqlizer {
+	return squirrel.Expr("?", id)
+}
+
+// SnapshotQuery - Filters the relationships that are visible at the given transaction
+func (Dialect) SnapshotQuery(sl squirrel.SelectBuilder, id uint64) squirrel.SelectBuilder {
+	return SnapshotQuery(sl, id)
+}
+
+// Now - Returns the SQL of the current time, in the layout timestamps are stored in
+func (Dialect) Now() string {
+	return "strftime('%Y-%m-%d %H:%M:%f000', 'now')"
+}
+
+// Timestamp - Returns the value of the time for the timestamp columns, in the layout timestamps are stored in
+func (Dialect) Timestamp(t time.Time) interface{} {
+	return Timestamp(t)
+}
+
+// CommittedQuery - Returns the query as is, the writers take the write lock of the database when they begin, so the
+// transactions are visible in commit order
+func (Dialect) CommittedQuery(sl squirrel.SelectBuilder) squirrel.SelectBuilder {
+	return sl
+}
+
+// Binary - Returns the SQL of the column to compare and order its values by their bytes,
+// the text columns have the default binary collation
+func (Dialect) Binary(column string) string {
+	return column
+}
+
+// ExpiresAt - Returns the value of the expires_at column of the tuple
+func ExpiresAt(t *base.Tuple) interface{} {
+	if t.GetExpiresAt() == nil {
+		return nil
+	}
+	return Timestamp(t.GetExpiresAt().AsTime())
+}
+
+// Rollback - Rollbacks a transaction and logs the error
+func Rollback(tx *sql.Tx, logger logger.Interface) {
+	if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) && err != nil {
+		logger.Error("failed to rollback transaction", err)
+	}
+}
+
+// IsRetryable - Reports whether the statement failed because the database was locked and can be retried
+func IsRetryable(err error) bool {
+	var e *sqlite.Error
+	return errors.As(err, &e) && (e.Code()&0xff == sqlite3.SQLITE_BUSY || e.Code()&0xff == sqlite3.SQLITE_LOCKED)
+}
+
+// IsDuplicate - Reports whether the statement failed because it violates a unique key
+func IsDuplicate(err error) bool {
+	var e *sqlite.Error
+	return errors.As(err, &e) && (e.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || e.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Permify/permify/internal/storage/sqlite/utils"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+)
+
+func TestSnapshotQuery(t *testing.T) {
+	sl := squirrel.Select("column").From("table")
+	revision := uint64(42)
+
+	query := utils.SnapshotQuery(sl, revision)
+	sql, args, err := query.ToSql()
+
+	assert.NoError(t, err)
+	expectedSQL := "SELECT column FROM table WHERE created_tx_id <= ? AND (expired_tx_id = ? OR expired_tx_id > ?)"
+	assert.Equal(t, expectedSQL, sql)
+	assert.Equal(t, []interface{}{revision, 0, revision}, args)
+}
+
+func TestTimestamp(t *testing.T) {
+	at := time.Date(2023, 10, 19, 9, 5, 3, 120000000, time.FixedZone("UTC+2", 2*60*60))
+
+	assert.Equal(t, "2023-10-19 07:05:03.120000", utils.Timestamp(at))
+
+	// Timestamps compare as strings in the order of the times
+	assert.Less(t, utils.Timestamp(at), utils.Timestamp(at.Add(time.Microsecond)))
+	assert.Less(t, utils.Timestamp(at), utils.Timestamp(at.Add(time.Hour*10)))
+}
+
+func TestDialect(t *testing.T) {
+	d := utils.Dialect{}
+
+	sql, args, err := squirrel.Expr("id = ?", d.TxID(42)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "id = ?", sql)
+	assert.Equal(t, []interface{}{uint64(42)}, args)
+
+	assert.Equal(t, "strftime('%Y-%m-%d %H:%M:%f000', 'now')", d.Now())
+
+	at := time.Date(2023, 10, 21, 12, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, "2023-10-21 09:30:00.000000", d.Timestamp(at))
+}
+
+func TestExpiresAt(t *testing.T) {
+	assert.Nil(t, utils.ExpiresAt(&base.Tuple{}))
+
+	expiresAt := time.Date(2023, 10, 21, 12, 30, 0, 500000000, time.FixedZone("UTC+3", 3*60*60))
+	assert.Equal(t, "2023-10-21 09:30:00.500000", utils.ExpiresAt(&base.Tuple{ExpiresAt: timestamppb.New(expiresAt)}))
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Permify/permify/internal/storage/sqlite/snapshot"
+	"github.com/Permify/permify/pkg/logger"
+	base "github.com/Permify/permify/pkg/pb/base/v1"
+	"github.com/Permify/permify/pkg/token"
+	"github.com/Permify/permify/pkg/tuple"
+)
+
+// next receives the next changes from the watcher.
+func next(t *testing.T, changes <-chan *base.TupleChanges, errs <-chan error) *base.TupleChanges {
+	select {
+	case c := <-changes:
+		require.NotNil(t, c)
+		return c
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no changes received")
+	}
+	return nil
+}
+
+// operations returns the operations of the changes with their tuples.
+func operations(c *base.TupleChanges) []string {
+	var values []string
+	for _, change := range c.GetTupleChanges() {
+		values = append(values, change.GetOperation().String()+" "+tuple.EntityAndRelationToString(&base.EntityAndRelation{
+			Entity:   change.GetTuple().GetEntity(),
+			Relation: change.GetTuple().GetRelation(),
+		})+"@"+tuple.SubjectToString(change.GetTuple().GetSubject()))
+	}
+	return values
+}
+
+func TestWatcher_StreamsChangesInCommitOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	watcher := NewWatcher(db, l)
+	watcher.PollInterval = 10 * time.Millisecond
+
+	changes, errs := watcher.Watch(ctx, "t1", snapshot.NewToken(0).Encode().String())
+
+	written, err := writer.WriteRelationships(ctx, "t1", tuples(t, "organization:1#member@user:1", "organization:1#member@user:2"))
+	require.NoError(t, err)
+
+	// Changes of other tenants are not streamed
+	_, err = writer.WriteRelationships(ctx, "t2", tuples(t, "organization:1#member@user:3"))
+	require.NoError(t, err)
+
+	deleted, err := writer.DeleteRelationships(ctx, "t1", &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: "organization", Ids: []string{"1"}},
+		Relation: "member",
+		Subject:  &base.SubjectFilter{Type: "user", Ids: []string{"1"}},
+	})
+	require.NoError(t, err)
+
+	c := next(t, changes, errs)
+	assert.Equal(t, written.String(), c.GetSnapToken())
+	assert.Equal(t, []string{
+		"OPERATION_CREATE organization:1#member@user:1",
+		"OPERATION_CREATE organization:1#member@user:2",
+	}, operations(c))
+
+	c = next(t, changes, errs)
+	assert.Equal(t, deleted.String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_DELETE organization:1#member@user:1"}, operations(c))
+}
+
+func TestWatcher_Resumes(t *testing.T) {
+	ctx := context.Background()
+	l := logger.New("fatal")
+	db := newDatabase(t)
+
+	writer := NewRelationshipWriter(db, l)
+	watcher := NewWatcher(db, l)
+	watcher.PollInterval = 10 * time.Millisecond
+
+	snaps := make([]token.EncodedSnapToken, 3)
+	for i, v := range []string{"organization:1#member@user:1", "organization:1#member@user:2", "organization:1#member@user:3"} {
+		var err error
+		snaps[i], err = writer.WriteRelationships(ctx, "t1", tuples(t, v))
+		require.NoError(t, err)
+	}
+
+	// Watching from the snap token of a change streams the changes after it
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	changes, errs := watcher.Watch(wctx, "t1", snaps[0].String())
+
+	c := next(t, changes, errs)
+	assert.Equal(t, snaps[1].String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_CREATE organization:1#member@user:2"}, operations(c))
+
+	c = next(t, changes, errs)
+	assert.Equal(t, snaps[2].String(), c.GetSnapToken())
+	assert.Equal(t, []string{"OPERATION_CREATE organization:1#member@user:3"}, operations(c))
+
+	// Both channels are closed once the context is canceled
+	cancel()
+	for range changes {
+	}
+	_, ok := <-errs
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/Permify/permify/pkg/database"
 	base "github.com/Permify/permify/pkg/pb/base/v1"
@@ -14,8 +15,12 @@ type RelationshipReader interface {
 	QueryRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string) (iterator *database.TupleIterator, err error)
 	// ReadRelationships reads relation tuples from the repository with different options.
 	ReadRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter, snap string, pagination database.Pagination) (collection *database.TupleCollection, ct database.EncodedContinuousToken, err error)
+	// ReadEntityIDs reads the ids of the entities of the type that have relation tuples from the repository, up to limit of them after the given id in the byte order of the ids.
+	ReadEntityIDs(ctx context.Context, tenantID, entityType, snap, after string, limit int) (ids []string, err error)
 	// HeadSnapshot reads the latest version of the snapshot from the repository.
 	HeadSnapshot(ctx context.Context, tenantID string) (token.SnapToken, error)
+	// SnapshotAt reads the latest version of the snapshot committed at or before the given time from the repository.
+	SnapshotAt(ctx context.Context, tenantID string, timestamp time.Time) (token.SnapToken, error)
 }
 
 // RelationshipWriter -
@@ -24,6 +29,20 @@ type RelationshipWriter interface {
 	WriteRelationships(ctx context.Context, tenantID string, collection *database.TupleCollection) (token token.EncodedSnapToken, err error)
 	// DeleteRelationships deletes relation tuples from the repository.
 	DeleteRelationships(ctx context.Context, tenantID string, filter *base.TupleFilter) (token token.EncodedSnapToken, err error)
+	// BulkImport writes the relation tuples of the batches to the repository, they become visible at once after the last batch.
+	// The imported count does not include the relation tuples that were already written.
+	BulkImport(ctx context.Context, tenantID string, batches TupleBatches) (token token.EncodedSnapToken, imported int64, err error)
+	// TransactRelationships applies the operations to the relation tuples of the repository atomically, if all the preconditions hold.
+	TransactRelationships(ctx context.Context, tenantID string, preconditions []*base.TuplePrecondition, operations []*base.TupleOperation) (token token.EncodedSnapToken, err error)
+}
+
+// TupleBatches returns the next batch of relation tuples to import, and io.EOF after the last batch.
+type TupleBatches func() (*database.TupleCollection, error)
+
+// Watcher -
+type Watcher interface {
+	// Watch streams the changes to the relation tuples of the tenant committed after the given snapshot, in commit order.
+	Watch(ctx context.Context, tenantID string, snap string) (<-chan *base.TupleChanges, <-chan error)
 }
 
 // SchemaReader -
@@ -34,6 +53,10 @@ type SchemaReader interface {
 	ReadSchemaDefinition(ctx context.Context, tenantID string, entityType, version string) (definition *base.EntityDefinition, v string, err error)
 	// HeadVersion reads the latest version of the schema from the repository.
 	HeadVersion(ctx context.Context, tenantID string) (version string, err error)
+	// VersionAt reads the latest version of the schema written at or before the given time from the repository.
+	VersionAt(ctx context.Context, tenantID string, timestamp time.Time) (version string, err error)
+	// ListSchemaDefinitions reads the definitions of all the versions of the schema from the repository, ordered by version.
+	ListSchemaDefinitions(ctx context.Context, tenantID string) (definitions []SchemaDefinition, err error)
 }
 
 // SchemaWriter -
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// VersionBound returns the smallest schema version that can have been written after the given time. Schema versions
+// are xids, which sort by the second they were generated in, so the versions written at or before the time are the
+// versions below the bound.
+func VersionBound(timestamp time.Time) string {
+	var id xid.ID
+	binary.BigEndian.PutUint32(id[:4], uint32(timestamp.Unix()+1))
+	return id.String()
+}
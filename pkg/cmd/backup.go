@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+
+	"github.com/Permify/permify/internal/backup"
+	"github.com/Permify/permify/internal/factories"
+	"github.com/Permify/permify/pkg/cmd/flags"
+	"github.com/Permify/permify/pkg/logger"
+)
+
+const (
+	output  = "output"
+	input   = "input"
+	tenants = "tenants"
+)
+
+// NewBackupCommand - Creates new backup command
+func NewBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "back up and restore the schemas and relationships of tenants",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(NewBackupCreateCommand())
+	cmd.AddCommand(NewBackupRestoreCommand())
+
+	return cmd
+}
+
+// NewBackupCreateCommand - Creates new backup create command
+func NewBackupCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "write a compressed archive of the tenants, their schema versions and relationships",
+		RunE:  backupCreate(),
+		Args:  cobra.NoArgs,
+	}
+
+	// add flags to the backup create command
+	flags.RegisterBackupFlags(cmd)
+	cmd.PersistentFlags().String(output, "-", "file to write the archive to, - for the standard output")
+	cmd.PersistentFlags().StringSlice(tenants, nil, "tenants to back up, all the tenants if not set")
+
+	return cmd
+}
+
+// NewBackupRestoreCommand - Creates new backup restore command
+func NewBackupRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "restore the tenants, their schema versions and relationships from an archive",
+		RunE:  backupRestore(),
+		Args:  cobra.NoArgs,
+	}
+
+	// add flags to the backup restore command
+	flags.RegisterBackupFlags(cmd)
+	cmd.PersistentFlags().String(input, "-", "file to read the archive from, - for the standard input")
+	cmd.PersistentFlags().StringSlice(tenants, nil, "tenants to restore, all the tenants of the archive if not set")
+
+	return cmd
+}
+
+// backupCreate - permify backup create command
+func backupCreate() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path, err := cmd.Flags().GetString(output)
+		if err != nil {
+			return err
+		}
+
+		ids, err := cmd.Flags().GetStringSlice(tenants)
+		if err != nil {
+			return err
+		}
+
+		l := logger.New("error")
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := factories.DatabaseFactory(cfg.Database, l)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, color.Warn.Sprint("backup failed: database connection error"))
+			return err
+		}
+		defer db.Close()
+
+		var w io.Writer = os.Stdout
+		if path != "-" {
+			var f *os.File
+			f, err = os.Create(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, color.Warn.Sprint("backup failed: "+err.Error()))
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		creator := backup.NewCreator(
+			factories.TenantReaderFactory(db, l),
+			factories.SchemaReaderFactory(db, l),
+			factories.RelationshipReaderFactory(db, l),
+		)
+		if err = creator.Create(ctx, w, ids...); err != nil {
+			fmt.Fprintln(os.Stderr, color.Warn.Sprint("backup failed: "+err.Error()))
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, color.Success.Sprint("backup successfully created: ✓ ✅ "))
+		return nil
+	}
+}
+
+// backupRestore - permify backup restore command
+func backupRestore() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path, err := cmd.Flags().GetString(input)
+		if err != nil {
+			return err
+		}
+
+		ids, err := cmd.Flags().GetStringSlice(tenants)
+		if err != nil {
+			return err
+		}
+
+		l := logger.New("error")
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		db, err := factories.DatabaseFactory(cfg.Database, l)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, color.Warn.Sprint("restore failed: database connection error"))
+			return err
+		}
+		defer db.Close()
+
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			var f *os.File
+			f, err = os.Open(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, color.Warn.Sprint("restore failed: "+err.Error()))
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		restorer := backup.NewRestorer(
+			factories.TenantReaderFactory(db, l),
+			factories.TenantWriterFactory(db, l),
+			factories.SchemaReaderFactory(db, l),
+			factories.SchemaWriterFactory(db, l),
+			factories.RelationshipWriterFactory(db, l),
+		)
+		if err = restorer.Restore(ctx, r, ids...); err != nil {
+			fmt.Fprintln(os.Stderr, color.Warn.Sprint("restore failed: "+err.Error()))
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, color.Success.Sprint("backup successfully restored: ✓ ✅ "))
+		return nil
+	}
+}
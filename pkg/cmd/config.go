@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/Permify/permify/internal/config"
+)
+
+// loadConfig - Loads the config from the config file if one is given, with the values of the flags and the
+// environment variables bound to it
+func loadConfig() (*config.Config, error) {
+	var cfg *config.Config
+	var err error
+	cfgFile := viper.GetString("config.file")
+	if cfgFile != "" {
+		cfg, err = config.NewConfigWithFile(cfgFile)
+	} else {
+		cfg, err = config.NewConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new config: %w", err)
+	}
+
+	if err = viper.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
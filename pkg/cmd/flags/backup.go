@@ -0,0 +1,25 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// RegisterBackupFlags - Define the config file and database flags of a backup command. They are bound when the
+// command runs, so that they do not replace the flags of the serve command.
+func RegisterBackupFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	// Config File
+	flags.StringP("config", "c", "", "config file (default is $HOME/.permify.yaml)")
+
+	// DATABASE
+	RegisterDatabaseFlags(cmd)
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := viper.BindPFlag("config.file", cmd.Flags().Lookup("config")); err != nil {
+			return err
+		}
+		return BindDatabaseFlags(cmd)
+	}
+}
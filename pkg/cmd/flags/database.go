@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Permify/permify/internal/config"
+)
+
+// databaseFlags are the names of the database flags, with the config keys and the environment variables they are
+// bound to
+var databaseFlags = []struct {
+	name string
+	key  string
+	env  string
+}{
+	{"database-engine", "database.engine", "PERMIFY_DATABASE_ENGINE"},
+	{"database-uri", "database.uri", "PERMIFY_DATABASE_URI"},
+	{"database-auto-migrate", "database.auto_migrate", "PERMIFY_DATABASE_AUTO_MIGRATE"},
+	{"database-max-open-connections", "database.max_open_connections", "PERMIFY_DATABASE_MAX_OPEN_CONNECTIONS"},
+	{"database-max-idle-connections", "database.max_idle_connections", "PERMIFY_DATABASE_MAX_IDLE_CONNECTIONS"},
+	{"database-max-connection-lifetime", "database.max_connection_lifetime", "PERMIFY_DATABASE_MAX_CONNECTION_LIFETIME"},
+	{"database-max-connection-idle-time", "database.max_connection_idle_time", "PERMIFY_DATABASE_MAX_CONNECTION_IDLE_TIME"},
+	{"database-garbage-collection-enable", "database.garbage_collection.enable", "PERMIFY_DATABASE_GARBAGE_ENABLE"},
+	{"database-garbage-collection-interval", "database.garbage_collection.interval", "PERMIFY_DATABASE_GARBAGE_COLLECTION_INTERVAL"},
+	{"database-garbage-collection-timeout", "database.garbage_collection.timeout", "PERMIFY_DATABASE_GARBAGE_COLLECTION_TIMEOUT"},
+	{"database-garbage-collection-window", "database.garbage_collection.window", "PERMIFY_DATABASE_GARBAGE_COLLECTION_WINDOW"},
+	{"database-garbage-collection-number-of-threads", "database.garbage_collection.number_of_threads", "PERMIFY_DATABASE_GARBAGE_COLLECTION_NUMBER_OF_THREADS"},
+	{"database-persistence-enabled", "database.persistence.enabled", "PERMIFY_DATABASE_PERSISTENCE_ENABLED"},
+	{"database-persistence-directory", "database.persistence.directory", "PERMIFY_DATABASE_PERSISTENCE_DIRECTORY"},
+	{"database-persistence-snapshot-interval", "database.persistence.snapshot_interval", "PERMIFY_DATABASE_PERSISTENCE_SNAPSHOT_INTERVAL"},
+}
+
+// RegisterDatabaseFlags - Define the flags of the database config
+func RegisterDatabaseFlags(cmd *cobra.Command) {
+	conf := config.DefaultConfig()
+
+	flags := cmd.Flags()
+	flags.String("database-engine", conf.Database.Engine, "data source. e.g. postgres, memory")
+	flags.String("database-uri", conf.Database.URI, "uri of your data source to store relation tuples and schema")
+	flags.Bool("database-auto-migrate", conf.Database.AutoMigrate, "auto migrate database tables")
+	flags.Int("database-max-open-connections", conf.Database.MaxOpenConnections, "maximum number of parallel connections that can be made to the database at any time")
+	flags.Int("database-max-idle-connections", conf.Database.MaxIdleConnections, "maximum number of idle connections that can be made to the database at any time")
+	flags.Duration("database-max-connection-lifetime", conf.Database.MaxConnectionLifetime, "maximum amount of time a connection may be reused")
+	flags.Duration("database-max-connection-idle-time", conf.Database.MaxConnectionIdleTime, "maximum amount of time a connection may be idle")
+	flags.Bool("database-garbage-collection-enable", conf.Database.DatabaseGarbageCollection.Enable, "use database garbage collection for expired relation tuples")
+	flags.Duration("database-garbage-collection-interval", conf.Database.DatabaseGarbageCollection.Interval, "interval for database garbage collection")
+	flags.Duration("database-garbage-collection-timeout", conf.Database.DatabaseGarbageCollection.Timeout, "timeout for database garbage collection")
+	flags.Duration("database-garbage-collection-window", conf.Database.DatabaseGarbageCollection.Window, "window for database garbage collection")
+	flags.Int("database-garbage-collection-number-of-threads", conf.Database.DatabaseGarbageCollection.NumberOfThreads, "number of threads for database garbage collection")
+	flags.Bool("database-persistence-enabled", conf.Database.Persistence.Enabled, "keep the memory database in a local directory and restore it at startup")
+	flags.String("database-persistence-directory", conf.Database.Persistence.Directory, "directory of the write log and the snapshots of the memory database")
+	flags.Duration("database-persistence-snapshot-interval", conf.Database.Persistence.SnapshotInterval, "interval the memory database is snapshotted at")
+}
+
+// BindDatabaseFlags - Bind the database flags and their environment variables to the database config. The commands
+// that define the database flags share the config keys, so the flags of a command other than serve are bound when it
+// runs.
+func BindDatabaseFlags(cmd *cobra.Command) error {
+	for _, f := range databaseFlags {
+		if err := viper.BindPFlag(f.key, cmd.Flags().Lookup(f.name)); err != nil {
+			return err
+		}
+		if err := viper.BindEnv(f.key, f.env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -262,133 +262,90 @@ func RegisterServeFlags(cmd *cobra.Command) {
 		panic(err)
 	}
 
-	flags.Int64("service-permission-cache-number-of-counters", conf.Service.Permission.Cache.NumberOfCounters, "permission service cache number of counters")
-	if err = viper.BindPFlag("service.permission.cache.number_of_counters", flags.Lookup("service-permission-cache-number-of-counters")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("service.permission.cache.number_of_counters", "PERMIFY_SERVICE_PERMISSION_CACHE_NUMBER_OF_COUNTERS"); err != nil {
-		panic(err)
-	}
-
-	flags.String("service-permission-cache-max-cost", conf.Service.Permission.Cache.MaxCost, "permission service cache max cost")
-	if err = viper.BindPFlag("service.permission.cache.max_cost", flags.Lookup("service-permission-cache-max-cost")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("service.permission.cache.max_cost", "PERMIFY_SERVICE_PERMISSION_CACHE_MAX_COST"); err != nil {
-		panic(err)
-	}
-
-	// DATABASE
-	flags.String("database-engine", conf.Database.Engine, "data source. e.g. postgres, memory")
-	if err = viper.BindPFlag("database.engine", flags.Lookup("database-engine")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("database.engine", "PERMIFY_DATABASE_ENGINE"); err != nil {
-		panic(err)
-	}
-
-	flags.String("database-uri", conf.Database.URI, "uri of your data source to store relation tuples and schema")
-	if err = viper.BindPFlag("database.uri", flags.Lookup("database-uri")); err != nil {
+	flags.Duration("service-permission-snapshot-quantization", conf.Service.Permission.SnapshotQuantization, "window that minimize latency snapshots are quantized to")
+	if err = viper.BindPFlag("service.permission.snapshot_quantization", flags.Lookup("service-permission-snapshot-quantization")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.uri", "PERMIFY_DATABASE_URI"); err != nil {
+	if err = viper.BindEnv("service.permission.snapshot_quantization", "PERMIFY_SERVICE_PERMISSION_SNAPSHOT_QUANTIZATION"); err != nil {
 		panic(err)
 	}
 
-	flags.Bool("database-auto-migrate", conf.Database.AutoMigrate, "auto migrate database tables")
-	if err = viper.BindPFlag("database.auto_migrate", flags.Lookup("database-auto-migrate")); err != nil {
+	flags.Bool("service-permission-materialization", conf.Service.Permission.Materialization, "answer materialized permissions from the materialized permission index")
+	if err = viper.BindPFlag("service.permission.materialization", flags.Lookup("service-permission-materialization")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.auto_migrate", "PERMIFY_DATABASE_AUTO_MIGRATE"); err != nil {
+	if err = viper.BindEnv("service.permission.materialization", "PERMIFY_SERVICE_PERMISSION_MATERIALIZATION"); err != nil {
 		panic(err)
 	}
 
-	flags.Int("database-max-open-connections", conf.Database.MaxOpenConnections, "maximum number of parallel connections that can be made to the database at any time")
-	if err = viper.BindPFlag("database.max_open_connections", flags.Lookup("database-max-open-connections")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("database.max_open_connections", "PERMIFY_DATABASE_MAX_OPEN_CONNECTIONS"); err != nil {
-		panic(err)
-	}
-
-	flags.Int("database-max-idle-connections", conf.Database.MaxIdleConnections, "maximum number of idle connections that can be made to the database at any time")
-	if err = viper.BindPFlag("database.max_idle_connections", flags.Lookup("database-max-idle-connections")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("database.max_idle_connections", "PERMIFY_DATABASE_MAX_IDLE_CONNECTIONS"); err != nil {
-		panic(err)
-	}
-
-	flags.Duration("database-max-connection-lifetime", conf.Database.MaxConnectionLifetime, "maximum amount of time a connection may be reused")
-	if err = viper.BindPFlag("database.max_connection_lifetime", flags.Lookup("database-max-connection-lifetime")); err != nil {
+	flags.Int64("service-permission-cache-number-of-counters", conf.Service.Permission.Cache.NumberOfCounters, "permission service cache number of counters")
+	if err = viper.BindPFlag("service.permission.cache.number_of_counters", flags.Lookup("service-permission-cache-number-of-counters")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.max_connection_lifetime", "PERMIFY_DATABASE_MAX_CONNECTION_LIFETIME"); err != nil {
+	if err = viper.BindEnv("service.permission.cache.number_of_counters", "PERMIFY_SERVICE_PERMISSION_CACHE_NUMBER_OF_COUNTERS"); err != nil {
 		panic(err)
 	}
 
-	flags.Duration("database-max-connection-idle-time", conf.Database.MaxConnectionIdleTime, "maximum amount of time a connection may be idle")
-	if err = viper.BindPFlag("database.max_connection_idle_time", flags.Lookup("database-max-connection-idle-time")); err != nil {
+	flags.String("service-permission-cache-max-cost", conf.Service.Permission.Cache.MaxCost, "permission service cache max cost")
+	if err = viper.BindPFlag("service.permission.cache.max_cost", flags.Lookup("service-permission-cache-max-cost")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.max_connection_idle_time", "PERMIFY_DATABASE_MAX_CONNECTION_IDLE_TIME"); err != nil {
+	if err = viper.BindEnv("service.permission.cache.max_cost", "PERMIFY_SERVICE_PERMISSION_CACHE_MAX_COST"); err != nil {
 		panic(err)
 	}
 
-	flags.Bool("database-garbage-collection-enable", conf.Database.DatabaseGarbageCollection.Enable, "use database garbage collection for expired relation tuples")
-	if err = viper.BindPFlag("database.garbage_collection.enable", flags.Lookup("database-garbage-collection-enable")); err != nil {
-		panic(err)
-	}
-	if err = viper.BindEnv("database.garbage_collection.enable", "PERMIFY_DATABASE_GARBAGE_ENABLE"); err != nil {
+	// DATABASE
+	RegisterDatabaseFlags(cmd)
+	if err = BindDatabaseFlags(cmd); err != nil {
 		panic(err)
 	}
 
-	flags.Duration("database-garbage-collection-interval", conf.Database.DatabaseGarbageCollection.Interval, "interval for database garbage collection")
-	if err = viper.BindPFlag("database.garbage_collection.interval", flags.Lookup("database-garbage-collection-interval")); err != nil {
+	// Distributed
+	flags.Bool("distributed-enabled", conf.Distributed.Enabled, "enable distributed")
+	if err = viper.BindPFlag("distributed.enabled", flags.Lookup("distributed-enabled")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.garbage_collection.interval", "PERMIFY_DATABASE_GARBAGE_COLLECTION_INTERVAL"); err != nil {
+	if err = viper.BindEnv("distributed.enabled", "PERMIFY_DISTRIBUTED_ENABLED"); err != nil {
 		panic(err)
 	}
 
-	flags.Duration("database-garbage-collection-timeout", conf.Database.DatabaseGarbageCollection.Timeout, "timeout for database garbage collection")
-	if err = viper.BindPFlag("database.garbage_collection.timeout", flags.Lookup("database-garbage-collection-timeout")); err != nil {
+	flags.StringArray("distributed-nodes", conf.Distributed.Nodes, "list of peers")
+	if err = viper.BindPFlag("distributed.nodes", flags.Lookup("distributed-nodes")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.garbage_collection.timeout", "PERMIFY_DATABASE_GARBAGE_COLLECTION_TIMEOUT"); err != nil {
+	if err = viper.BindEnv("distributed.nodes", "PERMIFY_DISTRIBUTED_NODES"); err != nil {
 		panic(err)
 	}
 
-	flags.Duration("database-garbage-collection-window", conf.Database.DatabaseGarbageCollection.Window, "window for database garbage collection")
-	if err = viper.BindPFlag("database.garbage_collection.window", flags.Lookup("database-garbage-collection-window")); err != nil {
+	flags.String("distributed-port", conf.Distributed.Port, "port of the internal listener the nodes dispatch checks to each other on")
+	if err = viper.BindPFlag("distributed.port", flags.Lookup("distributed-port")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.garbage_collection.window", "PERMIFY_DATABASE_GARBAGE_COLLECTION_WINDOW"); err != nil {
+	if err = viper.BindEnv("distributed.port", "PERMIFY_DISTRIBUTED_PORT"); err != nil {
 		panic(err)
 	}
 
-	flags.Int("database-garbage-collection-number-of-threads", conf.Database.DatabaseGarbageCollection.NumberOfThreads, "number of threads for database garbage collection")
-	if err = viper.BindPFlag("database.garbage_collection.number_of_threads", flags.Lookup("database-garbage-collection-number-of-threads")); err != nil {
+	flags.String("distributed-tls-cert-path", conf.Distributed.TLS.CertPath, "certificate path of the node for the mutual tls between the nodes")
+	if err = viper.BindPFlag("distributed.tls.cert", flags.Lookup("distributed-tls-cert-path")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("database.garbage_collection.number_of_threads", "PERMIFY_DATABASE_GARBAGE_COLLECTION_NUMBER_OF_THREADS"); err != nil {
+	if err = viper.BindEnv("distributed.tls.cert", "PERMIFY_DISTRIBUTED_TLS_CERT_PATH"); err != nil {
 		panic(err)
 	}
 
-	// Distributed
-	flags.Bool("distributed-enabled", conf.Distributed.Enabled, "enable distributed")
-	if err = viper.BindPFlag("distributed.enabled", flags.Lookup("distributed-enabled")); err != nil {
+	flags.String("distributed-tls-key-path", conf.Distributed.TLS.KeyPath, "key path of the node for the mutual tls between the nodes")
+	if err = viper.BindPFlag("distributed.tls.key", flags.Lookup("distributed-tls-key-path")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("distributed.enabled", "PERMIFY_DISTRIBUTED_ENABLED"); err != nil {
+	if err = viper.BindEnv("distributed.tls.key", "PERMIFY_DISTRIBUTED_TLS_KEY_PATH"); err != nil {
 		panic(err)
 	}
 
-	flags.StringArray("distributed-nodes", conf.Distributed.Nodes, "list of peers")
-	if err = viper.BindPFlag("distributed.nodes", flags.Lookup("distributed-nodes")); err != nil {
+	flags.String("distributed-tls-ca-path", conf.Distributed.TLS.CAPath, "certificate path of the authority that signs the certificates of the nodes")
+	if err = viper.BindPFlag("distributed.tls.ca", flags.Lookup("distributed-tls-ca-path")); err != nil {
 		panic(err)
 	}
-	if err = viper.BindEnv("distributed.nodes", "PERMIFY_DISTRIBUTED_NODES"); err != nil {
+	if err = viper.BindEnv("distributed.tls.ca", "PERMIFY_DISTRIBUTED_TLS_CA_PATH"); err != nil {
 		panic(err)
 	}
 }
@@ -2,23 +2,27 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/Permify/permify/internal/engines/consistent"
 	"github.com/Permify/permify/internal/engines/keys"
+	"github.com/Permify/permify/internal/engines/materialize"
 	"github.com/Permify/permify/internal/invoke"
+	"github.com/Permify/permify/internal/storage/memory"
+	"github.com/Permify/permify/internal/storage/mysql"
 	"github.com/Permify/permify/internal/storage/postgres"
+	"github.com/Permify/permify/internal/storage/sqlite"
 	hash "github.com/Permify/permify/pkg/consistent"
+	IMDatabase "github.com/Permify/permify/pkg/database/memory"
+	MYDatabase "github.com/Permify/permify/pkg/database/mysql"
 	PQDatabase "github.com/Permify/permify/pkg/database/postgres"
+	SLDatabase "github.com/Permify/permify/pkg/database/sqlite"
 
-	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel/sdk/metric"
 
 	"github.com/Permify/permify/pkg/gossip"
@@ -29,7 +33,6 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/Permify/permify/internal"
-	"github.com/Permify/permify/internal/config"
 	"github.com/Permify/permify/internal/engines"
 	"github.com/Permify/permify/internal/factories"
 	"github.com/Permify/permify/internal/servers"
@@ -41,6 +44,7 @@ import (
 	"github.com/Permify/permify/pkg/telemetry"
 	"github.com/Permify/permify/pkg/telemetry/meterexporters"
 	"github.com/Permify/permify/pkg/telemetry/tracerexporters"
+	"github.com/Permify/permify/pkg/token"
 )
 
 // NewServeCommand returns a new Cobra command that can be used to run the "permify serve" command.
@@ -62,28 +66,9 @@ func NewServeCommand() *cobra.Command {
 // It returns an error if there is an issue with any of the components or if any goroutine fails.
 func serve() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		var cfg *config.Config
-		var err error
-		cfgFile := viper.GetString("config.file")
-		if cfgFile != "" {
-			cfg, err = config.NewConfigWithFile(cfgFile)
-			if err != nil {
-				return fmt.Errorf("failed to create new config: %w", err)
-			}
-
-			if err = viper.Unmarshal(cfg); err != nil {
-				return fmt.Errorf("failed to unmarshal config: %w", err)
-			}
-		} else {
-			// Load configuration
-			cfg, err = config.NewConfig()
-			if err != nil {
-				return fmt.Errorf("failed to create new config: %w", err)
-			}
-
-			if err = viper.Unmarshal(cfg); err != nil {
-				return fmt.Errorf("failed to unmarshal config: %w", err)
-			}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
 		}
 
 		// Print banner and initialize logger
@@ -105,7 +90,7 @@ func serve() func(cmd *cobra.Command, args []string) error {
 		}
 
 		// Initialize database
-		db, err := factories.DatabaseFactory(cfg.Database)
+		db, err := factories.DatabaseFactory(cfg.Database, l)
 		if err != nil {
 			l.Fatal("failed to initialize database: %w", err)
 		}
@@ -132,10 +117,23 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			}()
 		}
 
-		// Garbage collection
-		if cfg.DatabaseGarbageCollection.Enable && cfg.Database.Engine != "memory" {
+		// Garbage collection, the history of the memory database is always collected since it is kept in memory
+		if cfg.DatabaseGarbageCollection.Enable || cfg.Database.Engine == "memory" {
 			l.Info("🗑️ starting database garbage collection...")
-			gc := postgres.NewGarbageCollector(ctx, db.(*PQDatabase.Postgres), l, cfg.DatabaseGarbageCollection)
+			var gc interface {
+				Start() error
+				Stop()
+			}
+			switch cfg.Database.Engine {
+			case "mysql":
+				gc = mysql.NewGarbageCollector(ctx, db.(*MYDatabase.MySQL), l, cfg.DatabaseGarbageCollection)
+			case "sqlite":
+				gc = sqlite.NewGarbageCollector(ctx, db.(*SLDatabase.SQLite), l, cfg.DatabaseGarbageCollection)
+			case "memory":
+				gc = memory.NewGarbageCollector(ctx, db.(*IMDatabase.Memory), l, cfg.DatabaseGarbageCollection)
+			default:
+				gc = postgres.NewGarbageCollector(ctx, db.(*PQDatabase.Postgres), l, cfg.DatabaseGarbageCollection)
+			}
 
 			err := gc.Start()
 			if err != nil {
@@ -164,10 +162,12 @@ func serve() func(cmd *cobra.Command, args []string) error {
 
 		var gossipEngine *gossip.Gossip
 		var consistencyChecker *hash.ConsistentHash
+		var membership *consistent.Membership
 		if cfg.Distributed.Enabled {
 			l.Info("🔗 starting distributed mode...")
 
-			consistencyChecker = hash.NewConsistentHash(100, cfg.Distributed.Nodes, nil)
+			// The other nodes are added to the ring as the gossip protocol finds them alive
+			consistencyChecker = hash.NewConsistentHash(100, nil, nil)
 
 			externalIP, err := gossip.ExternalIP()
 			if err != nil {
@@ -175,25 +175,21 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			}
 			l.Info("🔗 external IP: " + externalIP)
 
-			consistencyChecker.Add(externalIP + ":" + cfg.HTTP.Port)
+			consistencyChecker.Add(externalIP + ":" + cfg.Server.GRPC.Port)
+			membership = consistent.NewMembership(consistencyChecker, externalIP+":"+cfg.Server.GRPC.Port, l)
 
 			grpcPort, err := strconv.Atoi(cfg.Server.GRPC.Port)
 			if err != nil {
 				return err
 			}
 
-			gossipEngine, err = gossip.InitMemberList(cfg.Distributed.Nodes, grpcPort)
+			// Nodes are added to and removed from the ring as they join, leave or fail
+			gossipEngine, err = gossip.InitMemberList(cfg.Distributed.Nodes, grpcPort, gossip.OnEvent(membership.Handle))
 			if err != nil {
 				l.Info("🔗 failed to start distributed mode: %s ", err.Error())
 				return err
 			}
 
-			go func() {
-				for {
-					consistencyChecker.SyncNodes(gossipEngine)
-				}
-			}()
-
 			defer func() {
 				if err = gossipEngine.Shutdown(); err != nil {
 					l.Fatal(err)
@@ -201,6 +197,13 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			}()
 		}
 
+		// snap token decoder
+		var snapTokenDecoder token.Decoder
+		snapTokenDecoder, err = factories.SnapTokenDecoderFactory(db)
+		if err != nil {
+			l.Fatal(err)
+		}
+
 		// schema cache
 		var schemaCache cache.Cache
 		schemaCache, err = ristretto.New(ristretto.NumberOfCounters(cfg.Schema.Cache.NumberOfCounters), ristretto.MaxCost(cfg.Schema.Cache.MaxCost))
@@ -222,6 +225,7 @@ func serve() func(cmd *cobra.Command, args []string) error {
 		schemaWriter := factories.SchemaWriterFactory(db, l)
 		tenantReader := factories.TenantReaderFactory(db, l)
 		tenantWriter := factories.TenantWriterFactory(db, l)
+		watcher := factories.WatcherFactory(db, l)
 
 		// Add caching to the schema reader using a decorator
 		schemaReader = decorators.NewSchemaReaderWithCache(schemaReader, schemaCache)
@@ -237,49 +241,90 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			schemaReader = decorators.NewSchemaReaderWithCircuitBreaker(schemaReader)
 		}
 
+		// Report cycles in the relationships so that the data can be fixed
+		cycleHandler := func(ctx context.Context, tenantID string, cycle []string) {
+			l.Warn("cycle detected in relationships of tenant %s: %s", tenantID, strings.Join(cycle, " -> "))
+		}
+
+		checkOptions := []engines.CheckOption{
+			engines.CheckConcurrencyLimit(cfg.Permission.ConcurrencyLimit),
+			engines.CheckCycleHandler(cycleHandler),
+		}
+		lookupEntityOptions := []engines.LookupEntityOption{
+			engines.LookupEntityConcurrencyLimit(cfg.Permission.BulkLimit),
+		}
+
+		// Answer materialized permissions from an index that follows the change stream of the storage, so that it sees
+		// the writes of every node of a cluster.
+		if cfg.Permission.Materialization {
+			index := materialize.NewIndex(
+				schemaReader,
+				relationshipReader,
+				watcher,
+				engines.NewExpandEngine(schemaReader, relationshipReader),
+				snapTokenDecoder,
+				l,
+			)
+			defer index.Close()
+
+			relationshipWriter = materialize.NewRelationshipWriterWithIndex(relationshipWriter, index)
+			checkOptions = append(checkOptions, engines.CheckPermissionIndex(index))
+			lookupEntityOptions = append(lookupEntityOptions, engines.LookupEntityPermissionIndex(index))
+		}
+
 		// Initialize the engines using the key manager, schema reader, and relationship reader
-		checkEngine := engines.NewCheckEngine(schemaReader, relationshipReader, engines.CheckConcurrencyLimit(cfg.Permission.ConcurrencyLimit))
+		checkEngine := engines.NewCheckEngine(schemaReader, relationshipReader, checkOptions...)
 		linkedEntityEngine := engines.NewLinkedEntityEngine(schemaReader, relationshipReader)
-		lookupEntityEngine := engines.NewLookupEntityEngine(checkEngine, linkedEntityEngine, engines.LookupEntityConcurrencyLimit(cfg.Permission.BulkLimit))
-		expandEngine := engines.NewExpandEngine(schemaReader, relationshipReader)
+		lookupEntityEngine := engines.NewLookupEntityEngine(checkEngine, linkedEntityEngine, lookupEntityOptions...)
+		matrixEngine := engines.NewMatrixEngine(
+			checkEngine,
+			engines.MatrixConcurrencyLimit(cfg.Permission.BulkLimit),
+			engines.MatrixBulkLimit(cfg.Permission.BulkLimit),
+		)
+		expandEngine := engines.NewExpandEngine(schemaReader, relationshipReader, engines.ExpandCycleHandler(cycleHandler))
+
+		// The checks that are evaluated on this node, and the sub-problems other nodes dispatch to it
+		local := keys.NewCheckEngineWithKeys(
+			keys.NewCheckEngineWithSingleflight(checkEngine),
+			engineKeyCache,
+			l,
+		)
 
 		var check invoke.Check
+		var dispatcher invoke.Check
 		if cfg.Distributed.Enabled {
 
-			options := []grpc.DialOption{
-				grpc.WithBlock(),
-			}
-			if cfg.GRPC.TLSConfig.Enabled {
-				c, err := credentials.NewClientTLSFromFile(cfg.GRPC.TLSConfig.CertPath, "")
-				if err != nil {
-					return err
-				}
-				options = append(options, grpc.WithTransportCredentials(c))
-			} else {
-				options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			// The nodes dial each other with mutual TLS
+			c, err := servers.PeerClientCredentials(cfg.Distributed.TLS)
+			if err != nil {
+				return err
 			}
+			options := []grpc.DialOption{grpc.WithTransportCredentials(c)}
 
-			check, err = consistent.NewCheckEngineWithHashring(
-				keys.NewCheckEngineWithKeys(
-					checkEngine,
-					engineKeyCache,
-					l,
-				),
+			// Dispatch every check, including the sub-problems of recursive checks, to the node that owns it
+			var hashring *consistent.Hashring
+			hashring, err = consistent.NewCheckEngineWithHashring(
+				local,
 				consistencyChecker,
 				gossipEngine,
 				cfg.Server.GRPC.Port,
+				cfg.Distributed.Port,
 				l,
 				options...,
 			)
 			if err != nil {
 				return err
 			}
+			defer func() {
+				if err = hashring.Close(); err != nil {
+					l.Error(err)
+				}
+			}()
+
+			check = hashring
+			dispatcher = local
 		} else {
-			check = keys.NewCheckEngineWithKeys(
-				checkEngine,
-				engineKeyCache,
-				l,
-			)
+			check = local
 		}
 
 		invoker := invoke.NewDirectInvoker(
@@ -288,6 +333,9 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			check,
 			expandEngine,
 			lookupEntityEngine,
+			matrixEngine,
+			invoke.SnapshotQuantization(cfg.Permission.SnapshotQuantization),
+			invoke.SnapTokenDecoder(snapTokenDecoder),
 		)
 
 		checkEngine.SetInvoker(invoker)
@@ -301,6 +349,9 @@ func serve() func(cmd *cobra.Command, args []string) error {
 			schemaWriter,
 			tenantReader,
 			tenantWriter,
+			watcher,
+			dispatcher,
+			membership,
 		)
 
 		// Create an error group with the provided context
@@ -309,7 +360,7 @@ func serve() func(cmd *cobra.Command, args []string) error {
 
 		// Add the container.Run function to the error group
 		g.Go(func() error {
-			return container.Run(ctx, &cfg.Server, &cfg.Authn, &cfg.Profiler, l)
+			return container.Run(ctx, &cfg.Server, &cfg.Authn, &cfg.Profiler, &cfg.Distributed, l)
 		})
 
 		// Wait for the error group to finish and log any errors
@@ -2,6 +2,7 @@ package hash
 
 import (
 	"log"
+	"math"
 	"sort"
 	"strconv"
 	"sync"
@@ -51,6 +52,10 @@ type Consistent interface {
 	// returns true if the key was successfully added; otherwise, it
 	// returns false.
 	AddKey(key string) bool
+
+	// Ownership returns the share of the hash space that each node
+	// of the consistent hashing ring is responsible for.
+	Ownership() map[string]float64
 }
 
 // Func is a function type that takes a byte slice as input and returns
@@ -273,6 +278,34 @@ func (h *ConsistentHash) AddKey(key string) bool {
 	return true
 }
 
+// Ownership returns the share of the hash space, between 0 and 1, that
+// each node of the ConsistentHash instance is responsible for. A node is
+// responsible for the hashes from the previous key of the ring, exclusive,
+// up to each of its keys, inclusive, so the shares of all nodes add up to 1.
+func (h *ConsistentHash) Ownership() map[string]float64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	ownership := make(map[string]float64, len(h.nodes))
+	if len(h.keys) == 0 {
+		return ownership
+	}
+	if len(h.keys) == 1 {
+		ownership[h.ring[h.keys[0]]] = 1
+		return ownership
+	}
+
+	// The first key of the ring wraps around from the last one, which the
+	// unsigned subtraction takes care of.
+	previous := h.keys[len(h.keys)-1]
+	for _, key := range h.keys {
+		ownership[h.ring[key]] += float64(key-previous) / math.MaxUint64
+		previous = key
+	}
+
+	return ownership
+}
+
 // addNode adds a node to the nodes map in the ConsistentHash instance.
 func (h *ConsistentHash) addNode(node string) {
 	h.nodes[node] = struct{}{}
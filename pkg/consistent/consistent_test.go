@@ -71,3 +71,29 @@ func TestConsistentHash_Updated(t *testing.T) {
 		t.Log(node, key)
 	}
 }
+
+func TestConsistentHash_Ownership(t *testing.T) {
+	ch := hash.NewConsistentHash(100, []string{"node1", "node2", "node3"}, nil)
+
+	ownership := ch.Ownership()
+	if len(ownership) != 3 {
+		t.Fatalf("Expected the ownership of 3 nodes, got %d", len(ownership))
+	}
+
+	total := 0.0
+	for node, share := range ownership {
+		if share <= 0 || share >= 1 {
+			t.Errorf("Expected the share of node '%s' to be between 0 and 1, got %f", node, share)
+		}
+		total += share
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("Expected the shares to add up to 1, got %f", total)
+	}
+
+	ch.Remove("node2")
+	ch.Remove("node3")
+	if share := ch.Ownership()["node1"]; share < 0.999 {
+		t.Errorf("Expected the only node to own the whole ring, got %f", share)
+	}
+}
@@ -35,6 +35,11 @@ func (i *TupleIterator) GetNext() *base.Tuple {
 	return nil
 }
 
+// Count - Returns the total number of tuples
+func (i *TupleIterator) Count() int {
+	return len(i.tuples)
+}
+
 // SUBJECT
 
 // SubjectIterator - Structure for subject iterator
@@ -63,6 +63,11 @@ func TestTupleIterator(t *testing.T) {
 	if tupleIterator.HasNext() {
 		t.Error("Expected false for HasNext(), but got true")
 	}
+
+	// Test Count() method
+	if tupleIterator.Count() != 3 {
+		t.Errorf("Expected 3 for Count(), but got %d", tupleIterator.Count())
+	}
 }
 
 func TestSubjectIterator(t *testing.T) {
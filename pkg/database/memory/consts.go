@@ -1 +1,13 @@
 package memory
+
+import (
+	"time"
+)
+
+const (
+	_defaultSnapshotInterval = 5 * time.Minute
+	_snapshotBatchSize       = 1000
+
+	_snapshotFile  = "snapshot"
+	_logFilePrefix = "log."
+)
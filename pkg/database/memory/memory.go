@@ -2,24 +2,121 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
+
+	"github.com/Permify/permify/pkg/logger"
 )
 
+// ErrClosed is returned when a write transaction is committed to a memory db that has been closed.
+var ErrClosed = errors.New("memory database: closed")
+
 // Memory - Structure for in memory db
 type Memory struct {
 	sync.RWMutex
 
 	DB *memdb.MemDB
+	// options
+	dir              string
+	snapshotInterval time.Duration
+	logger           logger.Interface
+
+	persistence *persistence
+	stop        chan struct{}
+	done        chan struct{}
+	// closed is closed when the memory db is closed, so the watchers reading from it stop
+	closed    chan struct{}
+	closeOnce sync.Once
+	// snapshotErr is the error of the last periodic snapshot, it is cleared once a snapshot succeeds
+	snapshotErr error
 }
 
-// New - Creates new database schema in memory
-func New(schema *memdb.DBSchema) (*Memory, error) {
+// New - Creates new database schema in memory, it is restored from its directory if it is persistent
+func New(schema *memdb.DBSchema, opts ...Option) (*Memory, error) {
+	m := &Memory{
+		snapshotInterval: _defaultSnapshotInterval,
+		closed:           make(chan struct{}),
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(m)
+	}
+
 	db, err := memdb.NewMemDB(schema)
-	return &Memory{
-		DB: db,
-	}, err
+	if err != nil {
+		return nil, err
+	}
+	m.DB = db
+
+	if m.dir != "" {
+		m.persistence, err = openPersistence(db, schema, m.dir)
+		if err != nil {
+			return nil, err
+		}
+		m.stop = make(chan struct{})
+		m.done = make(chan struct{})
+		go m.snapshots()
+	}
+
+	return m, nil
+}
+
+// Txn - Starts a new transaction, the changes of write transactions are tracked if the memory db is persistent
+func (m *Memory) Txn(write bool) *memdb.Txn {
+	txn := m.DB.Txn(write)
+	if write && m.persistence != nil {
+		txn.TrackChanges()
+	}
+	return txn
+}
+
+// Commit - Commits the write transaction, its changes are appended to the write log first if the memory db is
+// persistent. The transaction is not committed if they cannot be written.
+func (m *Memory) Commit(txn *memdb.Txn) error {
+	select {
+	case <-m.closed:
+		txn.Abort()
+		return ErrClosed
+	default:
+	}
+	if m.persistence != nil {
+		if err := m.persistence.append(txn.Changes()); err != nil {
+			return err
+		}
+	}
+	txn.Commit()
+	return nil
+}
+
+// snapshots - Snapshots the memory db periodically until it is closed, a failed snapshot is logged and tried again
+// at the next interval while the write log keeps the changes
+func (m *Memory) snapshots() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := m.persistence.snapshot(m.DB)
+			if err != nil && m.logger != nil {
+				m.logger.Error("failed to snapshot the memory database", err)
+			}
+			m.Lock()
+			m.snapshotErr = err
+			m.Unlock()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// Done - Returns a channel that is closed when the memory db is closed
+func (m *Memory) Done() <-chan struct{} {
+	return m.closed
 }
 
 // GetEngineType - Gets engine type, returns as string
@@ -27,15 +124,36 @@ func (m *Memory) GetEngineType() string {
 	return "memory"
 }
 
-// Close - Closing the in memory instance
-func (m *Memory) Close() error {
-	m.Lock()
-	defer m.Unlock()
-	m.DB = nil
-	return nil
+// Close - Closing the in memory instance, a persistent memory db is snapshotted before it is closed. The watchers
+// of the memory db stop, and the write transactions committed after are rejected.
+func (m *Memory) Close() (err error) {
+	m.closeOnce.Do(func() {
+		if m.persistence != nil {
+			close(m.stop)
+			<-m.done
+		}
+		close(m.closed)
+		if m.persistence != nil {
+			err = m.persistence.snapshot(m.DB)
+			if cerr := m.persistence.close(); err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
 }
 
-// IsReady - Check if database is ready
+// IsReady - Check if database is ready, a closed memory db is not, nor a persistent one whose last snapshot failed
 func (m *Memory) IsReady(ctx context.Context) (bool, error) {
+	select {
+	case <-m.closed:
+		return false, ErrClosed
+	default:
+	}
+	m.RLock()
+	defer m.RUnlock()
+	if m.snapshotErr != nil {
+		return false, m.snapshotErr
+	}
 	return true, nil
 }
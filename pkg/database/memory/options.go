@@ -1 +1,32 @@
 package memory
+
+import (
+	"time"
+
+	"github.com/Permify/permify/pkg/logger"
+)
+
+// Option - Option type
+type Option func(*Memory)
+
+// Persistence - Keeps the changes committed to the memory db in a write log and snapshots in the given directory,
+// the memory db is restored from them when it is created
+func Persistence(dir string) Option {
+	return func(m *Memory) {
+		m.dir = dir
+	}
+}
+
+// SnapshotInterval - Defines the interval the memory db is snapshotted at, if it is persistent
+func SnapshotInterval(d time.Duration) Option {
+	return func(m *Memory) {
+		m.snapshotInterval = d
+	}
+}
+
+// Logger - Defines the logger the failed periodic snapshots of the memory db are logged with
+func Logger(l logger.Interface) Option {
+	return func(m *Memory) {
+		m.logger = l
+	}
+}
@@ -0,0 +1,378 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-memdb"
+)
+
+// ErrCorruptedLog is returned when a write log that is not the last one, or a snapshot, cannot be read to its end.
+var ErrCorruptedLog = errors.New("memory database: corrupted write log")
+
+// entry is a record of a write log or a snapshot. The objects of the changes are encoded with gob, so their types
+// must be registered with gob.Register.
+type entry struct {
+	// Sequence is set in the first record of a snapshot, it is the sequence of the first write log written after it
+	Sequence uint64
+	Changes  []change
+}
+
+type change struct {
+	Table   string
+	Deleted bool
+	Object  interface{}
+}
+
+// persistence - Keeps the changes committed to the database in an append-only write log, and periodic snapshots
+// of the database that the write logs before them are removed after, in a local directory.
+type persistence struct {
+	mu sync.Mutex
+
+	dir    string
+	tables []string
+
+	file     *os.File
+	sequence uint64
+	offset   int64
+}
+
+// openPersistence restores the database from the snapshot and the write logs in the directory, and starts a new
+// write log for the changes committed after.
+func openPersistence(db *memdb.MemDB, schema *memdb.DBSchema, dir string) (*persistence, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	p := &persistence{dir: dir}
+	for table := range schema.Tables {
+		p.tables = append(p.tables, table)
+	}
+	sort.Strings(p.tables)
+
+	next, err := p.restore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.open(next); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// restore applies the snapshot and then the write logs written after it, and returns the sequence of the next write
+// log. The last record of the last write log can have been torn by a crash, it is discarded.
+func (p *persistence) restore(db *memdb.MemDB) (uint64, error) {
+	var sequence uint64
+
+	f, err := os.Open(filepath.Join(p.dir, _snapshotFile))
+	switch {
+	case err == nil:
+		first := true
+		err = read(f, func(e entry) error {
+			if first {
+				sequence = e.Sequence
+				first = false
+			}
+			return apply(db, e)
+		})
+		f.Close()
+		if err != nil {
+			return 0, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return 0, err
+	}
+
+	logs, err := p.logs()
+	if err != nil {
+		return 0, err
+	}
+
+	next := sequence
+	for i, seq := range logs {
+		if seq < sequence {
+			continue
+		}
+		f, err = os.Open(p.log(seq))
+		if err != nil {
+			return 0, err
+		}
+		err = read(f, func(e entry) error {
+			return apply(db, e)
+		})
+		f.Close()
+		if err != nil && !(errors.Is(err, ErrCorruptedLog) && i == len(logs)-1) {
+			return 0, err
+		}
+		next = seq + 1
+	}
+	return next, nil
+}
+
+// append writes the changes to the write log, they are synced to the disk before it returns.
+func (p *persistence) append(changes memdb.Changes) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	e := entry{Changes: make([]change, 0, len(changes))}
+	for _, c := range changes {
+		switch {
+		case c.Before == nil && c.After == nil:
+			// The object was inserted and deleted by the same transaction
+			continue
+		case c.Deleted():
+			e.Changes = append(e.Changes, change{Table: c.Table, Deleted: true, Object: c.Before})
+		default:
+			e.Changes = append(e.Changes, change{Table: c.Table, Object: c.After})
+		}
+	}
+
+	record, err := encode(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err = p.file.Write(record); err == nil {
+		err = p.file.Sync()
+	}
+	if err != nil {
+		// Drop what has been written of the record, so the records after it can still be read
+		_ = p.file.Truncate(p.offset)
+		_, _ = p.file.Seek(p.offset, io.SeekStart)
+		return err
+	}
+	p.offset += int64(len(record))
+	return nil
+}
+
+// snapshot writes the state of the database to a new snapshot, and removes the write logs it contains.
+func (p *persistence) snapshot(db *memdb.MemDB) error {
+	// The write transaction waits for the writers, the state read and the rotation of the write log are consistent
+	wtxn := db.Txn(true)
+	txn := db.Txn(false)
+	p.mu.Lock()
+	err := p.open(p.sequence + 1)
+	sequence := p.sequence
+	p.mu.Unlock()
+	wtxn.Abort()
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(p.dir, _snapshotFile+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	w := bufio.NewWriter(f)
+	if err = p.write(w, txn, sequence); err == nil {
+		if err = w.Flush(); err == nil {
+			err = f.Sync()
+		}
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp, filepath.Join(p.dir, _snapshotFile)); err != nil {
+		return err
+	}
+	if err = syncDir(p.dir); err != nil {
+		return err
+	}
+
+	logs, err := p.logs()
+	if err != nil {
+		return err
+	}
+	for _, seq := range logs {
+		if seq < sequence {
+			if err = os.Remove(p.log(seq)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// write writes the objects of all the tables read by the transaction as the records of a snapshot.
+func (p *persistence) write(w io.Writer, txn *memdb.Txn, sequence uint64) error {
+	e := entry{Sequence: sequence}
+	flush := func() error {
+		record, err := encode(e)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(record)
+		e = entry{}
+		return err
+	}
+
+	for _, table := range p.tables {
+		it, err := txn.Get(table, "id")
+		if err != nil {
+			return err
+		}
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			e.Changes = append(e.Changes, change{Table: table, Object: obj})
+			if len(e.Changes) == _snapshotBatchSize {
+				if err = flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// open starts the write log of the sequence, the caller holds the lock if the persistence is in use.
+func (p *persistence) open(sequence uint64) error {
+	f, err := os.OpenFile(p.log(sequence), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err = syncDir(p.dir); err != nil {
+		f.Close()
+		return err
+	}
+	if p.file != nil {
+		if err = p.file.Close(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	p.file = f
+	p.sequence = sequence
+	p.offset = 0
+	return nil
+}
+
+// close closes the current write log.
+func (p *persistence) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.file.Close()
+}
+
+// logs lists the sequences of the write logs in the directory, in order.
+func (p *persistence) logs() ([]uint64, error) {
+	files, err := os.ReadDir(p.dir)
+	if err != nil {
+		return nil, err
+	}
+	var sequences []uint64
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), _logFilePrefix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimPrefix(f.Name(), _logFilePrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		sequences = append(sequences, seq)
+	}
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i] < sequences[j]
+	})
+	return sequences, nil
+}
+
+// log returns the path of the write log of the sequence.
+func (p *persistence) log(sequence uint64) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%s%020d", _logFilePrefix, sequence))
+}
+
+// apply applies the changes of the entry to the database in a single transaction.
+func apply(db *memdb.MemDB, e entry) error {
+	txn := db.Txn(true)
+	defer txn.Abort()
+	for _, c := range e.Changes {
+		if c.Deleted {
+			if err := txn.Delete(c.Table, c.Object); err != nil && !errors.Is(err, memdb.ErrNotFound) {
+				return err
+			}
+			continue
+		}
+		if err := txn.Insert(c.Table, c.Object); err != nil {
+			return err
+		}
+	}
+	txn.Commit()
+	return nil
+}
+
+// encode encodes the entry as a record: the length and the checksum of the gob encoded entry, followed by it. Each
+// record has its own gob stream so it can be decoded on its own.
+func encode(e entry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, 8))
+	if err := gob.NewEncoder(&buf).Encode(e); err != nil {
+		return nil, err
+	}
+	record := buf.Bytes()
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(record)-8))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(record[8:]))
+	return record, nil
+}
+
+// read decodes the records of r and calls fn with each of them, in order. It returns ErrCorruptedLog if the last
+// record is incomplete or does not match its checksum.
+func read(r io.Reader, fn func(e entry) error) error {
+	br := bufio.NewReader(r)
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return ErrCorruptedLog
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(header[0:4]))
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return ErrCorruptedLog
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+			return ErrCorruptedLog
+		}
+		var e entry
+		if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&e); err != nil {
+			return fmt.Errorf("%w: %v", ErrCorruptedLog, err)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+}
+
+// syncDir syncs the directory, so the files created or renamed in it are durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/Masterminds/squirrel"
+
+	driver "github.com/go-sql-driver/mysql"
+)
+
+// MySQL - Structure for MySQL instance
+type MySQL struct {
+	DB      *sql.DB
+	Builder squirrel.StatementBuilderType
+	// options
+	maxConnectionLifeTime time.Duration
+	maxConnectionIdleTime time.Duration
+	maxOpenConnections    int
+	maxIdleConnections    int
+	// garbageCollectionWindow is the window after which history is garbage collected, 0 if it is kept
+	garbageCollectionWindow time.Duration
+}
+
+// New - Creates new mysql db instance
+func New(uri string, opts ...Option) (*MySQL, error) {
+	my := &MySQL{
+		maxOpenConnections: _defaultMaxOpenConnections,
+		maxIdleConnections: _defaultMaxIdleConnections,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(my)
+	}
+
+	my.Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)
+
+	dsn, err := DSN(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if my.maxOpenConnections != 0 {
+		db.SetMaxOpenConns(my.maxOpenConnections)
+	}
+
+	if my.maxIdleConnections != 0 {
+		db.SetMaxIdleConns(my.maxIdleConnections)
+	}
+
+	if my.maxConnectionLifeTime != 0 {
+		db.SetConnMaxLifetime(my.maxConnectionLifeTime)
+	}
+
+	if my.maxConnectionIdleTime != 0 {
+		db.SetConnMaxIdleTime(my.maxConnectionIdleTime)
+	}
+
+	policy := backoff.NewExponentialBackOff()
+	policy.MaxElapsedTime = 1 * time.Minute
+	err = backoff.Retry(func() error {
+		err = db.PingContext(context.Background())
+		if err != nil {
+			return err
+		}
+		return nil
+	}, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	my.DB = db
+	return my, nil
+}
+
+// DSN - Normalizes the given data source name so that timestamps are scanned into time.Time values in UTC,
+// which is how the storage layer reads and compares the timestamps of transactions and tenants.
+func DSN(uri string) (string, error) {
+	cfg, err := driver.ParseDSN(uri)
+	if err != nil {
+		return "", err
+	}
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	return cfg.FormatDSN(), nil
+}
+
+// GetEngineType - Get the engine type which is mysql in string
+func (m *MySQL) GetEngineType() string {
+	return "mysql"
+}
+
+// GetGarbageCollectionWindow - Get the window after which history is garbage collected, 0 if it is kept
+func (m *MySQL) GetGarbageCollectionWindow() time.Duration {
+	return m.garbageCollectionWindow
+}
+
+// Close - Close mysql instance
+func (m *MySQL) Close() error {
+	if m.DB != nil {
+		return m.DB.Close()
+	}
+	return nil
+}
+
+// IsReady - Check if database is ready
+func (m *MySQL) IsReady(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := m.DB.PingContext(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -34,3 +34,10 @@ func MaxConnectionLifeTime(d time.Duration) Option {
 		p.maxConnectionLifeTime = d
 	}
 }
+
+// GarbageCollectionWindow - Defines the window after which the history of the postgresql db is garbage collected
+func GarbageCollectionWindow(d time.Duration) Option {
+	return func(p *Postgres) {
+		p.garbageCollectionWindow = d
+	}
+}
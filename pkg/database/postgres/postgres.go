@@ -21,6 +21,8 @@ type Postgres struct {
 	maxConnectionIdleTime time.Duration
 	maxOpenConnections    int
 	maxIdleConnections    int
+	// garbageCollectionWindow is the window after which history is garbage collected, 0 if it is kept
+	garbageCollectionWindow time.Duration
 }
 
 // New - Creates new postgresql db instance
@@ -80,6 +82,11 @@ func (p *Postgres) GetEngineType() string {
 	return "postgres"
 }
 
+// GetGarbageCollectionWindow - Get the window after which history is garbage collected, 0 if it is kept
+func (p *Postgres) GetGarbageCollectionWindow() time.Duration {
+	return p.garbageCollectionWindow
+}
+
 // Close - Close postgresql instance
 func (p *Postgres) Close() error {
 	if p.DB != nil {
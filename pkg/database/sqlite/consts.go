@@ -0,0 +1,8 @@
+package sqlite
+
+const (
+	_defaultMaxOpenConnections = 20
+	_defaultMaxIdleConnections = 2
+	// _defaultBusyTimeout is the time in milliseconds a connection waits for the database to be unlocked
+	_defaultBusyTimeout = 5000
+)
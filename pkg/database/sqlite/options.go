@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"time"
+)
+
+// Option - Option type
+type Option func(*SQLite)
+
+// MaxOpenConnections - Defines maximum open connections for sqlite db
+func MaxOpenConnections(size int) Option {
+	return func(c *SQLite) {
+		c.maxOpenConnections = size
+	}
+}
+
+// MaxIdleConnections - Defines maximum idle connections for sqlite db
+func MaxIdleConnections(c int) Option {
+	return func(p *SQLite) {
+		p.maxIdleConnections = c
+	}
+}
+
+// MaxConnectionIdleTime - Defines maximum connection idle for sqlite db
+func MaxConnectionIdleTime(d time.Duration) Option {
+	return func(p *SQLite) {
+		p.maxConnectionIdleTime = d
+	}
+}
+
+// MaxConnectionLifeTime - Defines maximum connection lifetime for sqlite db
+func MaxConnectionLifeTime(d time.Duration) Option {
+	return func(p *SQLite) {
+		p.maxConnectionLifeTime = d
+	}
+}
+
+// GarbageCollectionWindow - Defines the window after which the history of the sqlite db is garbage collected
+func GarbageCollectionWindow(d time.Duration) Option {
+	return func(p *SQLite) {
+		p.garbageCollectionWindow = d
+	}
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLite - Structure for SQLite instance
+type SQLite struct {
+	DB      *sql.DB
+	Builder squirrel.StatementBuilderType
+	// options
+	maxConnectionLifeTime time.Duration
+	maxConnectionIdleTime time.Duration
+	maxOpenConnections    int
+	maxIdleConnections    int
+	// garbageCollectionWindow is the window after which history is garbage collected, 0 if it is kept
+	garbageCollectionWindow time.Duration
+}
+
+// New - Creates new sqlite db instance, the uri is the path of the database file
+func New(uri string, opts ...Option) (*SQLite, error) {
+	sl := &SQLite{
+		maxOpenConnections: _defaultMaxOpenConnections,
+		maxIdleConnections: _defaultMaxIdleConnections,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(sl)
+	}
+
+	sl.Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)
+
+	db, err := sql.Open("sqlite", DSN(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	if sl.maxOpenConnections != 0 {
+		db.SetMaxOpenConns(sl.maxOpenConnections)
+	}
+
+	if sl.maxIdleConnections != 0 {
+		db.SetMaxIdleConns(sl.maxIdleConnections)
+	}
+
+	if sl.maxConnectionLifeTime != 0 {
+		db.SetConnMaxLifetime(sl.maxConnectionLifeTime)
+	}
+
+	if sl.maxConnectionIdleTime != 0 {
+		db.SetConnMaxIdleTime(sl.maxConnectionIdleTime)
+	}
+
+	if err = db.PingContext(context.Background()); err != nil {
+		return nil, err
+	}
+
+	sl.DB = db
+	return sl, nil
+}
+
+// DSN - Adds the pragmas every connection needs to the given database path. The write-ahead log lets readers
+// keep reading their snapshot while a writer commits, read-write transactions take the write lock when they
+// begin so writers are serialized in commit order, and the busy timeout makes them wait for each other
+// instead of failing right away.
+func DSN(uri string) string {
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", _defaultBusyTimeout))
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + params.Encode()
+}
+
+// GetEngineType - Get the engine type which is sqlite in string
+func (s *SQLite) GetEngineType() string {
+	return "sqlite"
+}
+
+// GetGarbageCollectionWindow - Get the window after which history is garbage collected, 0 if it is kept
+func (s *SQLite) GetGarbageCollectionWindow() time.Duration {
+	return s.garbageCollectionWindow
+}
+
+// Close - Close sqlite instance
+func (s *SQLite) Close() error {
+	if s.DB != nil {
+		return s.DB.Close()
+	}
+	return nil
+}
+
+// IsReady - Check if database is ready
+func (s *SQLite) IsReady(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := s.DB.PingContext(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
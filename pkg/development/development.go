@@ -30,16 +30,16 @@ type Development struct {
 func NewContainer() *Development {
 	var err error
 
+	// Create a new logger instance
+	l := logger.New("debug")
+
 	// Create a new in-memory database using the factories package
 	var db database.Database
-	db, err = factories.DatabaseFactory(config.Database{Engine: database.MEMORY.String()})
+	db, err = factories.DatabaseFactory(config.Database{Engine: database.MEMORY.String()}, l)
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	// Create a new logger instance
-	l := logger.New("debug")
-
 	// Create instances of storage using the factories package
 	relationshipReader := factories.RelationshipReaderFactory(db, l)
 	relationshipWriter := factories.RelationshipWriterFactory(db, l)
@@ -53,6 +53,7 @@ func NewContainer() *Development {
 	expandEngine := engines.NewExpandEngine(schemaReader, relationshipReader)
 	linkedEntityEngine := engines.NewLinkedEntityEngine(schemaReader, relationshipReader)
 	lookupEntityEngine := engines.NewLookupEntityEngine(checkEngine, linkedEntityEngine)
+	matrixEngine := engines.NewMatrixEngine(checkEngine)
 
 	invoker := invoke.NewDirectInvoker(
 		schemaReader,
@@ -60,6 +61,7 @@ func NewContainer() *Development {
 		checkEngine,
 		expandEngine,
 		lookupEntityEngine,
+		matrixEngine,
 	)
 
 	checkEngine.SetInvoker(invoker)
@@ -74,6 +76,9 @@ func NewContainer() *Development {
 			schemaWriter,
 			tenantReader,
 			tenantWriter,
+			nil,
+			nil,
+			nil,
 		),
 	}
 }
@@ -187,8 +187,9 @@ func (ls *Identifier) GetType() ExpressionType {
 // PermissionStatement represents an permission statement, which consists of an permission name and an optional expression statement.
 // It implements the Statement interface.
 type PermissionStatement struct {
-	Permission          token.Token // token.PERMISSION
-	Name                token.Token // token.IDENT
+	Materialized        *token.Token // token.MATERIALIZED, if the permission is materialized
+	Permission          token.Token  // token.PERMISSION
+	Name                token.Token  // token.IDENT
 	ExpressionStatement Statement
 }
 
@@ -199,6 +200,10 @@ func (ls *PermissionStatement) statementNode() {}
 func (ls *PermissionStatement) String() string {
 	var sb strings.Builder
 	sb.WriteString("\t")
+	if ls.Materialized != nil {
+		sb.WriteString("materialized")
+		sb.WriteString(" ")
+	}
 	sb.WriteString("permission")
 	sb.WriteString(" ")
 	sb.WriteString(ls.Name.Literal)
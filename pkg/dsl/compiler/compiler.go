@@ -60,9 +60,105 @@ func (t *Compiler) Compile() ([]*base.EntityDefinition, error) {
 		entities = append(entities, entityDef)
 	}
 
+	// Materialized permissions are validated once every entity is compiled, since they may depend on any of them.
+	if !t.withoutReferenceValidation {
+		err := t.validateMaterialized(entities)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return entities, nil
 }
 
+// validateMaterialized - validates that no materialized permission depends on an exclusion. The materialized
+// permission index holds the subjects that have a permission, which cannot be told for a subject that is granted
+// a permission by the absence of a relation.
+func (t *Compiler) validateMaterialized(entities []*base.EntityDefinition) error {
+	definitions := make(map[string]*base.EntityDefinition, len(entities))
+	for _, entity := range entities {
+		definitions[entity.GetName()] = entity
+	}
+
+	for _, statement := range t.schema.Statements {
+		entityStatement, ok := statement.(*ast.EntityStatement)
+		if !ok {
+			continue
+		}
+		for _, ps := range entityStatement.PermissionStatements {
+			st, ok := ps.(*ast.PermissionStatement)
+			if !ok || st.Materialized == nil {
+				continue
+			}
+			if dependsOnExclusion(definitions, entityStatement.Name.Literal, st.Name.Literal, map[string]struct{}{}) {
+				return compileError(st.Materialized.PositionInfo, base.ErrorCode_ERROR_CODE_NOT_SUPPORTED_MATERIALIZATION.String())
+			}
+		}
+	}
+	return nil
+}
+
+// dependsOnExclusion - reports whether the relation or permission of the entity type, or any relation or permission
+// it is computed from, contains an exclusion.
+func dependsOnExclusion(definitions map[string]*base.EntityDefinition, entityType, name string, visited map[string]struct{}) bool {
+	key := utils.Key(entityType, name)
+	if _, ok := visited[key]; ok {
+		return false
+	}
+	visited[key] = struct{}{}
+
+	definition, ok := definitions[entityType]
+	if !ok {
+		return false
+	}
+
+	// The subject sets a relation holds are expanded to their subjects
+	if relation, ok := definition.GetRelations()[name]; ok {
+		for _, reference := range relation.GetRelationReferences() {
+			if reference.GetRelation() != "" && dependsOnExclusion(definitions, reference.GetType(), reference.GetRelation(), visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	permission, ok := definition.GetPermissions()[name]
+	if !ok {
+		return false
+	}
+	return childDependsOnExclusion(definitions, definition, permission.GetChild(), visited)
+}
+
+// childDependsOnExclusion - reports whether the child of a permission of the entity definition, or any relation or
+// permission it is computed from, contains an exclusion.
+func childDependsOnExclusion(definitions map[string]*base.EntityDefinition, definition *base.EntityDefinition, child *base.Child, visited map[string]struct{}) bool {
+	if rewrite := child.GetRewrite(); rewrite != nil {
+		for _, c := range rewrite.GetChildren() {
+			if childDependsOnExclusion(definitions, definition, c, visited) {
+				return true
+			}
+		}
+		return false
+	}
+
+	leaf := child.GetLeaf()
+	if leaf.GetExclusion() {
+		return true
+	}
+
+	switch l := leaf.GetType().(type) {
+	case *base.Leaf_ComputedUserSet:
+		return dependsOnExclusion(definitions, definition.GetName(), l.ComputedUserSet.GetRelation(), visited)
+	case *base.Leaf_TupleToUserSet:
+		for _, reference := range definition.GetRelations()[l.TupleToUserSet.GetTupleSet().GetRelation()].GetRelationReferences() {
+			if reference.GetRelation() == "" && dependsOnExclusion(definitions, reference.GetType(), l.TupleToUserSet.GetComputed().GetRelation(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // compile - compiles an EntityStatement into an EntityDefinition
 func (t *Compiler) compile(sc *ast.EntityStatement) (*base.EntityDefinition, error) {
 	// Initialize the entity definition
@@ -116,8 +212,9 @@ func (t *Compiler) compile(sc *ast.EntityStatement) (*base.EntityDefinition, err
 
 		// Initialize the permission definition and reference
 		permissionDefinition := &base.PermissionDefinition{
-			Name:  st.Name.Literal,
-			Child: ch,
+			Name:         st.Name.Literal,
+			Child:        ch,
+			Materialized: st.Materialized != nil,
 		}
 		entityDefinition.Permissions[permissionDefinition.GetName()] = permissionDefinition
 		entityDefinition.References[permissionDefinition.GetName()] = base.EntityDefinition_RELATIONAL_REFERENCE_PERMISSION
@@ -1206,5 +1206,57 @@ var _ = Describe("compiler", func() {
 
 			Expect(is).Should(Equal(i))
 		})
+
+		It("Case 13", func() {
+			sch, err := parser.NewParser(`
+			entity user {}
+
+			entity folder {
+				relation parent @folder
+				relation viewer @user
+
+				materialized permission view = viewer or parent.view
+			}
+			`).Parse()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			c := NewCompiler(false, sch)
+
+			var is []*base.EntityDefinition
+			is, err = c.Compile()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(is[1].GetPermissions()["view"].GetMaterialized()).Should(BeTrue())
+			Expect(is[1].GetPermissions()["view"].GetChild().GetRewrite().GetRewriteOperation()).Should(Equal(base.Rewrite_OPERATION_UNION))
+		})
+
+		It("Case 14", func() {
+			sch, err := parser.NewParser(`
+			entity user {}
+
+			entity organization {
+				relation member @user
+				relation banned @user
+
+				permission access = member and not banned
+			}
+
+			entity folder {
+				relation parent @folder
+				relation org @organization
+
+				materialized permission view = org.access or parent.view
+			}
+			`).Parse()
+
+			Expect(err).ShouldNot(HaveOccurred())
+
+			c := NewCompiler(false, sch)
+
+			_, err = c.Compile()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(Equal("15:6: not supported materialization"))
+		})
 	})
 })
@@ -650,5 +650,52 @@ entity organization {
 				Expect(index + lexeme.Literal).Should(Equal(index + tt.expectedLiteral))
 			}
 		})
+
+		It("Case 8", func() {
+			str := `
+	entity folder {
+		materialized permission view = parent.view
+	}`
+
+			tests := []struct {
+				expectedType    token.Type
+				expectedLiteral string
+			}{
+				{token.NEWLINE, "\n"},
+				{token.TAB, "\t"},
+				{token.ENTITY, "entity"},
+				{token.SPACE, " "},
+				{token.IDENT, "folder"},
+				{token.SPACE, " "},
+				{token.LBRACE, "{"},
+				{token.NEWLINE, "\n"},
+				{token.TAB, "\t"},
+				{token.TAB, "\t"},
+				{token.MATERIALIZED, "materialized"},
+				{token.SPACE, " "},
+				{token.PERMISSION, "permission"},
+				{token.SPACE, " "},
+				{token.IDENT, "view"},
+				{token.SPACE, " "},
+				{token.ASSIGN, "="},
+				{token.SPACE, " "},
+				{token.IDENT, "parent"},
+				{token.DOT, "."},
+				{token.IDENT, "view"},
+				{token.NEWLINE, "\n"},
+				{token.TAB, "\t"},
+				{token.RBRACE, "}"},
+				{token.EOF, ""},
+			}
+
+			l := NewLexer(str)
+
+			for i, tt := range tests {
+				lexeme := l.NextToken()
+				index := strconv.Itoa(i) + ": "
+				Expect(index + lexeme.Type.String()).Should(Equal(index + tt.expectedType.String()))
+				Expect(index + lexeme.Literal).Should(Equal(index + tt.expectedLiteral))
+			}
+		})
 	})
 })
@@ -326,11 +326,17 @@ func (p *Parser) parseEntityStatement() (*ast.EntityStatement, error) {
 				return nil, p.Error()
 			}
 			stmt.PermissionStatements = append(stmt.PermissionStatements, action)
+		case token.MATERIALIZED:
+			action, err := p.parseMaterializedPermissionStatement(stmt.Name.Literal)
+			if err != nil {
+				return nil, p.Error()
+			}
+			stmt.PermissionStatements = append(stmt.PermissionStatements, action)
 		default:
 			// if the currentToken is not recognized, check if it is a newline, left brace, or right brace token, and skip it if it is
 			if !p.currentTokenIs(token.NEWLINE) && !p.currentTokenIs(token.LBRACE) && !p.currentTokenIs(token.RBRACE) {
 				// if the currentToken is not recognized and not a newline, left brace, or right brace token, raise an error and return nil for both the statement and error values
-				p.currentError(token.RELATION, token.PERMISSION)
+				p.currentError(token.RELATION, token.PERMISSION, token.MATERIALIZED)
 				return nil, p.Error()
 			}
 		}
@@ -442,6 +448,26 @@ func (p *Parser) parsePermissionStatement(entityName string) (ast.Statement, err
 	return stmt, nil
 }
 
+// parseMaterializedPermissionStatement method parses a PERMISSION statement that is preceded by the MATERIALIZED
+// modifier and returns a PermissionStatement AST node that is marked as materialized
+func (p *Parser) parseMaterializedPermissionStatement(entityName string) (ast.Statement, error) {
+	// keep the MATERIALIZED token and expect the next token to be a PERMISSION token
+	materialized := p.currentToken
+	if !p.expectAndNext(token.PERMISSION) {
+		return nil, p.Error()
+	}
+
+	// parse the permission statement itself and mark it as materialized
+	stmt, err := p.parsePermissionStatement(entityName)
+	if err != nil {
+		return nil, err
+	}
+	stmt.(*ast.PermissionStatement).Materialized = &materialized
+
+	// return the parsed PermissionStatement and nil for the error value
+	return stmt, nil
+}
+
 // parseExpressionStatement method parses an expression statement and returns an ExpressionStatement AST node
 func (p *Parser) parseExpressionStatement() (*ast.ExpressionStatement, error) {
 	// create a new ExpressionStatement object
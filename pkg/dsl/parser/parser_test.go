@@ -510,5 +510,44 @@ var _ = Describe("parser", func() {
 			// Ensure the error message contains the expected string
 			Expect(err.Error()).Should(ContainSubstring("5:25:duplication found for organization#delete"))
 		})
+
+		It("Case 13 - Materialized permission", func() {
+			pr := NewParser(`
+			entity folder {
+				relation parent @folder
+				relation viewer @user
+
+				materialized permission view = viewer or parent.view
+				permission edit = viewer
+			}`)
+
+			schema, err := pr.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			st := schema.Statements[0].(*ast.EntityStatement)
+
+			a1 := st.PermissionStatements[0].(*ast.PermissionStatement)
+			Expect(a1.Name.Literal).Should(Equal("view"))
+			Expect(a1.Materialized).ShouldNot(BeNil())
+			Expect(a1.String()).Should(Equal("\tmaterialized permission view = (viewer or parent.view)"))
+
+			a2 := st.PermissionStatements[1].(*ast.PermissionStatement)
+			Expect(a2.Name.Literal).Should(Equal("edit"))
+			Expect(a2.Materialized).Should(BeNil())
+		})
+
+		It("Case 14 - Materialized relation", func() {
+			pr := NewParser(`
+			entity folder {
+				materialized relation viewer @user
+			}`)
+
+			_, err := pr.Parse()
+
+			// Ensure an error is returned
+			Expect(err).Should(HaveOccurred())
+
+			// Ensure the error message contains the expected string
+			Expect(err.Error()).Should(ContainSubstring("expected next token to be PERMISSION, got RELATION instead"))
+		})
 	})
 })
@@ -39,13 +39,14 @@ func New(positionInfo PositionInfo, typ Type, ch byte) Token {
 
 // keywords - maps string keywords to their corresponding Type.
 var keywords = map[string]Type{
-	"entity":     ENTITY,
-	"relation":   RELATION,
-	"action":     PERMISSION,
-	"permission": PERMISSION,
-	"and":        AND,
-	"or":         OR,
-	"not":        NOT,
+	"entity":       ENTITY,
+	"relation":     RELATION,
+	"action":       PERMISSION,
+	"permission":   PERMISSION,
+	"materialized": MATERIALIZED,
+	"and":          AND,
+	"or":           OR,
+	"not":          NOT,
 }
 
 // ignores - maps ignored token types to an empty struct.
@@ -90,6 +91,11 @@ const (
 	RELATION   = "RELATION"
 	PERMISSION = "PERMISSION"
 
+	/*
+		Modifiers
+	*/
+	MATERIALIZED = "MATERIALIZED"
+
 	/*
 		Prefix
 	*/
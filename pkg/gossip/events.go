@@ -0,0 +1,73 @@
+package gossip
+
+import (
+	"github.com/hashicorp/memberlist"
+)
+
+// Option - a functional option type for configuring the memberlist of the Gossip.
+type Option func(conf *memberlist.Config)
+
+// EventType is the type of a change in the membership of the cluster.
+type EventType int
+
+const (
+	// EventJoin is sent when a node joins the cluster, including the local node and the nodes that were members
+	// before the local node joined.
+	EventJoin EventType = iota
+	// EventLeave is sent when a node leaves the cluster, or is considered dead after failing to respond.
+	EventLeave
+	// EventUpdate is sent when the metadata of a node changes.
+	EventUpdate
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventJoin:
+		return "join"
+	case EventLeave:
+		return "leave"
+	case EventUpdate:
+		return "update"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change in the membership of the cluster.
+type Event struct {
+	Type EventType
+	// Node is the address of the node, in the form of host:port
+	Node string
+}
+
+// EventHandler is a function that is called with every change in the membership of the cluster. It is called
+// synchronously by the gossip protocol, so it must not block.
+type EventHandler func(event Event)
+
+// OnEvent - a functional option that sets the handler that is called with the changes in the membership of the cluster.
+func OnEvent(handler EventHandler) Option {
+	return func(conf *memberlist.Config) {
+		conf.Events = &eventDelegate{handler: handler}
+	}
+}
+
+// eventDelegate passes the membership events of the memberlist to an EventHandler.
+type eventDelegate struct {
+	handler EventHandler
+}
+
+// NotifyJoin is invoked when a node is detected to have joined.
+func (d *eventDelegate) NotifyJoin(node *memberlist.Node) {
+	d.handler(Event{Type: EventJoin, Node: node.Address()})
+}
+
+// NotifyLeave is invoked when a node is detected to have left.
+func (d *eventDelegate) NotifyLeave(node *memberlist.Node) {
+	d.handler(Event{Type: EventLeave, Node: node.Address()})
+}
+
+// NotifyUpdate is invoked when a node is detected to have updated, usually involving the meta data.
+func (d *eventDelegate) NotifyUpdate(node *memberlist.Node) {
+	d.handler(Event{Type: EventUpdate, Node: node.Address()})
+}
@@ -38,13 +38,18 @@ type Gossip struct {
 }
 
 // InitMemberList initializes a memberlist instance with the provided seed nodes and config.
-func InitMemberList(nodes []string, grpcPort int) (*Gossip, error) {
+func InitMemberList(nodes []string, grpcPort int, opts ...Option) (*Gossip, error) {
 	// Set up the default configuration for the memberlist.
 	conf := memberlist.DefaultLocalConfig()
 
 	// Discard logging to avoid cluttering the console.
 	conf.Logger = log.New(io.Discard, "", 0)
 
+	// Apply the provided options, such as the handler of membership events.
+	for _, opt := range opts {
+		opt(conf)
+	}
+
 	// Get the external IP address of the local machine.
 	ip, err := ExternalIP()
 	if err != nil {
@@ -0,0 +1,539 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: base/v1/dispatch.proto
+
+package basev1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on DispatchCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchCheckRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchCheckRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DispatchCheckRequestMultiError, or nil if none found.
+func (m *DispatchCheckRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchCheckRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := DispatchCheckRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_DispatchCheckRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := DispatchCheckRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMetadata() == nil {
+		err := DispatchCheckRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchCheckRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetEntity() == nil {
+		err := DispatchCheckRequestValidationError{
+			field:  "Entity",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetEntity()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Entity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Entity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEntity()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchCheckRequestValidationError{
+				field:  "Entity",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Permission
+
+	if m.GetSubject() == nil {
+		err := DispatchCheckRequestValidationError{
+			field:  "Subject",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetSubject()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchCheckRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchCheckRequestValidationError{
+				field:  "Subject",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DispatchCheckRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchCheckRequestMultiError is an error wrapping multiple validation
+// errors returned by DispatchCheckRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DispatchCheckRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchCheckRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchCheckRequestMultiError) AllErrors() []error { return m }
+
+// DispatchCheckRequestValidationError is the validation error returned by
+// DispatchCheckRequest.Validate if the designated constraints aren't met.
+type DispatchCheckRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchCheckRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchCheckRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchCheckRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchCheckRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchCheckRequestValidationError) ErrorName() string {
+	return "DispatchCheckRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchCheckRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchCheckRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchCheckRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchCheckRequestValidationError{}
+
+var _DispatchCheckRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on DispatchCheckRequestMetadata with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchCheckRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchCheckRequestMetadata with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DispatchCheckRequestMetadataMultiError, or nil if none found.
+func (m *DispatchCheckRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchCheckRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	// no validation rules for SnapToken
+
+	// no validation rules for Exclusion
+
+	if m.GetRemainingDepth() < 0 {
+		err := DispatchCheckRequestMetadataValidationError{
+			field:  "RemainingDepth",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DispatchCheckRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchCheckRequestMetadataMultiError is an error wrapping multiple
+// validation errors returned by DispatchCheckRequestMetadata.ValidateAll() if
+// the designated constraints aren't met.
+type DispatchCheckRequestMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchCheckRequestMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchCheckRequestMetadataMultiError) AllErrors() []error { return m }
+
+// DispatchCheckRequestMetadataValidationError is the validation error returned
+// by DispatchCheckRequestMetadata.Validate if the designated constraints
+// aren't met.
+type DispatchCheckRequestMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchCheckRequestMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchCheckRequestMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchCheckRequestMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchCheckRequestMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchCheckRequestMetadataValidationError) ErrorName() string {
+	return "DispatchCheckRequestMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchCheckRequestMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchCheckRequestMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchCheckRequestMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchCheckRequestMetadataValidationError{}
+
+// Validate checks the field values on DispatchCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchCheckResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchCheckResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DispatchCheckResponseMultiError, or nil if none found.
+func (m *DispatchCheckResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchCheckResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Can
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchCheckResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchCheckResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchCheckResponseValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for CyclicLength
+
+	if len(errors) > 0 {
+		return DispatchCheckResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchCheckResponseMultiError is an error wrapping multiple validation
+// errors returned by DispatchCheckResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DispatchCheckResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchCheckResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchCheckResponseMultiError) AllErrors() []error { return m }
+
+// DispatchCheckResponseValidationError is the validation error returned by
+// DispatchCheckResponse.Validate if the designated constraints aren't met.
+type DispatchCheckResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchCheckResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchCheckResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchCheckResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchCheckResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchCheckResponseValidationError) ErrorName() string {
+	return "DispatchCheckResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchCheckResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchCheckResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchCheckResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchCheckResponseValidationError{}
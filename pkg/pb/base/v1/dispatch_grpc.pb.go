@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: base/v1/dispatch.proto
+
+package basev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Dispatch_DispatchCheck_FullMethodName = "/base.v1.Dispatch/DispatchCheck"
+)
+
+// DispatchClient is the client API for Dispatch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DispatchClient interface {
+	DispatchCheck(ctx context.Context, in *DispatchCheckRequest, opts ...grpc.CallOption) (*DispatchCheckResponse, error)
+}
+
+type dispatchClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDispatchClient(cc grpc.ClientConnInterface) DispatchClient {
+	return &dispatchClient{cc}
+}
+
+func (c *dispatchClient) DispatchCheck(ctx context.Context, in *DispatchCheckRequest, opts ...grpc.CallOption) (*DispatchCheckResponse, error) {
+	out := new(DispatchCheckResponse)
+	err := c.cc.Invoke(ctx, Dispatch_DispatchCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DispatchServer is the server API for Dispatch service.
+// All implementations must embed UnimplementedDispatchServer
+// for forward compatibility
+type DispatchServer interface {
+	DispatchCheck(context.Context, *DispatchCheckRequest) (*DispatchCheckResponse, error)
+	mustEmbedUnimplementedDispatchServer()
+}
+
+// UnimplementedDispatchServer must be embedded to have forward compatible implementations.
+type UnimplementedDispatchServer struct {
+}
+
+func (UnimplementedDispatchServer) DispatchCheck(context.Context, *DispatchCheckRequest) (*DispatchCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchCheck not implemented")
+}
+func (UnimplementedDispatchServer) mustEmbedUnimplementedDispatchServer() {}
+
+// UnsafeDispatchServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DispatchServer will
+// result in compilation errors.
+type UnsafeDispatchServer interface {
+	mustEmbedUnimplementedDispatchServer()
+}
+
+func RegisterDispatchServer(s grpc.ServiceRegistrar, srv DispatchServer) {
+	s.RegisterService(&Dispatch_ServiceDesc, srv)
+}
+
+func _Dispatch_DispatchCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServer).DispatchCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dispatch_DispatchCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServer).DispatchCheck(ctx, req.(*DispatchCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Dispatch_ServiceDesc is the grpc.ServiceDesc for Dispatch service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Dispatch_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "base.v1.Dispatch",
+	HandlerType: (*DispatchServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DispatchCheck",
+			Handler:    _Dispatch_DispatchCheck_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "base/v1/dispatch.proto",
+}
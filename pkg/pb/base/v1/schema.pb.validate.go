@@ -1208,6 +1208,8 @@ func (m *PermissionDefinition) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Materialized
+
 	if len(errors) > 0 {
 		return PermissionDefinitionMultiError(errors)
 	}
@@ -221,6 +221,73 @@ func (m *PermissionCheckRequest) validate(all bool) error {
 		}
 	}
 
+	if len(m.GetSubjects()) > 20 {
+		err := PermissionCheckRequestValidationError{
+			field:  "Subjects",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetSubjects() {
+		_, _ = idx, item
+
+		if item == nil {
+			err := PermissionCheckRequestValidationError{
+				field:  fmt.Sprintf("Subjects[%v]", idx),
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PermissionCheckRequestValidationError{
+						field:  fmt.Sprintf("Subjects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PermissionCheckRequestValidationError{
+						field:  fmt.Sprintf("Subjects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PermissionCheckRequestValidationError{
+					field:  fmt.Sprintf("Subjects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if _, ok := SubjectMatch_name[int32(m.GetSubjectMatch())]; !ok {
+		err := PermissionCheckRequestValidationError{
+			field:  "SubjectMatch",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return PermissionCheckRequestMultiError(errors)
 	}
@@ -344,6 +411,76 @@ func (m *PermissionCheckRequestMetadata) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if _, ok := Consistency_name[int32(m.GetConsistency())]; !ok {
+		err := PermissionCheckRequestMetadataValidationError{
+			field:  "Consistency",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAtTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionCheckRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionCheckRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAtTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionCheckRequestMetadataValidationError{
+				field:  "AtTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetBudget(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = PermissionCheckRequestMetadataValidationError{
+				field:  "Budget",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := PermissionCheckRequestMetadataValidationError{
+					field:  "Budget",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
 	if len(errors) > 0 {
 		return PermissionCheckRequestMetadataMultiError(errors)
 	}
@@ -582,6 +719,8 @@ func (m *PermissionCheckResponseMetadata) validate(all bool) error {
 
 	// no validation rules for CheckCount
 
+	// no validation rules for Reason
+
 	if len(errors) > 0 {
 		return PermissionCheckResponseMetadataMultiError(errors)
 	}
@@ -813,6 +952,10 @@ func (m *PermissionExpandRequest) validate(all bool) error {
 
 	}
 
+	// no validation rules for MaxDepth
+
+	// no validation rules for Flatten
+
 	if len(errors) > 0 {
 		return PermissionExpandRequestMultiError(errors)
 	}
@@ -923,6 +1066,46 @@ func (m *PermissionExpandRequestMetadata) validate(all bool) error {
 
 	// no validation rules for SnapToken
 
+	if _, ok := Consistency_name[int32(m.GetConsistency())]; !ok {
+		err := PermissionExpandRequestMetadataValidationError{
+			field:  "Consistency",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAtTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionExpandRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionExpandRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAtTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionExpandRequestMetadataValidationError{
+				field:  "AtTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return PermissionExpandRequestMetadataMultiError(errors)
 	}
@@ -1135,6 +1318,142 @@ var _ interface {
 	ErrorName() string
 } = PermissionExpandResponseValidationError{}
 
+// Validate checks the field values on PermissionExpandStreamResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PermissionExpandStreamResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PermissionExpandStreamResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// PermissionExpandStreamResponseMultiError, or nil if none found.
+func (m *PermissionExpandStreamResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PermissionExpandStreamResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for ParentId
+
+	if all {
+		switch v := interface{}(m.GetNode()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionExpandStreamResponseValidationError{
+					field:  "Node",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionExpandStreamResponseValidationError{
+					field:  "Node",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetNode()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionExpandStreamResponseValidationError{
+				field:  "Node",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PermissionExpandStreamResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PermissionExpandStreamResponseMultiError is an error wrapping multiple
+// validation errors returned by PermissionExpandStreamResponse.ValidateAll()
+// if the designated constraints aren't met.
+type PermissionExpandStreamResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PermissionExpandStreamResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PermissionExpandStreamResponseMultiError) AllErrors() []error { return m }
+
+// PermissionExpandStreamResponseValidationError is the validation error
+// returned by PermissionExpandStreamResponse.Validate if the designated
+// constraints aren't met.
+type PermissionExpandStreamResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PermissionExpandStreamResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PermissionExpandStreamResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PermissionExpandStreamResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PermissionExpandStreamResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PermissionExpandStreamResponseValidationError) ErrorName() string {
+	return "PermissionExpandStreamResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PermissionExpandStreamResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPermissionExpandStreamResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PermissionExpandStreamResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PermissionExpandStreamResponseValidationError{}
+
 // Validate checks the field values on PermissionLookupEntityRequest with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -1303,28 +1622,85 @@ func (m *PermissionLookupEntityRequest) validate(all bool) error {
 		}
 	}
 
-	if len(errors) > 0 {
-		return PermissionLookupEntityRequestMultiError(errors)
-	}
-
-	return nil
-}
+	if m.GetPageSize() != 0 {
 
-// PermissionLookupEntityRequestMultiError is an error wrapping multiple
-// validation errors returned by PermissionLookupEntityRequest.ValidateAll()
-// if the designated constraints aren't met.
-type PermissionLookupEntityRequestMultiError []error
+		if val := m.GetPageSize(); val < 1 || val > 100 {
+			err := PermissionLookupEntityRequestValidationError{
+				field:  "PageSize",
+				reason: "value must be inside range [1, 100]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
 
-// Error returns a concatenation of all the error messages it wraps.
-func (m PermissionLookupEntityRequestMultiError) Error() string {
-	var msgs []string
-	for _, err := range m {
-		msgs = append(msgs, err.Error())
 	}
-	return strings.Join(msgs, "; ")
-}
 
-// AllErrors returns a list of validation violation errors.
+	if m.GetContinuousToken() != "" {
+
+	}
+
+	if len(m.GetEntityIds()) > 100 {
+		err := PermissionLookupEntityRequestValidationError{
+			field:  "EntityIds",
+			reason: "value must contain no more than 100 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetEntityIds() {
+		_, _ = idx, item
+
+		if len(item) > 128 {
+			err := PermissionLookupEntityRequestValidationError{
+				field:  fmt.Sprintf("EntityIds[%v]", idx),
+				reason: "value length must be at most 128 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_PermissionLookupEntityRequest_EntityIds_Pattern.MatchString(item) {
+			err := PermissionLookupEntityRequestValidationError{
+				field:  fmt.Sprintf("EntityIds[%v]", idx),
+				reason: "value does not match regex pattern \"^([a-zA-Z0-9_][a-zA-Z0-9_|-]{0,127})$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PermissionLookupEntityRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PermissionLookupEntityRequestMultiError is an error wrapping multiple
+// validation errors returned by PermissionLookupEntityRequest.ValidateAll()
+// if the designated constraints aren't met.
+type PermissionLookupEntityRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PermissionLookupEntityRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
 func (m PermissionLookupEntityRequestMultiError) AllErrors() []error { return m }
 
 // PermissionLookupEntityRequestValidationError is the validation error
@@ -1390,6 +1766,8 @@ var _PermissionLookupEntityRequest_EntityType_Pattern = regexp.MustCompile("^([a
 
 var _PermissionLookupEntityRequest_Permission_Pattern = regexp.MustCompile("^([a-zA-Z][a-zA-Z0-9_]{1,62}[a-zA-Z0-9])$")
 
+var _PermissionLookupEntityRequest_EntityIds_Pattern = regexp.MustCompile("^([a-zA-Z0-9_][a-zA-Z0-9_|-]{0,127})$")
+
 // Validate checks the field values on PermissionLookupEntityRequestMetadata
 // with the rules defined in the proto definition for this message. If any
 // rules are violated, the first error encountered is returned, or nil if
@@ -1428,6 +1806,76 @@ func (m *PermissionLookupEntityRequestMetadata) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if _, ok := Consistency_name[int32(m.GetConsistency())]; !ok {
+		err := PermissionLookupEntityRequestMetadataValidationError{
+			field:  "Consistency",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAtTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionLookupEntityRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionLookupEntityRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAtTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionLookupEntityRequestMetadataValidationError{
+				field:  "AtTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetBudget(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = PermissionLookupEntityRequestMetadataValidationError{
+				field:  "Budget",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := PermissionLookupEntityRequestMetadataValidationError{
+					field:  "Budget",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
 	if len(errors) > 0 {
 		return PermissionLookupEntityRequestMetadataMultiError(errors)
 	}
@@ -1532,6 +1980,10 @@ func (m *PermissionLookupEntityResponse) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for ContinuousToken
+
+	// no validation rules for Truncated
+
 	if len(errors) > 0 {
 		return PermissionLookupEntityResponseMultiError(errors)
 	}
@@ -1638,6 +2090,8 @@ func (m *PermissionLookupEntityStreamResponse) validate(all bool) error {
 
 	// no validation rules for EntityId
 
+	// no validation rules for Truncated
+
 	if len(errors) > 0 {
 		return PermissionLookupEntityStreamResponseMultiError(errors)
 	}
@@ -1720,22 +2174,22 @@ var _ interface {
 	ErrorName() string
 } = PermissionLookupEntityStreamResponseValidationError{}
 
-// Validate checks the field values on PermissionLinkedEntityRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// Validate checks the field values on PermissionMatrixRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *PermissionLinkedEntityRequest) Validate() error {
+func (m *PermissionMatrixRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on PermissionLinkedEntityRequest with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// PermissionLinkedEntityRequestMultiError, or nil if none found.
-func (m *PermissionLinkedEntityRequest) ValidateAll() error {
+// ValidateAll checks the field values on PermissionMatrixRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PermissionMatrixRequestMultiError, or nil if none found.
+func (m *PermissionMatrixRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *PermissionLinkedEntityRequest) validate(all bool) error {
+func (m *PermissionMatrixRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1743,7 +2197,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 	var errors []error
 
 	if len(m.GetTenantId()) > 64 {
-		err := PermissionLinkedEntityRequestValidationError{
+		err := PermissionMatrixRequestValidationError{
 			field:  "TenantId",
 			reason: "value length must be at most 64 bytes",
 		}
@@ -1753,8 +2207,8 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_PermissionLinkedEntityRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := PermissionLinkedEntityRequestValidationError{
+	if !_PermissionMatrixRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := PermissionMatrixRequestValidationError{
 			field:  "TenantId",
 			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
 		}
@@ -1765,7 +2219,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 	}
 
 	if m.GetMetadata() == nil {
-		err := PermissionLinkedEntityRequestValidationError{
+		err := PermissionMatrixRequestValidationError{
 			field:  "Metadata",
 			reason: "value is required",
 		}
@@ -1779,7 +2233,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 		switch v := interface{}(m.GetMetadata()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+				errors = append(errors, PermissionMatrixRequestValidationError{
 					field:  "Metadata",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1787,7 +2241,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+				errors = append(errors, PermissionMatrixRequestValidationError{
 					field:  "Metadata",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1796,7 +2250,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return PermissionLinkedEntityRequestValidationError{
+			return PermissionMatrixRequestValidationError{
 				field:  "Metadata",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1804,40 +2258,120 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 		}
 	}
 
-	if all {
-		switch v := interface{}(m.GetEntityReference()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
-					field:  "EntityReference",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	if len(m.GetEntityType()) > 64 {
+		err := PermissionMatrixRequestValidationError{
+			field:  "EntityType",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_PermissionMatrixRequest_EntityType_Pattern.MatchString(m.GetEntityType()) {
+		err := PermissionMatrixRequestValidationError{
+			field:  "EntityType",
+			reason: "value does not match regex pattern \"^([a-z][a-z0-9_]{1,62}[a-z0-9])$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetEntityIds()) < 1 {
+		err := PermissionMatrixRequestValidationError{
+			field:  "EntityIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetEntityIds() {
+		_, _ = idx, item
+
+		if len(item) > 128 {
+			err := PermissionMatrixRequestValidationError{
+				field:  fmt.Sprintf("EntityIds[%v]", idx),
+				reason: "value length must be at most 128 bytes",
 			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
-					field:  "EntityReference",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+			if !all {
+				return err
 			}
+			errors = append(errors, err)
 		}
-	} else if v, ok := interface{}(m.GetEntityReference()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return PermissionLinkedEntityRequestValidationError{
-				field:  "EntityReference",
-				reason: "embedded message failed validation",
-				cause:  err,
+
+		if !_PermissionMatrixRequest_EntityIds_Pattern.MatchString(item) {
+			err := PermissionMatrixRequestValidationError{
+				field:  fmt.Sprintf("EntityIds[%v]", idx),
+				reason: "value does not match regex pattern \"^([a-zA-Z0-9_][a-zA-Z0-9_|-]{0,127})$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetPermissions()) < 1 {
+		err := PermissionMatrixRequestValidationError{
+			field:  "Permissions",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetPermissions() {
+		_, _ = idx, item
+
+		if len(item) > 64 {
+			err := PermissionMatrixRequestValidationError{
+				field:  fmt.Sprintf("Permissions[%v]", idx),
+				reason: "value length must be at most 64 bytes",
 			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_PermissionMatrixRequest_Permissions_Pattern.MatchString(item) {
+			err := PermissionMatrixRequestValidationError{
+				field:  fmt.Sprintf("Permissions[%v]", idx),
+				reason: "value does not match regex pattern \"^([a-zA-Z][a-zA-Z0-9_]{1,62}[a-zA-Z0-9])$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetSubject() == nil {
+		err := PermissionMatrixRequestValidationError{
+			field:  "Subject",
+			reason: "value is required",
+		}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
 	if all {
 		switch v := interface{}(m.GetSubject()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+				errors = append(errors, PermissionMatrixRequestValidationError{
 					field:  "Subject",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1845,7 +2379,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+				errors = append(errors, PermissionMatrixRequestValidationError{
 					field:  "Subject",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1854,7 +2388,7 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return PermissionLinkedEntityRequestValidationError{
+			return PermissionMatrixRequestValidationError{
 				field:  "Subject",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1863,19 +2397,19 @@ func (m *PermissionLinkedEntityRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return PermissionLinkedEntityRequestMultiError(errors)
+		return PermissionMatrixRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// PermissionLinkedEntityRequestMultiError is an error wrapping multiple
-// validation errors returned by PermissionLinkedEntityRequest.ValidateAll()
-// if the designated constraints aren't met.
-type PermissionLinkedEntityRequestMultiError []error
+// PermissionMatrixRequestMultiError is an error wrapping multiple validation
+// errors returned by PermissionMatrixRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PermissionMatrixRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m PermissionLinkedEntityRequestMultiError) Error() string {
+func (m PermissionMatrixRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1884,12 +2418,11 @@ func (m PermissionLinkedEntityRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m PermissionLinkedEntityRequestMultiError) AllErrors() []error { return m }
+func (m PermissionMatrixRequestMultiError) AllErrors() []error { return m }
 
-// PermissionLinkedEntityRequestValidationError is the validation error
-// returned by PermissionLinkedEntityRequest.Validate if the designated
-// constraints aren't met.
-type PermissionLinkedEntityRequestValidationError struct {
+// PermissionMatrixRequestValidationError is the validation error returned by
+// PermissionMatrixRequest.Validate if the designated constraints aren't met.
+type PermissionMatrixRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1897,24 +2430,24 @@ type PermissionLinkedEntityRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e PermissionLinkedEntityRequestValidationError) Field() string { return e.field }
+func (e PermissionMatrixRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e PermissionLinkedEntityRequestValidationError) Reason() string { return e.reason }
+func (e PermissionMatrixRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e PermissionLinkedEntityRequestValidationError) Cause() error { return e.cause }
+func (e PermissionMatrixRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e PermissionLinkedEntityRequestValidationError) Key() bool { return e.key }
+func (e PermissionMatrixRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e PermissionLinkedEntityRequestValidationError) ErrorName() string {
-	return "PermissionLinkedEntityRequestValidationError"
+func (e PermissionMatrixRequestValidationError) ErrorName() string {
+	return "PermissionMatrixRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e PermissionLinkedEntityRequestValidationError) Error() string {
+func (e PermissionMatrixRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1926,14 +2459,14 @@ func (e PermissionLinkedEntityRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sPermissionLinkedEntityRequest.%s: %s%s",
+		"invalid %sPermissionMatrixRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = PermissionLinkedEntityRequestValidationError{}
+var _ error = PermissionMatrixRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1941,27 +2474,32 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = PermissionLinkedEntityRequestValidationError{}
+} = PermissionMatrixRequestValidationError{}
 
-var _PermissionLinkedEntityRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+var _PermissionMatrixRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
 
-// Validate checks the field values on PermissionLinkedEntityRequestMetadata
-// with the rules defined in the proto definition for this message. If any
-// rules are violated, the first error encountered is returned, or nil if
-// there are no violations.
-func (m *PermissionLinkedEntityRequestMetadata) Validate() error {
+var _PermissionMatrixRequest_EntityType_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,62}[a-z0-9])$")
+
+var _PermissionMatrixRequest_EntityIds_Pattern = regexp.MustCompile("^([a-zA-Z0-9_][a-zA-Z0-9_|-]{0,127})$")
+
+var _PermissionMatrixRequest_Permissions_Pattern = regexp.MustCompile("^([a-zA-Z][a-zA-Z0-9_]{1,62}[a-zA-Z0-9])$")
+
+// Validate checks the field values on PermissionMatrixRequestMetadata with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PermissionMatrixRequestMetadata) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on PermissionLinkedEntityRequestMetadata
-// with the rules defined in the proto definition for this message. If any
-// rules are violated, the result is a list of violation errors wrapped in
-// PermissionLinkedEntityRequestMetadataMultiError, or nil if none found.
-func (m *PermissionLinkedEntityRequestMetadata) ValidateAll() error {
+// ValidateAll checks the field values on PermissionMatrixRequestMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// PermissionMatrixRequestMetadataMultiError, or nil if none found.
+func (m *PermissionMatrixRequestMetadata) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *PermissionLinkedEntityRequestMetadata) validate(all bool) error {
+func (m *PermissionMatrixRequestMetadata) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1973,7 +2511,7 @@ func (m *PermissionLinkedEntityRequestMetadata) validate(all bool) error {
 	// no validation rules for SnapToken
 
 	if m.GetDepth() < 3 {
-		err := PermissionLinkedEntityRequestMetadataValidationError{
+		err := PermissionMatrixRequestMetadataValidationError{
 			field:  "Depth",
 			reason: "value must be greater than or equal to 3",
 		}
@@ -1983,21 +2521,60 @@ func (m *PermissionLinkedEntityRequestMetadata) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if _, ok := Consistency_name[int32(m.GetConsistency())]; !ok {
+		err := PermissionMatrixRequestMetadataValidationError{
+			field:  "Consistency",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAtTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionMatrixRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionMatrixRequestMetadataValidationError{
+					field:  "AtTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAtTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionMatrixRequestMetadataValidationError{
+				field:  "AtTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
-		return PermissionLinkedEntityRequestMetadataMultiError(errors)
+		return PermissionMatrixRequestMetadataMultiError(errors)
 	}
 
 	return nil
 }
 
-// PermissionLinkedEntityRequestMetadataMultiError is an error wrapping
-// multiple validation errors returned by
-// PermissionLinkedEntityRequestMetadata.ValidateAll() if the designated
-// constraints aren't met.
-type PermissionLinkedEntityRequestMetadataMultiError []error
+// PermissionMatrixRequestMetadataMultiError is an error wrapping multiple
+// validation errors returned by PermissionMatrixRequestMetadata.ValidateAll()
+// if the designated constraints aren't met.
+type PermissionMatrixRequestMetadataMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m PermissionLinkedEntityRequestMetadataMultiError) Error() string {
+func (m PermissionMatrixRequestMetadataMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2006,12 +2583,12 @@ func (m PermissionLinkedEntityRequestMetadataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m PermissionLinkedEntityRequestMetadataMultiError) AllErrors() []error { return m }
+func (m PermissionMatrixRequestMetadataMultiError) AllErrors() []error { return m }
 
-// PermissionLinkedEntityRequestMetadataValidationError is the validation error
-// returned by PermissionLinkedEntityRequestMetadata.Validate if the
-// designated constraints aren't met.
-type PermissionLinkedEntityRequestMetadataValidationError struct {
+// PermissionMatrixRequestMetadataValidationError is the validation error
+// returned by PermissionMatrixRequestMetadata.Validate if the designated
+// constraints aren't met.
+type PermissionMatrixRequestMetadataValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2019,24 +2596,24 @@ type PermissionLinkedEntityRequestMetadataValidationError struct {
 }
 
 // Field function returns field value.
-func (e PermissionLinkedEntityRequestMetadataValidationError) Field() string { return e.field }
+func (e PermissionMatrixRequestMetadataValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e PermissionLinkedEntityRequestMetadataValidationError) Reason() string { return e.reason }
+func (e PermissionMatrixRequestMetadataValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e PermissionLinkedEntityRequestMetadataValidationError) Cause() error { return e.cause }
+func (e PermissionMatrixRequestMetadataValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e PermissionLinkedEntityRequestMetadataValidationError) Key() bool { return e.key }
+func (e PermissionMatrixRequestMetadataValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e PermissionLinkedEntityRequestMetadataValidationError) ErrorName() string {
-	return "PermissionLinkedEntityRequestMetadataValidationError"
+func (e PermissionMatrixRequestMetadataValidationError) ErrorName() string {
+	return "PermissionMatrixRequestMetadataValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e PermissionLinkedEntityRequestMetadataValidationError) Error() string {
+func (e PermissionMatrixRequestMetadataValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2048,14 +2625,14 @@ func (e PermissionLinkedEntityRequestMetadataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sPermissionLinkedEntityRequestMetadata.%s: %s%s",
+		"invalid %sPermissionMatrixRequestMetadata.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = PermissionLinkedEntityRequestMetadataValidationError{}
+var _ error = PermissionMatrixRequestMetadataValidationError{}
 
 var _ interface {
 	Field() string
@@ -2063,68 +2640,107 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = PermissionLinkedEntityRequestMetadataValidationError{}
+} = PermissionMatrixRequestMetadataValidationError{}
 
-// Validate checks the field values on SchemaWriteRequest with the rules
+// Validate checks the field values on PermissionMatrixResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SchemaWriteRequest) Validate() error {
+func (m *PermissionMatrixResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SchemaWriteRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on PermissionMatrixResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SchemaWriteRequestMultiError, or nil if none found.
-func (m *SchemaWriteRequest) ValidateAll() error {
+// PermissionMatrixResponseMultiError, or nil if none found.
+func (m *PermissionMatrixResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SchemaWriteRequest) validate(all bool) error {
+func (m *PermissionMatrixResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTenantId()) > 64 {
-		err := SchemaWriteRequestValidationError{
-			field:  "TenantId",
-			reason: "value length must be at most 64 bytes",
-		}
-		if !all {
-			return err
+	for idx, item := range m.GetRows() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PermissionMatrixResponseValidationError{
+						field:  fmt.Sprintf("Rows[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PermissionMatrixResponseValidationError{
+						field:  fmt.Sprintf("Rows[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PermissionMatrixResponseValidationError{
+					field:  fmt.Sprintf("Rows[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
 		}
-		errors = append(errors, err)
+
 	}
 
-	if !_SchemaWriteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := SchemaWriteRequestValidationError{
-			field:  "TenantId",
-			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionMatrixResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionMatrixResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
 		}
-		if !all {
-			return err
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionMatrixResponseValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
 		}
-		errors = append(errors, err)
 	}
 
-	// no validation rules for Schema
-
 	if len(errors) > 0 {
-		return SchemaWriteRequestMultiError(errors)
+		return PermissionMatrixResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// SchemaWriteRequestMultiError is an error wrapping multiple validation errors
-// returned by SchemaWriteRequest.ValidateAll() if the designated constraints
-// aren't met.
-type SchemaWriteRequestMultiError []error
+// PermissionMatrixResponseMultiError is an error wrapping multiple validation
+// errors returned by PermissionMatrixResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PermissionMatrixResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SchemaWriteRequestMultiError) Error() string {
+func (m PermissionMatrixResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2133,11 +2749,11 @@ func (m SchemaWriteRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SchemaWriteRequestMultiError) AllErrors() []error { return m }
+func (m PermissionMatrixResponseMultiError) AllErrors() []error { return m }
 
-// SchemaWriteRequestValidationError is the validation error returned by
-// SchemaWriteRequest.Validate if the designated constraints aren't met.
-type SchemaWriteRequestValidationError struct {
+// PermissionMatrixResponseValidationError is the validation error returned by
+// PermissionMatrixResponse.Validate if the designated constraints aren't met.
+type PermissionMatrixResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2145,24 +2761,24 @@ type SchemaWriteRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e SchemaWriteRequestValidationError) Field() string { return e.field }
+func (e PermissionMatrixResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SchemaWriteRequestValidationError) Reason() string { return e.reason }
+func (e PermissionMatrixResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SchemaWriteRequestValidationError) Cause() error { return e.cause }
+func (e PermissionMatrixResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SchemaWriteRequestValidationError) Key() bool { return e.key }
+func (e PermissionMatrixResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SchemaWriteRequestValidationError) ErrorName() string {
-	return "SchemaWriteRequestValidationError"
+func (e PermissionMatrixResponseValidationError) ErrorName() string {
+	return "PermissionMatrixResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SchemaWriteRequestValidationError) Error() string {
+func (e PermissionMatrixResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2174,14 +2790,14 @@ func (e SchemaWriteRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSchemaWriteRequest.%s: %s%s",
+		"invalid %sPermissionMatrixResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SchemaWriteRequestValidationError{}
+var _ error = PermissionMatrixResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2189,48 +2805,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SchemaWriteRequestValidationError{}
+} = PermissionMatrixResponseValidationError{}
 
-var _SchemaWriteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
-
-// Validate checks the field values on SchemaWriteResponse with the rules
+// Validate checks the field values on PermissionMatrixRow with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SchemaWriteResponse) Validate() error {
+func (m *PermissionMatrixRow) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SchemaWriteResponse with the rules
+// ValidateAll checks the field values on PermissionMatrixRow with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SchemaWriteResponseMultiError, or nil if none found.
-func (m *SchemaWriteResponse) ValidateAll() error {
+// PermissionMatrixRowMultiError, or nil if none found.
+func (m *PermissionMatrixRow) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SchemaWriteResponse) validate(all bool) error {
+func (m *PermissionMatrixRow) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SchemaVersion
+	// no validation rules for EntityId
 
 	if len(errors) > 0 {
-		return SchemaWriteResponseMultiError(errors)
+		return PermissionMatrixRowMultiError(errors)
 	}
 
 	return nil
 }
 
-// SchemaWriteResponseMultiError is an error wrapping multiple validation
-// errors returned by SchemaWriteResponse.ValidateAll() if the designated
+// PermissionMatrixRowMultiError is an error wrapping multiple validation
+// errors returned by PermissionMatrixRow.ValidateAll() if the designated
 // constraints aren't met.
-type SchemaWriteResponseMultiError []error
+type PermissionMatrixRowMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SchemaWriteResponseMultiError) Error() string {
+func (m PermissionMatrixRowMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2239,11 +2853,11 @@ func (m SchemaWriteResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SchemaWriteResponseMultiError) AllErrors() []error { return m }
+func (m PermissionMatrixRowMultiError) AllErrors() []error { return m }
 
-// SchemaWriteResponseValidationError is the validation error returned by
-// SchemaWriteResponse.Validate if the designated constraints aren't met.
-type SchemaWriteResponseValidationError struct {
+// PermissionMatrixRowValidationError is the validation error returned by
+// PermissionMatrixRow.Validate if the designated constraints aren't met.
+type PermissionMatrixRowValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2251,24 +2865,24 @@ type SchemaWriteResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e SchemaWriteResponseValidationError) Field() string { return e.field }
+func (e PermissionMatrixRowValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SchemaWriteResponseValidationError) Reason() string { return e.reason }
+func (e PermissionMatrixRowValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SchemaWriteResponseValidationError) Cause() error { return e.cause }
+func (e PermissionMatrixRowValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SchemaWriteResponseValidationError) Key() bool { return e.key }
+func (e PermissionMatrixRowValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SchemaWriteResponseValidationError) ErrorName() string {
-	return "SchemaWriteResponseValidationError"
+func (e PermissionMatrixRowValidationError) ErrorName() string {
+	return "PermissionMatrixRowValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SchemaWriteResponseValidationError) Error() string {
+func (e PermissionMatrixRowValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2280,14 +2894,14 @@ func (e SchemaWriteResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSchemaWriteResponse.%s: %s%s",
+		"invalid %sPermissionMatrixRow.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SchemaWriteResponseValidationError{}
+var _ error = PermissionMatrixRowValidationError{}
 
 var _ interface {
 	Field() string
@@ -2295,106 +2909,2469 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SchemaWriteResponseValidationError{}
+} = PermissionMatrixRowValidationError{}
 
-// Validate checks the field values on SchemaReadRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *SchemaReadRequest) Validate() error {
+// Validate checks the field values on PermissionMatrixResponseMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *PermissionMatrixResponseMetadata) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SchemaReadRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// SchemaReadRequestMultiError, or nil if none found.
-func (m *SchemaReadRequest) ValidateAll() error {
+// ValidateAll checks the field values on PermissionMatrixResponseMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// PermissionMatrixResponseMetadataMultiError, or nil if none found.
+func (m *PermissionMatrixResponseMetadata) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SchemaReadRequest) validate(all bool) error {
+func (m *PermissionMatrixResponseMetadata) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTenantId()) > 64 {
-		err := SchemaReadRequestValidationError{
-			field:  "TenantId",
-			reason: "value length must be at most 64 bytes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if !_SchemaReadRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := SchemaReadRequestValidationError{
-			field:  "TenantId",
-			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if m.GetMetadata() == nil {
-		err := SchemaReadRequestValidationError{
-			field:  "Metadata",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if all {
-		switch v := interface{}(m.GetMetadata()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SchemaReadRequestValidationError{
-					field:  "Metadata",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, SchemaReadRequestValidationError{
-					field:  "Metadata",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return SchemaReadRequestValidationError{
-				field:  "Metadata",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for CheckCount
 
 	if len(errors) > 0 {
-		return SchemaReadRequestMultiError(errors)
+		return PermissionMatrixResponseMetadataMultiError(errors)
 	}
 
 	return nil
 }
 
-// SchemaReadRequestMultiError is an error wrapping multiple validation errors
+// PermissionMatrixResponseMetadataMultiError is an error wrapping multiple
+// validation errors returned by
+// PermissionMatrixResponseMetadata.ValidateAll() if the designated
+// constraints aren't met.
+type PermissionMatrixResponseMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PermissionMatrixResponseMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PermissionMatrixResponseMetadataMultiError) AllErrors() []error { return m }
+
+// PermissionMatrixResponseMetadataValidationError is the validation error
+// returned by PermissionMatrixResponseMetadata.Validate if the designated
+// constraints aren't met.
+type PermissionMatrixResponseMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PermissionMatrixResponseMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PermissionMatrixResponseMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PermissionMatrixResponseMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PermissionMatrixResponseMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PermissionMatrixResponseMetadataValidationError) ErrorName() string {
+	return "PermissionMatrixResponseMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PermissionMatrixResponseMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPermissionMatrixResponseMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PermissionMatrixResponseMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PermissionMatrixResponseMetadataValidationError{}
+
+// Validate checks the field values on PermissionLinkedEntityRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PermissionLinkedEntityRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PermissionLinkedEntityRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// PermissionLinkedEntityRequestMultiError, or nil if none found.
+func (m *PermissionLinkedEntityRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PermissionLinkedEntityRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := PermissionLinkedEntityRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_PermissionLinkedEntityRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := PermissionLinkedEntityRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMetadata() == nil {
+		err := PermissionLinkedEntityRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionLinkedEntityRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEntityReference()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "EntityReference",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "EntityReference",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEntityReference()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionLinkedEntityRequestValidationError{
+				field:  "EntityReference",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSubject()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PermissionLinkedEntityRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PermissionLinkedEntityRequestValidationError{
+				field:  "Subject",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PermissionLinkedEntityRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PermissionLinkedEntityRequestMultiError is an error wrapping multiple
+// validation errors returned by PermissionLinkedEntityRequest.ValidateAll()
+// if the designated constraints aren't met.
+type PermissionLinkedEntityRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PermissionLinkedEntityRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PermissionLinkedEntityRequestMultiError) AllErrors() []error { return m }
+
+// PermissionLinkedEntityRequestValidationError is the validation error
+// returned by PermissionLinkedEntityRequest.Validate if the designated
+// constraints aren't met.
+type PermissionLinkedEntityRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PermissionLinkedEntityRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PermissionLinkedEntityRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PermissionLinkedEntityRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PermissionLinkedEntityRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PermissionLinkedEntityRequestValidationError) ErrorName() string {
+	return "PermissionLinkedEntityRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PermissionLinkedEntityRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPermissionLinkedEntityRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PermissionLinkedEntityRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PermissionLinkedEntityRequestValidationError{}
+
+var _PermissionLinkedEntityRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on PermissionLinkedEntityRequestMetadata
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *PermissionLinkedEntityRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PermissionLinkedEntityRequestMetadata
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// PermissionLinkedEntityRequestMetadataMultiError, or nil if none found.
+func (m *PermissionLinkedEntityRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PermissionLinkedEntityRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	// no validation rules for SnapToken
+
+	if m.GetDepth() < 3 {
+		err := PermissionLinkedEntityRequestMetadataValidationError{
+			field:  "Depth",
+			reason: "value must be greater than or equal to 3",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PermissionLinkedEntityRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// PermissionLinkedEntityRequestMetadataMultiError is an error wrapping
+// multiple validation errors returned by
+// PermissionLinkedEntityRequestMetadata.ValidateAll() if the designated
+// constraints aren't met.
+type PermissionLinkedEntityRequestMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PermissionLinkedEntityRequestMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PermissionLinkedEntityRequestMetadataMultiError) AllErrors() []error { return m }
+
+// PermissionLinkedEntityRequestMetadataValidationError is the validation error
+// returned by PermissionLinkedEntityRequestMetadata.Validate if the
+// designated constraints aren't met.
+type PermissionLinkedEntityRequestMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PermissionLinkedEntityRequestMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PermissionLinkedEntityRequestMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PermissionLinkedEntityRequestMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PermissionLinkedEntityRequestMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PermissionLinkedEntityRequestMetadataValidationError) ErrorName() string {
+	return "PermissionLinkedEntityRequestMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PermissionLinkedEntityRequestMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPermissionLinkedEntityRequestMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PermissionLinkedEntityRequestMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PermissionLinkedEntityRequestMetadataValidationError{}
+
+// Validate checks the field values on SchemaWriteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaWriteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaWriteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaWriteRequestMultiError, or nil if none found.
+func (m *SchemaWriteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaWriteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := SchemaWriteRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_SchemaWriteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := SchemaWriteRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Schema
+
+	if len(errors) > 0 {
+		return SchemaWriteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaWriteRequestMultiError is an error wrapping multiple validation errors
+// returned by SchemaWriteRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SchemaWriteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaWriteRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaWriteRequestMultiError) AllErrors() []error { return m }
+
+// SchemaWriteRequestValidationError is the validation error returned by
+// SchemaWriteRequest.Validate if the designated constraints aren't met.
+type SchemaWriteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaWriteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaWriteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaWriteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaWriteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaWriteRequestValidationError) ErrorName() string {
+	return "SchemaWriteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaWriteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaWriteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaWriteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaWriteRequestValidationError{}
+
+var _SchemaWriteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on SchemaWriteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaWriteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaWriteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaWriteResponseMultiError, or nil if none found.
+func (m *SchemaWriteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaWriteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	if len(errors) > 0 {
+		return SchemaWriteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaWriteResponseMultiError is an error wrapping multiple validation
+// errors returned by SchemaWriteResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SchemaWriteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaWriteResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaWriteResponseMultiError) AllErrors() []error { return m }
+
+// SchemaWriteResponseValidationError is the validation error returned by
+// SchemaWriteResponse.Validate if the designated constraints aren't met.
+type SchemaWriteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaWriteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaWriteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaWriteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaWriteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaWriteResponseValidationError) ErrorName() string {
+	return "SchemaWriteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaWriteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaWriteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaWriteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaWriteResponseValidationError{}
+
+// Validate checks the field values on SchemaReadRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SchemaReadRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaReadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaReadRequestMultiError, or nil if none found.
+func (m *SchemaReadRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaReadRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := SchemaReadRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_SchemaReadRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := SchemaReadRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMetadata() == nil {
+		err := SchemaReadRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SchemaReadRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SchemaReadRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SchemaReadRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SchemaReadRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaReadRequestMultiError is an error wrapping multiple validation errors
 // returned by SchemaReadRequest.ValidateAll() if the designated constraints
 // aren't met.
-type SchemaReadRequestMultiError []error
+type SchemaReadRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaReadRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaReadRequestMultiError) AllErrors() []error { return m }
+
+// SchemaReadRequestValidationError is the validation error returned by
+// SchemaReadRequest.Validate if the designated constraints aren't met.
+type SchemaReadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaReadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaReadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaReadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaReadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaReadRequestValidationError) ErrorName() string {
+	return "SchemaReadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaReadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaReadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaReadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaReadRequestValidationError{}
+
+var _SchemaReadRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on SchemaReadRequestMetadata with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaReadRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaReadRequestMetadata with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaReadRequestMetadataMultiError, or nil if none found.
+func (m *SchemaReadRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaReadRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	if len(errors) > 0 {
+		return SchemaReadRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaReadRequestMetadataMultiError is an error wrapping multiple validation
+// errors returned by SchemaReadRequestMetadata.ValidateAll() if the
+// designated constraints aren't met.
+type SchemaReadRequestMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaReadRequestMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaReadRequestMetadataMultiError) AllErrors() []error { return m }
+
+// SchemaReadRequestMetadataValidationError is the validation error returned by
+// SchemaReadRequestMetadata.Validate if the designated constraints aren't met.
+type SchemaReadRequestMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaReadRequestMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaReadRequestMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaReadRequestMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaReadRequestMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaReadRequestMetadataValidationError) ErrorName() string {
+	return "SchemaReadRequestMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaReadRequestMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaReadRequestMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaReadRequestMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaReadRequestMetadataValidationError{}
+
+// Validate checks the field values on SchemaReadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaReadResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaReadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaReadResponseMultiError, or nil if none found.
+func (m *SchemaReadResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaReadResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSchema()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SchemaReadResponseValidationError{
+					field:  "Schema",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SchemaReadResponseValidationError{
+					field:  "Schema",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSchema()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SchemaReadResponseValidationError{
+				field:  "Schema",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SchemaReadResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaReadResponseMultiError is an error wrapping multiple validation errors
+// returned by SchemaReadResponse.ValidateAll() if the designated constraints
+// aren't met.
+type SchemaReadResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaReadResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaReadResponseMultiError) AllErrors() []error { return m }
+
+// SchemaReadResponseValidationError is the validation error returned by
+// SchemaReadResponse.Validate if the designated constraints aren't met.
+type SchemaReadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaReadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaReadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaReadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaReadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaReadResponseValidationError) ErrorName() string {
+	return "SchemaReadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaReadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaReadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaReadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaReadResponseValidationError{}
+
+// Validate checks the field values on RelationshipWriteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipWriteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipWriteRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipWriteRequestMultiError, or nil if none found.
+func (m *RelationshipWriteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipWriteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := RelationshipWriteRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationshipWriteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := RelationshipWriteRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMetadata() == nil {
+		err := RelationshipWriteRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationshipWriteRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationshipWriteRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationshipWriteRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if l := len(m.GetTuples()); l < 1 || l > 100 {
+		err := RelationshipWriteRequestValidationError{
+			field:  "Tuples",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTuples() {
+		_, _ = idx, item
+
+		if item == nil {
+			err := RelationshipWriteRequestValidationError{
+				field:  fmt.Sprintf("Tuples[%v]", idx),
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RelationshipWriteRequestValidationError{
+						field:  fmt.Sprintf("Tuples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RelationshipWriteRequestValidationError{
+						field:  fmt.Sprintf("Tuples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return RelationshipWriteRequestValidationError{
+					field:  fmt.Sprintf("Tuples[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if _, ok := WriteMode_name[int32(m.GetMode())]; !ok {
+		err := RelationshipWriteRequestValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ClearExpiration
+
+	if len(errors) > 0 {
+		return RelationshipWriteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipWriteRequestMultiError is an error wrapping multiple validation
+// errors returned by RelationshipWriteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RelationshipWriteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipWriteRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipWriteRequestMultiError) AllErrors() []error { return m }
+
+// RelationshipWriteRequestValidationError is the validation error returned by
+// RelationshipWriteRequest.Validate if the designated constraints aren't met.
+type RelationshipWriteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipWriteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipWriteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipWriteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipWriteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipWriteRequestValidationError) ErrorName() string {
+	return "RelationshipWriteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipWriteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipWriteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipWriteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipWriteRequestValidationError{}
+
+var _RelationshipWriteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on RelationshipWriteRequestMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *RelationshipWriteRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipWriteRequestMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RelationshipWriteRequestMetadataMultiError, or nil if none found.
+func (m *RelationshipWriteRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipWriteRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	if len(errors) > 0 {
+		return RelationshipWriteRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipWriteRequestMetadataMultiError is an error wrapping multiple
+// validation errors returned by
+// RelationshipWriteRequestMetadata.ValidateAll() if the designated
+// constraints aren't met.
+type RelationshipWriteRequestMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipWriteRequestMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipWriteRequestMetadataMultiError) AllErrors() []error { return m }
+
+// RelationshipWriteRequestMetadataValidationError is the validation error
+// returned by RelationshipWriteRequestMetadata.Validate if the designated
+// constraints aren't met.
+type RelationshipWriteRequestMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipWriteRequestMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipWriteRequestMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipWriteRequestMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipWriteRequestMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipWriteRequestMetadataValidationError) ErrorName() string {
+	return "RelationshipWriteRequestMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipWriteRequestMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipWriteRequestMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipWriteRequestMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipWriteRequestMetadataValidationError{}
+
+// Validate checks the field values on RelationshipWriteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipWriteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipWriteResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipWriteResponseMultiError, or nil if none found.
+func (m *RelationshipWriteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipWriteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SnapToken
+
+	if len(errors) > 0 {
+		return RelationshipWriteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipWriteResponseMultiError is an error wrapping multiple validation
+// errors returned by RelationshipWriteResponse.ValidateAll() if the
+// designated constraints aren't met.
+type RelationshipWriteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipWriteResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipWriteResponseMultiError) AllErrors() []error { return m }
+
+// RelationshipWriteResponseValidationError is the validation error returned by
+// RelationshipWriteResponse.Validate if the designated constraints aren't met.
+type RelationshipWriteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipWriteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipWriteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipWriteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipWriteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipWriteResponseValidationError) ErrorName() string {
+	return "RelationshipWriteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipWriteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipWriteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipWriteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipWriteResponseValidationError{}
+
+// Validate checks the field values on RelationshipReadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipReadRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipReadRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipReadRequestMultiError, or nil if none found.
+func (m *RelationshipReadRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipReadRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := RelationshipReadRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationshipReadRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := RelationshipReadRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMetadata() == nil {
+		err := RelationshipReadRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationshipReadRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationshipReadRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationshipReadRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationshipReadRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationshipReadRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationshipReadRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetPageSize() != 0 {
+
+		if val := m.GetPageSize(); val < 1 || val > 100 {
+			err := RelationshipReadRequestValidationError{
+				field:  "PageSize",
+				reason: "value must be inside range [1, 100]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetContinuousToken() != "" {
+
+	}
+
+	if len(errors) > 0 {
+		return RelationshipReadRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipReadRequestMultiError is an error wrapping multiple validation
+// errors returned by RelationshipReadRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RelationshipReadRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipReadRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipReadRequestMultiError) AllErrors() []error { return m }
+
+// RelationshipReadRequestValidationError is the validation error returned by
+// RelationshipReadRequest.Validate if the designated constraints aren't met.
+type RelationshipReadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipReadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipReadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipReadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipReadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipReadRequestValidationError) ErrorName() string {
+	return "RelationshipReadRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipReadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipReadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipReadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipReadRequestValidationError{}
+
+var _RelationshipReadRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on RelationshipReadRequestMetadata with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipReadRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipReadRequestMetadata with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RelationshipReadRequestMetadataMultiError, or nil if none found.
+func (m *RelationshipReadRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipReadRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SnapToken
+
+	if len(errors) > 0 {
+		return RelationshipReadRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipReadRequestMetadataMultiError is an error wrapping multiple
+// validation errors returned by RelationshipReadRequestMetadata.ValidateAll()
+// if the designated constraints aren't met.
+type RelationshipReadRequestMetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipReadRequestMetadataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipReadRequestMetadataMultiError) AllErrors() []error { return m }
+
+// RelationshipReadRequestMetadataValidationError is the validation error
+// returned by RelationshipReadRequestMetadata.Validate if the designated
+// constraints aren't met.
+type RelationshipReadRequestMetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipReadRequestMetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipReadRequestMetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipReadRequestMetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipReadRequestMetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipReadRequestMetadataValidationError) ErrorName() string {
+	return "RelationshipReadRequestMetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipReadRequestMetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipReadRequestMetadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipReadRequestMetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipReadRequestMetadataValidationError{}
+
+// Validate checks the field values on RelationshipReadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipReadResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipReadResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipReadResponseMultiError, or nil if none found.
+func (m *RelationshipReadResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipReadResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTuples() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RelationshipReadResponseValidationError{
+						field:  fmt.Sprintf("Tuples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RelationshipReadResponseValidationError{
+						field:  fmt.Sprintf("Tuples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return RelationshipReadResponseValidationError{
+					field:  fmt.Sprintf("Tuples[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for ContinuousToken
+
+	if len(errors) > 0 {
+		return RelationshipReadResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipReadResponseMultiError is an error wrapping multiple validation
+// errors returned by RelationshipReadResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RelationshipReadResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipReadResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipReadResponseMultiError) AllErrors() []error { return m }
+
+// RelationshipReadResponseValidationError is the validation error returned by
+// RelationshipReadResponse.Validate if the designated constraints aren't met.
+type RelationshipReadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipReadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipReadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipReadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipReadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipReadResponseValidationError) ErrorName() string {
+	return "RelationshipReadResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipReadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipReadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipReadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipReadResponseValidationError{}
+
+// Validate checks the field values on RelationshipDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipDeleteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipDeleteRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipDeleteRequestMultiError, or nil if none found.
+func (m *RelationshipDeleteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipDeleteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := RelationshipDeleteRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationshipDeleteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := RelationshipDeleteRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationshipDeleteRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationshipDeleteRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationshipDeleteRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RelationshipDeleteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipDeleteRequestMultiError is an error wrapping multiple validation
+// errors returned by RelationshipDeleteRequest.ValidateAll() if the
+// designated constraints aren't met.
+type RelationshipDeleteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipDeleteRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipDeleteRequestMultiError) AllErrors() []error { return m }
+
+// RelationshipDeleteRequestValidationError is the validation error returned by
+// RelationshipDeleteRequest.Validate if the designated constraints aren't met.
+type RelationshipDeleteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipDeleteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipDeleteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipDeleteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipDeleteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipDeleteRequestValidationError) ErrorName() string {
+	return "RelationshipDeleteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipDeleteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipDeleteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipDeleteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipDeleteRequestValidationError{}
+
+var _RelationshipDeleteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on RelationshipDeleteResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipDeleteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipDeleteResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationshipDeleteResponseMultiError, or nil if none found.
+func (m *RelationshipDeleteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipDeleteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SnapToken
+
+	if len(errors) > 0 {
+		return RelationshipDeleteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipDeleteResponseMultiError is an error wrapping multiple
+// validation errors returned by RelationshipDeleteResponse.ValidateAll() if
+// the designated constraints aren't met.
+type RelationshipDeleteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipDeleteResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipDeleteResponseMultiError) AllErrors() []error { return m }
+
+// RelationshipDeleteResponseValidationError is the validation error returned
+// by RelationshipDeleteResponse.Validate if the designated constraints aren't met.
+type RelationshipDeleteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipDeleteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipDeleteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipDeleteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipDeleteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipDeleteResponseValidationError) ErrorName() string {
+	return "RelationshipDeleteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipDeleteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipDeleteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipDeleteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipDeleteResponseValidationError{}
+
+// Validate checks the field values on RelationshipBulkImportRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RelationshipBulkImportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipBulkImportRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RelationshipBulkImportRequestMultiError, or nil if none found.
+func (m *RelationshipBulkImportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipBulkImportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTenantId()) > 64 {
+		err := RelationshipBulkImportRequestValidationError{
+			field:  "TenantId",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationshipBulkImportRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := RelationshipBulkImportRequestValidationError{
+			field:  "TenantId",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationshipBulkImportRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationshipBulkImportRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationshipBulkImportRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetTuples()) > 1000 {
+		err := RelationshipBulkImportRequestValidationError{
+			field:  "Tuples",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTuples() {
+		_, _ = idx, item
+
+		// skipping validation for tuples
+
+	}
+
+	if len(errors) > 0 {
+		return RelationshipBulkImportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipBulkImportRequestMultiError is an error wrapping multiple
+// validation errors returned by RelationshipBulkImportRequest.ValidateAll()
+// if the designated constraints aren't met.
+type RelationshipBulkImportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationshipBulkImportRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationshipBulkImportRequestMultiError) AllErrors() []error { return m }
+
+// RelationshipBulkImportRequestValidationError is the validation error
+// returned by RelationshipBulkImportRequest.Validate if the designated
+// constraints aren't met.
+type RelationshipBulkImportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationshipBulkImportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationshipBulkImportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationshipBulkImportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationshipBulkImportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationshipBulkImportRequestValidationError) ErrorName() string {
+	return "RelationshipBulkImportRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RelationshipBulkImportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationshipBulkImportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationshipBulkImportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationshipBulkImportRequestValidationError{}
+
+var _RelationshipBulkImportRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on RelationshipBulkImportRequestMetadata
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *RelationshipBulkImportRequestMetadata) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationshipBulkImportRequestMetadata
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// RelationshipBulkImportRequestMetadataMultiError, or nil if none found.
+func (m *RelationshipBulkImportRequestMetadata) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationshipBulkImportRequestMetadata) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaVersion
+
+	if len(errors) > 0 {
+		return RelationshipBulkImportRequestMetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationshipBulkImportRequestMetadataMultiError is an error wrapping
+// multiple validation errors returned by
+// RelationshipBulkImportRequestMetadata.ValidateAll() if the designated
+// constraints aren't met.
+type RelationshipBulkImportRequestMetadataMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SchemaReadRequestMultiError) Error() string {
+func (m RelationshipBulkImportRequestMetadataMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2403,11 +5380,12 @@ func (m SchemaReadRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SchemaReadRequestMultiError) AllErrors() []error { return m }
+func (m RelationshipBulkImportRequestMetadataMultiError) AllErrors() []error { return m }
 
-// SchemaReadRequestValidationError is the validation error returned by
-// SchemaReadRequest.Validate if the designated constraints aren't met.
-type SchemaReadRequestValidationError struct {
+// RelationshipBulkImportRequestMetadataValidationError is the validation error
+// returned by RelationshipBulkImportRequestMetadata.Validate if the
+// designated constraints aren't met.
+type RelationshipBulkImportRequestMetadataValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2415,24 +5393,24 @@ type SchemaReadRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e SchemaReadRequestValidationError) Field() string { return e.field }
+func (e RelationshipBulkImportRequestMetadataValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SchemaReadRequestValidationError) Reason() string { return e.reason }
+func (e RelationshipBulkImportRequestMetadataValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SchemaReadRequestValidationError) Cause() error { return e.cause }
+func (e RelationshipBulkImportRequestMetadataValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SchemaReadRequestValidationError) Key() bool { return e.key }
+func (e RelationshipBulkImportRequestMetadataValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SchemaReadRequestValidationError) ErrorName() string {
-	return "SchemaReadRequestValidationError"
+func (e RelationshipBulkImportRequestMetadataValidationError) ErrorName() string {
+	return "RelationshipBulkImportRequestMetadataValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SchemaReadRequestValidationError) Error() string {
+func (e RelationshipBulkImportRequestMetadataValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2444,14 +5422,14 @@ func (e SchemaReadRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSchemaReadRequest.%s: %s%s",
+		"invalid %sRelationshipBulkImportRequestMetadata.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SchemaReadRequestValidationError{}
+var _ error = RelationshipBulkImportRequestMetadataValidationError{}
 
 var _ interface {
 	Field() string
@@ -2459,48 +5437,86 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SchemaReadRequestValidationError{}
-
-var _SchemaReadRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+} = RelationshipBulkImportRequestMetadataValidationError{}
 
-// Validate checks the field values on SchemaReadRequestMetadata with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RelationshipBulkImportResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SchemaReadRequestMetadata) Validate() error {
+func (m *RelationshipBulkImportResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SchemaReadRequestMetadata with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// SchemaReadRequestMetadataMultiError, or nil if none found.
-func (m *SchemaReadRequestMetadata) ValidateAll() error {
+// ValidateAll checks the field values on RelationshipBulkImportResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RelationshipBulkImportResponseMultiError, or nil if none found.
+func (m *RelationshipBulkImportResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SchemaReadRequestMetadata) validate(all bool) error {
+func (m *RelationshipBulkImportResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SchemaVersion
+	// no validation rules for SnapToken
+
+	// no validation rules for ImportedCount
+
+	// no validation rules for RejectedCount
+
+	for idx, item := range m.GetRejections() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RelationshipBulkImportResponseValidationError{
+						field:  fmt.Sprintf("Rejections[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RelationshipBulkImportResponseValidationError{
+						field:  fmt.Sprintf("Rejections[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return RelationshipBulkImportResponseValidationError{
+					field:  fmt.Sprintf("Rejections[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for ReceivedCount
 
 	if len(errors) > 0 {
-		return SchemaReadRequestMetadataMultiError(errors)
+		return RelationshipBulkImportResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// SchemaReadRequestMetadataMultiError is an error wrapping multiple validation
-// errors returned by SchemaReadRequestMetadata.ValidateAll() if the
-// designated constraints aren't met.
-type SchemaReadRequestMetadataMultiError []error
+// RelationshipBulkImportResponseMultiError is an error wrapping multiple
+// validation errors returned by RelationshipBulkImportResponse.ValidateAll()
+// if the designated constraints aren't met.
+type RelationshipBulkImportResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SchemaReadRequestMetadataMultiError) Error() string {
+func (m RelationshipBulkImportResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2509,11 +5525,12 @@ func (m SchemaReadRequestMetadataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SchemaReadRequestMetadataMultiError) AllErrors() []error { return m }
+func (m RelationshipBulkImportResponseMultiError) AllErrors() []error { return m }
 
-// SchemaReadRequestMetadataValidationError is the validation error returned by
-// SchemaReadRequestMetadata.Validate if the designated constraints aren't met.
-type SchemaReadRequestMetadataValidationError struct {
+// RelationshipBulkImportResponseValidationError is the validation error
+// returned by RelationshipBulkImportResponse.Validate if the designated
+// constraints aren't met.
+type RelationshipBulkImportResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2521,24 +5538,24 @@ type SchemaReadRequestMetadataValidationError struct {
 }
 
 // Field function returns field value.
-func (e SchemaReadRequestMetadataValidationError) Field() string { return e.field }
+func (e RelationshipBulkImportResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SchemaReadRequestMetadataValidationError) Reason() string { return e.reason }
+func (e RelationshipBulkImportResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SchemaReadRequestMetadataValidationError) Cause() error { return e.cause }
+func (e RelationshipBulkImportResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SchemaReadRequestMetadataValidationError) Key() bool { return e.key }
+func (e RelationshipBulkImportResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SchemaReadRequestMetadataValidationError) ErrorName() string {
-	return "SchemaReadRequestMetadataValidationError"
+func (e RelationshipBulkImportResponseValidationError) ErrorName() string {
+	return "RelationshipBulkImportResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SchemaReadRequestMetadataValidationError) Error() string {
+func (e RelationshipBulkImportResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2550,14 +5567,14 @@ func (e SchemaReadRequestMetadataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSchemaReadRequestMetadata.%s: %s%s",
+		"invalid %sRelationshipBulkImportResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SchemaReadRequestMetadataValidationError{}
+var _ error = RelationshipBulkImportResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2565,73 +5582,77 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SchemaReadRequestMetadataValidationError{}
+} = RelationshipBulkImportResponseValidationError{}
 
-// Validate checks the field values on SchemaReadResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RelationshipBulkImportRejection with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SchemaReadResponse) Validate() error {
+func (m *RelationshipBulkImportRejection) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SchemaReadResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// SchemaReadResponseMultiError, or nil if none found.
-func (m *SchemaReadResponse) ValidateAll() error {
+// ValidateAll checks the field values on RelationshipBulkImportRejection with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RelationshipBulkImportRejectionMultiError, or nil if none found.
+func (m *RelationshipBulkImportRejection) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SchemaReadResponse) validate(all bool) error {
+func (m *RelationshipBulkImportRejection) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for Index
+
 	if all {
-		switch v := interface{}(m.GetSchema()).(type) {
+		switch v := interface{}(m.GetTuple()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SchemaReadResponseValidationError{
-					field:  "Schema",
+				errors = append(errors, RelationshipBulkImportRejectionValidationError{
+					field:  "Tuple",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, SchemaReadResponseValidationError{
-					field:  "Schema",
+				errors = append(errors, RelationshipBulkImportRejectionValidationError{
+					field:  "Tuple",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetSchema()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetTuple()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return SchemaReadResponseValidationError{
-				field:  "Schema",
+			return RelationshipBulkImportRejectionValidationError{
+				field:  "Tuple",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for Reason
+
 	if len(errors) > 0 {
-		return SchemaReadResponseMultiError(errors)
+		return RelationshipBulkImportRejectionMultiError(errors)
 	}
 
 	return nil
 }
 
-// SchemaReadResponseMultiError is an error wrapping multiple validation errors
-// returned by SchemaReadResponse.ValidateAll() if the designated constraints
-// aren't met.
-type SchemaReadResponseMultiError []error
+// RelationshipBulkImportRejectionMultiError is an error wrapping multiple
+// validation errors returned by RelationshipBulkImportRejection.ValidateAll()
+// if the designated constraints aren't met.
+type RelationshipBulkImportRejectionMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SchemaReadResponseMultiError) Error() string {
+func (m RelationshipBulkImportRejectionMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2640,11 +5661,12 @@ func (m SchemaReadResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SchemaReadResponseMultiError) AllErrors() []error { return m }
+func (m RelationshipBulkImportRejectionMultiError) AllErrors() []error { return m }
 
-// SchemaReadResponseValidationError is the validation error returned by
-// SchemaReadResponse.Validate if the designated constraints aren't met.
-type SchemaReadResponseValidationError struct {
+// RelationshipBulkImportRejectionValidationError is the validation error
+// returned by RelationshipBulkImportRejection.Validate if the designated
+// constraints aren't met.
+type RelationshipBulkImportRejectionValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2652,24 +5674,24 @@ type SchemaReadResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e SchemaReadResponseValidationError) Field() string { return e.field }
+func (e RelationshipBulkImportRejectionValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SchemaReadResponseValidationError) Reason() string { return e.reason }
+func (e RelationshipBulkImportRejectionValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SchemaReadResponseValidationError) Cause() error { return e.cause }
+func (e RelationshipBulkImportRejectionValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SchemaReadResponseValidationError) Key() bool { return e.key }
+func (e RelationshipBulkImportRejectionValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SchemaReadResponseValidationError) ErrorName() string {
-	return "SchemaReadResponseValidationError"
+func (e RelationshipBulkImportRejectionValidationError) ErrorName() string {
+	return "RelationshipBulkImportRejectionValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SchemaReadResponseValidationError) Error() string {
+func (e RelationshipBulkImportRejectionValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2681,14 +5703,14 @@ func (e SchemaReadResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSchemaReadResponse.%s: %s%s",
+		"invalid %sRelationshipBulkImportRejection.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SchemaReadResponseValidationError{}
+var _ error = RelationshipBulkImportRejectionValidationError{}
 
 var _ interface {
 	Field() string
@@ -2696,24 +5718,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SchemaReadResponseValidationError{}
+} = RelationshipBulkImportRejectionValidationError{}
 
-// Validate checks the field values on RelationshipWriteRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RelationshipTransactRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipWriteRequest) Validate() error {
+func (m *RelationshipTransactRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipWriteRequest with the
+// ValidateAll checks the field values on RelationshipTransactRequest with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RelationshipWriteRequestMultiError, or nil if none found.
-func (m *RelationshipWriteRequest) ValidateAll() error {
+// RelationshipTransactRequestMultiError, or nil if none found.
+func (m *RelationshipTransactRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipWriteRequest) validate(all bool) error {
+func (m *RelationshipTransactRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -2721,7 +5743,7 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 	var errors []error
 
 	if len(m.GetTenantId()) > 64 {
-		err := RelationshipWriteRequestValidationError{
+		err := RelationshipTransactRequestValidationError{
 			field:  "TenantId",
 			reason: "value length must be at most 64 bytes",
 		}
@@ -2731,8 +5753,8 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_RelationshipWriteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := RelationshipWriteRequestValidationError{
+	if !_RelationshipTransactRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := RelationshipTransactRequestValidationError{
 			field:  "TenantId",
 			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
 		}
@@ -2743,7 +5765,7 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 	}
 
 	if m.GetMetadata() == nil {
-		err := RelationshipWriteRequestValidationError{
+		err := RelationshipTransactRequestValidationError{
 			field:  "Metadata",
 			reason: "value is required",
 		}
@@ -2757,7 +5779,7 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 		switch v := interface{}(m.GetMetadata()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, RelationshipWriteRequestValidationError{
+				errors = append(errors, RelationshipTransactRequestValidationError{
 					field:  "Metadata",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -2765,7 +5787,7 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, RelationshipWriteRequestValidationError{
+				errors = append(errors, RelationshipTransactRequestValidationError{
 					field:  "Metadata",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -2774,7 +5796,7 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return RelationshipWriteRequestValidationError{
+			return RelationshipTransactRequestValidationError{
 				field:  "Metadata",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -2782,9 +5804,65 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 		}
 	}
 
-	if l := len(m.GetTuples()); l < 1 || l > 100 {
-		err := RelationshipWriteRequestValidationError{
-			field:  "Tuples",
+	if len(m.GetPreconditions()) > 100 {
+		err := RelationshipTransactRequestValidationError{
+			field:  "Preconditions",
+			reason: "value must contain no more than 100 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetPreconditions() {
+		_, _ = idx, item
+
+		if item == nil {
+			err := RelationshipTransactRequestValidationError{
+				field:  fmt.Sprintf("Preconditions[%v]", idx),
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RelationshipTransactRequestValidationError{
+						field:  fmt.Sprintf("Preconditions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RelationshipTransactRequestValidationError{
+						field:  fmt.Sprintf("Preconditions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return RelationshipTransactRequestValidationError{
+					field:  fmt.Sprintf("Preconditions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if l := len(m.GetOperations()); l < 1 || l > 100 {
+		err := RelationshipTransactRequestValidationError{
+			field:  "Operations",
 			reason: "value must contain between 1 and 100 items, inclusive",
 		}
 		if !all {
@@ -2793,12 +5871,12 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	for idx, item := range m.GetTuples() {
+	for idx, item := range m.GetOperations() {
 		_, _ = idx, item
 
 		if item == nil {
-			err := RelationshipWriteRequestValidationError{
-				field:  fmt.Sprintf("Tuples[%v]", idx),
+			err := RelationshipTransactRequestValidationError{
+				field:  fmt.Sprintf("Operations[%v]", idx),
 				reason: "value is required",
 			}
 			if !all {
@@ -2811,16 +5889,16 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 			switch v := interface{}(item).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, RelationshipWriteRequestValidationError{
-						field:  fmt.Sprintf("Tuples[%v]", idx),
+					errors = append(errors, RelationshipTransactRequestValidationError{
+						field:  fmt.Sprintf("Operations[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, RelationshipWriteRequestValidationError{
-						field:  fmt.Sprintf("Tuples[%v]", idx),
+					errors = append(errors, RelationshipTransactRequestValidationError{
+						field:  fmt.Sprintf("Operations[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2828,8 +5906,8 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 			}
 		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return RelationshipWriteRequestValidationError{
-					field:  fmt.Sprintf("Tuples[%v]", idx),
+				return RelationshipTransactRequestValidationError{
+					field:  fmt.Sprintf("Operations[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -2839,19 +5917,19 @@ func (m *RelationshipWriteRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return RelationshipWriteRequestMultiError(errors)
+		return RelationshipTransactRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipWriteRequestMultiError is an error wrapping multiple validation
-// errors returned by RelationshipWriteRequest.ValidateAll() if the designated
-// constraints aren't met.
-type RelationshipWriteRequestMultiError []error
+// RelationshipTransactRequestMultiError is an error wrapping multiple
+// validation errors returned by RelationshipTransactRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RelationshipTransactRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipWriteRequestMultiError) Error() string {
+func (m RelationshipTransactRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2860,11 +5938,12 @@ func (m RelationshipWriteRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipWriteRequestMultiError) AllErrors() []error { return m }
+func (m RelationshipTransactRequestMultiError) AllErrors() []error { return m }
 
-// RelationshipWriteRequestValidationError is the validation error returned by
-// RelationshipWriteRequest.Validate if the designated constraints aren't met.
-type RelationshipWriteRequestValidationError struct {
+// RelationshipTransactRequestValidationError is the validation error returned
+// by RelationshipTransactRequest.Validate if the designated constraints
+// aren't met.
+type RelationshipTransactRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2872,24 +5951,24 @@ type RelationshipWriteRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipWriteRequestValidationError) Field() string { return e.field }
+func (e RelationshipTransactRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipWriteRequestValidationError) Reason() string { return e.reason }
+func (e RelationshipTransactRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipWriteRequestValidationError) Cause() error { return e.cause }
+func (e RelationshipTransactRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipWriteRequestValidationError) Key() bool { return e.key }
+func (e RelationshipTransactRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipWriteRequestValidationError) ErrorName() string {
-	return "RelationshipWriteRequestValidationError"
+func (e RelationshipTransactRequestValidationError) ErrorName() string {
+	return "RelationshipTransactRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipWriteRequestValidationError) Error() string {
+func (e RelationshipTransactRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2901,14 +5980,14 @@ func (e RelationshipWriteRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipWriteRequest.%s: %s%s",
+		"invalid %sRelationshipTransactRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipWriteRequestValidationError{}
+var _ error = RelationshipTransactRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2916,27 +5995,27 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipWriteRequestValidationError{}
+} = RelationshipTransactRequestValidationError{}
 
-var _RelationshipWriteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+var _RelationshipTransactRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
 
-// Validate checks the field values on RelationshipWriteRequestMetadata with
+// Validate checks the field values on RelationshipTransactRequestMetadata with
 // the rules defined in the proto definition for this message. If any rules
 // are violated, the first error encountered is returned, or nil if there are
 // no violations.
-func (m *RelationshipWriteRequestMetadata) Validate() error {
+func (m *RelationshipTransactRequestMetadata) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipWriteRequestMetadata with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// RelationshipWriteRequestMetadataMultiError, or nil if none found.
-func (m *RelationshipWriteRequestMetadata) ValidateAll() error {
+// ValidateAll checks the field values on RelationshipTransactRequestMetadata
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// RelationshipTransactRequestMetadataMultiError, or nil if none found.
+func (m *RelationshipTransactRequestMetadata) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipWriteRequestMetadata) validate(all bool) error {
+func (m *RelationshipTransactRequestMetadata) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -2946,20 +6025,20 @@ func (m *RelationshipWriteRequestMetadata) validate(all bool) error {
 	// no validation rules for SchemaVersion
 
 	if len(errors) > 0 {
-		return RelationshipWriteRequestMetadataMultiError(errors)
+		return RelationshipTransactRequestMetadataMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipWriteRequestMetadataMultiError is an error wrapping multiple
+// RelationshipTransactRequestMetadataMultiError is an error wrapping multiple
 // validation errors returned by
-// RelationshipWriteRequestMetadata.ValidateAll() if the designated
+// RelationshipTransactRequestMetadata.ValidateAll() if the designated
 // constraints aren't met.
-type RelationshipWriteRequestMetadataMultiError []error
+type RelationshipTransactRequestMetadataMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipWriteRequestMetadataMultiError) Error() string {
+func (m RelationshipTransactRequestMetadataMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2968,12 +6047,12 @@ func (m RelationshipWriteRequestMetadataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipWriteRequestMetadataMultiError) AllErrors() []error { return m }
+func (m RelationshipTransactRequestMetadataMultiError) AllErrors() []error { return m }
 
-// RelationshipWriteRequestMetadataValidationError is the validation error
-// returned by RelationshipWriteRequestMetadata.Validate if the designated
+// RelationshipTransactRequestMetadataValidationError is the validation error
+// returned by RelationshipTransactRequestMetadata.Validate if the designated
 // constraints aren't met.
-type RelationshipWriteRequestMetadataValidationError struct {
+type RelationshipTransactRequestMetadataValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2981,24 +6060,24 @@ type RelationshipWriteRequestMetadataValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipWriteRequestMetadataValidationError) Field() string { return e.field }
+func (e RelationshipTransactRequestMetadataValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipWriteRequestMetadataValidationError) Reason() string { return e.reason }
+func (e RelationshipTransactRequestMetadataValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipWriteRequestMetadataValidationError) Cause() error { return e.cause }
+func (e RelationshipTransactRequestMetadataValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipWriteRequestMetadataValidationError) Key() bool { return e.key }
+func (e RelationshipTransactRequestMetadataValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipWriteRequestMetadataValidationError) ErrorName() string {
-	return "RelationshipWriteRequestMetadataValidationError"
+func (e RelationshipTransactRequestMetadataValidationError) ErrorName() string {
+	return "RelationshipTransactRequestMetadataValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipWriteRequestMetadataValidationError) Error() string {
+func (e RelationshipTransactRequestMetadataValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3010,14 +6089,14 @@ func (e RelationshipWriteRequestMetadataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipWriteRequestMetadata.%s: %s%s",
+		"invalid %sRelationshipTransactRequestMetadata.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipWriteRequestMetadataValidationError{}
+var _ error = RelationshipTransactRequestMetadataValidationError{}
 
 var _ interface {
 	Field() string
@@ -3025,24 +6104,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipWriteRequestMetadataValidationError{}
+} = RelationshipTransactRequestMetadataValidationError{}
 
-// Validate checks the field values on RelationshipWriteResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RelationshipTransactResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipWriteResponse) Validate() error {
+func (m *RelationshipTransactResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipWriteResponse with the
+// ValidateAll checks the field values on RelationshipTransactResponse with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RelationshipWriteResponseMultiError, or nil if none found.
-func (m *RelationshipWriteResponse) ValidateAll() error {
+// RelationshipTransactResponseMultiError, or nil if none found.
+func (m *RelationshipTransactResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipWriteResponse) validate(all bool) error {
+func (m *RelationshipTransactResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3052,19 +6131,19 @@ func (m *RelationshipWriteResponse) validate(all bool) error {
 	// no validation rules for SnapToken
 
 	if len(errors) > 0 {
-		return RelationshipWriteResponseMultiError(errors)
+		return RelationshipTransactResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipWriteResponseMultiError is an error wrapping multiple validation
-// errors returned by RelationshipWriteResponse.ValidateAll() if the
-// designated constraints aren't met.
-type RelationshipWriteResponseMultiError []error
+// RelationshipTransactResponseMultiError is an error wrapping multiple
+// validation errors returned by RelationshipTransactResponse.ValidateAll() if
+// the designated constraints aren't met.
+type RelationshipTransactResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipWriteResponseMultiError) Error() string {
+func (m RelationshipTransactResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3073,11 +6152,12 @@ func (m RelationshipWriteResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipWriteResponseMultiError) AllErrors() []error { return m }
+func (m RelationshipTransactResponseMultiError) AllErrors() []error { return m }
 
-// RelationshipWriteResponseValidationError is the validation error returned by
-// RelationshipWriteResponse.Validate if the designated constraints aren't met.
-type RelationshipWriteResponseValidationError struct {
+// RelationshipTransactResponseValidationError is the validation error returned
+// by RelationshipTransactResponse.Validate if the designated constraints
+// aren't met.
+type RelationshipTransactResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3085,24 +6165,24 @@ type RelationshipWriteResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipWriteResponseValidationError) Field() string { return e.field }
+func (e RelationshipTransactResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipWriteResponseValidationError) Reason() string { return e.reason }
+func (e RelationshipTransactResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipWriteResponseValidationError) Cause() error { return e.cause }
+func (e RelationshipTransactResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipWriteResponseValidationError) Key() bool { return e.key }
+func (e RelationshipTransactResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipWriteResponseValidationError) ErrorName() string {
-	return "RelationshipWriteResponseValidationError"
+func (e RelationshipTransactResponseValidationError) ErrorName() string {
+	return "RelationshipTransactResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipWriteResponseValidationError) Error() string {
+func (e RelationshipTransactResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3114,14 +6194,14 @@ func (e RelationshipWriteResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipWriteResponse.%s: %s%s",
+		"invalid %sRelationshipTransactResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipWriteResponseValidationError{}
+var _ error = RelationshipTransactResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3129,24 +6209,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipWriteResponseValidationError{}
+} = RelationshipTransactResponseValidationError{}
 
-// Validate checks the field values on RelationshipReadRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipReadRequest) Validate() error {
+// Validate checks the field values on WatchRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipReadRequest with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// RelationshipReadRequestMultiError, or nil if none found.
-func (m *RelationshipReadRequest) ValidateAll() error {
+// ValidateAll checks the field values on WatchRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchRequestMultiError, or
+// nil if none found.
+func (m *WatchRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipReadRequest) validate(all bool) error {
+func (m *WatchRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3154,7 +6234,7 @@ func (m *RelationshipReadRequest) validate(all bool) error {
 	var errors []error
 
 	if len(m.GetTenantId()) > 64 {
-		err := RelationshipReadRequestValidationError{
+		err := WatchRequestValidationError{
 			field:  "TenantId",
 			reason: "value length must be at most 64 bytes",
 		}
@@ -3164,8 +6244,8 @@ func (m *RelationshipReadRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_RelationshipReadRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := RelationshipReadRequestValidationError{
+	if !_WatchRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
+		err := WatchRequestValidationError{
 			field:  "TenantId",
 			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
 		}
@@ -3175,108 +6255,21 @@ func (m *RelationshipReadRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if m.GetMetadata() == nil {
-		err := RelationshipReadRequestValidationError{
-			field:  "Metadata",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if all {
-		switch v := interface{}(m.GetMetadata()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, RelationshipReadRequestValidationError{
-					field:  "Metadata",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, RelationshipReadRequestValidationError{
-					field:  "Metadata",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return RelationshipReadRequestValidationError{
-				field:  "Metadata",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	if all {
-		switch v := interface{}(m.GetFilter()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, RelationshipReadRequestValidationError{
-					field:  "Filter",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, RelationshipReadRequestValidationError{
-					field:  "Filter",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return RelationshipReadRequestValidationError{
-				field:  "Filter",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	if m.GetPageSize() != 0 {
-
-		if val := m.GetPageSize(); val < 1 || val > 100 {
-			err := RelationshipReadRequestValidationError{
-				field:  "PageSize",
-				reason: "value must be inside range [1, 100]",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
-
-	}
-
-	if m.GetContinuousToken() != "" {
-
-	}
+	// no validation rules for SnapToken
 
 	if len(errors) > 0 {
-		return RelationshipReadRequestMultiError(errors)
+		return WatchRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipReadRequestMultiError is an error wrapping multiple validation
-// errors returned by RelationshipReadRequest.ValidateAll() if the designated
-// constraints aren't met.
-type RelationshipReadRequestMultiError []error
+// WatchRequestMultiError is an error wrapping multiple validation errors
+// returned by WatchRequest.ValidateAll() if the designated constraints aren't met.
+type WatchRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipReadRequestMultiError) Error() string {
+func (m WatchRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3285,11 +6278,11 @@ func (m RelationshipReadRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipReadRequestMultiError) AllErrors() []error { return m }
+func (m WatchRequestMultiError) AllErrors() []error { return m }
 
-// RelationshipReadRequestValidationError is the validation error returned by
-// RelationshipReadRequest.Validate if the designated constraints aren't met.
-type RelationshipReadRequestValidationError struct {
+// WatchRequestValidationError is the validation error returned by
+// WatchRequest.Validate if the designated constraints aren't met.
+type WatchRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3297,24 +6290,22 @@ type RelationshipReadRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipReadRequestValidationError) Field() string { return e.field }
+func (e WatchRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipReadRequestValidationError) Reason() string { return e.reason }
+func (e WatchRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipReadRequestValidationError) Cause() error { return e.cause }
+func (e WatchRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipReadRequestValidationError) Key() bool { return e.key }
+func (e WatchRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipReadRequestValidationError) ErrorName() string {
-	return "RelationshipReadRequestValidationError"
-}
+func (e WatchRequestValidationError) ErrorName() string { return "WatchRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e RelationshipReadRequestValidationError) Error() string {
+func (e WatchRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3326,14 +6317,14 @@ func (e RelationshipReadRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipReadRequest.%s: %s%s",
+		"invalid %sWatchRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipReadRequestValidationError{}
+var _ error = WatchRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3341,48 +6332,75 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipReadRequestValidationError{}
+} = WatchRequestValidationError{}
 
-var _RelationshipReadRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+var _WatchRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
 
-// Validate checks the field values on RelationshipReadRequestMetadata with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipReadRequestMetadata) Validate() error {
+// Validate checks the field values on WatchResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipReadRequestMetadata with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// RelationshipReadRequestMetadataMultiError, or nil if none found.
-func (m *RelationshipReadRequestMetadata) ValidateAll() error {
+// ValidateAll checks the field values on WatchResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchResponseMultiError, or
+// nil if none found.
+func (m *WatchResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipReadRequestMetadata) validate(all bool) error {
+func (m *WatchResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SnapToken
+	if all {
+		switch v := interface{}(m.GetChanges()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, WatchResponseValidationError{
+					field:  "Changes",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, WatchResponseValidationError{
+					field:  "Changes",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetChanges()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return WatchResponseValidationError{
+				field:  "Changes",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return RelationshipReadRequestMetadataMultiError(errors)
+		return WatchResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipReadRequestMetadataMultiError is an error wrapping multiple
-// validation errors returned by RelationshipReadRequestMetadata.ValidateAll()
-// if the designated constraints aren't met.
-type RelationshipReadRequestMetadataMultiError []error
+// WatchResponseMultiError is an error wrapping multiple validation errors
+// returned by WatchResponse.ValidateAll() if the designated constraints
+// aren't met.
+type WatchResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipReadRequestMetadataMultiError) Error() string {
+func (m WatchResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3391,12 +6409,11 @@ func (m RelationshipReadRequestMetadataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipReadRequestMetadataMultiError) AllErrors() []error { return m }
+func (m WatchResponseMultiError) AllErrors() []error { return m }
 
-// RelationshipReadRequestMetadataValidationError is the validation error
-// returned by RelationshipReadRequestMetadata.Validate if the designated
-// constraints aren't met.
-type RelationshipReadRequestMetadataValidationError struct {
+// WatchResponseValidationError is the validation error returned by
+// WatchResponse.Validate if the designated constraints aren't met.
+type WatchResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3404,24 +6421,22 @@ type RelationshipReadRequestMetadataValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipReadRequestMetadataValidationError) Field() string { return e.field }
+func (e WatchResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipReadRequestMetadataValidationError) Reason() string { return e.reason }
+func (e WatchResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipReadRequestMetadataValidationError) Cause() error { return e.cause }
+func (e WatchResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipReadRequestMetadataValidationError) Key() bool { return e.key }
+func (e WatchResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipReadRequestMetadataValidationError) ErrorName() string {
-	return "RelationshipReadRequestMetadataValidationError"
-}
+func (e WatchResponseValidationError) ErrorName() string { return "WatchResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e RelationshipReadRequestMetadataValidationError) Error() string {
+func (e WatchResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3433,14 +6448,14 @@ func (e RelationshipReadRequestMetadataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipReadRequestMetadata.%s: %s%s",
+		"invalid %sWatchResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipReadRequestMetadataValidationError{}
+var _ error = WatchResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3448,80 +6463,77 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipReadRequestMetadataValidationError{}
+} = WatchResponseValidationError{}
 
-// Validate checks the field values on RelationshipReadResponse with the rules
+// Validate checks the field values on TenantCreateRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipReadResponse) Validate() error {
+func (m *TenantCreateRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipReadResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on TenantCreateRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RelationshipReadResponseMultiError, or nil if none found.
-func (m *RelationshipReadResponse) ValidateAll() error {
+// TenantCreateRequestMultiError, or nil if none found.
+func (m *TenantCreateRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipReadResponse) validate(all bool) error {
+func (m *TenantCreateRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetTuples() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, RelationshipReadResponseValidationError{
-						field:  fmt.Sprintf("Tuples[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, RelationshipReadResponseValidationError{
-						field:  fmt.Sprintf("Tuples[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return RelationshipReadResponseValidationError{
-					field:  fmt.Sprintf("Tuples[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
+	if len(m.GetId()) > 64 {
+		err := TenantCreateRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
+	}
 
+	if !_TenantCreateRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := TenantCreateRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
-	// no validation rules for ContinuousToken
+	if len(m.GetName()) > 64 {
+		err := TenantCreateRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return RelationshipReadResponseMultiError(errors)
+		return TenantCreateRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipReadResponseMultiError is an error wrapping multiple validation
-// errors returned by RelationshipReadResponse.ValidateAll() if the designated
+// TenantCreateRequestMultiError is an error wrapping multiple validation
+// errors returned by TenantCreateRequest.ValidateAll() if the designated
 // constraints aren't met.
-type RelationshipReadResponseMultiError []error
+type TenantCreateRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipReadResponseMultiError) Error() string {
+func (m TenantCreateRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3530,11 +6542,11 @@ func (m RelationshipReadResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipReadResponseMultiError) AllErrors() []error { return m }
+func (m TenantCreateRequestMultiError) AllErrors() []error { return m }
 
-// RelationshipReadResponseValidationError is the validation error returned by
-// RelationshipReadResponse.Validate if the designated constraints aren't met.
-type RelationshipReadResponseValidationError struct {
+// TenantCreateRequestValidationError is the validation error returned by
+// TenantCreateRequest.Validate if the designated constraints aren't met.
+type TenantCreateRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3542,24 +6554,24 @@ type RelationshipReadResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipReadResponseValidationError) Field() string { return e.field }
+func (e TenantCreateRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipReadResponseValidationError) Reason() string { return e.reason }
+func (e TenantCreateRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipReadResponseValidationError) Cause() error { return e.cause }
+func (e TenantCreateRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipReadResponseValidationError) Key() bool { return e.key }
+func (e TenantCreateRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipReadResponseValidationError) ErrorName() string {
-	return "RelationshipReadResponseValidationError"
+func (e TenantCreateRequestValidationError) ErrorName() string {
+	return "TenantCreateRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipReadResponseValidationError) Error() string {
+func (e TenantCreateRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3571,14 +6583,14 @@ func (e RelationshipReadResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipReadResponse.%s: %s%s",
+		"invalid %sTenantCreateRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipReadResponseValidationError{}
+var _ error = TenantCreateRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3586,75 +6598,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipReadResponseValidationError{}
+} = TenantCreateRequestValidationError{}
 
-// Validate checks the field values on RelationshipDeleteRequest with the rules
+var _TenantCreateRequest_Id_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+
+// Validate checks the field values on TenantCreateResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipDeleteRequest) Validate() error {
+func (m *TenantCreateResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipDeleteRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on TenantCreateResponse with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RelationshipDeleteRequestMultiError, or nil if none found.
-func (m *RelationshipDeleteRequest) ValidateAll() error {
+// TenantCreateResponseMultiError, or nil if none found.
+func (m *TenantCreateResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipDeleteRequest) validate(all bool) error {
+func (m *TenantCreateResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTenantId()) > 64 {
-		err := RelationshipDeleteRequestValidationError{
-			field:  "TenantId",
-			reason: "value length must be at most 64 bytes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if !_RelationshipDeleteRequest_TenantId_Pattern.MatchString(m.GetTenantId()) {
-		err := RelationshipDeleteRequestValidationError{
-			field:  "TenantId",
-			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
 	if all {
-		switch v := interface{}(m.GetFilter()).(type) {
+		switch v := interface{}(m.GetTenant()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, RelationshipDeleteRequestValidationError{
-					field:  "Filter",
+				errors = append(errors, TenantCreateResponseValidationError{
+					field:  "Tenant",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, RelationshipDeleteRequestValidationError{
-					field:  "Filter",
+				errors = append(errors, TenantCreateResponseValidationError{
+					field:  "Tenant",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetTenant()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return RelationshipDeleteRequestValidationError{
-				field:  "Filter",
+			return TenantCreateResponseValidationError{
+				field:  "Tenant",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -3662,19 +6654,19 @@ func (m *RelationshipDeleteRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return RelationshipDeleteRequestMultiError(errors)
+		return TenantCreateResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// RelationshipDeleteRequestMultiError is an error wrapping multiple validation
-// errors returned by RelationshipDeleteRequest.ValidateAll() if the
-// designated constraints aren't met.
-type RelationshipDeleteRequestMultiError []error
+// TenantCreateResponseMultiError is an error wrapping multiple validation
+// errors returned by TenantCreateResponse.ValidateAll() if the designated
+// constraints aren't met.
+type TenantCreateResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipDeleteRequestMultiError) Error() string {
+func (m TenantCreateResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3683,11 +6675,11 @@ func (m RelationshipDeleteRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipDeleteRequestMultiError) AllErrors() []error { return m }
+func (m TenantCreateResponseMultiError) AllErrors() []error { return m }
 
-// RelationshipDeleteRequestValidationError is the validation error returned by
-// RelationshipDeleteRequest.Validate if the designated constraints aren't met.
-type RelationshipDeleteRequestValidationError struct {
+// TenantCreateResponseValidationError is the validation error returned by
+// TenantCreateResponse.Validate if the designated constraints aren't met.
+type TenantCreateResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3695,24 +6687,24 @@ type RelationshipDeleteRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipDeleteRequestValidationError) Field() string { return e.field }
+func (e TenantCreateResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipDeleteRequestValidationError) Reason() string { return e.reason }
+func (e TenantCreateResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipDeleteRequestValidationError) Cause() error { return e.cause }
+func (e TenantCreateResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipDeleteRequestValidationError) Key() bool { return e.key }
+func (e TenantCreateResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipDeleteRequestValidationError) ErrorName() string {
-	return "RelationshipDeleteRequestValidationError"
+func (e TenantCreateResponseValidationError) ErrorName() string {
+	return "TenantCreateResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipDeleteRequestValidationError) Error() string {
+func (e TenantCreateResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3724,14 +6716,14 @@ func (e RelationshipDeleteRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipDeleteRequest.%s: %s%s",
+		"invalid %sTenantCreateResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipDeleteRequestValidationError{}
+var _ error = TenantCreateResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3739,48 +6731,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipDeleteRequestValidationError{}
-
-var _RelationshipDeleteRequest_TenantId_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+} = TenantCreateResponseValidationError{}
 
-// Validate checks the field values on RelationshipDeleteResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// Validate checks the field values on TenantDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RelationshipDeleteResponse) Validate() error {
+func (m *TenantDeleteRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RelationshipDeleteResponse with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on TenantDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RelationshipDeleteResponseMultiError, or nil if none found.
-func (m *RelationshipDeleteResponse) ValidateAll() error {
+// TenantDeleteRequestMultiError, or nil if none found.
+func (m *TenantDeleteRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RelationshipDeleteResponse) validate(all bool) error {
+func (m *TenantDeleteRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SnapToken
-
 	if len(errors) > 0 {
-		return RelationshipDeleteResponseMultiError(errors)
+		return TenantDeleteRequestMultiError(errors)
 	}
 
-	return nil
-}
-
-// RelationshipDeleteResponseMultiError is an error wrapping multiple
-// validation errors returned by RelationshipDeleteResponse.ValidateAll() if
-// the designated constraints aren't met.
-type RelationshipDeleteResponseMultiError []error
+	return nil
+}
+
+// TenantDeleteRequestMultiError is an error wrapping multiple validation
+// errors returned by TenantDeleteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type TenantDeleteRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RelationshipDeleteResponseMultiError) Error() string {
+func (m TenantDeleteRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3789,11 +6777,11 @@ func (m RelationshipDeleteResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RelationshipDeleteResponseMultiError) AllErrors() []error { return m }
+func (m TenantDeleteRequestMultiError) AllErrors() []error { return m }
 
-// RelationshipDeleteResponseValidationError is the validation error returned
-// by RelationshipDeleteResponse.Validate if the designated constraints aren't met.
-type RelationshipDeleteResponseValidationError struct {
+// TenantDeleteRequestValidationError is the validation error returned by
+// TenantDeleteRequest.Validate if the designated constraints aren't met.
+type TenantDeleteRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3801,24 +6789,24 @@ type RelationshipDeleteResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e RelationshipDeleteResponseValidationError) Field() string { return e.field }
+func (e TenantDeleteRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RelationshipDeleteResponseValidationError) Reason() string { return e.reason }
+func (e TenantDeleteRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RelationshipDeleteResponseValidationError) Cause() error { return e.cause }
+func (e TenantDeleteRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RelationshipDeleteResponseValidationError) Key() bool { return e.key }
+func (e TenantDeleteRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RelationshipDeleteResponseValidationError) ErrorName() string {
-	return "RelationshipDeleteResponseValidationError"
+func (e TenantDeleteRequestValidationError) ErrorName() string {
+	return "TenantDeleteRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RelationshipDeleteResponseValidationError) Error() string {
+func (e TenantDeleteRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3830,14 +6818,14 @@ func (e RelationshipDeleteResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRelationshipDeleteResponse.%s: %s%s",
+		"invalid %sTenantDeleteRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RelationshipDeleteResponseValidationError{}
+var _ error = TenantDeleteRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3845,77 +6833,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RelationshipDeleteResponseValidationError{}
+} = TenantDeleteRequestValidationError{}
 
-// Validate checks the field values on TenantCreateRequest with the rules
+// Validate checks the field values on TenantDeleteResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *TenantCreateRequest) Validate() error {
+func (m *TenantDeleteResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantCreateRequest with the rules
+// ValidateAll checks the field values on TenantDeleteResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TenantCreateRequestMultiError, or nil if none found.
-func (m *TenantCreateRequest) ValidateAll() error {
+// TenantDeleteResponseMultiError, or nil if none found.
+func (m *TenantDeleteResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantCreateRequest) validate(all bool) error {
+func (m *TenantDeleteResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetId()) > 64 {
-		err := TenantCreateRequestValidationError{
-			field:  "Id",
-			reason: "value length must be at most 64 bytes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if !_TenantCreateRequest_Id_Pattern.MatchString(m.GetId()) {
-		err := TenantCreateRequestValidationError{
-			field:  "Id",
-			reason: "value does not match regex pattern \"[a-zA-Z0-9-,]+\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if len(m.GetName()) > 64 {
-		err := TenantCreateRequestValidationError{
-			field:  "Name",
-			reason: "value length must be at most 64 bytes",
+	if all {
+		switch v := interface{}(m.GetTenant()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TenantDeleteResponseValidationError{
+					field:  "Tenant",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TenantDeleteResponseValidationError{
+					field:  "Tenant",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
 		}
-		if !all {
-			return err
+	} else if v, ok := interface{}(m.GetTenant()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TenantDeleteResponseValidationError{
+				field:  "Tenant",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
 		}
-		errors = append(errors, err)
 	}
 
 	if len(errors) > 0 {
-		return TenantCreateRequestMultiError(errors)
+		return TenantDeleteResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantCreateRequestMultiError is an error wrapping multiple validation
-// errors returned by TenantCreateRequest.ValidateAll() if the designated
+// TenantDeleteResponseMultiError is an error wrapping multiple validation
+// errors returned by TenantDeleteResponse.ValidateAll() if the designated
 // constraints aren't met.
-type TenantCreateRequestMultiError []error
+type TenantDeleteResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantCreateRequestMultiError) Error() string {
+func (m TenantDeleteResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3924,11 +6908,11 @@ func (m TenantCreateRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantCreateRequestMultiError) AllErrors() []error { return m }
+func (m TenantDeleteResponseMultiError) AllErrors() []error { return m }
 
-// TenantCreateRequestValidationError is the validation error returned by
-// TenantCreateRequest.Validate if the designated constraints aren't met.
-type TenantCreateRequestValidationError struct {
+// TenantDeleteResponseValidationError is the validation error returned by
+// TenantDeleteResponse.Validate if the designated constraints aren't met.
+type TenantDeleteResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3936,24 +6920,24 @@ type TenantCreateRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantCreateRequestValidationError) Field() string { return e.field }
+func (e TenantDeleteResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantCreateRequestValidationError) Reason() string { return e.reason }
+func (e TenantDeleteResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantCreateRequestValidationError) Cause() error { return e.cause }
+func (e TenantDeleteResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantCreateRequestValidationError) Key() bool { return e.key }
+func (e TenantDeleteResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantCreateRequestValidationError) ErrorName() string {
-	return "TenantCreateRequestValidationError"
+func (e TenantDeleteResponseValidationError) ErrorName() string {
+	return "TenantDeleteResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TenantCreateRequestValidationError) Error() string {
+func (e TenantDeleteResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3965,14 +6949,14 @@ func (e TenantCreateRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantCreateRequest.%s: %s%s",
+		"invalid %sTenantDeleteResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantCreateRequestValidationError{}
+var _ error = TenantDeleteResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3980,75 +6964,63 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantCreateRequestValidationError{}
-
-var _TenantCreateRequest_Id_Pattern = regexp.MustCompile("[a-zA-Z0-9-,]+")
+} = TenantDeleteResponseValidationError{}
 
-// Validate checks the field values on TenantCreateResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *TenantCreateResponse) Validate() error {
+// Validate checks the field values on TenantListRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *TenantListRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantCreateResponse with the rules
+// ValidateAll checks the field values on TenantListRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TenantCreateResponseMultiError, or nil if none found.
-func (m *TenantCreateResponse) ValidateAll() error {
+// TenantListRequestMultiError, or nil if none found.
+func (m *TenantListRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantCreateResponse) validate(all bool) error {
+func (m *TenantListRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetTenant()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, TenantCreateResponseValidationError{
-					field:  "Tenant",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, TenantCreateResponseValidationError{
-					field:  "Tenant",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	if m.GetPageSize() != 0 {
+
+		if val := m.GetPageSize(); val < 1 || val > 100 {
+			err := TenantListRequestValidationError{
+				field:  "PageSize",
+				reason: "value must be inside range [1, 100]",
 			}
-		}
-	} else if v, ok := interface{}(m.GetTenant()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return TenantCreateResponseValidationError{
-				field:  "Tenant",
-				reason: "embedded message failed validation",
-				cause:  err,
+			if !all {
+				return err
 			}
+			errors = append(errors, err)
 		}
+
+	}
+
+	if m.GetContinuousToken() != "" {
+
 	}
 
 	if len(errors) > 0 {
-		return TenantCreateResponseMultiError(errors)
+		return TenantListRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantCreateResponseMultiError is an error wrapping multiple validation
-// errors returned by TenantCreateResponse.ValidateAll() if the designated
-// constraints aren't met.
-type TenantCreateResponseMultiError []error
+// TenantListRequestMultiError is an error wrapping multiple validation errors
+// returned by TenantListRequest.ValidateAll() if the designated constraints
+// aren't met.
+type TenantListRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantCreateResponseMultiError) Error() string {
+func (m TenantListRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -4057,11 +7029,11 @@ func (m TenantCreateResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantCreateResponseMultiError) AllErrors() []error { return m }
+func (m TenantListRequestMultiError) AllErrors() []error { return m }
 
-// TenantCreateResponseValidationError is the validation error returned by
-// TenantCreateResponse.Validate if the designated constraints aren't met.
-type TenantCreateResponseValidationError struct {
+// TenantListRequestValidationError is the validation error returned by
+// TenantListRequest.Validate if the designated constraints aren't met.
+type TenantListRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4069,24 +7041,24 @@ type TenantCreateResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantCreateResponseValidationError) Field() string { return e.field }
+func (e TenantListRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantCreateResponseValidationError) Reason() string { return e.reason }
+func (e TenantListRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantCreateResponseValidationError) Cause() error { return e.cause }
+func (e TenantListRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantCreateResponseValidationError) Key() bool { return e.key }
+func (e TenantListRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantCreateResponseValidationError) ErrorName() string {
-	return "TenantCreateResponseValidationError"
+func (e TenantListRequestValidationError) ErrorName() string {
+	return "TenantListRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TenantCreateResponseValidationError) Error() string {
+func (e TenantListRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4098,14 +7070,14 @@ func (e TenantCreateResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantCreateResponse.%s: %s%s",
+		"invalid %sTenantListRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantCreateResponseValidationError{}
+var _ error = TenantListRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -4113,44 +7085,80 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantCreateResponseValidationError{}
+} = TenantListRequestValidationError{}
 
-// Validate checks the field values on TenantDeleteRequest with the rules
+// Validate checks the field values on TenantListResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *TenantDeleteRequest) Validate() error {
+func (m *TenantListResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantDeleteRequest with the rules
+// ValidateAll checks the field values on TenantListResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TenantDeleteRequestMultiError, or nil if none found.
-func (m *TenantDeleteRequest) ValidateAll() error {
+// TenantListResponseMultiError, or nil if none found.
+func (m *TenantListResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantDeleteRequest) validate(all bool) error {
-	if m == nil {
-		return nil
+func (m *TenantListResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTenants() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TenantListResponseValidationError{
+						field:  fmt.Sprintf("Tenants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TenantListResponseValidationError{
+						field:  fmt.Sprintf("Tenants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TenantListResponseValidationError{
+					field:  fmt.Sprintf("Tenants[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
-	var errors []error
+	// no validation rules for ContinuousToken
 
 	if len(errors) > 0 {
-		return TenantDeleteRequestMultiError(errors)
+		return TenantListResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantDeleteRequestMultiError is an error wrapping multiple validation
-// errors returned by TenantDeleteRequest.ValidateAll() if the designated
-// constraints aren't met.
-type TenantDeleteRequestMultiError []error
+// TenantListResponseMultiError is an error wrapping multiple validation errors
+// returned by TenantListResponse.ValidateAll() if the designated constraints
+// aren't met.
+type TenantListResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantDeleteRequestMultiError) Error() string {
+func (m TenantListResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -4159,11 +7167,11 @@ func (m TenantDeleteRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantDeleteRequestMultiError) AllErrors() []error { return m }
+func (m TenantListResponseMultiError) AllErrors() []error { return m }
 
-// TenantDeleteRequestValidationError is the validation error returned by
-// TenantDeleteRequest.Validate if the designated constraints aren't met.
-type TenantDeleteRequestValidationError struct {
+// TenantListResponseValidationError is the validation error returned by
+// TenantListResponse.Validate if the designated constraints aren't met.
+type TenantListResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4171,24 +7179,24 @@ type TenantDeleteRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantDeleteRequestValidationError) Field() string { return e.field }
+func (e TenantListResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantDeleteRequestValidationError) Reason() string { return e.reason }
+func (e TenantListResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantDeleteRequestValidationError) Cause() error { return e.cause }
+func (e TenantListResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantDeleteRequestValidationError) Key() bool { return e.key }
+func (e TenantListResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantDeleteRequestValidationError) ErrorName() string {
-	return "TenantDeleteRequestValidationError"
+func (e TenantListResponseValidationError) ErrorName() string {
+	return "TenantListResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TenantDeleteRequestValidationError) Error() string {
+func (e TenantListResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4200,14 +7208,14 @@ func (e TenantDeleteRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantDeleteRequest.%s: %s%s",
+		"invalid %sTenantListResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantDeleteRequestValidationError{}
+var _ error = TenantListResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -4215,73 +7223,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantDeleteRequestValidationError{}
+} = TenantListResponseValidationError{}
 
-// Validate checks the field values on TenantDeleteResponse with the rules
+// Validate checks the field values on ClusterMembersRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *TenantDeleteResponse) Validate() error {
+func (m *ClusterMembersRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantDeleteResponse with the rules
+// ValidateAll checks the field values on ClusterMembersRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TenantDeleteResponseMultiError, or nil if none found.
-func (m *TenantDeleteResponse) ValidateAll() error {
+// ClusterMembersRequestMultiError, or nil if none found.
+func (m *ClusterMembersRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantDeleteResponse) validate(all bool) error {
+func (m *ClusterMembersRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetTenant()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, TenantDeleteResponseValidationError{
-					field:  "Tenant",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, TenantDeleteResponseValidationError{
-					field:  "Tenant",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetTenant()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return TenantDeleteResponseValidationError{
-				field:  "Tenant",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
 	if len(errors) > 0 {
-		return TenantDeleteResponseMultiError(errors)
+		return ClusterMembersRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantDeleteResponseMultiError is an error wrapping multiple validation
-// errors returned by TenantDeleteResponse.ValidateAll() if the designated
+// ClusterMembersRequestMultiError is an error wrapping multiple validation
+// errors returned by ClusterMembersRequest.ValidateAll() if the designated
 // constraints aren't met.
-type TenantDeleteResponseMultiError []error
+type ClusterMembersRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantDeleteResponseMultiError) Error() string {
+func (m ClusterMembersRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -4290,11 +7269,11 @@ func (m TenantDeleteResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantDeleteResponseMultiError) AllErrors() []error { return m }
+func (m ClusterMembersRequestMultiError) AllErrors() []error { return m }
 
-// TenantDeleteResponseValidationError is the validation error returned by
-// TenantDeleteResponse.Validate if the designated constraints aren't met.
-type TenantDeleteResponseValidationError struct {
+// ClusterMembersRequestValidationError is the validation error returned by
+// ClusterMembersRequest.Validate if the designated constraints aren't met.
+type ClusterMembersRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4302,24 +7281,24 @@ type TenantDeleteResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantDeleteResponseValidationError) Field() string { return e.field }
+func (e ClusterMembersRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantDeleteResponseValidationError) Reason() string { return e.reason }
+func (e ClusterMembersRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantDeleteResponseValidationError) Cause() error { return e.cause }
+func (e ClusterMembersRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantDeleteResponseValidationError) Key() bool { return e.key }
+func (e ClusterMembersRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantDeleteResponseValidationError) ErrorName() string {
-	return "TenantDeleteResponseValidationError"
+func (e ClusterMembersRequestValidationError) ErrorName() string {
+	return "ClusterMembersRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TenantDeleteResponseValidationError) Error() string {
+func (e ClusterMembersRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4331,14 +7310,14 @@ func (e TenantDeleteResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantDeleteResponse.%s: %s%s",
+		"invalid %sClusterMembersRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantDeleteResponseValidationError{}
+var _ error = ClusterMembersRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -4346,63 +7325,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantDeleteResponseValidationError{}
+} = ClusterMembersRequestValidationError{}
 
-// Validate checks the field values on TenantListRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *TenantListRequest) Validate() error {
+// Validate checks the field values on ClusterMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ClusterMembersResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantListRequest with the rules
+// ValidateAll checks the field values on ClusterMembersResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// TenantListRequestMultiError, or nil if none found.
-func (m *TenantListRequest) ValidateAll() error {
+// ClusterMembersResponseMultiError, or nil if none found.
+func (m *ClusterMembersResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantListRequest) validate(all bool) error {
+func (m *ClusterMembersResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetPageSize() != 0 {
+	for idx, item := range m.GetMembers() {
+		_, _ = idx, item
 
-		if val := m.GetPageSize(); val < 1 || val > 100 {
-			err := TenantListRequestValidationError{
-				field:  "PageSize",
-				reason: "value must be inside range [1, 100]",
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ClusterMembersResponseValidationError{
+						field:  fmt.Sprintf("Members[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ClusterMembersResponseValidationError{
+						field:  fmt.Sprintf("Members[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-			if !all {
-				return err
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ClusterMembersResponseValidationError{
+					field:  fmt.Sprintf("Members[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
 			}
-			errors = append(errors, err)
 		}
 
 	}
 
-	if m.GetContinuousToken() != "" {
-
-	}
-
 	if len(errors) > 0 {
-		return TenantListRequestMultiError(errors)
+		return ClusterMembersResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantListRequestMultiError is an error wrapping multiple validation errors
-// returned by TenantListRequest.ValidateAll() if the designated constraints
-// aren't met.
-type TenantListRequestMultiError []error
+// ClusterMembersResponseMultiError is an error wrapping multiple validation
+// errors returned by ClusterMembersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ClusterMembersResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantListRequestMultiError) Error() string {
+func (m ClusterMembersResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -4411,11 +7405,11 @@ func (m TenantListRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantListRequestMultiError) AllErrors() []error { return m }
+func (m ClusterMembersResponseMultiError) AllErrors() []error { return m }
 
-// TenantListRequestValidationError is the validation error returned by
-// TenantListRequest.Validate if the designated constraints aren't met.
-type TenantListRequestValidationError struct {
+// ClusterMembersResponseValidationError is the validation error returned by
+// ClusterMembersResponse.Validate if the designated constraints aren't met.
+type ClusterMembersResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4423,24 +7417,24 @@ type TenantListRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantListRequestValidationError) Field() string { return e.field }
+func (e ClusterMembersResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantListRequestValidationError) Reason() string { return e.reason }
+func (e ClusterMembersResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantListRequestValidationError) Cause() error { return e.cause }
+func (e ClusterMembersResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantListRequestValidationError) Key() bool { return e.key }
+func (e ClusterMembersResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantListRequestValidationError) ErrorName() string {
-	return "TenantListRequestValidationError"
+func (e ClusterMembersResponseValidationError) ErrorName() string {
+	return "ClusterMembersResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e TenantListRequestValidationError) Error() string {
+func (e ClusterMembersResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4452,14 +7446,14 @@ func (e TenantListRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantListRequest.%s: %s%s",
+		"invalid %sClusterMembersResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantListRequestValidationError{}
+var _ error = ClusterMembersResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -4467,80 +7461,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantListRequestValidationError{}
+} = ClusterMembersResponseValidationError{}
 
-// Validate checks the field values on TenantListResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *TenantListResponse) Validate() error {
+// Validate checks the field values on ClusterMember with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ClusterMember) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on TenantListResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// TenantListResponseMultiError, or nil if none found.
-func (m *TenantListResponse) ValidateAll() error {
+// ValidateAll checks the field values on ClusterMember with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ClusterMemberMultiError, or
+// nil if none found.
+func (m *ClusterMember) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *TenantListResponse) validate(all bool) error {
+func (m *ClusterMember) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetTenants() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, TenantListResponseValidationError{
-						field:  fmt.Sprintf("Tenants[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, TenantListResponseValidationError{
-						field:  fmt.Sprintf("Tenants[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return TenantListResponseValidationError{
-					field:  fmt.Sprintf("Tenants[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for Address
 
-	}
+	// no validation rules for Local
 
-	// no validation rules for ContinuousToken
+	// no validation rules for Ownership
 
 	if len(errors) > 0 {
-		return TenantListResponseMultiError(errors)
+		return ClusterMemberMultiError(errors)
 	}
 
 	return nil
 }
 
-// TenantListResponseMultiError is an error wrapping multiple validation errors
-// returned by TenantListResponse.ValidateAll() if the designated constraints
+// ClusterMemberMultiError is an error wrapping multiple validation errors
+// returned by ClusterMember.ValidateAll() if the designated constraints
 // aren't met.
-type TenantListResponseMultiError []error
+type ClusterMemberMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m TenantListResponseMultiError) Error() string {
+func (m ClusterMemberMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -4549,11 +7513,11 @@ func (m TenantListResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m TenantListResponseMultiError) AllErrors() []error { return m }
+func (m ClusterMemberMultiError) AllErrors() []error { return m }
 
-// TenantListResponseValidationError is the validation error returned by
-// TenantListResponse.Validate if the designated constraints aren't met.
-type TenantListResponseValidationError struct {
+// ClusterMemberValidationError is the validation error returned by
+// ClusterMember.Validate if the designated constraints aren't met.
+type ClusterMemberValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4561,24 +7525,22 @@ type TenantListResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e TenantListResponseValidationError) Field() string { return e.field }
+func (e ClusterMemberValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TenantListResponseValidationError) Reason() string { return e.reason }
+func (e ClusterMemberValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TenantListResponseValidationError) Cause() error { return e.cause }
+func (e ClusterMemberValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TenantListResponseValidationError) Key() bool { return e.key }
+func (e ClusterMemberValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TenantListResponseValidationError) ErrorName() string {
-	return "TenantListResponseValidationError"
-}
+func (e ClusterMemberValidationError) ErrorName() string { return "ClusterMemberValidationError" }
 
 // Error satisfies the builtin error interface
-func (e TenantListResponseValidationError) Error() string {
+func (e ClusterMemberValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4590,14 +7552,14 @@ func (e TenantListResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTenantListResponse.%s: %s%s",
+		"invalid %sClusterMember.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TenantListResponseValidationError{}
+var _ error = ClusterMemberValidationError{}
 
 var _ interface {
 	Field() string
@@ -4605,4 +7567,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TenantListResponseValidationError{}
+} = ClusterMemberValidationError{}
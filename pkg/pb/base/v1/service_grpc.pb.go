@@ -21,8 +21,10 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Permission_Check_FullMethodName              = "/base.v1.Permission/Check"
 	Permission_Expand_FullMethodName             = "/base.v1.Permission/Expand"
+	Permission_ExpandStream_FullMethodName       = "/base.v1.Permission/ExpandStream"
 	Permission_LookupEntity_FullMethodName       = "/base.v1.Permission/LookupEntity"
 	Permission_LookupEntityStream_FullMethodName = "/base.v1.Permission/LookupEntityStream"
+	Permission_Matrix_FullMethodName             = "/base.v1.Permission/Matrix"
 )
 
 // PermissionClient is the client API for Permission service.
@@ -31,8 +33,10 @@ const (
 type PermissionClient interface {
 	Check(ctx context.Context, in *PermissionCheckRequest, opts ...grpc.CallOption) (*PermissionCheckResponse, error)
 	Expand(ctx context.Context, in *PermissionExpandRequest, opts ...grpc.CallOption) (*PermissionExpandResponse, error)
+	ExpandStream(ctx context.Context, in *PermissionExpandRequest, opts ...grpc.CallOption) (Permission_ExpandStreamClient, error)
 	LookupEntity(ctx context.Context, in *PermissionLookupEntityRequest, opts ...grpc.CallOption) (*PermissionLookupEntityResponse, error)
 	LookupEntityStream(ctx context.Context, in *PermissionLookupEntityRequest, opts ...grpc.CallOption) (Permission_LookupEntityStreamClient, error)
+	Matrix(ctx context.Context, in *PermissionMatrixRequest, opts ...grpc.CallOption) (*PermissionMatrixResponse, error)
 }
 
 type permissionClient struct {
@@ -61,6 +65,38 @@ func (c *permissionClient) Expand(ctx context.Context, in *PermissionExpandReque
 	return out, nil
 }
 
+func (c *permissionClient) ExpandStream(ctx context.Context, in *PermissionExpandRequest, opts ...grpc.CallOption) (Permission_ExpandStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Permission_ServiceDesc.Streams[0], Permission_ExpandStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionExpandStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Permission_ExpandStreamClient interface {
+	Recv() (*PermissionExpandStreamResponse, error)
+	grpc.ClientStream
+}
+
+type permissionExpandStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionExpandStreamClient) Recv() (*PermissionExpandStreamResponse, error) {
+	m := new(PermissionExpandStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *permissionClient) LookupEntity(ctx context.Context, in *PermissionLookupEntityRequest, opts ...grpc.CallOption) (*PermissionLookupEntityResponse, error) {
 	out := new(PermissionLookupEntityResponse)
 	err := c.cc.Invoke(ctx, Permission_LookupEntity_FullMethodName, in, out, opts...)
@@ -71,7 +107,7 @@ func (c *permissionClient) LookupEntity(ctx context.Context, in *PermissionLooku
 }
 
 func (c *permissionClient) LookupEntityStream(ctx context.Context, in *PermissionLookupEntityRequest, opts ...grpc.CallOption) (Permission_LookupEntityStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Permission_ServiceDesc.Streams[0], Permission_LookupEntityStream_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Permission_ServiceDesc.Streams[1], Permission_LookupEntityStream_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,14 +138,25 @@ func (x *permissionLookupEntityStreamClient) Recv() (*PermissionLookupEntityStre
 	return m, nil
 }
 
+func (c *permissionClient) Matrix(ctx context.Context, in *PermissionMatrixRequest, opts ...grpc.CallOption) (*PermissionMatrixResponse, error) {
+	out := new(PermissionMatrixResponse)
+	err := c.cc.Invoke(ctx, Permission_Matrix_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PermissionServer is the server API for Permission service.
 // All implementations must embed UnimplementedPermissionServer
 // for forward compatibility
 type PermissionServer interface {
 	Check(context.Context, *PermissionCheckRequest) (*PermissionCheckResponse, error)
 	Expand(context.Context, *PermissionExpandRequest) (*PermissionExpandResponse, error)
+	ExpandStream(*PermissionExpandRequest, Permission_ExpandStreamServer) error
 	LookupEntity(context.Context, *PermissionLookupEntityRequest) (*PermissionLookupEntityResponse, error)
 	LookupEntityStream(*PermissionLookupEntityRequest, Permission_LookupEntityStreamServer) error
+	Matrix(context.Context, *PermissionMatrixRequest) (*PermissionMatrixResponse, error)
 	mustEmbedUnimplementedPermissionServer()
 }
 
@@ -123,12 +170,18 @@ func (UnimplementedPermissionServer) Check(context.Context, *PermissionCheckRequ
 func (UnimplementedPermissionServer) Expand(context.Context, *PermissionExpandRequest) (*PermissionExpandResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Expand not implemented")
 }
+func (UnimplementedPermissionServer) ExpandStream(*PermissionExpandRequest, Permission_ExpandStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExpandStream not implemented")
+}
 func (UnimplementedPermissionServer) LookupEntity(context.Context, *PermissionLookupEntityRequest) (*PermissionLookupEntityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LookupEntity not implemented")
 }
 func (UnimplementedPermissionServer) LookupEntityStream(*PermissionLookupEntityRequest, Permission_LookupEntityStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method LookupEntityStream not implemented")
 }
+func (UnimplementedPermissionServer) Matrix(context.Context, *PermissionMatrixRequest) (*PermissionMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Matrix not implemented")
+}
 func (UnimplementedPermissionServer) mustEmbedUnimplementedPermissionServer() {}
 
 // UnsafePermissionServer may be embedded to opt out of forward compatibility for this service.
@@ -178,6 +231,27 @@ func _Permission_Expand_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Permission_ExpandStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PermissionExpandRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermissionServer).ExpandStream(m, &permissionExpandStreamServer{stream})
+}
+
+type Permission_ExpandStreamServer interface {
+	Send(*PermissionExpandStreamResponse) error
+	grpc.ServerStream
+}
+
+type permissionExpandStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionExpandStreamServer) Send(m *PermissionExpandStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Permission_LookupEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PermissionLookupEntityRequest)
 	if err := dec(in); err != nil {
@@ -217,6 +291,24 @@ func (x *permissionLookupEntityStreamServer) Send(m *PermissionLookupEntityStrea
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Permission_Matrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).Matrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Permission_Matrix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).Matrix(ctx, req.(*PermissionMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Permission_ServiceDesc is the grpc.ServiceDesc for Permission service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -236,8 +328,17 @@ var Permission_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LookupEntity",
 			Handler:    _Permission_LookupEntity_Handler,
 		},
+		{
+			MethodName: "Matrix",
+			Handler:    _Permission_Matrix_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExpandStream",
+			Handler:       _Permission_ExpandStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "LookupEntityStream",
 			Handler:       _Permission_LookupEntityStream_Handler,
@@ -375,9 +476,11 @@ var Schema_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	Relationship_Write_FullMethodName  = "/base.v1.Relationship/Write"
-	Relationship_Read_FullMethodName   = "/base.v1.Relationship/Read"
-	Relationship_Delete_FullMethodName = "/base.v1.Relationship/Delete"
+	Relationship_Write_FullMethodName      = "/base.v1.Relationship/Write"
+	Relationship_Read_FullMethodName       = "/base.v1.Relationship/Read"
+	Relationship_Delete_FullMethodName     = "/base.v1.Relationship/Delete"
+	Relationship_BulkImport_FullMethodName = "/base.v1.Relationship/BulkImport"
+	Relationship_Transact_FullMethodName   = "/base.v1.Relationship/Transact"
 )
 
 // RelationshipClient is the client API for Relationship service.
@@ -387,6 +490,10 @@ type RelationshipClient interface {
 	Write(ctx context.Context, in *RelationshipWriteRequest, opts ...grpc.CallOption) (*RelationshipWriteResponse, error)
 	Read(ctx context.Context, in *RelationshipReadRequest, opts ...grpc.CallOption) (*RelationshipReadResponse, error)
 	Delete(ctx context.Context, in *RelationshipDeleteRequest, opts ...grpc.CallOption) (*RelationshipDeleteResponse, error)
+	// BulkImport is served over HTTP at POST /v1/tenants/{tenant_id}/relationships/bulk-import by a handler of the
+	// gateway of its own, since the gateway cannot bind path parameters to client streams.
+	BulkImport(ctx context.Context, opts ...grpc.CallOption) (Relationship_BulkImportClient, error)
+	Transact(ctx context.Context, in *RelationshipTransactRequest, opts ...grpc.CallOption) (*RelationshipTransactResponse, error)
 }
 
 type relationshipClient struct {
@@ -424,6 +531,49 @@ func (c *relationshipClient) Delete(ctx context.Context, in *RelationshipDeleteR
 	return out, nil
 }
 
+func (c *relationshipClient) BulkImport(ctx context.Context, opts ...grpc.CallOption) (Relationship_BulkImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Relationship_ServiceDesc.Streams[0], Relationship_BulkImport_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relationshipBulkImportClient{stream}
+	return x, nil
+}
+
+type Relationship_BulkImportClient interface {
+	Send(*RelationshipBulkImportRequest) error
+	CloseAndRecv() (*RelationshipBulkImportResponse, error)
+	grpc.ClientStream
+}
+
+type relationshipBulkImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *relationshipBulkImportClient) Send(m *RelationshipBulkImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *relationshipBulkImportClient) CloseAndRecv() (*RelationshipBulkImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RelationshipBulkImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *relationshipClient) Transact(ctx context.Context, in *RelationshipTransactRequest, opts ...grpc.CallOption) (*RelationshipTransactResponse, error) {
+	out := new(RelationshipTransactResponse)
+	err := c.cc.Invoke(ctx, Relationship_Transact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RelationshipServer is the server API for Relationship service.
 // All implementations must embed UnimplementedRelationshipServer
 // for forward compatibility
@@ -431,6 +581,10 @@ type RelationshipServer interface {
 	Write(context.Context, *RelationshipWriteRequest) (*RelationshipWriteResponse, error)
 	Read(context.Context, *RelationshipReadRequest) (*RelationshipReadResponse, error)
 	Delete(context.Context, *RelationshipDeleteRequest) (*RelationshipDeleteResponse, error)
+	// BulkImport is served over HTTP at POST /v1/tenants/{tenant_id}/relationships/bulk-import by a handler of the
+	// gateway of its own, since the gateway cannot bind path parameters to client streams.
+	BulkImport(Relationship_BulkImportServer) error
+	Transact(context.Context, *RelationshipTransactRequest) (*RelationshipTransactResponse, error)
 	mustEmbedUnimplementedRelationshipServer()
 }
 
@@ -447,6 +601,12 @@ func (UnimplementedRelationshipServer) Read(context.Context, *RelationshipReadRe
 func (UnimplementedRelationshipServer) Delete(context.Context, *RelationshipDeleteRequest) (*RelationshipDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedRelationshipServer) BulkImport(Relationship_BulkImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkImport not implemented")
+}
+func (UnimplementedRelationshipServer) Transact(context.Context, *RelationshipTransactRequest) (*RelationshipTransactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transact not implemented")
+}
 func (UnimplementedRelationshipServer) mustEmbedUnimplementedRelationshipServer() {}
 
 // UnsafeRelationshipServer may be embedded to opt out of forward compatibility for this service.
@@ -514,6 +674,50 @@ func _Relationship_Delete_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relationship_BulkImport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RelationshipServer).BulkImport(&relationshipBulkImportServer{stream})
+}
+
+type Relationship_BulkImportServer interface {
+	SendAndClose(*RelationshipBulkImportResponse) error
+	Recv() (*RelationshipBulkImportRequest, error)
+	grpc.ServerStream
+}
+
+type relationshipBulkImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *relationshipBulkImportServer) SendAndClose(m *RelationshipBulkImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *relationshipBulkImportServer) Recv() (*RelationshipBulkImportRequest, error) {
+	m := new(RelationshipBulkImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Relationship_Transact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationshipTransactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServer).Transact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Relationship_Transact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServer).Transact(ctx, req.(*RelationshipTransactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Relationship_ServiceDesc is the grpc.ServiceDesc for Relationship service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -533,8 +737,135 @@ var Relationship_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Relationship_Delete_Handler,
 		},
+		{
+			MethodName: "Transact",
+			Handler:    _Relationship_Transact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkImport",
+			Handler:       _Relationship_BulkImport_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "base/v1/service.proto",
+}
+
+const (
+	Watch_Watch_FullMethodName = "/base.v1.Watch/Watch"
+)
+
+// WatchClient is the client API for Watch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WatchClient interface {
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Watch_WatchClient, error)
+}
+
+type watchClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWatchClient(cc grpc.ClientConnInterface) WatchClient {
+	return &watchClient{cc}
+}
+
+func (c *watchClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Watch_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Watch_ServiceDesc.Streams[0], Watch_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Watch_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type watchWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WatchServer is the server API for Watch service.
+// All implementations must embed UnimplementedWatchServer
+// for forward compatibility
+type WatchServer interface {
+	Watch(*WatchRequest, Watch_WatchServer) error
+	mustEmbedUnimplementedWatchServer()
+}
+
+// UnimplementedWatchServer must be embedded to have forward compatible implementations.
+type UnimplementedWatchServer struct {
+}
+
+func (UnimplementedWatchServer) Watch(*WatchRequest, Watch_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedWatchServer) mustEmbedUnimplementedWatchServer() {}
+
+// UnsafeWatchServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WatchServer will
+// result in compilation errors.
+type UnsafeWatchServer interface {
+	mustEmbedUnimplementedWatchServer()
+}
+
+func RegisterWatchServer(s grpc.ServiceRegistrar, srv WatchServer) {
+	s.RegisterService(&Watch_ServiceDesc, srv)
+}
+
+func _Watch_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WatchServer).Watch(m, &watchWatchServer{stream})
+}
+
+type Watch_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type watchWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Watch_ServiceDesc is the grpc.ServiceDesc for Watch service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Watch_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "base.v1.Watch",
+	HandlerType: (*WatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Watch_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "base/v1/service.proto",
 }
 
@@ -701,3 +1032,93 @@ var Tenancy_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "base/v1/service.proto",
 }
+
+const (
+	Cluster_Members_FullMethodName = "/base.v1.Cluster/Members"
+)
+
+// ClusterClient is the client API for Cluster service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ClusterClient interface {
+	Members(ctx context.Context, in *ClusterMembersRequest, opts ...grpc.CallOption) (*ClusterMembersResponse, error)
+}
+
+type clusterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClusterClient(cc grpc.ClientConnInterface) ClusterClient {
+	return &clusterClient{cc}
+}
+
+func (c *clusterClient) Members(ctx context.Context, in *ClusterMembersRequest, opts ...grpc.CallOption) (*ClusterMembersResponse, error) {
+	out := new(ClusterMembersResponse)
+	err := c.cc.Invoke(ctx, Cluster_Members_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServer is the server API for Cluster service.
+// All implementations must embed UnimplementedClusterServer
+// for forward compatibility
+type ClusterServer interface {
+	Members(context.Context, *ClusterMembersRequest) (*ClusterMembersResponse, error)
+	mustEmbedUnimplementedClusterServer()
+}
+
+// UnimplementedClusterServer must be embedded to have forward compatible implementations.
+type UnimplementedClusterServer struct {
+}
+
+func (UnimplementedClusterServer) Members(context.Context, *ClusterMembersRequest) (*ClusterMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Members not implemented")
+}
+func (UnimplementedClusterServer) mustEmbedUnimplementedClusterServer() {}
+
+// UnsafeClusterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClusterServer will
+// result in compilation errors.
+type UnsafeClusterServer interface {
+	mustEmbedUnimplementedClusterServer()
+}
+
+func RegisterClusterServer(s grpc.ServiceRegistrar, srv ClusterServer) {
+	s.RegisterService(&Cluster_ServiceDesc, srv)
+}
+
+func _Cluster_Members_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).Members(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_Members_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).Members(ctx, req.(*ClusterMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Cluster_ServiceDesc is the grpc.ServiceDesc for Cluster service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Cluster_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "base.v1.Cluster",
+	HandlerType: (*ClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Members",
+			Handler:    _Cluster_Members_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "base/v1/service.proto",
+}
@@ -158,6 +158,35 @@ func (m *Tuple) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TupleValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TupleValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TupleValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return TupleMultiError(errors)
 	}
@@ -369,6 +398,607 @@ var _ interface {
 	ErrorName() string
 } = TuplesValidationError{}
 
+// Validate checks the field values on TupleOperation with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TupleOperation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TupleOperation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TupleOperationMultiError,
+// or nil if none found.
+func (m *TupleOperation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TupleOperation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _TupleOperation_Operation_NotInLookup[m.GetOperation()]; ok {
+		err := TupleOperationValidationError{
+			field:  "Operation",
+			reason: "value must not be in list [OPERATION_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := TupleOperation_Operation_name[int32(m.GetOperation())]; !ok {
+		err := TupleOperationValidationError{
+			field:  "Operation",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTuple() == nil {
+		err := TupleOperationValidationError{
+			field:  "Tuple",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetTuple()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TupleOperationValidationError{
+					field:  "Tuple",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TupleOperationValidationError{
+					field:  "Tuple",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTuple()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TupleOperationValidationError{
+				field:  "Tuple",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ClearExpiration
+
+	if len(errors) > 0 {
+		return TupleOperationMultiError(errors)
+	}
+
+	return nil
+}
+
+// TupleOperationMultiError is an error wrapping multiple validation errors
+// returned by TupleOperation.ValidateAll() if the designated constraints
+// aren't met.
+type TupleOperationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TupleOperationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TupleOperationMultiError) AllErrors() []error { return m }
+
+// TupleOperationValidationError is the validation error returned by
+// TupleOperation.Validate if the designated constraints aren't met.
+type TupleOperationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TupleOperationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TupleOperationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TupleOperationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TupleOperationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TupleOperationValidationError) ErrorName() string { return "TupleOperationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TupleOperationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTupleOperation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TupleOperationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TupleOperationValidationError{}
+
+var _TupleOperation_Operation_NotInLookup = map[TupleOperation_Operation]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on TuplePrecondition with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *TuplePrecondition) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TuplePrecondition with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TuplePreconditionMultiError, or nil if none found.
+func (m *TuplePrecondition) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TuplePrecondition) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _TuplePrecondition_Operation_NotInLookup[m.GetOperation()]; ok {
+		err := TuplePreconditionValidationError{
+			field:  "Operation",
+			reason: "value must not be in list [OPERATION_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := TuplePrecondition_Operation_name[int32(m.GetOperation())]; !ok {
+		err := TuplePreconditionValidationError{
+			field:  "Operation",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetFilter() == nil {
+		err := TuplePreconditionValidationError{
+			field:  "Filter",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TuplePreconditionValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TuplePreconditionValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TuplePreconditionValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return TuplePreconditionMultiError(errors)
+	}
+
+	return nil
+}
+
+// TuplePreconditionMultiError is an error wrapping multiple validation errors
+// returned by TuplePrecondition.ValidateAll() if the designated constraints
+// aren't met.
+type TuplePreconditionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TuplePreconditionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TuplePreconditionMultiError) AllErrors() []error { return m }
+
+// TuplePreconditionValidationError is the validation error returned by
+// TuplePrecondition.Validate if the designated constraints aren't met.
+type TuplePreconditionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TuplePreconditionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TuplePreconditionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TuplePreconditionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TuplePreconditionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TuplePreconditionValidationError) ErrorName() string {
+	return "TuplePreconditionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TuplePreconditionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTuplePrecondition.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TuplePreconditionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TuplePreconditionValidationError{}
+
+var _TuplePrecondition_Operation_NotInLookup = map[TuplePrecondition_Operation]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on TupleChanges with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TupleChanges) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TupleChanges with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TupleChangesMultiError, or
+// nil if none found.
+func (m *TupleChanges) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TupleChanges) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SnapToken
+
+	for idx, item := range m.GetTupleChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TupleChangesValidationError{
+						field:  fmt.Sprintf("TupleChanges[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TupleChangesValidationError{
+						field:  fmt.Sprintf("TupleChanges[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TupleChangesValidationError{
+					field:  fmt.Sprintf("TupleChanges[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return TupleChangesMultiError(errors)
+	}
+
+	return nil
+}
+
+// TupleChangesMultiError is an error wrapping multiple validation errors
+// returned by TupleChanges.ValidateAll() if the designated constraints aren't met.
+type TupleChangesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TupleChangesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TupleChangesMultiError) AllErrors() []error { return m }
+
+// TupleChangesValidationError is the validation error returned by
+// TupleChanges.Validate if the designated constraints aren't met.
+type TupleChangesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TupleChangesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TupleChangesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TupleChangesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TupleChangesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TupleChangesValidationError) ErrorName() string { return "TupleChangesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TupleChangesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTupleChanges.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TupleChangesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TupleChangesValidationError{}
+
+// Validate checks the field values on TupleChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TupleChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TupleChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TupleChangeMultiError, or
+// nil if none found.
+func (m *TupleChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TupleChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Operation
+
+	if all {
+		switch v := interface{}(m.GetTuple()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TupleChangeValidationError{
+					field:  "Tuple",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TupleChangeValidationError{
+					field:  "Tuple",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTuple()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TupleChangeValidationError{
+				field:  "Tuple",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return TupleChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// TupleChangeMultiError is an error wrapping multiple validation errors
+// returned by TupleChange.ValidateAll() if the designated constraints aren't met.
+type TupleChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TupleChangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TupleChangeMultiError) AllErrors() []error { return m }
+
+// TupleChangeValidationError is the validation error returned by
+// TupleChange.Validate if the designated constraints aren't met.
+type TupleChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TupleChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TupleChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TupleChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TupleChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TupleChangeValidationError) ErrorName() string { return "TupleChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TupleChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTupleChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TupleChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TupleChangeValidationError{}
+
 // Validate checks the field values on Entity with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
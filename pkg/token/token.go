@@ -18,6 +18,9 @@ type SnapToken interface {
 	Lt(token SnapToken) bool
 }
 
+// Decoder decodes the string representation of a snapshot token produced by a storage engine.
+type Decoder func(value string) (SnapToken, error)
+
 type (
 	NoopToken struct {
 		Value string